@@ -1,11 +1,45 @@
 package main
 
 import (
+	"context"
 	"finanzas-api/config"
+	"finanzas-api/internal/accounts"
+	accountRoutes "finanzas-api/internal/accounts/routes"
 	"finanzas-api/internal/auth"
 	authRoutes "finanzas-api/internal/auth/routes"
+	"finanzas-api/internal/budgets"
+	budgetRoutes "finanzas-api/internal/budgets/routes"
+	"finanzas-api/internal/categories"
+	categoryRoutes "finanzas-api/internal/categories/routes"
+	"finanzas-api/internal/categorizer"
+	categorizerRoutes "finanzas-api/internal/categorizer/routes"
+	"finanzas-api/internal/dashboard"
+	dashboardRoutes "finanzas-api/internal/dashboard/routes"
+	"finanzas-api/internal/exchange"
+	exchangeRoutes "finanzas-api/internal/exchange/routes"
+	"finanzas-api/internal/exports"
+	exportRoutes "finanzas-api/internal/exports/routes"
+	"finanzas-api/internal/imports"
+	importRoutes "finanzas-api/internal/imports/routes"
+	"finanzas-api/internal/ledger"
+	ledgerRoutes "finanzas-api/internal/ledger/routes"
+	"finanzas-api/internal/recurring"
+	recurringRoutes "finanzas-api/internal/recurring/routes"
+	"finanzas-api/internal/reports"
+	reportRoutes "finanzas-api/internal/reports/routes"
+	"finanzas-api/internal/roles"
+	roleRoutes "finanzas-api/internal/roles/routes"
+	"finanzas-api/internal/rules"
+	ruleRoutes "finanzas-api/internal/rules/routes"
+	"finanzas-api/internal/signup"
+	signupRoutes "finanzas-api/internal/signup/routes"
+	"finanzas-api/internal/transactions"
+	transactionRoutes "finanzas-api/internal/transactions/routes"
 	"finanzas-api/internal/users"
 	userRoutes "finanzas-api/internal/users/routes"
+	"finanzas-api/internal/views"
+	viewRoutes "finanzas-api/internal/views/routes"
+	"finanzas-api/shared/authz"
 	DataBase "finanzas-api/shared/db"
 	"fmt"
 	"log"
@@ -41,11 +75,60 @@ func main() {
 
 	}
 
-	userModule := users.NewUsersModule(db)
-	authModule := auth.NewAuthModule(db, config)
+	// Política de autorización compartida: cada módulo registra sus reglas
+	policy := authz.NewPolicy()
 
-	authRoutes.SetupAuthRoutes(r, authModule.Handler)
+	userModule := users.NewUsersModule(db, policy)
+	rolesModule := roles.NewRolesModule(db, userModule.Repository)
+	userModule.OnUserCreated(rolesModule.AssignDefaultRoleHook())
+	userModule.OnBeforeDisable(rolesModule.LastAdminGuardHook())
+	categoriesModule := categories.NewCategoriesModule(db)
+	userModule.OnUserCreated(categoriesModule.SeedDefaultsHook())
+	authModule := auth.NewAuthModule(db, config, rolesModule.UseCase)
+	signupModule := signup.NewSignupModule(db, config, userModule.UseCase, userModule.Repository)
+	accountsModule := accounts.NewAccountsModule(db)
+	transactionsModule := transactions.NewTransactionsModule(db, accountsModule.Repository, categoriesModule.Repository)
+	rulesModule := rules.NewRulesModule(db, transactionsModule.Repository, transactionsModule.TagRepository, accountsModule.Repository, categoriesModule.Repository)
+	transactionsModule.OnBeforeCreate(rulesModule.ApplyRulesHook())
+	categorizerModule := categorizer.NewCategorizerModule(db, transactionsModule.Repository, categoriesModule.Repository)
+	transactionsModule.OnBeforeCreate(categorizerModule.AutoApplyHook())
+	transactionsModule.OnCategoryChange(categorizerModule.LearnHook())
+	ledgerModule := ledger.NewLedgerModule(db, accountsModule.Repository)
+	exchangeModule := exchange.NewExchangeModule(db, userModule.Repository, config.App.ExchangeRatesFile, config.Jobs.ConversionInterval)
+	importsModule := imports.NewImportsModule(db, accountsModule.Repository, accountsModule.UseCase, categoriesModule.UseCase,
+		transactionsModule.UseCase, transactionsModule.DuplicateUseCase, ledgerModule.UseCase, exchangeModule.UseCase)
+	viewsModule := views.NewViewsModule(db, transactionsModule.Repository, accountsModule.Repository, categoriesModule.Repository)
+	exportsModule := exports.NewExportsModule(db, accountsModule.Repository, categoriesModule.Repository, viewsModule.UseCase)
+	budgetsModule := budgets.NewBudgetsModule(db, categoriesModule.Repository, transactionsModule.Repository, exchangeModule.UseCase)
+	reportsModule := reports.NewReportsModule(db, exchangeModule.UseCase, accountsModule.Repository, viewsModule.UseCase)
+	recurringModule := recurring.NewRecurringModule(db, accountsModule.Repository, categoriesModule.Repository, config.Jobs.SchedulerInterval)
+	dashboardModule := dashboard.NewDashboardModule(transactionsModule.UseCase, budgetsModule.UseCase, reportsModule.UseCase, recurringModule, config.Server.RequestTimeout)
+
+	// Worker que materializa las transacciones recurrentes vencidas
+	go recurringModule.Scheduler.Start(context.Background())
+	// Worker que registra las conversiones de moneda pendientes
+	go exchangeModule.Recorder.Start(context.Background())
+	go authModule.KeyRotator.Start(context.Background())
+
+	authRoutes.SetupAuthRoutes(r, authModule.Handler, authModule.Middleware.Handler)
+	signupRoutes.SetupSignupRoutes(r, signupModule.Handler)
 	userRoutes.SetupUserRoutes(r, userModule.Handler, authModule.Middleware.Handler)
+	roleRoutes.SetupRoleRoutes(r, rolesModule.Handler, authModule.Middleware.Handler)
+	accountRoutes.SetupAccountRoutes(r, accountsModule.Handler, authModule.Middleware.Handler)
+	categoryRoutes.SetupCategoryRoutes(r, categoriesModule.Handler, authModule.Middleware.Handler)
+	transactionRoutes.SetupTransactionRoutes(r, transactionsModule.Handler, authModule.Middleware.Handler)
+	transactionRoutes.SetupTagRoutes(r, transactionsModule.TagHandler, authModule.Middleware.Handler)
+	viewRoutes.SetupViewRoutes(r, viewsModule.Handler, authModule.Middleware.Handler)
+	ledgerRoutes.SetupLedgerRoutes(r, ledgerModule.Handler, authModule.Middleware.Handler)
+	importRoutes.SetupImportRoutes(r, importsModule.Handler, authModule.Middleware.Handler)
+	exportRoutes.SetupExportRoutes(r, exportsModule.Handler, authModule.Middleware.Handler)
+	exchangeRoutes.SetupExchangeRoutes(r, exchangeModule.Handler, authModule.Middleware.Handler)
+	budgetRoutes.SetupBudgetRoutes(r, budgetsModule.Handler, authModule.Middleware.Handler)
+	reportRoutes.SetupReportRoutes(r, reportsModule.Handler, authModule.Middleware.Handler)
+	ruleRoutes.SetupRuleRoutes(r, rulesModule.Handler, authModule.Middleware.Handler)
+	categorizerRoutes.SetupCategorizerRoutes(r, categorizerModule.Handler, authModule.Middleware.Handler)
+	recurringRoutes.SetupRecurringRoutes(r, recurringModule.Handler, authModule.Middleware.Handler)
+	dashboardRoutes.SetupDashboardRoutes(r, dashboardModule.Handler, authModule.Middleware.Handler)
 
 	log.Println("🚀 Servidor iniciado en " + config.Server.Host + ":" + strconv.Itoa(config.Server.Port))
 	r.Run(config.Server.Host + ":" + strconv.Itoa(config.Server.Port))
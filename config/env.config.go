@@ -15,6 +15,9 @@ type Config struct {
 	JWT      JWTConfig
 	Server   ServerConfig
 	App      AppConfig
+	Jobs     JobsConfig
+	Mail     MailConfig
+	Signup   SignupConfig
 }
 
 type DatabaseConfig struct {
@@ -31,17 +34,47 @@ func (d *DatabaseConfig) GetDatabaseURL() any {
 }
 
 type JWTConfig struct {
-	Secret  string        `validate:"required"`
-	Expires time.Duration `validate:"required"`
+	KeysDir        string        `validate:"required"` // Directorio de claves privadas PEM, una por kid
+	Algorithm      string        `validate:"required"` // RS256 | EdDSA
+	KeyRotation    time.Duration `validate:"required"` // Cada cuánto se genera una clave de firma nueva
+	RotationCheck  time.Duration `validate:"required"` // Cada cuánto se revisa si toca rotar o retirar claves
+	Issuer         string        `validate:"required"`
+	Audience       string        `validate:"required"`
+	Expires        time.Duration `validate:"required"` // Vigencia de los access tokens
+	RefreshExpires time.Duration `validate:"required"` // Vigencia de cada refresh token
 }
 
 type ServerConfig struct {
-	Host string `validate:"required"`
-	Port int    `validate:"required"`
+	Host           string        `validate:"required"`
+	Port           int           `validate:"required"`
+	RequestTimeout time.Duration `validate:"required"`
 }
 
 type AppConfig struct {
-	Environment string `validate:"required"`
+	Environment       string `validate:"required"`
+	ExchangeRatesFile string // CSV de cotizaciones que se importa al iniciar (opcional)
+}
+
+type JobsConfig struct {
+	SchedulerInterval  time.Duration `validate:"required"`
+	ConversionInterval time.Duration `validate:"required"` // Cada cuánto se registran las conversiones de moneda
+}
+
+type MailConfig struct {
+	Driver       string `validate:"required"` // smtp | file | log
+	From         string `validate:"required"`
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string // Vacío si el servidor no pide autenticación
+	SMTPPassword string
+	Dir          string // Directorio donde el driver file deja los .eml
+}
+
+type SignupConfig struct {
+	VerifyURL       string        `validate:"required"` // Enlace del correo; se le agrega ?token=
+	VerificationTTL time.Duration `validate:"required"` // Vigencia de cada enlace de verificación
+	ResendInterval  time.Duration // Espera mínima entre dos envíos al mismo usuario; cero la desactiva
+	MaxDailyEmails  int           `validate:"required"` // Envíos por usuario en 24 horas
 }
 
 func LoadConfig() (*Config, error) {
@@ -52,10 +85,64 @@ func LoadConfig() (*Config, error) {
 		log.Fatal("Error loading .env file")
 	}
 
-	jwtExpiresIn, err := time.ParseDuration(getEnv("JWT_EXPIRES_IN", "24h"))
+	jwtExpiresIn, err := time.ParseDuration(getEnv("JWT_EXPIRES_IN", "15m"))
+
+	if err != nil {
+		jwtExpiresIn = 15 * time.Minute // Default to 15 minutes if parsing fails
+	}
+
+	jwtRefreshExpiresIn, err := time.ParseDuration(getEnv("JWT_REFRESH_EXPIRES_IN", "720h"))
+
+	if err != nil {
+		jwtRefreshExpiresIn = 30 * 24 * time.Hour // Default to 30 days if parsing fails
+	}
+
+	jwtKeyRotation, err := time.ParseDuration(getEnv("JWT_KEY_ROTATION", "720h"))
+
+	if err != nil {
+		jwtKeyRotation = 30 * 24 * time.Hour // Default to 30 days if parsing fails
+	}
+
+	jwtRotationCheck, err := time.ParseDuration(getEnv("JWT_KEY_ROTATION_CHECK", "1h"))
+
+	if err != nil {
+		jwtRotationCheck = time.Hour // Default to 1 hour if parsing fails
+	}
+
+	requestTimeout, err := time.ParseDuration(getEnv("REQUEST_TIMEOUT", "5s"))
+
+	if err != nil {
+		requestTimeout = 5 * time.Second // Default to 5 seconds if parsing fails
+	}
+
+	schedulerInterval, err := time.ParseDuration(getEnv("SCHEDULER_INTERVAL", "15m"))
 
 	if err != nil {
-		jwtExpiresIn = 24 * time.Hour // Default to 24 hours if parsing fails
+		schedulerInterval = 15 * time.Minute // Default to 15 minutes if parsing fails
+	}
+
+	conversionInterval, err := time.ParseDuration(getEnv("FX_CONVERSION_INTERVAL", "1h"))
+
+	if err != nil {
+		conversionInterval = time.Hour // Default to 1 hour if parsing fails
+	}
+
+	verificationTTL, err := time.ParseDuration(getEnv("SIGNUP_VERIFICATION_TTL", "24h"))
+
+	if err != nil || verificationTTL <= 0 {
+		verificationTTL = 24 * time.Hour // Default to 24 hours if parsing fails or it is not positive
+	}
+
+	resendInterval, err := time.ParseDuration(getEnv("SIGNUP_RESEND_INTERVAL", "1m"))
+
+	if err != nil || resendInterval < 0 {
+		resendInterval = time.Minute // Default to 1 minute if parsing fails or it is negative
+	}
+
+	maxDailyEmails := getEnvAsInt("SIGNUP_MAX_DAILY_EMAILS", 5)
+
+	if maxDailyEmails <= 0 {
+		maxDailyEmails = 5 // Default to 5 emails if it is not positive
 	}
 
 	Config := &Config{
@@ -68,15 +155,42 @@ func LoadConfig() (*Config, error) {
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 		},
 		JWT: JWTConfig{
-			Secret:  getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
-			Expires: jwtExpiresIn,
+			KeysDir:        getEnv("JWT_KEYS_DIR", "keys"),
+			Algorithm:      getEnv("JWT_ALGORITHM", "EdDSA"),
+			KeyRotation:    jwtKeyRotation,
+			RotationCheck:  jwtRotationCheck,
+			Issuer:         getEnv("JWT_ISSUER", "finanzas-api"),
+			Audience:       getEnv("JWT_AUDIENCE", "finanzas-api"),
+			Expires:        jwtExpiresIn,
+			RefreshExpires: jwtRefreshExpiresIn,
 		},
 		Server: ServerConfig{
-			Host: getEnv("SERVER_HOST", "localhost"),
-			Port: getEnvAsInt("SERVER_PORT", 8080),
+			Host:           getEnv("SERVER_HOST", "localhost"),
+			Port:           getEnvAsInt("SERVER_PORT", 8080),
+			RequestTimeout: requestTimeout,
 		},
 		App: AppConfig{
-			Environment: getEnv("APP_ENV", "development"),
+			Environment:       getEnv("APP_ENV", "development"),
+			ExchangeRatesFile: getEnv("FX_RATES_FILE", ""),
+		},
+		Jobs: JobsConfig{
+			SchedulerInterval:  schedulerInterval,
+			ConversionInterval: conversionInterval,
+		},
+		Mail: MailConfig{
+			Driver:       getEnv("MAIL_DRIVER", "log"),
+			From:         getEnv("MAIL_FROM", "Finanzas <no-reply@localhost>"),
+			SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+			SMTPPort:     getEnvAsInt("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			Dir:          getEnv("MAIL_DIR", "mail"),
+		},
+		Signup: SignupConfig{
+			VerifyURL:       getEnv("SIGNUP_VERIFY_URL", "http://localhost:8080/api/v1/signup/verify"),
+			VerificationTTL: verificationTTL,
+			ResendInterval:  resendInterval,
+			MaxDailyEmails:  maxDailyEmails,
 		},
 	}
 	return Config, nil
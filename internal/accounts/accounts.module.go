@@ -0,0 +1,38 @@
+package accounts
+
+import (
+	"fmt"
+
+	"finanzas-api/internal/accounts/domain"
+	"finanzas-api/internal/accounts/handler"
+	"finanzas-api/internal/accounts/repository"
+	"finanzas-api/internal/accounts/usecase"
+
+	"gorm.io/gorm"
+)
+
+type AccountsModule struct {
+	Handler    *handler.AccountHandler
+	UseCase    domain.AccountUseCase
+	Repository domain.AccountRepository
+}
+
+func NewAccountsModule(db *gorm.DB) *AccountsModule {
+	var accountRepo domain.AccountRepository
+	var accountUseCase domain.AccountUseCase
+	var accountHandler *handler.AccountHandler
+
+	if err := db.AutoMigrate(&domain.Account{}); err != nil {
+		panic(fmt.Sprintf("Error migrating accounts: %v", err))
+	}
+
+	accountRepo = repository.NewAccountPostgresRepository(db)
+	accountUseCase = usecase.NewAccountUseCase(accountRepo)
+	accountHandler = handler.NewAccountHandler(accountUseCase)
+
+	return &AccountsModule{
+		Handler:    accountHandler,
+		UseCase:    accountUseCase,
+		Repository: accountRepo,
+	}
+}
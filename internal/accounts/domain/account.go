@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	userDomain "finanzas-api/internal/users/domain"
+
+	"gorm.io/gorm"
+)
+
+// AccountType identifica el tipo de cuenta financiera
+type AccountType string
+
+const (
+	AccountTypeBank       AccountType = "bank"
+	AccountTypeCash       AccountType = "cash"
+	AccountTypeCreditCard AccountType = "credit_card"
+	AccountTypeSavings    AccountType = "savings"
+)
+
+// ErrCurrencyLocked se retorna al cambiar la moneda de una cuenta que ya
+// tiene transacciones: cada una guarda la moneda de la cuenta al crearse
+var ErrCurrencyLocked = errors.New("currency cannot change on an account with transactions")
+
+type Account struct {
+	ID              uint            `json:"id" gorm:"primaryKey"`
+	UserID          uint            `json:"user_id" gorm:"not null;index"`
+	User            userDomain.User `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Name            string          `json:"name" gorm:"not null"`
+	Type            AccountType     `json:"type" gorm:"type:varchar(20);not null"`
+	Currency        string          `json:"currency" gorm:"type:char(3);not null"`
+	OpeningBalance  int64           `json:"opening_balance" gorm:"not null;default:0"` // En unidades menores (centavos)
+	InstitutionName string          `json:"institution_name"`
+	IsArchived      bool            `json:"is_archived" gorm:"default:false"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt  `json:"-" gorm:"index"` // Soft delete
+}
+
+// AccountRepository define la interfaz del repositorio de cuentas
+type AccountRepository interface {
+	// WithContext retorna el repositorio con sus consultas atadas a ctx: se
+	// cancelan cuando ctx vence
+	WithContext(ctx context.Context) AccountRepository
+	Create(account *Account) error
+	GetByID(id uint) (*Account, error)
+	Update(account *Account) error
+	Delete(id uint) error
+	ListByUser(userID uint, includeArchived bool, limit, offset int) ([]*Account, error)
+	// HasTransactions indica si la cuenta tiene transacciones sin eliminar
+	HasTransactions(id uint) (bool, error)
+}
+
+type AccountUseCase interface {
+	CreateAccount(account *Account) error
+	GetAccount(userID, id uint) (*Account, error)
+	UpdateAccount(userID uint, account *Account) error
+	DeleteAccount(userID, id uint) error
+	ListAccounts(userID uint, includeArchived bool, limit, offset int) ([]*Account, error)
+	ValidateAccountData(account *Account) error
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (Account) TableName() string {
+	return "accounts"
+}
+
+// IsValidType verifica si el tipo de cuenta es soportado
+func (a *Account) IsValidType() bool {
+	switch a.Type {
+	case AccountTypeBank, AccountTypeCash, AccountTypeCreditCard, AccountTypeSavings:
+		return true
+	}
+	return false
+}
+
+// BelongsTo verifica si la cuenta pertenece al usuario indicado
+func (a *Account) BelongsTo(userID uint) bool {
+	return a.UserID == userID
+}
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"finanzas-api/internal/accounts/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountHandler struct {
+	accountUseCase domain.AccountUseCase
+}
+
+// NewAccountHandler crea una nueva instancia del handler de cuentas
+func NewAccountHandler(accountUseCase domain.AccountUseCase) *AccountHandler {
+	return &AccountHandler{
+		accountUseCase: accountUseCase,
+	}
+}
+
+// CreateAccountRequest representa la estructura de la petición para crear una cuenta
+type CreateAccountRequest struct {
+	Name            string `json:"name" binding:"required"`
+	Type            string `json:"type" binding:"required,oneof=bank cash credit_card savings"`
+	Currency        string `json:"currency" binding:"required,len=3"`
+	OpeningBalance  int64  `json:"opening_balance"`
+	InstitutionName string `json:"institution_name"`
+}
+
+// UpdateAccountRequest representa la estructura de la petición para actualizar una cuenta
+type UpdateAccountRequest struct {
+	Name            string  `json:"name" binding:"omitempty"`
+	Type            string  `json:"type" binding:"omitempty,oneof=bank cash credit_card savings"`
+	Currency        string  `json:"currency" binding:"omitempty,len=3"`
+	OpeningBalance  *int64  `json:"opening_balance"`
+	InstitutionName *string `json:"institution_name"`
+	IsArchived      *bool   `json:"is_archived"`
+}
+
+// AccountResponse representa la respuesta de una cuenta
+type AccountResponse struct {
+	ID              uint   `json:"id"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	Currency        string `json:"currency"`
+	OpeningBalance  int64  `json:"opening_balance"`
+	InstitutionName string `json:"institution_name"`
+	IsArchived      bool   `json:"is_archived"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
+}
+
+// CreateAccount maneja la creación de cuentas del usuario autenticado
+func (h *AccountHandler) CreateAccount(c *gin.Context) {
+	var req CreateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	account := &domain.Account{
+		UserID:          c.GetUint("userID"),
+		Name:            req.Name,
+		Type:            domain.AccountType(req.Type),
+		Currency:        req.Currency,
+		OpeningBalance:  req.OpeningBalance,
+		InstitutionName: req.InstitutionName,
+	}
+
+	if err := h.accountUseCase.CreateAccount(account); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Account created successfully",
+		"account": h.toAccountResponse(account),
+	})
+}
+
+// GetAccount obtiene una cuenta del usuario autenticado
+func (h *AccountHandler) GetAccount(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid account ID",
+		})
+		return
+	}
+
+	account, err := h.accountUseCase.GetAccount(c.GetUint("userID"), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Account not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"account": h.toAccountResponse(account),
+	})
+}
+
+// UpdateAccount actualiza una cuenta del usuario autenticado
+func (h *AccountHandler) UpdateAccount(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid account ID",
+		})
+		return
+	}
+
+	var req UpdateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.GetUint("userID")
+
+	// Obtener cuenta existente
+	account, err := h.accountUseCase.GetAccount(userID, uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Account not found",
+		})
+		return
+	}
+
+	// Actualizar campos si se proporcionan
+	if req.Name != "" {
+		account.Name = req.Name
+	}
+	if req.Type != "" {
+		account.Type = domain.AccountType(req.Type)
+	}
+	if req.Currency != "" {
+		account.Currency = req.Currency
+	}
+	if req.OpeningBalance != nil {
+		account.OpeningBalance = *req.OpeningBalance
+	}
+	if req.InstitutionName != nil {
+		account.InstitutionName = *req.InstitutionName
+	}
+	if req.IsArchived != nil {
+		account.IsArchived = *req.IsArchived
+	}
+
+	if err := h.accountUseCase.UpdateAccount(userID, account); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrCurrencyLocked) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Account updated successfully",
+		"account": h.toAccountResponse(account),
+	})
+}
+
+// DeleteAccount elimina una cuenta del usuario autenticado
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid account ID",
+		})
+		return
+	}
+
+	if err := h.accountUseCase.DeleteAccount(c.GetUint("userID"), uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Account not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Account deleted successfully",
+	})
+}
+
+// ListAccounts obtiene las cuentas del usuario autenticado
+func (h *AccountHandler) ListAccounts(c *gin.Context) {
+	// Obtener parámetros de paginación
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 0 {
+		limit = 10
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	includeArchived := c.Query("include_archived") == "true"
+
+	accounts, err := h.accountUseCase.ListAccounts(c.GetUint("userID"), includeArchived, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Convertir a respuesta
+	accountResponses := make([]AccountResponse, 0, len(accounts))
+	for _, account := range accounts {
+		accountResponses = append(accountResponses, h.toAccountResponse(account))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"accounts": accountResponses,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(accountResponses),
+		},
+	})
+}
+
+// toAccountResponse convierte una cuenta del dominio a respuesta HTTP
+func (h *AccountHandler) toAccountResponse(account *domain.Account) AccountResponse {
+	return AccountResponse{
+		ID:              account.ID,
+		Name:            account.Name,
+		Type:            string(account.Type),
+		Currency:        account.Currency,
+		OpeningBalance:  account.OpeningBalance,
+		InstitutionName: account.InstitutionName,
+		IsArchived:      account.IsArchived,
+		CreatedAt:       account.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:       account.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/accounts/domain"
+
+type AccountRepository interface {
+	domain.AccountRepository
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"finanzas-api/internal/accounts/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+type accountRepositoryMemory struct {
+	accounts map[uint]*domain.Account
+	nextID   uint
+	mutex    sync.RWMutex
+}
+
+func NewAccountMemoryRepository() domain.AccountRepository {
+	return &accountRepositoryMemory{
+		accounts: make(map[uint]*domain.Account),
+		nextID:   1,
+	}
+}
+
+// WithContext retorna el mismo repositorio: en memoria no hay consultas que
+// cancelar
+func (r *accountRepositoryMemory) WithContext(ctx context.Context) domain.AccountRepository {
+	return r
+}
+
+func (r *accountRepositoryMemory) Create(account *domain.Account) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Asignar ID y timestamps
+	account.ID = r.nextID
+	r.nextID++
+	account.CreatedAt = time.Now()
+	account.UpdatedAt = time.Now()
+
+	copied := *account
+	r.accounts[account.ID] = &copied
+	return nil
+}
+
+func (r *accountRepositoryMemory) GetByID(id uint) (*domain.Account, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	account, exists := r.accounts[id]
+	if !exists || !account.DeletedAt.Time.IsZero() {
+		return nil, errors.New("account not found")
+	}
+
+	copied := *account
+	return &copied, nil
+}
+
+func (r *accountRepositoryMemory) Update(account *domain.Account) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existing, exists := r.accounts[account.ID]
+	if !exists || !existing.DeletedAt.Time.IsZero() {
+		return errors.New("account not found")
+	}
+
+	account.UpdatedAt = time.Now()
+	copied := *account
+	r.accounts[account.ID] = &copied
+
+	return nil
+}
+
+func (r *accountRepositoryMemory) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	account, exists := r.accounts[id]
+	if !exists || !account.DeletedAt.Time.IsZero() {
+		return errors.New("account not found")
+	}
+
+	// Soft delete
+	account.DeletedAt.Time = time.Now()
+	account.DeletedAt.Valid = true
+	account.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (r *accountRepositoryMemory) ListByUser(userID uint, includeArchived bool, limit, offset int) ([]*domain.Account, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var matches []*domain.Account
+	for _, account := range r.accounts {
+		// Saltar cuentas eliminadas o de otros usuarios
+		if !account.DeletedAt.Time.IsZero() || account.UserID != userID {
+			continue
+		}
+		if account.IsArchived && !includeArchived {
+			continue
+		}
+		copied := *account
+		matches = append(matches, &copied)
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+
+	// Aplicar offset y limit
+	if offset >= len(matches) {
+		return nil, nil
+	}
+	matches = matches[offset:]
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches, nil
+}
+
+// HasTransactions no conoce las transacciones: el repositorio en memoria
+// solo guarda cuentas
+func (r *accountRepositoryMemory) HasTransactions(id uint) (bool, error) {
+	return false, nil
+}
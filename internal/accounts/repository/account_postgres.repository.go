@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"finanzas-api/internal/accounts/domain"
+
+	"gorm.io/gorm"
+)
+
+type accountPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewAccountPostgresRepository(db *gorm.DB) domain.AccountRepository {
+	return &accountPostgresRepository{db: db}
+}
+
+func (r *accountPostgresRepository) WithContext(ctx context.Context) domain.AccountRepository {
+	return &accountPostgresRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *accountPostgresRepository) Create(account *domain.Account) error {
+	return r.db.Create(account).Error
+}
+
+func (r *accountPostgresRepository) GetByID(id uint) (*domain.Account, error) {
+	var account domain.Account
+	if err := r.db.First(&account, id).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *accountPostgresRepository) Update(account *domain.Account) error {
+	return r.db.Save(account).Error
+}
+
+func (r *accountPostgresRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Account{}, id).Error // soft delete
+}
+
+func (r *accountPostgresRepository) ListByUser(userID uint, includeArchived bool, limit, offset int) ([]*domain.Account, error) {
+	var accounts []*domain.Account
+	query := r.db.Where("user_id = ?", userID)
+	if !includeArchived {
+		query = query.Where("is_archived = ?", false)
+	}
+	// Un limit de 0 devuelve todas las cuentas del usuario
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Order("id").Offset(offset).Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (r *accountPostgresRepository) HasTransactions(id uint) (bool, error) {
+	var exists bool
+	err := r.db.Raw("SELECT EXISTS (SELECT 1 FROM transactions WHERE account_id = ? AND deleted_at IS NULL)", id).
+		Scan(&exists).Error
+	return exists, err
+}
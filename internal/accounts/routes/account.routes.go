@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"finanzas-api/internal/accounts/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAccountRoutes configura las rutas para el módulo de cuentas
+func SetupAccountRoutes(router *gin.Engine, accountHandler *handler.AccountHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	// Grupo de rutas para cuentas; cada usuario solo ve sus propias cuentas
+	accountRoutes := router.Group("/api/v1/accounts")
+	{
+		// POST /api/v1/accounts - Crear cuenta
+		accountRoutes.POST("", authMiddleware("accounts:write"), accountHandler.CreateAccount)
+
+		// GET /api/v1/accounts - Listar cuentas del usuario
+		accountRoutes.GET("", authMiddleware("accounts:read"), accountHandler.ListAccounts)
+
+		// GET /api/v1/accounts/:id - Obtener cuenta por ID
+		accountRoutes.GET("/:id", authMiddleware("accounts:read"), accountHandler.GetAccount)
+
+		// PUT /api/v1/accounts/:id - Actualizar cuenta
+		accountRoutes.PUT("/:id", authMiddleware("accounts:write"), accountHandler.UpdateAccount)
+
+		// DELETE /api/v1/accounts/:id - Eliminar cuenta
+		accountRoutes.DELETE("/:id", authMiddleware("accounts:write"), accountHandler.DeleteAccount)
+	}
+}
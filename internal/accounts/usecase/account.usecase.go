@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"errors"
+	"finanzas-api/internal/accounts/domain"
+	"finanzas-api/shared/money"
+	"strings"
+)
+
+type AccountUseCase struct {
+	accountRepo domain.AccountRepository
+}
+
+func NewAccountUseCase(accountRepo domain.AccountRepository) domain.AccountUseCase {
+	return &AccountUseCase{
+		accountRepo: accountRepo,
+	}
+}
+
+// CreateAccount implements domain.AccountUseCase.
+func (uc *AccountUseCase) CreateAccount(account *domain.Account) error {
+	if err := uc.ValidateAccountData(account); err != nil {
+		return err
+	}
+
+	if account.UserID == 0 {
+		return errors.New("user ID is required")
+	}
+
+	return uc.accountRepo.Create(account)
+}
+
+// GetAccount implements domain.AccountUseCase.
+func (uc *AccountUseCase) GetAccount(userID, id uint) (*domain.Account, error) {
+	if id == 0 {
+		return nil, errors.New("invalid account ID")
+	}
+
+	account, err := uc.accountRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Una cuenta ajena se reporta como inexistente para no filtrar información
+	if !account.BelongsTo(userID) {
+		return nil, errors.New("account not found")
+	}
+
+	return account, nil
+}
+
+// UpdateAccount implements domain.AccountUseCase.
+func (uc *AccountUseCase) UpdateAccount(userID uint, account *domain.Account) error {
+	if account.ID == 0 {
+		return errors.New("account ID is required")
+	}
+
+	if err := uc.ValidateAccountData(account); err != nil {
+		return err
+	}
+
+	existing, err := uc.GetAccount(userID, account.ID)
+	if err != nil {
+		return err
+	}
+
+	// El propietario no puede cambiar
+	account.UserID = existing.UserID
+
+	// Las transacciones guardan la moneda de la cuenta: cambiarla después
+	// descuadraría conversiones y saldos
+	if account.Currency != existing.Currency {
+		hasTransactions, err := uc.accountRepo.HasTransactions(account.ID)
+		if err != nil {
+			return err
+		}
+		if hasTransactions {
+			return domain.ErrCurrencyLocked
+		}
+	}
+
+	return uc.accountRepo.Update(account)
+}
+
+// DeleteAccount implements domain.AccountUseCase.
+func (uc *AccountUseCase) DeleteAccount(userID, id uint) error {
+	if _, err := uc.GetAccount(userID, id); err != nil {
+		return err
+	}
+
+	return uc.accountRepo.Delete(id)
+}
+
+// ListAccounts implements domain.AccountUseCase.
+func (uc *AccountUseCase) ListAccounts(userID uint, includeArchived bool, limit, offset int) ([]*domain.Account, error) {
+	if limit < 0 || offset < 0 {
+		return nil, errors.New("limit and offset must be non-negative")
+	}
+
+	// Valor por defecto para limit
+	if limit == 0 {
+		limit = 10
+	}
+
+	// Máximo 100 cuentas por página
+	if limit > 100 {
+		limit = 100
+	}
+	return uc.accountRepo.ListByUser(userID, includeArchived, limit, offset)
+}
+
+// ValidateAccountData implements domain.AccountUseCase.
+func (uc *AccountUseCase) ValidateAccountData(account *domain.Account) error {
+	if account == nil {
+		return errors.New("account is required")
+	}
+
+	account.Name = strings.TrimSpace(account.Name)
+	if account.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if len(account.Name) > 100 {
+		return errors.New("name too long")
+	}
+
+	if !account.IsValidType() {
+		return errors.New("invalid account type")
+	}
+
+	// Código de moneda ISO 4217 en mayúsculas
+	account.Currency = strings.ToUpper(strings.TrimSpace(account.Currency))
+	if !money.IsValidCurrency(account.Currency) {
+		return errors.New("invalid currency code")
+	}
+
+	account.InstitutionName = strings.TrimSpace(account.InstitutionName)
+	if len(account.InstitutionName) > 100 {
+		return errors.New("institution name too long")
+	}
+
+	return nil
+}
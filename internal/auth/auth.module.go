@@ -1,26 +1,57 @@
 package auth
 
 import (
+	"fmt"
+	"time"
+
 	"finanzas-api/config"
 	"finanzas-api/internal/auth/domain"
 	"finanzas-api/internal/auth/handler"
 	"finanzas-api/internal/auth/middleware"
+	"finanzas-api/internal/auth/repository"
+	"finanzas-api/internal/auth/scheduler"
 	"finanzas-api/internal/auth/usecase"
 	userRepo "finanzas-api/internal/users/repository"
+	"finanzas-api/shared/security"
 
 	"gorm.io/gorm"
 )
 
 type AuthModule struct {
-	Handler    *handler.AuthHandler
-	UseCase    domain.AuthUseCase
-	Middleware *middleware.Middleware
+	Handler           *handler.AuthHandler
+	UseCase           domain.AuthUseCase
+	SessionRepository domain.SessionRepository
+	Middleware        *middleware.Middleware
+	KeyRotator        *scheduler.KeyRotator
 }
 
-func NewAuthModule(db *gorm.DB, cfg *config.Config) *AuthModule {
+func NewAuthModule(db *gorm.DB, cfg *config.Config, permissions domain.PermissionResolver) *AuthModule {
+	if err := db.AutoMigrate(&domain.Session{}, &domain.RefreshToken{}, &domain.RevokedToken{}); err != nil {
+		panic(fmt.Sprintf("Error migrating sessions: %v", err))
+	}
+
 	repo := userRepo.NewUserPostgresRepository(db)
-	uc := usecase.NewAuthUseCase(repo, cfg.JWT)
+	sessionRepo := repository.NewSessionPostgresRepository(db)
+
+	// Los tokens vencidos ya no sirven para detectar reutilización
+	if err := sessionRepo.PurgeExpired(time.Now()); err != nil {
+		panic(fmt.Sprintf("Error purging expired tokens: %v", err))
+	}
+
+	keys, err := security.LoadKeySet(cfg.JWT.KeysDir, cfg.JWT.Algorithm, cfg.JWT.KeyRotation, cfg.JWT.Expires)
+	if err != nil {
+		panic(fmt.Sprintf("Error loading signing keys: %v", err))
+	}
+	tokens := security.NewTokenService(keys, cfg.JWT.Issuer, cfg.JWT.Audience)
+
+	uc := usecase.NewAuthUseCase(repo, sessionRepo, permissions, tokens, cfg.JWT)
 	h := handler.NewAuthHandler(uc)
-	mw := middleware.NewMiddleware(cfg.JWT.Secret)
-	return &AuthModule{Handler: h, UseCase: uc, Middleware: mw}
+	mw := middleware.NewMiddleware(tokens, sessionRepo)
+	return &AuthModule{
+		Handler:           h,
+		UseCase:           uc,
+		SessionRepository: sessionRepo,
+		Middleware:        mw,
+		KeyRotator:        scheduler.NewKeyRotator(keys, cfg.JWT.RotationCheck),
+	}
 }
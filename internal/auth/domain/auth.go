@@ -1,6 +1,112 @@
 package domain
 
+import (
+	"errors"
+	"time"
+
+	userDomain "finanzas-api/internal/users/domain"
+	"finanzas-api/shared/security"
+)
+
+// ErrInvalidRefreshToken se retorna cuando el refresh token no existe, venció,
+// ya se usó o su sesión fue revocada
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+// ErrUserInactive se retorna cuando el usuario fue desactivado o eliminado
+var ErrUserInactive = errors.New("user inactive")
+
+// ErrEmailNotVerified se retorna en el login de una cuenta del registro
+// público que aún no confirma su email
+var ErrEmailNotVerified = errors.New("email not verified")
+
+// Session es una familia de refresh tokens: nace en el login y cada refresh
+// rota su token. Revocarla invalida los refresh y access tokens que emitió.
+type Session struct {
+	ID           uint            `gorm:"primaryKey"`
+	UserID       uint            `gorm:"not null;index"`
+	User         userDomain.User `gorm:"constraint:OnDelete:CASCADE"`
+	RevokedAt    *time.Time
+	RevokeReason string `gorm:"type:varchar(50)"` // logout, reuse, user inactive
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// RefreshToken se guarda solo como hash SHA-256. Al rotarlo se marca como
+// usado en lugar de borrarlo: si se presenta de nuevo, alguien lo copió.
+type RefreshToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	SessionID uint      `gorm:"not null;index"`
+	Session   Session   `gorm:"constraint:OnDelete:CASCADE"`
+	TokenHash string    `gorm:"type:char(64);uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// RevokedToken es un access token revocado antes de vencer. Se conserva hasta
+// su vencimiento original.
+type RevokedToken struct {
+	JTI       string    `gorm:"primaryKey;type:varchar(64)"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+// TokenPair es lo que reciben los clientes al iniciar sesión o refrescar
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time // Vencimiento del access token
+}
+
+// SessionRepository define la interfaz del repositorio de sesiones
+type SessionRepository interface {
+	Create(session *Session, token *RefreshToken) error
+	GetByID(id uint) (*Session, error)
+	Revoke(id uint, reason string) error
+	GetRefreshToken(tokenHash string) (*RefreshToken, error)
+	// RotateRefreshToken marca current como usado y guarda next. Retorna
+	// false sin guardar next si current ya estaba usado.
+	RotateRefreshToken(currentID uint, next *RefreshToken) (bool, error)
+	RevokeToken(jti string, expiresAt time.Time) error
+	// IsRevoked es true si el jti fue revocado o la sesión no existe o fue
+	// revocada
+	IsRevoked(sessionID uint, jti string) (bool, error)
+	// PurgeExpired borra los refresh tokens y revocaciones vencidos
+	PurgeExpired(now time.Time) error
+}
+
+// PermissionResolver retorna los roles de un usuario y la unión de sus
+// permisos. Lo implementa el módulo de roles.
+type PermissionResolver interface {
+	UserPermissions(userID uint) (roles []string, permissions []string, err error)
+}
+
 // AuthUseCase defines authentication methods
 type AuthUseCase interface {
-	Login(email, password string) (string, error)
+	Login(email, password string) (*TokenPair, error)
+	Refresh(refreshToken string) (*TokenPair, error)
+	// Logout revoca la sesión y el access token con el que se llamó
+	Logout(sessionID uint, jti string, expiresAt time.Time) error
+	// JWKS retorna las claves públicas de verificación de los access tokens
+	JWKS() security.JWKSet
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
+
+// IsRevoked verifica si la sesión fue revocada
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
 }
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"finanzas-api/internal/auth/domain"
@@ -20,8 +21,16 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LoginResponse conserva "token" para el access token; los clientes deben
+// guardar refresh_token y pedir uno nuevo antes de expires_at
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresAt    string `json:"expires_at"`
 }
 
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -30,10 +39,55 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 		return
 	}
-	token, err := h.useCase.Login(req.Email, req.Password)
+	pair, err := h.useCase.Login(req.Email, req.Password)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, LoginResponse{Token: token})
+	c.JSON(http.StatusOK, toLoginResponse(pair))
+}
+
+// Refresh cambia un refresh token por un par nuevo; el anterior deja de servir
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	pair, err := h.useCase.Refresh(req.RefreshToken)
+	if err != nil {
+		status := http.StatusUnauthorized
+		if !errors.Is(err, domain.ErrInvalidRefreshToken) && !errors.Is(err, domain.ErrUserInactive) {
+			status = http.StatusInternalServerError
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, toLoginResponse(pair))
+}
+
+// Logout revoca la sesión del access token con el que se llama, incluidos
+// sus refresh tokens
+func (h *AuthHandler) Logout(c *gin.Context) {
+	if err := h.useCase.Logout(c.GetUint("sessionID"), c.GetString("tokenID"), c.GetTime("tokenExpiresAt")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// JWKS publica las claves públicas vigentes para que otros servicios
+// verifiquen los access tokens. Incluye las claves anteriores mientras haya
+// tokens firmados con ellas sin vencer.
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.useCase.JWKS())
+}
+
+func toLoginResponse(pair *domain.TokenPair) LoginResponse {
+	return LoginResponse{
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresAt:    pair.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
 }
@@ -1,47 +1,75 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	"finanzas-api/shared/authz"
 	"finanzas-api/shared/security"
 	"github.com/gin-gonic/gin"
 )
 
+// RevocationChecker indica si un access token fue revocado por su jti o por
+// su sesión
+type RevocationChecker interface {
+	IsRevoked(sessionID uint, jti string) (bool, error)
+}
+
 type Middleware struct {
-	Secret string
+	Tokens      *security.TokenService
+	Revocations RevocationChecker
 }
 
-func NewMiddleware(secret string) *Middleware {
-	return &Middleware{Secret: secret}
+func NewMiddleware(tokens *security.TokenService, revocations RevocationChecker) *Middleware {
+	return &Middleware{Tokens: tokens, Revocations: revocations}
 }
 
-func (m *Middleware) Handler(roles ...string) gin.HandlerFunc {
+// Handler exige un access token válido que tenga todos los permisos
+// indicados, p. ej. Handler("reports:read"). Sin permisos basta con estar
+// autenticado. Un permiso fuera del catálogo es un error de programación y
+// detiene el arranque.
+func (m *Middleware) Handler(permissions ...string) gin.HandlerFunc {
+	for _, permission := range permissions {
+		if !authz.IsKnownPermission(permission) {
+			panic(fmt.Sprintf("unknown permission %q", permission))
+		}
+	}
+
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if strings.HasPrefix(authHeader, "Bearer ") {
 			authHeader = strings.TrimPrefix(authHeader, "Bearer ")
 		}
-		claims, err := security.ParseToken(authHeader, m.Secret)
+		claims, err := m.Tokens.ParseToken(authHeader)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 			return
 		}
-		if len(roles) > 0 {
-			allowed := false
-			for _, r := range roles {
-				if claims.Role == r {
-					allowed = true
-					break
-				}
-			}
-			if !allowed {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		// Los tokens sin sesión (emitidos antes de las sesiones) cuentan como revocados
+		revoked, err := m.Revocations.IsRevoked(claims.SessionID, claims.ID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "could not verify token"})
+			return
+		}
+		if revoked {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		subject := authz.Subject{UserID: claims.UserID, Permissions: claims.Permissions}
+		for _, permission := range permissions {
+			if !subject.Has(permission) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden", "missing_permission": permission})
 				return
 			}
 		}
 		c.Set("userID", claims.UserID)
-		c.Set("userRole", claims.Role)
+		c.Set("userRoles", claims.Roles)
+		c.Set("userPermissions", claims.Permissions)
+		c.Set("sessionID", claims.SessionID)
+		c.Set("tokenID", claims.ID)
+		c.Set("tokenExpiresAt", time.Unix(claims.Exp, 0))
 		c.Next()
 	}
 }
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"finanzas-api/internal/auth/domain"
+	"finanzas-api/internal/auth/repository"
+	"finanzas-api/shared/security"
+	"github.com/gin-gonic/gin"
+)
+
+func TestHandlerRevocation(t *testing.T) {
+	tests := []struct {
+		name       string
+		revoke     func(sessions domain.SessionRepository, sessionID uint, jti string) error
+		sessionID  uint // Cero usa la sesión creada
+		wantStatus int
+	}{
+		{name: "valid token", wantStatus: http.StatusOK},
+		{
+			name: "revoked jti",
+			revoke: func(sessions domain.SessionRepository, sessionID uint, jti string) error {
+				return sessions.RevokeToken(jti, time.Now().Add(time.Hour))
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "revoked session",
+			revoke: func(sessions domain.SessionRepository, sessionID uint, jti string) error {
+				return sessions.Revoke(sessionID, "logout")
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{name: "unknown session", sessionID: 99, wantStatus: http.StatusUnauthorized},
+	}
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := security.LoadKeySet(t.TempDir(), security.AlgorithmEdDSA, 24*time.Hour, time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tokens := security.NewTokenService(keys, "finanzas-api", "finanzas-web")
+			sessions := repository.NewSessionMemoryRepository()
+			session := &domain.Session{UserID: 1}
+			if err := sessions.Create(session, &domain.RefreshToken{ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+				t.Fatal(err)
+			}
+
+			sessionID := session.ID
+			if tt.sessionID != 0 {
+				sessionID = tt.sessionID
+			}
+			token, err := tokens.GenerateToken(security.TokenClaims{UserID: 1, SessionID: sessionID}, time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.revoke != nil {
+				claims, err := tokens.ParseToken(token)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := tt.revoke(sessions, session.ID, claims.ID); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			router := gin.New()
+			router.GET("/", NewMiddleware(tokens, sessions).Handler(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Header.Set("Authorization", "Bearer "+token)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/auth/domain"
+
+type SessionRepository interface {
+	domain.SessionRepository
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"finanzas-api/internal/auth/domain"
+)
+
+type sessionRepositoryMemory struct {
+	sessions      map[uint]*domain.Session
+	refreshTokens map[uint]*domain.RefreshToken
+	revokedTokens map[string]time.Time
+	nextID        uint
+	mutex         sync.RWMutex
+}
+
+func NewSessionMemoryRepository() domain.SessionRepository {
+	return &sessionRepositoryMemory{
+		sessions:      make(map[uint]*domain.Session),
+		refreshTokens: make(map[uint]*domain.RefreshToken),
+		revokedTokens: make(map[string]time.Time),
+		nextID:        1,
+	}
+}
+
+func (r *sessionRepositoryMemory) Create(session *domain.Session, token *domain.RefreshToken) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Asignar ID y timestamps
+	session.ID = r.nextID
+	r.nextID++
+	session.CreatedAt = time.Now()
+	session.UpdatedAt = time.Now()
+
+	copied := *session
+	r.sessions[session.ID] = &copied
+
+	token.SessionID = session.ID
+	r.addRefreshToken(token)
+	return nil
+}
+
+func (r *sessionRepositoryMemory) GetByID(id uint) (*domain.Session, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	session, exists := r.sessions[id]
+	if !exists {
+		return nil, errors.New("session not found")
+	}
+
+	copied := *session
+	return &copied, nil
+}
+
+func (r *sessionRepositoryMemory) Revoke(id uint, reason string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	session, exists := r.sessions[id]
+	if !exists || session.RevokedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	session.RevokedAt = &now
+	session.RevokeReason = reason
+	session.UpdatedAt = now
+	return nil
+}
+
+func (r *sessionRepositoryMemory) GetRefreshToken(tokenHash string) (*domain.RefreshToken, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, token := range r.refreshTokens {
+		if token.TokenHash == tokenHash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, errors.New("refresh token not found")
+}
+
+func (r *sessionRepositoryMemory) RotateRefreshToken(currentID uint, next *domain.RefreshToken) (bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	current, exists := r.refreshTokens[currentID]
+	if !exists || current.UsedAt != nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	current.UsedAt = &now
+	r.addRefreshToken(next)
+	return true, nil
+}
+
+func (r *sessionRepositoryMemory) RevokeToken(jti string, expiresAt time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.revokedTokens[jti]; !exists {
+		r.revokedTokens[jti] = expiresAt
+	}
+	return nil
+}
+
+func (r *sessionRepositoryMemory) IsRevoked(sessionID uint, jti string) (bool, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if _, revoked := r.revokedTokens[jti]; revoked {
+		return true, nil
+	}
+	session, exists := r.sessions[sessionID]
+	return !exists || session.RevokedAt != nil, nil
+}
+
+func (r *sessionRepositoryMemory) PurgeExpired(now time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for jti, expiresAt := range r.revokedTokens {
+		if expiresAt.Before(now) {
+			delete(r.revokedTokens, jti)
+		}
+	}
+	for id, token := range r.refreshTokens {
+		if token.ExpiresAt.Before(now) {
+			delete(r.refreshTokens, id)
+		}
+	}
+	return nil
+}
+
+// addRefreshToken asigna ID al token y guarda una copia; requiere el lock
+func (r *sessionRepositoryMemory) addRefreshToken(token *domain.RefreshToken) {
+	token.ID = r.nextID
+	r.nextID++
+	token.CreatedAt = time.Now()
+
+	copied := *token
+	r.refreshTokens[token.ID] = &copied
+}
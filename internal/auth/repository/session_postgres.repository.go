@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"time"
+
+	"finanzas-api/internal/auth/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sessionPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionPostgresRepository(db *gorm.DB) domain.SessionRepository {
+	return &sessionPostgresRepository{db: db}
+}
+
+func (r *sessionPostgresRepository) Create(session *domain.Session, token *domain.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		token.SessionID = session.ID
+		return tx.Create(token).Error
+	})
+}
+
+func (r *sessionPostgresRepository) GetByID(id uint) (*domain.Session, error) {
+	var session domain.Session
+	if err := r.db.First(&session, id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *sessionPostgresRepository) Revoke(id uint, reason string) error {
+	// Una sesión ya revocada conserva su motivo original
+	return r.db.Model(&domain.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoke_reason": reason}).Error
+}
+
+func (r *sessionPostgresRepository) GetRefreshToken(tokenHash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *sessionPostgresRepository) RotateRefreshToken(currentID uint, next *domain.RefreshToken) (bool, error) {
+	rotated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// La condición used_at IS NULL hace que de dos refresh simultáneos
+		// con el mismo token solo uno gane
+		result := tx.Model(&domain.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL", currentID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		rotated = true
+		return tx.Create(next).Error
+	})
+	return rotated, err
+}
+
+func (r *sessionPostgresRepository) RevokeToken(jti string, expiresAt time.Time) error {
+	token := &domain.RevokedToken{JTI: jti, ExpiresAt: expiresAt}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+func (r *sessionPostgresRepository) IsRevoked(sessionID uint, jti string) (bool, error) {
+	var revoked bool
+	err := r.db.Raw(`SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = ?)
+		OR NOT EXISTS (SELECT 1 FROM sessions WHERE id = ? AND revoked_at IS NULL)`,
+		jti, sessionID).Scan(&revoked).Error
+	return revoked, err
+}
+
+func (r *sessionPostgresRepository) PurgeExpired(now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < ?", now).Delete(&domain.RevokedToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("expires_at < ?", now).Delete(&domain.RefreshToken{}).Error
+	})
+}
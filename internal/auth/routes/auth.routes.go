@@ -5,6 +5,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupAuthRoutes(router *gin.Engine, h *handler.AuthHandler) {
+func SetupAuthRoutes(router *gin.Engine, h *handler.AuthHandler, authMiddleware func(...string) gin.HandlerFunc) {
 	router.POST("/api/v1/login", h.Login)
+	router.POST("/api/v1/token/refresh", h.Refresh)
+	router.POST("/api/v1/logout", authMiddleware(), h.Logout)
+	router.GET("/.well-known/jwks.json", h.JWKS)
 }
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"finanzas-api/shared/security"
+)
+
+// KeyRotator revisa periódicamente si toca generar una clave de firma nueva
+// o retirar las que ya no respaldan tokens vigentes. La rotación efectiva la
+// decide el KeySet según la antigüedad de la clave actual.
+type KeyRotator struct {
+	keys     *security.KeySet
+	interval time.Duration
+}
+
+// NewKeyRotator crea un rotador que revisa cada interval
+func NewKeyRotator(keys *security.KeySet, interval time.Duration) *KeyRotator {
+	return &KeyRotator{
+		keys:     keys,
+		interval: interval,
+	}
+}
+
+// Start revisa una vez por intervalo hasta que ctx se cancele. La revisión
+// inicial ya la hizo LoadKeySet.
+func (r *KeyRotator) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Rotación de claves detenida")
+			return
+		case <-ticker.C:
+			r.run()
+		}
+	}
+}
+
+func (r *KeyRotator) run() {
+	previous := r.keys.Current().ID
+	if err := r.keys.Rotate(time.Now()); err != nil {
+		log.Printf("⚠️ Error rotando las claves de firma: %v", err)
+		return
+	}
+	if current := r.keys.Current().ID; current != previous {
+		log.Printf("🔑 Nueva clave de firma %s", current)
+	}
+}
@@ -2,31 +2,148 @@ package usecase
 
 import (
 	"errors"
+	"log"
+	"time"
 
 	"finanzas-api/config"
+	"finanzas-api/internal/auth/domain"
 	userDomain "finanzas-api/internal/users/domain"
 	"finanzas-api/shared/security"
 )
 
 type AuthUseCase struct {
-	userRepo  userDomain.UserRepository
-	jwtConfig config.JWTConfig
+	userRepo    userDomain.UserRepository
+	sessionRepo domain.SessionRepository
+	permissions domain.PermissionResolver
+	tokens      *security.TokenService
+	jwtConfig   config.JWTConfig
 }
 
-func NewAuthUseCase(repo userDomain.UserRepository, cfg config.JWTConfig) *AuthUseCase {
-	return &AuthUseCase{userRepo: repo, jwtConfig: cfg}
+func NewAuthUseCase(repo userDomain.UserRepository, sessionRepo domain.SessionRepository, permissions domain.PermissionResolver, tokens *security.TokenService, cfg config.JWTConfig) *AuthUseCase {
+	return &AuthUseCase{userRepo: repo, sessionRepo: sessionRepo, permissions: permissions, tokens: tokens, jwtConfig: cfg}
 }
 
-func (uc *AuthUseCase) Login(email, password string) (string, error) {
+func (uc *AuthUseCase) Login(email, password string) (*domain.TokenPair, error) {
 	user, err := uc.userRepo.GetByEmail(email)
 	if err != nil {
-		return "", errors.New("invalid credentials")
+		return nil, errors.New("invalid credentials")
 	}
 	if !security.CheckPasswordHash(password, user.Password) {
-		return "", errors.New("invalid credentials")
+		return nil, errors.New("invalid credentials")
+	}
+	// Una cuenta sin verificar también está inactiva: el error más útil es
+	// el que indica cómo activarla
+	if !user.IsEmailVerified() {
+		return nil, domain.ErrEmailNotVerified
 	}
 	if !user.IsValidForAuth() {
-		return "", errors.New("user inactive")
+		return nil, domain.ErrUserInactive
+	}
+
+	// Cada login abre una sesión nueva con su primer refresh token
+	refreshToken, token, err := uc.newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	session := &domain.Session{UserID: user.ID}
+	if err := uc.sessionRepo.Create(session, token); err != nil {
+		return nil, err
+	}
+	return uc.tokenPair(user, session.ID, refreshToken)
+}
+
+// Refresh rota el refresh token: el presentado queda usado y se emite otro
+// en la misma sesión. Presentar un token ya usado revoca toda la sesión,
+// porque significa que el token se filtró.
+func (uc *AuthUseCase) Refresh(refreshToken string) (*domain.TokenPair, error) {
+	current, err := uc.sessionRepo.GetRefreshToken(security.HashOpaqueToken(refreshToken))
+	if err != nil {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	session, err := uc.sessionRepo.GetByID(current.SessionID)
+	if err != nil || session.IsRevoked() {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if current.UsedAt != nil {
+		return nil, uc.revokeReused(session.ID)
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	user, err := uc.userRepo.GetByID(session.UserID)
+	if err != nil || !user.IsValidForAuth() {
+		if err := uc.sessionRepo.Revoke(session.ID, "user inactive"); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrUserInactive
+	}
+
+	nextToken, next, err := uc.newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	next.SessionID = session.ID
+	rotated, err := uc.sessionRepo.RotateRefreshToken(current.ID, next)
+	if err != nil {
+		return nil, err
+	}
+	// Otra petición usó el mismo token entre la lectura y la rotación
+	if !rotated {
+		return nil, uc.revokeReused(session.ID)
+	}
+	return uc.tokenPair(user, session.ID, nextToken)
+}
+
+func (uc *AuthUseCase) Logout(sessionID uint, jti string, expiresAt time.Time) error {
+	if err := uc.sessionRepo.Revoke(sessionID, "logout"); err != nil {
+		return err
+	}
+	return uc.sessionRepo.RevokeToken(jti, expiresAt)
+}
+
+func (uc *AuthUseCase) JWKS() security.JWKSet {
+	return uc.tokens.JWKS()
+}
+
+// revokeReused revoca la sesión de un refresh token reutilizado
+func (uc *AuthUseCase) revokeReused(sessionID uint) error {
+	log.Printf("refresh token reuse detected, revoking session %d", sessionID)
+	if err := uc.sessionRepo.Revoke(sessionID, "reuse"); err != nil {
+		return err
+	}
+	return domain.ErrInvalidRefreshToken
+}
+
+// newRefreshToken genera un refresh token y el registro con su hash
+func (uc *AuthUseCase) newRefreshToken() (string, *domain.RefreshToken, error) {
+	raw, err := security.NewOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+	return raw, &domain.RefreshToken{
+		TokenHash: security.HashOpaqueToken(raw),
+		ExpiresAt: time.Now().Add(uc.jwtConfig.RefreshExpires),
+	}, nil
+}
+
+// tokenPair emite el access token con los permisos vigentes del usuario: un
+// cambio de roles se refleja en el siguiente refresh
+func (uc *AuthUseCase) tokenPair(user *userDomain.User, sessionID uint, refreshToken string) (*domain.TokenPair, error) {
+	roles, permissions, err := uc.permissions.UserPermissions(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(uc.jwtConfig.Expires)
+	accessToken, err := uc.tokens.GenerateToken(security.TokenClaims{
+		UserID:      user.ID,
+		Roles:       roles,
+		Permissions: permissions,
+		SessionID:   sessionID,
+	}, uc.jwtConfig.Expires)
+	if err != nil {
+		return nil, err
 	}
-	return security.GenerateToken(user.ID, user.Role, uc.jwtConfig.Secret, uc.jwtConfig.Expires)
+	return &domain.TokenPair{AccessToken: accessToken, RefreshToken: refreshToken, ExpiresAt: expiresAt}, nil
 }
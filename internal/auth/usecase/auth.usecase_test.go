@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"finanzas-api/config"
+	"finanzas-api/internal/auth/domain"
+	"finanzas-api/internal/auth/repository"
+	userDomain "finanzas-api/internal/users/domain"
+	userRepository "finanzas-api/internal/users/repository"
+	"finanzas-api/shared/security"
+)
+
+// testPermissions da a todos los usuarios el rol user sin permisos
+type testPermissions struct{}
+
+func (testPermissions) UserPermissions(userID uint) ([]string, []string, error) {
+	return []string{"user"}, nil, nil
+}
+
+type testAuth struct {
+	uc       *AuthUseCase
+	sessions domain.SessionRepository
+	tokens   *security.TokenService
+}
+
+// newTestAuth crea el caso de uso sobre repositorios en memoria con un
+// usuario activo ana@example.com / secreta123
+func newTestAuth(t *testing.T) *testAuth {
+	t.Helper()
+	keys, err := security.LoadKeySet(t.TempDir(), security.AlgorithmEdDSA, 24*time.Hour, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := security.HashPassword("secreta123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := userRepository.NewUserMemoryRepository()
+	verifiedAt := time.Now()
+	if err := users.Create(&userDomain.User{Email: "ana@example.com", FirstName: "Ana", LastName: "Gómez", Password: hash, IsActive: true, EmailVerifiedAt: &verifiedAt}); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &testAuth{sessions: repository.NewSessionMemoryRepository(), tokens: security.NewTokenService(keys, "finanzas-api", "finanzas-web")}
+	a.uc = NewAuthUseCase(users, a.sessions, testPermissions{}, a.tokens, config.JWTConfig{Expires: 15 * time.Minute, RefreshExpires: time.Hour})
+	return a
+}
+
+// sessionOf retorna la sesión y el jti del access token
+func (a *testAuth) sessionOf(t *testing.T, pair *domain.TokenPair) (*domain.Session, string) {
+	t.Helper()
+	claims, err := a.tokens.ParseToken(pair.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := a.sessions.GetByID(claims.SessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return session, claims.ID
+}
+
+func TestRefreshRotation(t *testing.T) {
+	tests := []struct {
+		name        string
+		reuse       bool // Presentar otra vez el token ya rotado
+		wantRevoked bool
+	}{
+		{name: "rotated token", wantRevoked: false},
+		{name: "reused token", reuse: true, wantRevoked: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAuth(t)
+			login, err := a.uc.Login("ana@example.com", "secreta123")
+			if err != nil {
+				t.Fatal(err)
+			}
+			rotated, err := a.uc.Refresh(login.RefreshToken)
+			if err != nil {
+				t.Fatalf("Refresh() error = %v", err)
+			}
+
+			if tt.reuse {
+				if _, err := a.uc.Refresh(login.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+					t.Fatalf("Refresh() with the used token error = %v, want ErrInvalidRefreshToken", err)
+				}
+			}
+
+			// Tras el reúso, el token vigente y los access tokens de la sesión
+			// tampoco sirven
+			session, jti := a.sessionOf(t, rotated)
+			if session.IsRevoked() != tt.wantRevoked {
+				t.Fatalf("session revoked = %v, want %v", session.IsRevoked(), tt.wantRevoked)
+			}
+			if tt.wantRevoked && session.RevokeReason != "reuse" {
+				t.Errorf("revoke reason = %q, want reuse", session.RevokeReason)
+			}
+			revoked, err := a.sessions.IsRevoked(session.ID, jti)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if revoked != tt.wantRevoked {
+				t.Errorf("access token revoked = %v, want %v", revoked, tt.wantRevoked)
+			}
+			_, err = a.uc.Refresh(rotated.RefreshToken)
+			if tt.wantRevoked != errors.Is(err, domain.ErrInvalidRefreshToken) {
+				t.Errorf("Refresh() with the current token error = %v", err)
+			}
+		})
+	}
+}
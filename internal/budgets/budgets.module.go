@@ -0,0 +1,40 @@
+package budgets
+
+import (
+	"fmt"
+
+	"finanzas-api/internal/budgets/domain"
+	"finanzas-api/internal/budgets/handler"
+	"finanzas-api/internal/budgets/repository"
+	"finanzas-api/internal/budgets/usecase"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+
+	"gorm.io/gorm"
+)
+
+type BudgetsModule struct {
+	Handler    *handler.BudgetHandler
+	UseCase    domain.BudgetUseCase
+	repository domain.BudgetRepository
+}
+
+func NewBudgetsModule(db *gorm.DB, categoryRepo categoryDomain.CategoryRepository, transactionRepo transactionDomain.TransactionRepository, converter domain.CurrencyConverter) *BudgetsModule {
+	var budgetRepo domain.BudgetRepository
+	var budgetUseCase domain.BudgetUseCase
+	var budgetHandler *handler.BudgetHandler
+
+	if err := db.AutoMigrate(&domain.Budget{}); err != nil {
+		panic(fmt.Sprintf("Error migrating budgets: %v", err))
+	}
+
+	budgetRepo = repository.NewBudgetPostgresRepository(db)
+	budgetUseCase = usecase.NewBudgetUseCase(budgetRepo, categoryRepo, transactionRepo, converter)
+	budgetHandler = handler.NewBudgetHandler(budgetUseCase)
+
+	return &BudgetsModule{
+		Handler:    budgetHandler,
+		UseCase:    budgetUseCase,
+		repository: budgetRepo,
+	}
+}
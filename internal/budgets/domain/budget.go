@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	categoryDomain "finanzas-api/internal/categories/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	userDomain "finanzas-api/internal/users/domain"
+
+	"gorm.io/gorm"
+)
+
+// Budget es el límite mensual de gasto de un usuario para una categoría.
+// El gasto de las subcategorías cuenta contra el presupuesto del padre.
+type Budget struct {
+	ID         uint                    `json:"id" gorm:"primaryKey"`
+	UserID     uint                    `json:"user_id" gorm:"not null;uniqueIndex:idx_budgets_user_category,where:deleted_at IS NULL"`
+	User       userDomain.User         `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	CategoryID uint                    `json:"category_id" gorm:"not null;uniqueIndex:idx_budgets_user_category,where:deleted_at IS NULL"`
+	Category   categoryDomain.Category `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Amount     int64                   `json:"amount" gorm:"not null"`                              // Límite mensual en unidades menores
+	Currency   string                  `json:"currency" gorm:"type:char(3);not null;default:'COP'"` // ISO 4217, por defecto la moneda base del usuario
+	// Rollover arrastra al mes siguiente lo no gastado (o el sobregiro)
+	Rollover   bool           `json:"rollover" gorm:"default:false"`
+	StartMonth time.Time      `json:"start_month" gorm:"type:date;not null"` // Primer día del mes inicial
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete
+}
+
+// BudgetStatus resume la ejecución de un presupuesto en un mes
+type BudgetStatus struct {
+	BudgetID     uint   `json:"budget_id"`
+	CategoryID   uint   `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	Month        string `json:"month"`
+	Currency     string `json:"currency"`
+	Budgeted     int64  `json:"budgeted"`
+	CarriedOver  int64  `json:"carried_over"`
+	Available    int64  `json:"available"`
+	Spent        int64  `json:"spent"`
+	Remaining    int64  `json:"remaining"`
+	Rollover     bool   `json:"rollover"`
+}
+
+// BudgetRepository define la interfaz del repositorio de presupuestos
+type BudgetRepository interface {
+	// WithContext retorna el repositorio con sus consultas atadas a ctx: se
+	// cancelan cuando ctx vence
+	WithContext(ctx context.Context) BudgetRepository
+	Create(budget *Budget) error
+	GetByID(id uint) (*Budget, error)
+	Update(budget *Budget) error
+	Delete(id uint) error
+	ListByUser(userID uint) ([]*Budget, error)
+	ExistsForCategory(userID, categoryID uint) (bool, error)
+}
+
+// CurrencyConverter convierte, sin registrar nada, las transacciones a la
+// moneda de cada presupuesto. Lo implementa el módulo de cotizaciones.
+type CurrencyConverter interface {
+	BaseCurrency(ctx context.Context, userID uint) (string, error)
+	PreviewConversions(ctx context.Context, userID uint, baseCurrency string, from, to time.Time) (transactionDomain.ConvertedAmounts, error)
+}
+
+type BudgetUseCase interface {
+	CreateBudget(budget *Budget) error
+	GetBudget(userID, id uint) (*Budget, error)
+	UpdateBudget(userID uint, budget *Budget) error
+	DeleteBudget(userID, id uint) error
+	ListBudgets(userID uint) ([]*Budget, error)
+	GetMonthlyStatus(ctx context.Context, userID uint, month time.Time) ([]*BudgetStatus, error)
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (Budget) TableName() string {
+	return "budgets"
+}
+
+// BelongsTo verifica si el presupuesto pertenece al usuario indicado
+func (b *Budget) BelongsTo(userID uint) bool {
+	return b.UserID == userID
+}
+
+// MonthStart normaliza una fecha al primer día de su mes
+func MonthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
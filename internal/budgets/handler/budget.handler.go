@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"finanzas-api/internal/budgets/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const monthLayout = "2006-01"
+
+type BudgetHandler struct {
+	budgetUseCase domain.BudgetUseCase
+}
+
+// NewBudgetHandler crea una nueva instancia del handler de presupuestos
+func NewBudgetHandler(budgetUseCase domain.BudgetUseCase) *BudgetHandler {
+	return &BudgetHandler{
+		budgetUseCase: budgetUseCase,
+	}
+}
+
+// CreateBudgetRequest representa la estructura de la petición para crear un presupuesto
+type CreateBudgetRequest struct {
+	CategoryID uint   `json:"category_id" binding:"required"`
+	Amount     int64  `json:"amount" binding:"required,gt=0"`
+	Currency   string `json:"currency" binding:"omitempty,len=3"` // Por defecto la moneda base del usuario
+	Rollover   bool   `json:"rollover"`
+	StartMonth string `json:"start_month"` // YYYY-MM, por defecto el mes actual
+}
+
+// UpdateBudgetRequest representa la estructura de la petición para actualizar un presupuesto
+type UpdateBudgetRequest struct {
+	Amount     int64  `json:"amount" binding:"omitempty,gt=0"`
+	Rollover   *bool  `json:"rollover"`
+	StartMonth string `json:"start_month"`
+}
+
+// CreateBudget maneja la creación de presupuestos del usuario autenticado
+func (h *BudgetHandler) CreateBudget(c *gin.Context) {
+	var req CreateBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	budget := &domain.Budget{
+		UserID:     c.GetUint("userID"),
+		CategoryID: req.CategoryID,
+		Amount:     req.Amount,
+		Currency:   req.Currency,
+		Rollover:   req.Rollover,
+	}
+	if req.StartMonth != "" {
+		start, err := time.Parse(monthLayout, req.StartMonth)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid start_month, expected YYYY-MM",
+			})
+			return
+		}
+		budget.StartMonth = start
+	}
+
+	if err := h.budgetUseCase.CreateBudget(budget); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Budget created successfully",
+		"budget":  budget,
+	})
+}
+
+// GetBudget obtiene un presupuesto del usuario autenticado
+func (h *BudgetHandler) GetBudget(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid budget ID",
+		})
+		return
+	}
+
+	budget, err := h.budgetUseCase.GetBudget(c.GetUint("userID"), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Budget not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"budget": budget,
+	})
+}
+
+// UpdateBudget actualiza un presupuesto del usuario autenticado
+func (h *BudgetHandler) UpdateBudget(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid budget ID",
+		})
+		return
+	}
+
+	var req UpdateBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.GetUint("userID")
+
+	// Obtener presupuesto existente
+	budget, err := h.budgetUseCase.GetBudget(userID, uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Budget not found",
+		})
+		return
+	}
+
+	// Actualizar campos si se proporcionan
+	if req.Amount != 0 {
+		budget.Amount = req.Amount
+	}
+	if req.Rollover != nil {
+		budget.Rollover = *req.Rollover
+	}
+	if req.StartMonth != "" {
+		start, err := time.Parse(monthLayout, req.StartMonth)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid start_month, expected YYYY-MM",
+			})
+			return
+		}
+		budget.StartMonth = start
+	}
+
+	if err := h.budgetUseCase.UpdateBudget(userID, budget); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Budget updated successfully",
+		"budget":  budget,
+	})
+}
+
+// DeleteBudget elimina un presupuesto del usuario autenticado
+func (h *BudgetHandler) DeleteBudget(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid budget ID",
+		})
+		return
+	}
+
+	if err := h.budgetUseCase.DeleteBudget(c.GetUint("userID"), uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Budget not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Budget deleted successfully",
+	})
+}
+
+// ListBudgets lista los presupuestos del usuario autenticado
+func (h *BudgetHandler) ListBudgets(c *gin.Context) {
+	budgets, err := h.budgetUseCase.ListBudgets(c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"budgets": budgets,
+	})
+}
+
+// GetStatus reporta presupuestado, gastado y restante para un mes (?month=YYYY-MM)
+func (h *BudgetHandler) GetStatus(c *gin.Context) {
+	month, err := time.Parse(monthLayout, c.DefaultQuery("month", time.Now().Format(monthLayout)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid month, expected YYYY-MM",
+		})
+		return
+	}
+
+	statuses, err := h.budgetUseCase.GetMonthlyStatus(c.Request.Context(), c.GetUint("userID"), month)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	var available, spent, remaining int64
+	for _, status := range statuses {
+		available += status.Available
+		spent += status.Spent
+		remaining += status.Remaining
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"month":   month.Format(monthLayout),
+		"budgets": statuses,
+		"totals": gin.H{
+			"available": available,
+			"spent":     spent,
+			"remaining": remaining,
+		},
+	})
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/budgets/domain"
+
+type BudgetRepository interface {
+	domain.BudgetRepository
+}
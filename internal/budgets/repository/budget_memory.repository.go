@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"finanzas-api/internal/budgets/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+type budgetRepositoryMemory struct {
+	budgets map[uint]*domain.Budget
+	nextID  uint
+	mutex   sync.RWMutex
+}
+
+func NewBudgetMemoryRepository() domain.BudgetRepository {
+	return &budgetRepositoryMemory{
+		budgets: make(map[uint]*domain.Budget),
+		nextID:  1,
+	}
+}
+
+// WithContext retorna el mismo repositorio: en memoria no hay consultas que
+// cancelar
+func (r *budgetRepositoryMemory) WithContext(ctx context.Context) domain.BudgetRepository {
+	return r
+}
+
+func (r *budgetRepositoryMemory) Create(budget *domain.Budget) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Un presupuesto activo por categoría
+	for _, existing := range r.budgets {
+		if existing.DeletedAt.Time.IsZero() && existing.UserID == budget.UserID && existing.CategoryID == budget.CategoryID {
+			return errors.New("budget already exists for category")
+		}
+	}
+
+	// Asignar ID y timestamps
+	budget.ID = r.nextID
+	r.nextID++
+	budget.CreatedAt = time.Now()
+	budget.UpdatedAt = time.Now()
+
+	r.budgets[budget.ID] = budget
+	return nil
+}
+
+func (r *budgetRepositoryMemory) GetByID(id uint) (*domain.Budget, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	budget, exists := r.budgets[id]
+	if !exists || !budget.DeletedAt.Time.IsZero() {
+		return nil, errors.New("budget not found")
+	}
+
+	return budget, nil
+}
+
+func (r *budgetRepositoryMemory) Update(budget *domain.Budget) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existing, exists := r.budgets[budget.ID]
+	if !exists || !existing.DeletedAt.Time.IsZero() {
+		return errors.New("budget not found")
+	}
+
+	budget.UpdatedAt = time.Now()
+	r.budgets[budget.ID] = budget
+
+	return nil
+}
+
+func (r *budgetRepositoryMemory) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	budget, exists := r.budgets[id]
+	if !exists || !budget.DeletedAt.Time.IsZero() {
+		return errors.New("budget not found")
+	}
+
+	// Soft delete
+	budget.DeletedAt.Time = time.Now()
+	budget.DeletedAt.Valid = true
+	budget.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (r *budgetRepositoryMemory) ListByUser(userID uint) ([]*domain.Budget, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var budgets []*domain.Budget
+	for _, budget := range r.budgets {
+		if budget.DeletedAt.Time.IsZero() && budget.UserID == userID {
+			budgets = append(budgets, budget)
+		}
+	}
+
+	sort.Slice(budgets, func(i, j int) bool { return budgets[i].ID < budgets[j].ID })
+	return budgets, nil
+}
+
+func (r *budgetRepositoryMemory) ExistsForCategory(userID, categoryID uint) (bool, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, budget := range r.budgets {
+		if budget.DeletedAt.Time.IsZero() && budget.UserID == userID && budget.CategoryID == categoryID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
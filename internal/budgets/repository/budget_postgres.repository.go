@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"finanzas-api/internal/budgets/domain"
+
+	"gorm.io/gorm"
+)
+
+type budgetPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewBudgetPostgresRepository(db *gorm.DB) domain.BudgetRepository {
+	return &budgetPostgresRepository{db: db}
+}
+
+func (r *budgetPostgresRepository) WithContext(ctx context.Context) domain.BudgetRepository {
+	return &budgetPostgresRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *budgetPostgresRepository) Create(budget *domain.Budget) error {
+	return r.db.Create(budget).Error
+}
+
+func (r *budgetPostgresRepository) GetByID(id uint) (*domain.Budget, error) {
+	var budget domain.Budget
+	if err := r.db.First(&budget, id).Error; err != nil {
+		return nil, err
+	}
+	return &budget, nil
+}
+
+func (r *budgetPostgresRepository) Update(budget *domain.Budget) error {
+	return r.db.Save(budget).Error
+}
+
+func (r *budgetPostgresRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Budget{}, id).Error // soft delete
+}
+
+func (r *budgetPostgresRepository) ListByUser(userID uint) ([]*domain.Budget, error) {
+	var budgets []*domain.Budget
+	if err := r.db.Where("user_id = ?", userID).Order("id").Find(&budgets).Error; err != nil {
+		return nil, err
+	}
+	return budgets, nil
+}
+
+func (r *budgetPostgresRepository) ExistsForCategory(userID, categoryID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.Budget{}).Where("user_id = ? AND category_id = ?", userID, categoryID).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"finanzas-api/internal/budgets/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupBudgetRoutes configura las rutas para el módulo de presupuestos
+func SetupBudgetRoutes(router *gin.Engine, budgetHandler *handler.BudgetHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	budgetRoutes := router.Group("/api/v1/budgets")
+	{
+		// POST /api/v1/budgets - Crear presupuesto mensual para una categoría
+		budgetRoutes.POST("", authMiddleware("budgets:write"), budgetHandler.CreateBudget)
+
+		// GET /api/v1/budgets - Listar presupuestos
+		budgetRoutes.GET("", authMiddleware("budgets:read"), budgetHandler.ListBudgets)
+
+		// GET /api/v1/budgets/status?month=YYYY-MM - Presupuestado, gastado y restante
+		budgetRoutes.GET("/status", authMiddleware("budgets:read"), budgetHandler.GetStatus)
+
+		// GET /api/v1/budgets/:id - Obtener presupuesto por ID
+		budgetRoutes.GET("/:id", authMiddleware("budgets:read"), budgetHandler.GetBudget)
+
+		// PUT /api/v1/budgets/:id - Actualizar presupuesto
+		budgetRoutes.PUT("/:id", authMiddleware("budgets:write"), budgetHandler.UpdateBudget)
+
+		// DELETE /api/v1/budgets/:id - Eliminar presupuesto
+		budgetRoutes.DELETE("/:id", authMiddleware("budgets:write"), budgetHandler.DeleteBudget)
+	}
+}
@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"finanzas-api/internal/budgets/domain"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	"finanzas-api/shared/money"
+	"strings"
+	"time"
+)
+
+type BudgetUseCase struct {
+	budgetRepo      domain.BudgetRepository
+	categoryRepo    categoryDomain.CategoryRepository
+	transactionRepo transactionDomain.TransactionRepository
+	converter       domain.CurrencyConverter
+}
+
+func NewBudgetUseCase(budgetRepo domain.BudgetRepository, categoryRepo categoryDomain.CategoryRepository, transactionRepo transactionDomain.TransactionRepository, converter domain.CurrencyConverter) domain.BudgetUseCase {
+	return &BudgetUseCase{
+		budgetRepo:      budgetRepo,
+		categoryRepo:    categoryRepo,
+		transactionRepo: transactionRepo,
+		converter:       converter,
+	}
+}
+
+// CreateBudget implements domain.BudgetUseCase.
+func (uc *BudgetUseCase) CreateBudget(budget *domain.Budget) error {
+	// Moneda por defecto
+	budget.Currency = strings.ToUpper(strings.TrimSpace(budget.Currency))
+	if budget.Currency == "" {
+		currency, err := uc.converter.BaseCurrency(context.Background(), budget.UserID)
+		if err != nil {
+			return err
+		}
+		budget.Currency = currency
+	}
+
+	if err := uc.validateBudgetData(budget); err != nil {
+		return err
+	}
+
+	exists, err := uc.budgetRepo.ExistsForCategory(budget.UserID, budget.CategoryID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("budget already exists for category")
+	}
+
+	return uc.budgetRepo.Create(budget)
+}
+
+// GetBudget implements domain.BudgetUseCase.
+func (uc *BudgetUseCase) GetBudget(userID, id uint) (*domain.Budget, error) {
+	if id == 0 {
+		return nil, errors.New("invalid budget ID")
+	}
+
+	budget, err := uc.budgetRepo.GetByID(id)
+	if err != nil || !budget.BelongsTo(userID) {
+		return nil, errors.New("budget not found")
+	}
+
+	return budget, nil
+}
+
+// UpdateBudget implements domain.BudgetUseCase.
+func (uc *BudgetUseCase) UpdateBudget(userID uint, budget *domain.Budget) error {
+	if budget.ID == 0 {
+		return errors.New("budget ID is required")
+	}
+
+	existing, err := uc.GetBudget(userID, budget.ID)
+	if err != nil {
+		return err
+	}
+
+	// El propietario, la categoría y la moneda no cambian
+	budget.UserID = existing.UserID
+	budget.CategoryID = existing.CategoryID
+	budget.Currency = existing.Currency
+
+	if err := uc.validateBudgetData(budget); err != nil {
+		return err
+	}
+
+	return uc.budgetRepo.Update(budget)
+}
+
+// DeleteBudget implements domain.BudgetUseCase.
+func (uc *BudgetUseCase) DeleteBudget(userID, id uint) error {
+	if _, err := uc.GetBudget(userID, id); err != nil {
+		return err
+	}
+
+	return uc.budgetRepo.Delete(id)
+}
+
+// ListBudgets implements domain.BudgetUseCase.
+func (uc *BudgetUseCase) ListBudgets(userID uint) ([]*domain.Budget, error) {
+	return uc.budgetRepo.ListByUser(userID)
+}
+
+// GetMonthlyStatus implements domain.BudgetUseCase.
+func (uc *BudgetUseCase) GetMonthlyStatus(ctx context.Context, userID uint, month time.Time) ([]*domain.BudgetStatus, error) {
+	month = domain.MonthStart(month)
+
+	budgets, err := uc.budgetRepo.WithContext(ctx).ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := uc.categoryRepo.WithContext(ctx).ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// El gasto de meses anteriores solo se necesita para el arrastre
+	from := month
+	for _, budget := range budgets {
+		if budget.Rollover && budget.StartMonth.Before(from) {
+			from = domain.MonthStart(budget.StartMonth)
+		}
+	}
+
+	// Gasto por moneda, mes y categoría; los montos en otras monedas se
+	// convierten a la de cada presupuesto y los que no tienen cotización se
+	// omiten.
+	spentByMonth := make(map[string]map[time.Time]map[uint]int64)
+	for _, budget := range budgets {
+		if spentByMonth[budget.Currency] != nil {
+			continue
+		}
+
+		converted, err := uc.converter.PreviewConversions(ctx, userID, budget.Currency, from, month.AddDate(0, 1, 0))
+		if err != nil {
+			return nil, err
+		}
+
+		totals, err := uc.transactionRepo.WithContext(ctx).MonthlyExpensesByCategory(userID, budget.Currency, from, month.AddDate(0, 1, 0), converted)
+		if err != nil {
+			return nil, err
+		}
+
+		byMonth := make(map[time.Time]map[uint]int64)
+		for _, total := range totals {
+			m := domain.MonthStart(total.Month)
+			if byMonth[m] == nil {
+				byMonth[m] = make(map[uint]int64)
+			}
+			byMonth[m][total.CategoryID] += total.Amount
+		}
+		spentByMonth[budget.Currency] = byMonth
+	}
+
+	names := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+
+	statuses := make([]*domain.BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		start := domain.MonthStart(budget.StartMonth)
+		if start.After(month) {
+			continue
+		}
+
+		subtree := descendants(categories, budget.CategoryID)
+		spentIn := func(m time.Time) int64 {
+			var spent int64
+			for categoryID := range subtree {
+				spent += spentByMonth[budget.Currency][m][categoryID]
+			}
+			return spent
+		}
+
+		// Lo no gastado suma y el sobregiro resta en los meses siguientes
+		var carried int64
+		if budget.Rollover {
+			for m := start; m.Before(month); m = m.AddDate(0, 1, 0) {
+				carried += budget.Amount - spentIn(m)
+			}
+		}
+
+		spent := spentIn(month)
+		available := budget.Amount + carried
+		statuses = append(statuses, &domain.BudgetStatus{
+			BudgetID:     budget.ID,
+			CategoryID:   budget.CategoryID,
+			CategoryName: names[budget.CategoryID],
+			Month:        month.Format("2006-01"),
+			Currency:     budget.Currency,
+			Budgeted:     budget.Amount,
+			CarriedOver:  carried,
+			Available:    available,
+			Spent:        spent,
+			Remaining:    available - spent,
+			Rollover:     budget.Rollover,
+		})
+	}
+
+	return statuses, nil
+}
+
+// validateBudgetData valida el presupuesto y su categoría
+func (uc *BudgetUseCase) validateBudgetData(budget *domain.Budget) error {
+	if budget == nil {
+		return errors.New("budget is required")
+	}
+
+	if budget.UserID == 0 {
+		return errors.New("user ID is required")
+	}
+
+	if budget.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+
+	if budget.StartMonth.IsZero() {
+		budget.StartMonth = time.Now()
+	}
+	budget.StartMonth = domain.MonthStart(budget.StartMonth)
+
+	if !money.IsValidCurrency(budget.Currency) {
+		return errors.New("invalid currency code")
+	}
+
+	category, err := uc.categoryRepo.GetByID(budget.CategoryID)
+	if err != nil || !category.BelongsTo(budget.UserID) {
+		return errors.New("category not found")
+	}
+
+	if category.Kind != categoryDomain.KindExpense {
+		return errors.New("budgets can only be set on expense categories")
+	}
+
+	return nil
+}
+
+// descendants retorna el conjunto formado por la categoría y todas sus subcategorías
+func descendants(categories []*categoryDomain.Category, rootID uint) map[uint]bool {
+	children := make(map[uint][]uint)
+	for _, category := range categories {
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+		}
+	}
+
+	result := map[uint]bool{rootID: true}
+	pending := []uint{rootID}
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		for _, child := range children[current] {
+			if !result[child] {
+				result[child] = true
+				pending = append(pending, child)
+			}
+		}
+	}
+	return result
+}
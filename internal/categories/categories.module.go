@@ -0,0 +1,47 @@
+package categories
+
+import (
+	"fmt"
+
+	"finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/categories/handler"
+	"finanzas-api/internal/categories/repository"
+	"finanzas-api/internal/categories/usecase"
+	userDomain "finanzas-api/internal/users/domain"
+
+	"gorm.io/gorm"
+)
+
+type CategoriesModule struct {
+	Handler    *handler.CategoryHandler
+	UseCase    domain.CategoryUseCase
+	Repository domain.CategoryRepository
+}
+
+func NewCategoriesModule(db *gorm.DB) *CategoriesModule {
+	var categoryRepo domain.CategoryRepository
+	var categoryUseCase domain.CategoryUseCase
+	var categoryHandler *handler.CategoryHandler
+
+	if err := db.AutoMigrate(&domain.Category{}); err != nil {
+		panic(fmt.Sprintf("Error migrating categories: %v", err))
+	}
+
+	categoryRepo = repository.NewCategoryPostgresRepository(db)
+	categoryUseCase = usecase.NewCategoryUseCase(categoryRepo)
+	categoryHandler = handler.NewCategoryHandler(categoryUseCase)
+
+	return &CategoriesModule{
+		Handler:    categoryHandler,
+		UseCase:    categoryUseCase,
+		Repository: categoryRepo,
+	}
+}
+
+// SeedDefaultsHook retorna el hook que crea el árbol de categorías por
+// defecto al registrar un usuario.
+func (m *CategoriesModule) SeedDefaultsHook() userDomain.UserCreatedHook {
+	return func(user *userDomain.User) error {
+		return m.UseCase.SeedDefaultCategories(user.ID, user.Locale)
+	}
+}
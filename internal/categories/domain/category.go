@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	userDomain "finanzas-api/internal/users/domain"
+
+	"gorm.io/gorm"
+)
+
+// Kind indica si la categoría clasifica ingresos o gastos
+type Kind string
+
+const (
+	KindIncome  Kind = "income"
+	KindExpense Kind = "expense"
+)
+
+type Category struct {
+	ID        uint            `json:"id" gorm:"primaryKey"`
+	UserID    uint            `json:"user_id" gorm:"not null;index"`
+	User      userDomain.User `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	ParentID  *uint           `json:"parent_id" gorm:"index"`
+	Parent    *Category       `json:"-" gorm:"constraint:OnDelete:SET NULL"`
+	Name      string          `json:"name" gorm:"not null"`
+	Icon      string          `json:"icon"`
+	Color     string          `json:"color" gorm:"type:varchar(7)"` // Formato #RRGGBB
+	Kind      Kind            `json:"kind" gorm:"type:varchar(10);not null"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	DeletedAt gorm.DeletedAt  `json:"-" gorm:"index"` // Soft delete
+
+	// Children se llena al construir el árbol y no se persiste
+	Children []*Category `json:"children,omitempty" gorm:"-"`
+}
+
+// CategoryRepository define la interfaz del repositorio de categorías
+type CategoryRepository interface {
+	// WithContext retorna el repositorio con sus consultas atadas a ctx: se
+	// cancelan cuando ctx vence
+	WithContext(ctx context.Context) CategoryRepository
+	Create(category *Category) error
+	GetByID(id uint) (*Category, error)
+	Update(category *Category) error
+	// Delete elimina la categoría, sube sus hijas al padre y deja sin
+	// categoría los registros que la usaban.
+	Delete(id uint) error
+	ListByUser(userID uint) ([]*Category, error)
+	// Merge reasigna a target las hijas y todos los registros que usaban
+	// source y luego elimina source.
+	Merge(sourceID, targetID uint) error
+}
+
+type CategoryUseCase interface {
+	CreateCategory(category *Category) error
+	GetCategory(userID, id uint) (*Category, error)
+	UpdateCategory(userID uint, category *Category) error
+	DeleteCategory(userID, id uint) error
+	ListCategories(userID uint) ([]*Category, error)
+	GetCategoryTree(userID uint) ([]*Category, error)
+	MoveCategory(userID, id uint, parentID *uint) (*Category, error)
+	MergeCategories(userID, sourceID, targetID uint) error
+	SeedDefaultCategories(userID uint, locale string) error
+	ValidateCategoryData(category *Category) error
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (Category) TableName() string {
+	return "categories"
+}
+
+// IsValidKind verifica si el tipo es ingreso o gasto
+func (c *Category) IsValidKind() bool {
+	return c.Kind == KindIncome || c.Kind == KindExpense
+}
+
+// BelongsTo verifica si la categoría pertenece al usuario indicado
+func (c *Category) BelongsTo(userID uint) bool {
+	return c.UserID == userID
+}
+
+// IsRoot indica si la categoría no tiene padre
+func (c *Category) IsRoot() bool {
+	return c.ParentID == nil
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"finanzas-api/internal/categories/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryHandler struct {
+	categoryUseCase domain.CategoryUseCase
+}
+
+// NewCategoryHandler crea una nueva instancia del handler de categorías
+func NewCategoryHandler(categoryUseCase domain.CategoryUseCase) *CategoryHandler {
+	return &CategoryHandler{
+		categoryUseCase: categoryUseCase,
+	}
+}
+
+// CreateCategoryRequest representa la estructura de la petición para crear una categoría
+type CreateCategoryRequest struct {
+	ParentID *uint  `json:"parent_id"`
+	Name     string `json:"name" binding:"required"`
+	Icon     string `json:"icon"`
+	Color    string `json:"color"`
+	Kind     string `json:"kind" binding:"required,oneof=income expense"`
+}
+
+// UpdateCategoryRequest representa la estructura de la petición para actualizar una categoría
+type UpdateCategoryRequest struct {
+	Name  string  `json:"name" binding:"omitempty"`
+	Icon  *string `json:"icon"`
+	Color *string `json:"color"`
+}
+
+// MoveCategoryRequest indica el nuevo padre; null mueve la categoría a la raíz
+type MoveCategoryRequest struct {
+	ParentID *uint `json:"parent_id"`
+}
+
+// MergeCategoryRequest indica la categoría que absorbe a la actual
+type MergeCategoryRequest struct {
+	TargetID uint `json:"target_id" binding:"required"`
+}
+
+// CreateCategory maneja la creación de categorías del usuario autenticado
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var req CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	category := &domain.Category{
+		UserID:   c.GetUint("userID"),
+		ParentID: req.ParentID,
+		Name:     req.Name,
+		Icon:     req.Icon,
+		Color:    req.Color,
+		Kind:     domain.Kind(req.Kind),
+	}
+
+	if err := h.categoryUseCase.CreateCategory(category); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Category created successfully",
+		"category": category,
+	})
+}
+
+// GetCategory obtiene una categoría del usuario autenticado
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid category ID",
+		})
+		return
+	}
+
+	category, err := h.categoryUseCase.GetCategory(c.GetUint("userID"), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Category not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"category": category,
+	})
+}
+
+// UpdateCategory actualiza nombre, icono o color de una categoría
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid category ID",
+		})
+		return
+	}
+
+	var req UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.GetUint("userID")
+
+	// Obtener categoría existente
+	category, err := h.categoryUseCase.GetCategory(userID, uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Category not found",
+		})
+		return
+	}
+
+	// Actualizar campos si se proporcionan
+	if req.Name != "" {
+		category.Name = req.Name
+	}
+	if req.Icon != nil {
+		category.Icon = *req.Icon
+	}
+	if req.Color != nil {
+		category.Color = *req.Color
+	}
+
+	if err := h.categoryUseCase.UpdateCategory(userID, category); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Category updated successfully",
+		"category": category,
+	})
+}
+
+// DeleteCategory elimina una categoría del usuario autenticado
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid category ID",
+		})
+		return
+	}
+
+	if err := h.categoryUseCase.DeleteCategory(c.GetUint("userID"), uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Category not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Category deleted successfully",
+	})
+}
+
+// ListCategories lista las categorías del usuario; con tree=true las anida
+func (h *CategoryHandler) ListCategories(c *gin.Context) {
+	userID := c.GetUint("userID")
+
+	var categories []*domain.Category
+	var err error
+	if c.Query("tree") == "true" {
+		categories, err = h.categoryUseCase.GetCategoryTree(userID)
+	} else {
+		categories, err = h.categoryUseCase.ListCategories(userID)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"categories": categories,
+	})
+}
+
+// MoveCategory cambia el padre de una categoría
+func (h *CategoryHandler) MoveCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid category ID",
+		})
+		return
+	}
+
+	var req MoveCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	category, err := h.categoryUseCase.MoveCategory(c.GetUint("userID"), uint(id), req.ParentID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Category moved successfully",
+		"category": category,
+	})
+}
+
+// MergeCategory fusiona la categoría en target_id y reasigna sus registros
+func (h *CategoryHandler) MergeCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid category ID",
+		})
+		return
+	}
+
+	var req MergeCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.categoryUseCase.MergeCategories(c.GetUint("userID"), uint(id), req.TargetID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Categories merged successfully",
+	})
+}
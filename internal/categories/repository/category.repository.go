@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/categories/domain"
+
+type CategoryRepository interface {
+	domain.CategoryRepository
+}
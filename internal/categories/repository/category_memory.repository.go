@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"finanzas-api/internal/categories/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+// La versión en memoria solo administra las categorías; no tiene acceso a
+// los registros de otros módulos que las referencian.
+type categoryRepositoryMemory struct {
+	categories map[uint]*domain.Category
+	nextID     uint
+	mutex      sync.RWMutex
+}
+
+func NewCategoryMemoryRepository() domain.CategoryRepository {
+	return &categoryRepositoryMemory{
+		categories: make(map[uint]*domain.Category),
+		nextID:     1,
+	}
+}
+
+// WithContext retorna el mismo repositorio: en memoria no hay consultas que
+// cancelar
+func (r *categoryRepositoryMemory) WithContext(ctx context.Context) domain.CategoryRepository {
+	return r
+}
+
+func (r *categoryRepositoryMemory) Create(category *domain.Category) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Asignar ID y timestamps
+	category.ID = r.nextID
+	r.nextID++
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = time.Now()
+
+	r.categories[category.ID] = category
+	return nil
+}
+
+func (r *categoryRepositoryMemory) GetByID(id uint) (*domain.Category, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	category, exists := r.categories[id]
+	if !exists || !category.DeletedAt.Time.IsZero() {
+		return nil, errors.New("category not found")
+	}
+
+	return category, nil
+}
+
+func (r *categoryRepositoryMemory) Update(category *domain.Category) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existing, exists := r.categories[category.ID]
+	if !exists || !existing.DeletedAt.Time.IsZero() {
+		return errors.New("category not found")
+	}
+
+	category.UpdatedAt = time.Now()
+	r.categories[category.ID] = category
+
+	return nil
+}
+
+func (r *categoryRepositoryMemory) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	category, exists := r.categories[id]
+	if !exists || !category.DeletedAt.Time.IsZero() {
+		return errors.New("category not found")
+	}
+
+	// Las hijas suben un nivel
+	r.reparent(id, category.ParentID)
+
+	// Soft delete
+	category.DeletedAt.Time = time.Now()
+	category.DeletedAt.Valid = true
+	category.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (r *categoryRepositoryMemory) ListByUser(userID uint) ([]*domain.Category, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var categories []*domain.Category
+	for _, category := range r.categories {
+		if category.DeletedAt.Time.IsZero() && category.UserID == userID {
+			categories = append(categories, category)
+		}
+	}
+
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	return categories, nil
+}
+
+func (r *categoryRepositoryMemory) Merge(sourceID, targetID uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	source, exists := r.categories[sourceID]
+	if !exists || !source.DeletedAt.Time.IsZero() {
+		return errors.New("category not found")
+	}
+
+	r.reparent(sourceID, &targetID)
+
+	// Soft delete
+	source.DeletedAt.Time = time.Now()
+	source.DeletedAt.Valid = true
+	source.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// reparent mueve las hijas de id al nuevo padre
+func (r *categoryRepositoryMemory) reparent(id uint, parentID *uint) {
+	for _, category := range r.categories {
+		if category.ParentID != nil && *category.ParentID == id {
+			category.ParentID = parentID
+			category.UpdatedAt = time.Now()
+		}
+	}
+}
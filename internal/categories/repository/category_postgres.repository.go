@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+
+	"finanzas-api/internal/categories/domain"
+
+	"gorm.io/gorm"
+)
+
+// categoryReferences lista las tablas con una columna category_id que deben
+// actualizarse al fusionar o eliminar categorías.
+var categoryReferences = []string{"transactions", "rules", "recurring_rules", "duplicate_reviews"}
+
+type categoryPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryPostgresRepository(db *gorm.DB) domain.CategoryRepository {
+	return &categoryPostgresRepository{db: db}
+}
+
+func (r *categoryPostgresRepository) WithContext(ctx context.Context) domain.CategoryRepository {
+	return &categoryPostgresRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *categoryPostgresRepository) Create(category *domain.Category) error {
+	return r.db.Create(category).Error
+}
+
+func (r *categoryPostgresRepository) GetByID(id uint) (*domain.Category, error) {
+	var category domain.Category
+	if err := r.db.First(&category, id).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *categoryPostgresRepository) Update(category *domain.Category) error {
+	return r.db.Omit("Parent", "User").Save(category).Error
+}
+
+func (r *categoryPostgresRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var category domain.Category
+		if err := tx.First(&category, id).Error; err != nil {
+			return err
+		}
+		// Antes de subir las hijas, las vistas que filtraban por la categoría
+		// pasan a filtrar por ellas
+		if err := deleteFromViews(tx, id); err != nil {
+			return err
+		}
+		// Las hijas suben un nivel
+		if err := tx.Model(&domain.Category{}).Where("parent_id = ?", id).Update("parent_id", category.ParentID).Error; err != nil {
+			return err
+		}
+		for _, table := range categoryReferences {
+			if err := tx.Table(table).Where("category_id = ?", id).Update("category_id", nil).Error; err != nil {
+				return err
+			}
+		}
+		// Un presupuesto no puede quedar sin categoría
+		if err := tx.Exec("UPDATE budgets SET deleted_at = NOW() WHERE category_id = ? AND deleted_at IS NULL", id).Error; err != nil {
+			return err
+		}
+		// Sus transacciones quedan sin categoría: el categorizador la olvida
+		if err := deleteFromCategorizer(tx, id); err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Category{}, id).Error // soft delete
+	})
+}
+
+func (r *categoryPostgresRepository) ListByUser(userID uint) ([]*domain.Category, error) {
+	var categories []*domain.Category
+	if err := r.db.Where("user_id = ?", userID).Order("name").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (r *categoryPostgresRepository) Merge(sourceID, targetID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Category{}).Where("parent_id = ?", sourceID).Update("parent_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := mergeBudgets(tx, sourceID, targetID); err != nil {
+			return err
+		}
+		if err := mergeIntoViews(tx, sourceID, targetID); err != nil {
+			return err
+		}
+		if err := mergeCategorizer(tx, sourceID, targetID); err != nil {
+			return err
+		}
+		for _, table := range categoryReferences {
+			if err := tx.Table(table).Where("category_id = ?", sourceID).Update("category_id", targetID).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&domain.Category{}, sourceID).Error // soft delete
+	})
+}
+
+// mergeBudgets suma el presupuesto de source al de target si ambos existen;
+// si solo existe el de source, lo reasigna a target.
+func mergeBudgets(tx *gorm.DB, sourceID, targetID uint) error {
+	err := tx.Exec(`UPDATE budgets AS t SET amount = t.amount + s.amount, updated_at = NOW()
+		FROM budgets AS s
+		WHERE s.category_id = ? AND t.category_id = ?
+		AND s.user_id = t.user_id AND s.deleted_at IS NULL AND t.deleted_at IS NULL`, sourceID, targetID).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Exec(`UPDATE budgets AS s SET deleted_at = NOW()
+		WHERE s.category_id = ? AND s.deleted_at IS NULL
+		AND EXISTS (SELECT 1 FROM budgets AS t WHERE t.category_id = ? AND t.user_id = s.user_id AND t.deleted_at IS NULL)`, sourceID, targetID).Error
+	if err != nil {
+		return err
+	}
+	return tx.Exec("UPDATE budgets SET category_id = ?, updated_at = NOW() WHERE category_id = ? AND deleted_at IS NULL", targetID, sourceID).Error
+}
+
+// mergeCategorizer suma a target lo que el categorizador aprendió de source,
+// como si sus transacciones siempre hubieran sido de target. Los cambios de
+// categoría de Merge no pasan por el hook que lo mantiene al día.
+func mergeCategorizer(tx *gorm.DB, sourceID, targetID uint) error {
+	err := tx.Exec(`INSERT INTO categorizer_tokens (user_id, category_id, token, count)
+		SELECT user_id, ?, token, count FROM categorizer_tokens WHERE category_id = ?
+		ON CONFLICT (user_id, category_id, token) DO UPDATE SET count = categorizer_tokens.count + EXCLUDED.count`, targetID, sourceID).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Exec(`INSERT INTO categorizer_categories (user_id, category_id, documents, tokens)
+		SELECT user_id, ?, documents, tokens FROM categorizer_categories WHERE category_id = ?
+		ON CONFLICT (user_id, category_id) DO UPDATE SET documents = categorizer_categories.documents + EXCLUDED.documents,
+			tokens = categorizer_categories.tokens + EXCLUDED.tokens`, targetID, sourceID).Error
+	if err != nil {
+		return err
+	}
+	return deleteFromCategorizer(tx, sourceID)
+}
+
+// deleteFromCategorizer borra los conteos del categorizador de la categoría
+func deleteFromCategorizer(tx *gorm.DB, id uint) error {
+	if err := tx.Exec("DELETE FROM categorizer_tokens WHERE category_id = ?", id).Error; err != nil {
+		return err
+	}
+	return tx.Exec("DELETE FROM categorizer_categories WHERE category_id = ?", id).Error
+}
+
+// mergeIntoViews reemplaza source por target en las categorías de las vistas
+// guardadas, sin repetir target si la vista ya lo tenía.
+func mergeIntoViews(tx *gorm.DB, sourceID, targetID uint) error {
+	return tx.Exec(`UPDATE smart_views SET updated_at = NOW(), definition = jsonb_set(definition, '{category_ids}', (
+			SELECT jsonb_agg(DISTINCT CASE WHEN ids.id = to_jsonb(?::bigint) THEN to_jsonb(?::bigint) ELSE ids.id END)
+			FROM jsonb_array_elements(definition->'category_ids') AS ids(id)))
+		WHERE definition->'category_ids' @> jsonb_build_array(?::bigint) AND deleted_at IS NULL`, sourceID, targetID, sourceID).Error
+}
+
+// deleteFromViews reemplaza la categoría por sus hijas en las vistas
+// guardadas. Si no tiene hijas y es la única categoría de la vista, se deja:
+// sus transacciones quedan sin categoría y la vista no encuentra ninguna, en
+// vez de pasar a incluir todas las categorías.
+func deleteFromViews(tx *gorm.DB, id uint) error {
+	return tx.Exec(`UPDATE smart_views SET updated_at = NOW(), definition = jsonb_set(definition, '{category_ids}', (
+			SELECT jsonb_agg(DISTINCT kept.id) FROM (
+				SELECT ids.id FROM jsonb_array_elements(definition->'category_ids') AS ids(id) WHERE ids.id <> to_jsonb(?::bigint)
+				UNION ALL
+				SELECT to_jsonb(c.id) FROM categories AS c WHERE c.parent_id = ? AND c.deleted_at IS NULL
+			) AS kept(id)))
+		WHERE definition->'category_ids' @> jsonb_build_array(?::bigint) AND deleted_at IS NULL
+		AND (jsonb_array_length(definition->'category_ids') > 1
+			OR EXISTS (SELECT 1 FROM categories WHERE parent_id = ? AND deleted_at IS NULL))`, id, id, id, id).Error
+}
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"finanzas-api/internal/categories/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupCategoryRoutes configura las rutas para el módulo de categorías
+func SetupCategoryRoutes(router *gin.Engine, categoryHandler *handler.CategoryHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	categoryRoutes := router.Group("/api/v1/categories")
+	{
+		// POST /api/v1/categories - Crear categoría
+		categoryRoutes.POST("", authMiddleware("categories:write"), categoryHandler.CreateCategory)
+
+		// GET /api/v1/categories - Listar categorías (?tree=true para el árbol)
+		categoryRoutes.GET("", authMiddleware("categories:read"), categoryHandler.ListCategories)
+
+		// GET /api/v1/categories/:id - Obtener categoría por ID
+		categoryRoutes.GET("/:id", authMiddleware("categories:read"), categoryHandler.GetCategory)
+
+		// PUT /api/v1/categories/:id - Actualizar categoría
+		categoryRoutes.PUT("/:id", authMiddleware("categories:write"), categoryHandler.UpdateCategory)
+
+		// DELETE /api/v1/categories/:id - Eliminar categoría
+		categoryRoutes.DELETE("/:id", authMiddleware("categories:write"), categoryHandler.DeleteCategory)
+
+		// POST /api/v1/categories/:id/move - Cambiar el padre
+		categoryRoutes.POST("/:id/move", authMiddleware("categories:write"), categoryHandler.MoveCategory)
+
+		// POST /api/v1/categories/:id/merge - Fusionar en otra categoría
+		categoryRoutes.POST("/:id/merge", authMiddleware("categories:write"), categoryHandler.MergeCategory)
+	}
+}
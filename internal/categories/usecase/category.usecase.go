@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"errors"
+	"finanzas-api/internal/categories/domain"
+	"regexp"
+	"strings"
+)
+
+var colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+type CategoryUseCase struct {
+	categoryRepo domain.CategoryRepository
+}
+
+func NewCategoryUseCase(categoryRepo domain.CategoryRepository) domain.CategoryUseCase {
+	return &CategoryUseCase{
+		categoryRepo: categoryRepo,
+	}
+}
+
+// CreateCategory implements domain.CategoryUseCase.
+func (uc *CategoryUseCase) CreateCategory(category *domain.Category) error {
+	if err := uc.ValidateCategoryData(category); err != nil {
+		return err
+	}
+
+	if category.UserID == 0 {
+		return errors.New("user ID is required")
+	}
+
+	if category.ParentID != nil {
+		parent, err := uc.GetCategory(category.UserID, *category.ParentID)
+		if err != nil {
+			return errors.New("parent category not found")
+		}
+		if parent.Kind != category.Kind {
+			return errors.New("parent category has a different kind")
+		}
+	}
+
+	return uc.categoryRepo.Create(category)
+}
+
+// GetCategory implements domain.CategoryUseCase.
+func (uc *CategoryUseCase) GetCategory(userID, id uint) (*domain.Category, error) {
+	if id == 0 {
+		return nil, errors.New("invalid category ID")
+	}
+
+	category, err := uc.categoryRepo.GetByID(id)
+	if err != nil || !category.BelongsTo(userID) {
+		return nil, errors.New("category not found")
+	}
+
+	return category, nil
+}
+
+// UpdateCategory implements domain.CategoryUseCase.
+// El padre se cambia con MoveCategory.
+func (uc *CategoryUseCase) UpdateCategory(userID uint, category *domain.Category) error {
+	if category.ID == 0 {
+		return errors.New("category ID is required")
+	}
+
+	if err := uc.ValidateCategoryData(category); err != nil {
+		return err
+	}
+
+	existing, err := uc.GetCategory(userID, category.ID)
+	if err != nil {
+		return err
+	}
+
+	// El propietario y la posición en el árbol no cambian aquí
+	category.UserID = existing.UserID
+	category.ParentID = existing.ParentID
+
+	if category.Kind != existing.Kind {
+		return errors.New("category kind cannot be changed")
+	}
+
+	return uc.categoryRepo.Update(category)
+}
+
+// DeleteCategory implements domain.CategoryUseCase.
+func (uc *CategoryUseCase) DeleteCategory(userID, id uint) error {
+	if _, err := uc.GetCategory(userID, id); err != nil {
+		return err
+	}
+
+	return uc.categoryRepo.Delete(id)
+}
+
+// ListCategories implements domain.CategoryUseCase.
+func (uc *CategoryUseCase) ListCategories(userID uint) ([]*domain.Category, error) {
+	return uc.categoryRepo.ListByUser(userID)
+}
+
+// GetCategoryTree implements domain.CategoryUseCase.
+func (uc *CategoryUseCase) GetCategoryTree(userID uint) ([]*domain.Category, error) {
+	categories, err := uc.categoryRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]*domain.Category, len(categories))
+	for _, category := range categories {
+		category.Children = nil
+		byID[category.ID] = category
+	}
+
+	var roots []*domain.Category
+	for _, category := range categories {
+		if category.ParentID != nil {
+			if parent, exists := byID[*category.ParentID]; exists {
+				parent.Children = append(parent.Children, category)
+				continue
+			}
+		}
+		roots = append(roots, category)
+	}
+
+	return roots, nil
+}
+
+// MoveCategory implements domain.CategoryUseCase.
+func (uc *CategoryUseCase) MoveCategory(userID, id uint, parentID *uint) (*domain.Category, error) {
+	category, err := uc.GetCategory(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID != nil {
+		parent, err := uc.GetCategory(userID, *parentID)
+		if err != nil {
+			return nil, errors.New("parent category not found")
+		}
+		if parent.Kind != category.Kind {
+			return nil, errors.New("parent category has a different kind")
+		}
+		isDescendant, err := uc.isDescendant(userID, parent.ID, category.ID)
+		if err != nil {
+			return nil, err
+		}
+		if isDescendant {
+			return nil, errors.New("a category cannot be moved under itself")
+		}
+	}
+
+	category.ParentID = parentID
+	if err := uc.categoryRepo.Update(category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// MergeCategories implements domain.CategoryUseCase.
+func (uc *CategoryUseCase) MergeCategories(userID, sourceID, targetID uint) error {
+	if sourceID == targetID {
+		return errors.New("cannot merge a category into itself")
+	}
+
+	source, err := uc.GetCategory(userID, sourceID)
+	if err != nil {
+		return err
+	}
+
+	target, err := uc.GetCategory(userID, targetID)
+	if err != nil {
+		return err
+	}
+
+	if source.Kind != target.Kind {
+		return errors.New("categories have different kinds")
+	}
+
+	// Si target cuelga de source, al reasignar las hijas quedaría un ciclo
+	isDescendant, err := uc.isDescendant(userID, target.ID, source.ID)
+	if err != nil {
+		return err
+	}
+	if isDescendant {
+		return errors.New("cannot merge a category into one of its descendants")
+	}
+
+	return uc.categoryRepo.Merge(source.ID, target.ID)
+}
+
+// SeedDefaultCategories implements domain.CategoryUseCase.
+func (uc *CategoryUseCase) SeedDefaultCategories(userID uint, locale string) error {
+	if userID == 0 {
+		return errors.New("user ID is required")
+	}
+
+	if err := uc.seed(userID, nil, domain.KindExpense, locale, defaultExpenseTree); err != nil {
+		return err
+	}
+	return uc.seed(userID, nil, domain.KindIncome, locale, defaultIncomeTree)
+}
+
+// ValidateCategoryData implements domain.CategoryUseCase.
+func (uc *CategoryUseCase) ValidateCategoryData(category *domain.Category) error {
+	if category == nil {
+		return errors.New("category is required")
+	}
+
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if len(category.Name) > 100 {
+		return errors.New("name too long")
+	}
+
+	if !category.IsValidKind() {
+		return errors.New("invalid category kind")
+	}
+
+	category.Icon = strings.TrimSpace(category.Icon)
+	if len(category.Icon) > 50 {
+		return errors.New("icon too long")
+	}
+
+	if category.Color != "" && !colorPattern.MatchString(category.Color) {
+		return errors.New("invalid color, expected #RRGGBB")
+	}
+	category.Color = strings.ToUpper(category.Color)
+
+	return nil
+}
+
+// seed crea recursivamente el árbol de categorías por defecto
+func (uc *CategoryUseCase) seed(userID uint, parentID *uint, kind domain.Kind, locale string, tree []defaultCategory) error {
+	for _, node := range tree {
+		category := node.build(userID, kind, locale)
+		category.ParentID = parentID
+		if err := uc.categoryRepo.Create(category); err != nil {
+			return err
+		}
+		if err := uc.seed(userID, &category.ID, kind, locale, node.children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isDescendant verifica si candidate está en el subárbol de ancestorID
+// (incluido el propio ancestorID).
+func (uc *CategoryUseCase) isDescendant(userID, candidate, ancestorID uint) (bool, error) {
+	categories, err := uc.categoryRepo.ListByUser(userID)
+	if err != nil {
+		return false, err
+	}
+
+	parents := make(map[uint]*uint, len(categories))
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+
+	// Subir desde candidate hasta la raíz; el límite evita ciclos corruptos
+	current := &candidate
+	for steps := 0; current != nil && steps <= len(categories); steps++ {
+		if *current == ancestorID {
+			return true, nil
+		}
+		current = parents[*current]
+	}
+	return false, nil
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import "finanzas-api/internal/categories/domain"
+
+// defaultCategory describe una categoría del árbol inicial con su nombre
+// en español e inglés.
+type defaultCategory struct {
+	es       string
+	en       string
+	icon     string
+	color    string
+	children []defaultCategory
+}
+
+// defaultExpenseTree es el árbol de gastos que recibe cada usuario nuevo
+var defaultExpenseTree = []defaultCategory{
+	{es: "Hogar", en: "Home", icon: "home", color: "#8D6E63", children: []defaultCategory{
+		{es: "Arriendo", en: "Rent", icon: "key", color: "#8D6E63"},
+		{es: "Servicios", en: "Utilities", icon: "plug", color: "#8D6E63", children: []defaultCategory{
+			{es: "Energía", en: "Electricity", icon: "zap", color: "#FBC02D"},
+			{es: "Agua", en: "Water", icon: "droplet", color: "#29B6F6"},
+			{es: "Gas", en: "Gas", icon: "flame", color: "#FF7043"},
+			{es: "Internet y telefonía", en: "Internet and phone", icon: "wifi", color: "#5C6BC0"},
+		}},
+		{es: "Mantenimiento", en: "Maintenance", icon: "tool", color: "#8D6E63"},
+	}},
+	{es: "Alimentación", en: "Food", icon: "shopping-cart", color: "#43A047", children: []defaultCategory{
+		{es: "Mercado", en: "Groceries", icon: "shopping-bag", color: "#43A047"},
+		{es: "Restaurantes", en: "Restaurants", icon: "coffee", color: "#43A047"},
+	}},
+	{es: "Transporte", en: "Transportation", icon: "truck", color: "#1E88E5", children: []defaultCategory{
+		{es: "Combustible", en: "Fuel", icon: "fuel", color: "#1E88E5"},
+		{es: "Transporte público", en: "Public transport", icon: "bus", color: "#1E88E5"},
+		{es: "Taxi", en: "Taxi", icon: "navigation", color: "#1E88E5"},
+	}},
+	{es: "Salud", en: "Health", icon: "heart", color: "#E53935", children: []defaultCategory{
+		{es: "Medicamentos", en: "Medicines", icon: "package", color: "#E53935"},
+		{es: "Consultas médicas", en: "Doctor visits", icon: "activity", color: "#E53935"},
+	}},
+	{es: "Educación", en: "Education", icon: "book", color: "#6D4C41"},
+	{es: "Entretenimiento", en: "Entertainment", icon: "film", color: "#8E24AA", children: []defaultCategory{
+		{es: "Suscripciones", en: "Subscriptions", icon: "repeat", color: "#8E24AA"},
+	}},
+	{es: "Compras", en: "Shopping", icon: "tag", color: "#F4511E", children: []defaultCategory{
+		{es: "Ropa", en: "Clothing", icon: "shirt", color: "#F4511E"},
+	}},
+	{es: "Gastos financieros", en: "Financial expenses", icon: "credit-card", color: "#546E7A", children: []defaultCategory{
+		{es: "Intereses", en: "Interest", icon: "percent", color: "#546E7A"},
+		{es: "Comisiones bancarias", en: "Bank fees", icon: "file-text", color: "#546E7A"},
+		{es: "Impuestos", en: "Taxes", icon: "archive", color: "#546E7A"},
+	}},
+}
+
+// defaultIncomeTree es el árbol de ingresos que recibe cada usuario nuevo
+var defaultIncomeTree = []defaultCategory{
+	{es: "Salario", en: "Salary", icon: "briefcase", color: "#2E7D32"},
+	{es: "Inversiones", en: "Investments", icon: "trending-up", color: "#00897B"},
+	{es: "Otros ingresos", en: "Other income", icon: "plus-circle", color: "#7CB342"},
+}
+
+// name retorna el nombre de la categoría en el idioma indicado
+func (d defaultCategory) name(locale string) string {
+	if locale == "en" {
+		return d.en
+	}
+	return d.es
+}
+
+// build convierte la definición en categorías del dominio para el usuario
+func (d defaultCategory) build(userID uint, kind domain.Kind, locale string) *domain.Category {
+	return &domain.Category{
+		UserID: userID,
+		Name:   d.name(locale),
+		Icon:   d.icon,
+		Color:  d.color,
+		Kind:   kind,
+	}
+}
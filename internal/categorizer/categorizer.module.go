@@ -0,0 +1,53 @@
+package categorizer
+
+import (
+	"fmt"
+
+	categoryDomain "finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/categorizer/domain"
+	"finanzas-api/internal/categorizer/handler"
+	"finanzas-api/internal/categorizer/repository"
+	"finanzas-api/internal/categorizer/usecase"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+
+	"gorm.io/gorm"
+)
+
+type CategorizerModule struct {
+	Handler    *handler.CategorizerHandler
+	UseCase    domain.CategorizerUseCase
+	Repository domain.CategorizerRepository
+}
+
+func NewCategorizerModule(db *gorm.DB, transactionRepo transactionDomain.TransactionRepository, categoryRepo categoryDomain.CategoryRepository) *CategorizerModule {
+	var categorizerRepo domain.CategorizerRepository
+	var categorizerUseCase domain.CategorizerUseCase
+	var categorizerHandler *handler.CategorizerHandler
+
+	if err := db.AutoMigrate(&domain.CategoryCount{}, &domain.TokenCount{}, &domain.Settings{}); err != nil {
+		panic(fmt.Sprintf("Error migrating categorizer: %v", err))
+	}
+
+	categorizerRepo = repository.NewCategorizerPostgresRepository(db)
+	categorizerUseCase = usecase.NewCategorizerUseCase(categorizerRepo, transactionRepo, categoryRepo)
+	categorizerHandler = handler.NewCategorizerHandler(categorizerUseCase)
+
+	return &CategorizerModule{
+		Handler:    categorizerHandler,
+		UseCase:    categorizerUseCase,
+		Repository: categorizerRepo,
+	}
+}
+
+// AutoApplyHook retorna el hook que asigna la categoría sugerida a las
+// transacciones nuevas. Se registra después de las reglas, que tienen
+// prioridad por ser explícitas.
+func (m *CategorizerModule) AutoApplyHook() transactionDomain.BeforeCreateHook {
+	return m.UseCase.AutoApply
+}
+
+// LearnHook retorna el hook que actualiza el modelo cuando el usuario
+// cambia la categorización de una transacción
+func (m *CategorizerModule) LearnHook() transactionDomain.CategoryChangeHook {
+	return m.UseCase.Learn
+}
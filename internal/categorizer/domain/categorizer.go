@@ -0,0 +1,237 @@
+package domain
+
+import (
+	"math"
+	"sort"
+
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	userDomain "finanzas-api/internal/users/domain"
+)
+
+const (
+	// MaxSuggestions es la cantidad de categorías sugeridas que se retornan
+	MaxSuggestions = 3
+	// MinAutoApplyDocuments es cuántas transacciones categorizadas necesita
+	// el modelo antes de aplicar sugerencias automáticamente
+	MinAutoApplyDocuments = 20
+	// MinAutoApplyThreshold es el umbral más bajo aceptado: por debajo,
+	// otra categoría podría ser igual de probable
+	MinAutoApplyThreshold = 0.5
+	// maxTokenLength es el largo de la columna token
+	maxTokenLength = 100
+)
+
+// TokenCount cuenta en cuántas transacciones de una categoría aparece un
+// token. Es la parte del modelo bayesiano ingenuo que crece con el uso.
+type TokenCount struct {
+	UserID     uint            `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	User       userDomain.User `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	CategoryID uint            `json:"category_id" gorm:"primaryKey;autoIncrement:false"`
+	Token      string          `json:"token" gorm:"primaryKey;type:varchar(100)"`
+	Count      int             `json:"count" gorm:"not null"`
+}
+
+// CategoryCount resume las transacciones de entrenamiento de una categoría
+type CategoryCount struct {
+	UserID     uint            `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	User       userDomain.User `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	CategoryID uint            `json:"category_id" gorm:"primaryKey;autoIncrement:false"`
+	Documents  int             `json:"documents" gorm:"not null"` // Transacciones aprendidas
+	Tokens     int             `json:"tokens" gorm:"not null"`    // Suma de los conteos de sus tokens
+}
+
+// Settings guarda la configuración del categorizador de cada usuario
+type Settings struct {
+	UserID uint            `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	User   userDomain.User `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	// AutoApplyThreshold es la confianza mínima para asignar la categoría
+	// sugerida a las transacciones nuevas; nil desactiva la asignación
+	AutoApplyThreshold *float64 `json:"auto_apply_threshold"`
+}
+
+// Example es una transacción categorizada lista para entrenar
+type Example struct {
+	CategoryID uint
+	Tokens     []string
+}
+
+// CategoryStats son los conteos de una categoría que usa la predicción
+type CategoryStats struct {
+	Documents int
+	Tokens    int
+	Counts    map[string]int // Solo los tokens consultados
+}
+
+// ModelStats es la parte del modelo de un usuario necesaria para predecir
+type ModelStats struct {
+	Categories map[uint]*CategoryStats
+	Vocabulary int // Tokens distintos en todo el modelo del usuario
+}
+
+// Suggestion es una categoría sugerida con su confianza entre 0 y 1
+type Suggestion struct {
+	CategoryID   uint    `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Confidence   float64 `json:"confidence"`
+}
+
+// SuggestionInput son los datos de la transacción a categorizar
+type SuggestionInput struct {
+	Description string
+	Payee       string
+	Direction   transactionDomain.Direction // Vacía considera todas las categorías
+}
+
+// TrainingResult resume un reentrenamiento completo
+type TrainingResult struct {
+	Transactions int `json:"transactions"`
+	Categories   int `json:"categories"`
+}
+
+// CategorizerRepository define la interfaz del repositorio del modelo
+type CategorizerRepository interface {
+	// Learn suma (delta 1) o resta (delta -1) una transacción al modelo
+	Learn(userID uint, example Example, delta int) error
+	// Train reemplaza el modelo del usuario por uno entrenado con examples
+	Train(userID uint, examples []Example) error
+	// Stats retorna los conteos de todas las categorías, limitados a tokens
+	Stats(userID uint, tokens []string) (*ModelStats, error)
+	// GetSettings retorna la configuración del usuario o la de por defecto
+	GetSettings(userID uint) (*Settings, error)
+	SaveSettings(settings *Settings) error
+}
+
+type CategorizerUseCase interface {
+	Suggest(userID uint, input SuggestionInput) ([]*Suggestion, error)
+	// Retrain reconstruye el modelo con todo el historial categorizado
+	Retrain(userID uint) (*TrainingResult, error)
+	GetSettings(userID uint) (*Settings, error)
+	UpdateSettings(settings *Settings) error
+	// Learn actualiza el modelo con un cambio de categorización ya guardado
+	Learn(before, after *transactionDomain.Transaction) error
+	// AutoApply asigna la categoría sugerida a una transacción nueva sin
+	// categoría si supera el umbral del usuario
+	AutoApply(transaction *transactionDomain.Transaction) error
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (TokenCount) TableName() string {
+	return "categorizer_tokens"
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (CategoryCount) TableName() string {
+	return "categorizer_categories"
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (Settings) TableName() string {
+	return "categorizer_settings"
+}
+
+// Tokenize extrae los tokens de una transacción: las palabras de la
+// descripción y las del comercio, estas con prefijo para distinguirlas.
+// Cada token aparece una vez.
+func Tokenize(description, payee string) []string {
+	seen := make(map[string]bool)
+	var tokens []string
+	add := func(token string) {
+		// Palabras tan largas suelen ser referencias pegadas, no aportan
+		if len(token) > maxTokenLength {
+			return
+		}
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	for _, word := range transactionDomain.NormalizeDescription(description) {
+		add(word)
+	}
+	for _, word := range transactionDomain.NormalizeDescription(payee) {
+		add("payee:" + word)
+	}
+	return tokens
+}
+
+// ExampleFrom arma el ejemplo de entrenamiento de una transacción
+// categorizada; retorna false si no tiene categoría
+func ExampleFrom(transaction *transactionDomain.Transaction) (Example, bool) {
+	if transaction == nil || transaction.CategoryID == nil {
+		return Example{}, false
+	}
+	return Example{
+		CategoryID: *transaction.CategoryID,
+		Tokens:     Tokenize(transaction.Description, transaction.Payee),
+	}, true
+}
+
+// Documents es el total de transacciones aprendidas por el modelo
+func (s *ModelStats) Documents() int {
+	total := 0
+	for _, category := range s.Categories {
+		total += category.Documents
+	}
+	return total
+}
+
+// Predict calcula la probabilidad de cada candidata con bayes ingenuo y
+// suavizado de Laplace; la confianza es la probabilidad normalizada entre
+// las candidatas. Los tokens que el modelo nunca vio se ignoran y, si no
+// conoce ninguno, no hay evidencia para sugerir.
+func (s *ModelStats) Predict(tokens []string, candidates []uint) map[uint]float64 {
+	known := tokens[:0:0]
+	for _, token := range tokens {
+		for _, category := range s.Categories {
+			if category.Counts[token] > 0 {
+				known = append(known, token)
+				break
+			}
+		}
+	}
+	if len(known) == 0 {
+		return nil
+	}
+
+	documents := s.Documents()
+	scores := make(map[uint]float64)
+	best := math.Inf(-1)
+	for _, categoryID := range candidates {
+		category, exists := s.Categories[categoryID]
+		if !exists || category.Documents <= 0 {
+			continue
+		}
+		score := math.Log(float64(category.Documents+1) / float64(documents+len(s.Categories)))
+		for _, token := range known {
+			score += math.Log(float64(category.Counts[token]+1) / float64(category.Tokens+s.Vocabulary+1))
+		}
+		scores[categoryID] = score
+		best = math.Max(best, score)
+	}
+
+	// Softmax restando el máximo para no desbordar exp
+	var total float64
+	for categoryID, score := range scores {
+		scores[categoryID] = math.Exp(score - best)
+		total += scores[categoryID]
+	}
+	for categoryID := range scores {
+		scores[categoryID] /= total
+	}
+	return scores
+}
+
+// RankSuggestions ordena las sugerencias por confianza descendente y luego
+// por nombre, y deja las primeras limit
+func RankSuggestions(suggestions []*Suggestion, limit int) []*Suggestion {
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Confidence != suggestions[j].Confidence {
+			return suggestions[i].Confidence > suggestions[j].Confidence
+		}
+		return suggestions[i].CategoryName < suggestions[j].CategoryName
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"math"
+	"net/http"
+
+	"finanzas-api/internal/categorizer/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CategorizerHandler struct {
+	categorizerUseCase domain.CategorizerUseCase
+}
+
+// NewCategorizerHandler crea una nueva instancia del handler del categorizador
+func NewCategorizerHandler(categorizerUseCase domain.CategorizerUseCase) *CategorizerHandler {
+	return &CategorizerHandler{
+		categorizerUseCase: categorizerUseCase,
+	}
+}
+
+// SettingsRequest representa la configuración del categorizador. Sin
+// auto_apply_threshold (o con null) la asignación automática queda desactivada.
+type SettingsRequest struct {
+	AutoApplyThreshold *float64 `json:"auto_apply_threshold"`
+}
+
+// SuggestionResponse representa una categoría sugerida
+type SuggestionResponse struct {
+	CategoryID   uint    `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Confidence   float64 `json:"confidence"` // Entre 0 y 1, con tres decimales
+}
+
+// GetSuggestions sugiere categorías para una descripción y un comercio.
+// Query params: description, payee y direction (income | expense, opcional).
+func (h *CategorizerHandler) GetSuggestions(c *gin.Context) {
+	input := domain.SuggestionInput{
+		Description: c.Query("description"),
+		Payee:       c.Query("payee"),
+		Direction:   transactionDomain.Direction(c.Query("direction")),
+	}
+	if input.Description == "" && input.Payee == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "description or payee is required",
+		})
+		return
+	}
+
+	suggestions, err := h.categorizerUseCase.Suggest(c.GetUint("userID"), input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	responses := make([]SuggestionResponse, len(suggestions))
+	for i, suggestion := range suggestions {
+		responses[i] = SuggestionResponse{
+			CategoryID:   suggestion.CategoryID,
+			CategoryName: suggestion.CategoryName,
+			Confidence:   math.Round(suggestion.Confidence*1000) / 1000,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"suggestions": responses,
+	})
+}
+
+// Retrain reconstruye el modelo del usuario con todo su historial
+func (h *CategorizerHandler) Retrain(c *gin.Context) {
+	result, err := h.categorizerUseCase.Retrain(c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to train categorizer",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Categorizer trained successfully",
+		"result":  result,
+	})
+}
+
+// GetSettings obtiene la configuración del categorizador
+func (h *CategorizerHandler) GetSettings(c *gin.Context) {
+	settings, err := h.categorizerUseCase.GetSettings(c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get categorizer settings",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"settings": settings,
+	})
+}
+
+// UpdateSettings reemplaza la configuración del categorizador
+func (h *CategorizerHandler) UpdateSettings(c *gin.Context) {
+	var req SettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	settings := &domain.Settings{
+		UserID:             c.GetUint("userID"),
+		AutoApplyThreshold: req.AutoApplyThreshold,
+	}
+	if err := h.categorizerUseCase.UpdateSettings(settings); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Categorizer settings updated successfully",
+		"settings": settings,
+	})
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/categorizer/domain"
+
+type CategorizerRepository interface {
+	domain.CategorizerRepository
+}
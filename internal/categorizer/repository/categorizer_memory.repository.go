@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"sync"
+
+	"finanzas-api/internal/categorizer/domain"
+)
+
+type categorizerRepositoryMemory struct {
+	categories map[uint]map[uint]*domain.CategoryCount // Por usuario y categoría
+	tokens     map[uint]map[uint]map[string]int        // Por usuario, categoría y token
+	settings   map[uint]*domain.Settings
+	mutex      sync.RWMutex
+}
+
+func NewCategorizerMemoryRepository() domain.CategorizerRepository {
+	return &categorizerRepositoryMemory{
+		categories: make(map[uint]map[uint]*domain.CategoryCount),
+		tokens:     make(map[uint]map[uint]map[string]int),
+		settings:   make(map[uint]*domain.Settings),
+	}
+}
+
+func (r *categorizerRepositoryMemory) Learn(userID uint, example domain.Example, delta int) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.learn(userID, example, delta)
+	return nil
+}
+
+func (r *categorizerRepositoryMemory) Train(userID uint, examples []domain.Example) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.categories, userID)
+	delete(r.tokens, userID)
+	for _, example := range examples {
+		r.learn(userID, example, 1)
+	}
+	return nil
+}
+
+func (r *categorizerRepositoryMemory) Stats(userID uint, tokens []string) (*domain.ModelStats, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	stats := &domain.ModelStats{Categories: make(map[uint]*domain.CategoryStats)}
+	for categoryID, category := range r.categories[userID] {
+		counts := make(map[string]int)
+		for _, token := range tokens {
+			if count := r.tokens[userID][categoryID][token]; count > 0 {
+				counts[token] = count
+			}
+		}
+		stats.Categories[categoryID] = &domain.CategoryStats{
+			Documents: category.Documents,
+			Tokens:    category.Tokens,
+			Counts:    counts,
+		}
+	}
+
+	vocabulary := make(map[string]bool)
+	for _, counts := range r.tokens[userID] {
+		for token := range counts {
+			vocabulary[token] = true
+		}
+	}
+	stats.Vocabulary = len(vocabulary)
+
+	return stats, nil
+}
+
+func (r *categorizerRepositoryMemory) GetSettings(userID uint) (*domain.Settings, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	settings, exists := r.settings[userID]
+	if !exists {
+		return &domain.Settings{UserID: userID}, nil
+	}
+	copied := *settings
+	return &copied, nil
+}
+
+func (r *categorizerRepositoryMemory) SaveSettings(settings *domain.Settings) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	copied := *settings
+	r.settings[settings.UserID] = &copied
+	return nil
+}
+
+// learn aplica el delta con el mutex ya tomado
+func (r *categorizerRepositoryMemory) learn(userID uint, example domain.Example, delta int) {
+	if r.categories[userID] == nil {
+		r.categories[userID] = make(map[uint]*domain.CategoryCount)
+		r.tokens[userID] = make(map[uint]map[string]int)
+	}
+
+	category, exists := r.categories[userID][example.CategoryID]
+	if !exists {
+		category = &domain.CategoryCount{UserID: userID, CategoryID: example.CategoryID}
+		r.categories[userID][example.CategoryID] = category
+		r.tokens[userID][example.CategoryID] = make(map[string]int)
+	}
+	category.Documents += delta
+	category.Tokens = max(category.Tokens+delta*len(example.Tokens), 0)
+
+	counts := r.tokens[userID][example.CategoryID]
+	for _, token := range example.Tokens {
+		counts[token] += delta
+		if counts[token] <= 0 {
+			delete(counts, token)
+		}
+	}
+	if category.Documents <= 0 {
+		delete(r.categories[userID], example.CategoryID)
+		delete(r.tokens[userID], example.CategoryID)
+	}
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"errors"
+
+	"finanzas-api/internal/categorizer/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// trainBatchSize es cuántas filas se insertan por sentencia al entrenar
+const trainBatchSize = 500
+
+type categorizerPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewCategorizerPostgresRepository(db *gorm.DB) domain.CategorizerRepository {
+	return &categorizerPostgresRepository{db: db}
+}
+
+func (r *categorizerPostgresRepository) Learn(userID uint, example domain.Example, delta int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		category := &domain.CategoryCount{
+			UserID:     userID,
+			CategoryID: example.CategoryID,
+			Documents:  delta,
+			Tokens:     delta * len(example.Tokens),
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "category_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"documents": gorm.Expr("categorizer_categories.documents + excluded.documents"),
+				"tokens":    gorm.Expr("GREATEST(categorizer_categories.tokens + excluded.tokens, 0)"),
+			}),
+		}).Create(category).Error; err != nil {
+			return err
+		}
+
+		if len(example.Tokens) > 0 {
+			counts := make([]*domain.TokenCount, len(example.Tokens))
+			for i, token := range example.Tokens {
+				counts[i] = &domain.TokenCount{UserID: userID, CategoryID: example.CategoryID, Token: token, Count: delta}
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "user_id"}, {Name: "category_id"}, {Name: "token"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"count": gorm.Expr("categorizer_tokens.count + excluded.count"),
+				}),
+			}).Create(&counts).Error; err != nil {
+				return err
+			}
+		}
+
+		// Los conteos que llegan a cero se eliminan; pueden quedar negativos si
+		// la transacción se guardó antes de entrenar el modelo
+		if err := tx.Where("user_id = ? AND category_id = ? AND count <= 0", userID, example.CategoryID).
+			Delete(&domain.TokenCount{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ? AND category_id = ? AND documents <= 0", userID, example.CategoryID).
+			Delete(&domain.CategoryCount{}).Error
+	})
+}
+
+func (r *categorizerPostgresRepository) Train(userID uint, examples []domain.Example) error {
+	categories := make(map[uint]*domain.CategoryCount)
+	tokens := make(map[uint]map[string]int)
+	for _, example := range examples {
+		category, exists := categories[example.CategoryID]
+		if !exists {
+			category = &domain.CategoryCount{UserID: userID, CategoryID: example.CategoryID}
+			categories[example.CategoryID] = category
+			tokens[example.CategoryID] = make(map[string]int)
+		}
+		category.Documents++
+		category.Tokens += len(example.Tokens)
+		for _, token := range example.Tokens {
+			tokens[example.CategoryID][token]++
+		}
+	}
+
+	categoryRows := make([]*domain.CategoryCount, 0, len(categories))
+	for _, category := range categories {
+		categoryRows = append(categoryRows, category)
+	}
+	var tokenRows []*domain.TokenCount
+	for categoryID, counts := range tokens {
+		for token, count := range counts {
+			tokenRows = append(tokenRows, &domain.TokenCount{UserID: userID, CategoryID: categoryID, Token: token, Count: count})
+		}
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.TokenCount{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.CategoryCount{}).Error; err != nil {
+			return err
+		}
+		if len(categoryRows) > 0 {
+			if err := tx.CreateInBatches(categoryRows, trainBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(tokenRows) > 0 {
+			if err := tx.CreateInBatches(tokenRows, trainBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *categorizerPostgresRepository) Stats(userID uint, tokens []string) (*domain.ModelStats, error) {
+	var categories []*domain.CategoryCount
+	if err := r.db.Where("user_id = ? AND documents > 0", userID).Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	stats := &domain.ModelStats{Categories: make(map[uint]*domain.CategoryStats, len(categories))}
+	for _, category := range categories {
+		stats.Categories[category.CategoryID] = &domain.CategoryStats{
+			Documents: category.Documents,
+			Tokens:    category.Tokens,
+			Counts:    make(map[string]int),
+		}
+	}
+
+	var vocabulary int64
+	if err := r.db.Model(&domain.TokenCount{}).Where("user_id = ?", userID).
+		Distinct("token").Count(&vocabulary).Error; err != nil {
+		return nil, err
+	}
+	stats.Vocabulary = int(vocabulary)
+
+	if len(tokens) > 0 {
+		var counts []*domain.TokenCount
+		if err := r.db.Where("user_id = ? AND token IN ?", userID, tokens).Find(&counts).Error; err != nil {
+			return nil, err
+		}
+		for _, count := range counts {
+			if category, exists := stats.Categories[count.CategoryID]; exists {
+				category.Counts[count.Token] = count.Count
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+func (r *categorizerPostgresRepository) GetSettings(userID uint) (*domain.Settings, error) {
+	var settings domain.Settings
+	err := r.db.Where("user_id = ?", userID).First(&settings).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &domain.Settings{UserID: userID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *categorizerPostgresRepository) SaveSettings(settings *domain.Settings) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"auto_apply_threshold"}),
+	}).Create(settings).Error
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"finanzas-api/internal/categorizer/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupCategorizerRoutes configura las rutas para el módulo del categorizador
+func SetupCategorizerRoutes(router *gin.Engine, categorizerHandler *handler.CategorizerHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	categorizerRoutes := router.Group("/api/v1/categorizer")
+	{
+		// GET /api/v1/categorizer/suggestions?description=&payee=&direction= - Sugerir categorías
+		categorizerRoutes.GET("/suggestions", authMiddleware("categorizer:read"), categorizerHandler.GetSuggestions)
+		// POST /api/v1/categorizer/train - Reentrenar con todo el historial
+		categorizerRoutes.POST("/train", authMiddleware("categorizer:write"), categorizerHandler.Retrain)
+		// GET /api/v1/categorizer/settings - Obtener configuración
+		categorizerRoutes.GET("/settings", authMiddleware("categorizer:read"), categorizerHandler.GetSettings)
+		// PUT /api/v1/categorizer/settings - Reemplazar configuración
+		categorizerRoutes.PUT("/settings", authMiddleware("categorizer:write"), categorizerHandler.UpdateSettings)
+	}
+}
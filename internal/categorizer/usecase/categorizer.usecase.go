@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	categoryDomain "finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/categorizer/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+type CategorizerUseCase struct {
+	categorizerRepo domain.CategorizerRepository
+	transactionRepo transactionDomain.TransactionRepository
+	categoryRepo    categoryDomain.CategoryRepository
+}
+
+func NewCategorizerUseCase(
+	categorizerRepo domain.CategorizerRepository,
+	transactionRepo transactionDomain.TransactionRepository,
+	categoryRepo categoryDomain.CategoryRepository,
+) domain.CategorizerUseCase {
+	return &CategorizerUseCase{
+		categorizerRepo: categorizerRepo,
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+	}
+}
+
+// Suggest implements domain.CategorizerUseCase.
+func (uc *CategorizerUseCase) Suggest(userID uint, input domain.SuggestionInput) ([]*domain.Suggestion, error) {
+	if input.Direction != "" && input.Direction != transactionDomain.DirectionIncome && input.Direction != transactionDomain.DirectionExpense {
+		return nil, errors.New("invalid direction")
+	}
+
+	tokens := domain.Tokenize(input.Description, input.Payee)
+	if len(tokens) == 0 {
+		return []*domain.Suggestion{}, nil
+	}
+
+	stats, err := uc.categorizerRepo.Stats(userID, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.suggest(userID, stats, tokens, input.Direction)
+}
+
+// Retrain implements domain.CategorizerUseCase.
+func (uc *CategorizerUseCase) Retrain(userID uint) (*domain.TrainingResult, error) {
+	transactions, err := uc.transactionRepo.List(userID, transactionDomain.TransactionFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.TrainingResult{}
+	categories := make(map[uint]bool)
+	examples := make([]domain.Example, 0, len(transactions))
+	for _, transaction := range transactions {
+		// Las patas de asientos no las categoriza el usuario
+		if transaction.IsLedgerLeg() {
+			continue
+		}
+		example, ok := domain.ExampleFrom(transaction)
+		if !ok {
+			continue
+		}
+		examples = append(examples, example)
+		categories[example.CategoryID] = true
+	}
+	result.Transactions = len(examples)
+	result.Categories = len(categories)
+
+	if err := uc.categorizerRepo.Train(userID, examples); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSettings implements domain.CategorizerUseCase.
+func (uc *CategorizerUseCase) GetSettings(userID uint) (*domain.Settings, error) {
+	return uc.categorizerRepo.GetSettings(userID)
+}
+
+// UpdateSettings implements domain.CategorizerUseCase.
+func (uc *CategorizerUseCase) UpdateSettings(settings *domain.Settings) error {
+	if settings.UserID == 0 {
+		return errors.New("user ID is required")
+	}
+
+	if threshold := settings.AutoApplyThreshold; threshold != nil && (*threshold < domain.MinAutoApplyThreshold || *threshold > 1) {
+		return fmt.Errorf("auto apply threshold must be between %.1f and 1", domain.MinAutoApplyThreshold)
+	}
+
+	return uc.categorizerRepo.SaveSettings(settings)
+}
+
+// Learn implements domain.CategorizerUseCase.
+func (uc *CategorizerUseCase) Learn(before, after *transactionDomain.Transaction) error {
+	// Se olvida la versión anterior y se aprende la nueva
+	if example, ok := domain.ExampleFrom(before); ok {
+		if err := uc.categorizerRepo.Learn(before.UserID, example, -1); err != nil {
+			return err
+		}
+	}
+	if example, ok := domain.ExampleFrom(after); ok {
+		if err := uc.categorizerRepo.Learn(after.UserID, example, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AutoApply implements domain.CategorizerUseCase.
+func (uc *CategorizerUseCase) AutoApply(transaction *transactionDomain.Transaction) error {
+	// Nunca se pisa una categoría elegida por el usuario o por una regla
+	if transaction.CategoryID != nil {
+		return nil
+	}
+
+	settings, err := uc.categorizerRepo.GetSettings(transaction.UserID)
+	if err != nil {
+		return err
+	}
+	if settings.AutoApplyThreshold == nil {
+		return nil
+	}
+
+	tokens := domain.Tokenize(transaction.Description, transaction.Payee)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	stats, err := uc.categorizerRepo.Stats(transaction.UserID, tokens)
+	if err != nil {
+		return err
+	}
+	// Con poco historial la confianza es engañosamente alta
+	if stats.Documents() < domain.MinAutoApplyDocuments {
+		return nil
+	}
+
+	suggestions, err := uc.suggest(transaction.UserID, stats, tokens, transaction.Direction)
+	if err != nil {
+		return err
+	}
+	if len(suggestions) > 0 && suggestions[0].Confidence >= *settings.AutoApplyThreshold {
+		categoryID := suggestions[0].CategoryID
+		transaction.CategoryID = &categoryID
+	}
+	return nil
+}
+
+// suggest evalúa las categorías vigentes del usuario; con dirección, solo
+// las de ese tipo
+func (uc *CategorizerUseCase) suggest(userID uint, stats *domain.ModelStats, tokens []string, direction transactionDomain.Direction) ([]*domain.Suggestion, error) {
+	categories, err := uc.categoryRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uint]string, len(categories))
+	candidates := make([]uint, 0, len(categories))
+	for _, category := range categories {
+		if direction != "" && transactionDomain.Direction(category.Kind) != direction {
+			continue
+		}
+		names[category.ID] = category.Name
+		candidates = append(candidates, category.ID)
+	}
+
+	scores := stats.Predict(tokens, candidates)
+	suggestions := make([]*domain.Suggestion, 0, len(scores))
+	for categoryID, confidence := range scores {
+		suggestions = append(suggestions, &domain.Suggestion{
+			CategoryID:   categoryID,
+			CategoryName: names[categoryID],
+			Confidence:   confidence,
+		})
+	}
+	return domain.RankSuggestions(suggestions, domain.MaxSuggestions), nil
+}
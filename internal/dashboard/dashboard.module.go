@@ -0,0 +1,35 @@
+package dashboard
+
+import (
+	"time"
+
+	budgetDomain "finanzas-api/internal/budgets/domain"
+	"finanzas-api/internal/dashboard/domain"
+	"finanzas-api/internal/dashboard/handler"
+	"finanzas-api/internal/dashboard/usecase"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+type DashboardModule struct {
+	Handler *handler.DashboardHandler
+	UseCase domain.DashboardUseCase
+}
+
+func NewDashboardModule(
+	transactionUseCase transactionDomain.TransactionUseCase,
+	budgetUseCase budgetDomain.BudgetUseCase,
+	series domain.SeriesSource,
+	upcoming domain.UpcomingPaymentsProvider,
+	timeout time.Duration,
+) *DashboardModule {
+	var dashboardUseCase domain.DashboardUseCase
+	var dashboardHandler *handler.DashboardHandler
+
+	dashboardUseCase = usecase.NewDashboardUseCase(transactionUseCase, budgetUseCase, series, upcoming)
+	dashboardHandler = handler.NewDashboardHandler(dashboardUseCase, timeout)
+
+	return &DashboardModule{
+		Handler: dashboardHandler,
+		UseCase: dashboardUseCase,
+	}
+}
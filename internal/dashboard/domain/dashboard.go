@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	budgetDomain "finanzas-api/internal/budgets/domain"
+	reportDomain "finanzas-api/internal/reports/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+// CurrencyAmount es un monto en unidades menores de una moneda
+type CurrencyAmount struct {
+	Currency string `json:"currency"`
+	Amount   int64  `json:"amount"`
+}
+
+// BudgetProgress compara el gasto del mes en curso con lo presupuestado.
+// Los totales solo suman los presupuestos en la moneda del dashboard.
+type BudgetProgress struct {
+	Month      string                       `json:"month"`
+	Available  int64                        `json:"available"`
+	Spent      int64                        `json:"spent"`
+	Remaining  int64                        `json:"remaining"`
+	TotalSpent int64                        `json:"total_spent"` // Todo el gasto del mes, presupuestado o no
+	Budgets    []*budgetDomain.BudgetStatus `json:"budgets"`
+}
+
+// UpcomingPayment es un pago programado próximo a vencer
+type UpcomingPayment struct {
+	ID          uint                        `json:"id"`
+	AccountID   uint                        `json:"account_id"`
+	Description string                      `json:"description"`
+	Amount      int64                       `json:"amount"`
+	Direction   transactionDomain.Direction `json:"direction"`
+	DueDate     string                      `json:"due_date"`
+}
+
+// SeriesPoint es un mes de la serie de ingresos y gastos
+type SeriesPoint struct {
+	Month    string `json:"month"`
+	Income   int64  `json:"income"`
+	Expenses int64  `json:"expenses"`
+}
+
+// Dashboard agrupa todo lo que necesita la pantalla de inicio
+type Dashboard struct {
+	Currency           string                              `json:"currency"`
+	NetWorth           []CurrencyAmount                    `json:"net_worth"`
+	Accounts           []*transactionDomain.AccountBalance `json:"accounts"`
+	Budget             *BudgetProgress                     `json:"budget"`
+	UpcomingPayments   []*UpcomingPayment                  `json:"upcoming_payments"`
+	RecentTransactions []*transactionDomain.Transaction    `json:"recent_transactions"`
+	MonthlySeries      []SeriesPoint                       `json:"monthly_series"`
+}
+
+// UpcomingPaymentsProvider entrega los pagos programados de un usuario en
+// el rango [from, to]. Lo implementa el módulo de recurrencias.
+type UpcomingPaymentsProvider interface {
+	UpcomingPayments(ctx context.Context, userID uint, from, to time.Time) ([]*UpcomingPayment, error)
+}
+
+// SeriesSource entrega la serie mensual de ingresos y gastos, con los
+// montos convertidos a currency. Lo implementa el módulo de reportes.
+type SeriesSource interface {
+	BaseCurrency(ctx context.Context, userID uint) (string, error)
+	MonthlySeries(ctx context.Context, userID uint, currency string, from, to time.Time) ([]*reportDomain.MonthTotals, error)
+	PeriodTotals(ctx context.Context, userID uint, currency string, from, to time.Time) (*reportDomain.PeriodTotals, error)
+}
+
+type DashboardUseCase interface {
+	GetDashboard(ctx context.Context, userID uint, currency string) (*Dashboard, error)
+}
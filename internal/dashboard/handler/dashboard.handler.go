@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"finanzas-api/internal/dashboard/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DashboardHandler struct {
+	dashboardUseCase domain.DashboardUseCase
+	timeout          time.Duration
+}
+
+// NewDashboardHandler crea una nueva instancia del handler del dashboard.
+// timeout limita cuánto puede tardar el armado completo de la respuesta.
+func NewDashboardHandler(dashboardUseCase domain.DashboardUseCase, timeout time.Duration) *DashboardHandler {
+	return &DashboardHandler{
+		dashboardUseCase: dashboardUseCase,
+		timeout:          timeout,
+	}
+}
+
+// GetDashboard retorna el resumen financiero del usuario autenticado.
+// currency (por defecto la moneda base del usuario) define la moneda del
+// presupuesto y la serie.
+func (h *DashboardHandler) GetDashboard(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	dashboard, err := h.dashboardUseCase.GetDashboard(ctx, c.GetUint("userID"), c.Query("currency"))
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			c.JSON(http.StatusGatewayTimeout, gin.H{
+				"error": "dashboard timed out",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"dashboard": dashboard,
+	})
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"finanzas-api/internal/dashboard/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupDashboardRoutes configura las rutas para el dashboard financiero
+func SetupDashboardRoutes(router *gin.Engine, dashboardHandler *handler.DashboardHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	// GET /api/v1/dashboard - Resumen para la pantalla de inicio
+	router.GET("/api/v1/dashboard", authMiddleware("dashboard:read"), dashboardHandler.GetDashboard)
+}
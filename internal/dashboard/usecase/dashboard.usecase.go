@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	budgetDomain "finanzas-api/internal/budgets/domain"
+	"finanzas-api/internal/dashboard/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	"finanzas-api/shared/money"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	recentTransactionsLimit = 10
+	seriesMonths            = 12
+	upcomingDays            = 30
+)
+
+type DashboardUseCase struct {
+	transactionUseCase transactionDomain.TransactionUseCase
+	budgetUseCase      budgetDomain.BudgetUseCase
+	series             domain.SeriesSource
+	upcoming           domain.UpcomingPaymentsProvider
+}
+
+// NewDashboardUseCase crea el caso de uso; upcoming puede ser nil mientras
+// no exista un proveedor de pagos programados.
+func NewDashboardUseCase(transactionUseCase transactionDomain.TransactionUseCase, budgetUseCase budgetDomain.BudgetUseCase, series domain.SeriesSource, upcoming domain.UpcomingPaymentsProvider) domain.DashboardUseCase {
+	return &DashboardUseCase{
+		transactionUseCase: transactionUseCase,
+		budgetUseCase:      budgetUseCase,
+		series:             series,
+		upcoming:           upcoming,
+	}
+}
+
+// GetDashboard implements domain.DashboardUseCase.
+// Las consultas corren en paralelo atadas a ctx: si ctx vence o una falla,
+// las demás se cancelan y se retorna el primer error.
+func (uc *DashboardUseCase) GetDashboard(ctx context.Context, userID uint, currency string) (*domain.Dashboard, error) {
+	if userID == 0 {
+		return nil, errors.New("user ID is required")
+	}
+
+	// Sin moneda explícita se usa la moneda base del usuario
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		base, err := uc.series.BaseCurrency(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		currency = base
+	}
+	if !money.IsValidCurrency(currency) {
+		return nil, errors.New("invalid currency code")
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	dashboard := &domain.Dashboard{
+		Currency:           currency,
+		NetWorth:           []domain.CurrencyAmount{},
+		Accounts:           []*transactionDomain.AccountBalance{},
+		UpcomingPayments:   []*domain.UpcomingPayment{},
+		RecentTransactions: []*transactionDomain.Transaction{},
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	// Saldos por cuenta y patrimonio neto por moneda
+	g.Go(func() error {
+		balances, err := uc.transactionUseCase.GetAccountBalances(ctx, userID)
+		if err != nil {
+			return err
+		}
+		dashboard.Accounts = balances
+		dashboard.NetWorth = netWorth(balances)
+		return nil
+	})
+
+	// Gasto del mes en curso frente al presupuesto
+	g.Go(func() error {
+		statuses, err := uc.budgetUseCase.GetMonthlyStatus(ctx, userID, monthStart)
+		if err != nil {
+			return err
+		}
+		totals, err := uc.series.PeriodTotals(ctx, userID, currency, monthStart, today.AddDate(0, 0, 1))
+		if err != nil {
+			return err
+		}
+		progress := &domain.BudgetProgress{
+			Month:      monthStart.Format("2006-01"),
+			TotalSpent: totals.Expenses,
+			Budgets:    statuses,
+		}
+		for _, status := range statuses {
+			if status.Currency != currency {
+				continue
+			}
+			progress.Available += status.Available
+			progress.Spent += status.Spent
+			progress.Remaining += status.Remaining
+		}
+		dashboard.Budget = progress
+		return nil
+	})
+
+	// Pagos programados de los próximos días
+	if uc.upcoming != nil {
+		g.Go(func() error {
+			payments, err := uc.upcoming.UpcomingPayments(ctx, userID, today, today.AddDate(0, 0, upcomingDays))
+			if err != nil {
+				return err
+			}
+			dashboard.UpcomingPayments = payments
+			return nil
+		})
+	}
+
+	// Últimas transacciones
+	g.Go(func() error {
+		transactions, err := uc.transactionUseCase.ListTransactions(ctx, userID, transactionDomain.TransactionFilter{Limit: recentTransactionsLimit})
+		if err != nil {
+			return err
+		}
+		dashboard.RecentTransactions = transactions
+		return nil
+	})
+
+	// Serie de ingresos y gastos de los últimos 12 meses, incluido el actual
+	g.Go(func() error {
+		from := monthStart.AddDate(0, -(seriesMonths - 1), 0)
+		totals, err := uc.series.MonthlySeries(ctx, userID, currency, from, monthStart.AddDate(0, 1, 0))
+		if err != nil {
+			return err
+		}
+		dashboard.MonthlySeries = fillSeries(totals, from)
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return dashboard, nil
+}
+
+// netWorth suma los saldos de las cuentas agrupados por moneda
+func netWorth(balances []*transactionDomain.AccountBalance) []domain.CurrencyAmount {
+	byCurrency := make(map[string]int64)
+	for _, balance := range balances {
+		byCurrency[balance.Currency] += balance.Balance
+	}
+
+	result := make([]domain.CurrencyAmount, 0, len(byCurrency))
+	for currency, amount := range byCurrency {
+		result = append(result, domain.CurrencyAmount{Currency: currency, Amount: amount})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Currency < result[j].Currency })
+	return result
+}
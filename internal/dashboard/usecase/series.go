@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"time"
+
+	"finanzas-api/internal/dashboard/domain"
+	reportDomain "finanzas-api/internal/reports/domain"
+)
+
+// fillSeries completa con ceros los meses sin movimientos para que la serie
+// siempre tenga seriesMonths puntos.
+func fillSeries(totals []*reportDomain.MonthTotals, from time.Time) []domain.SeriesPoint {
+	byMonth := make(map[string]*reportDomain.MonthTotals, len(totals))
+	for _, t := range totals {
+		byMonth[t.Month.Format("2006-01")] = t
+	}
+
+	points := make([]domain.SeriesPoint, 0, seriesMonths)
+	for i := 0; i < seriesMonths; i++ {
+		month := from.AddDate(0, i, 0).Format("2006-01")
+		point := domain.SeriesPoint{Month: month}
+		if t, exists := byMonth[month]; exists {
+			point.Income = t.Income
+			point.Expenses = t.Expenses
+		}
+		points = append(points, point)
+	}
+	return points
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+// ErrRateNotFound se retorna cuando no hay cotización para el par en la fecha
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// ExchangeRate es la cotización de un par de monedas en una fecha: una
+// unidad de Base equivale a Rate unidades de Quote. Las cotizaciones son
+// inmutables; una vez registradas no se modifican para que las conversiones
+// que las usan puedan reproducirse.
+type ExchangeRate struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Base      string    `json:"base" gorm:"type:char(3);not null;uniqueIndex:idx_exchange_rates_pair_date"`
+	Quote     string    `json:"quote" gorm:"type:char(3);not null;uniqueIndex:idx_exchange_rates_pair_date"`
+	Date      time.Time `json:"date" gorm:"type:date;not null;uniqueIndex:idx_exchange_rates_pair_date"`
+	Rate      string    `json:"rate" gorm:"type:numeric(24,12);not null"` // Decimal exacto, nunca float64
+	Source    string    `json:"source" gorm:"type:varchar(100)"`          // Archivo o carga de origen
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TransactionConversion registra el monto de una transacción convertido a la
+// moneda base de su usuario y la cotización usada. Se guarda una copia del
+// monto, moneda y fecha de origen para detectar cuándo quedó desactualizada.
+type TransactionConversion struct {
+	ID             uint                          `json:"id" gorm:"primaryKey"`
+	TransactionID  uint                          `json:"transaction_id" gorm:"not null;uniqueIndex:idx_transaction_conversions_tx_base"`
+	Transaction    transactionDomain.Transaction `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	BaseCurrency   string                        `json:"base_currency" gorm:"type:char(3);not null;uniqueIndex:idx_transaction_conversions_tx_base"`
+	SourceCurrency string                        `json:"source_currency" gorm:"type:char(3);not null"`
+	SourceAmount   int64                         `json:"source_amount" gorm:"not null"`
+	Date           time.Time                     `json:"date" gorm:"type:date;not null"`
+	RateID         uint                          `json:"rate_id" gorm:"not null;index"`
+	Rate           ExchangeRate                  `json:"-" gorm:"constraint:OnDelete:RESTRICT"`
+	// Inverted indica que se usó la cotización BaseCurrency→SourceCurrency,
+	// por lo que el monto se dividió en lugar de multiplicarse.
+	Inverted   bool      `json:"inverted"`
+	BaseAmount int64     `json:"base_amount" gorm:"not null"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// RateFilter agrupa los criterios del listado de cotizaciones
+type RateFilter struct {
+	Base   string
+	Quote  string
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
+
+// ImportResult resume una importación de cotizaciones
+type ImportResult struct {
+	Read     int `json:"read"`
+	Inserted int `json:"inserted"`
+	Skipped  int `json:"skipped"` // Ya existían para el mismo par y fecha
+}
+
+// Conversion es el resultado de convertir un monto con una cotización
+type Conversion struct {
+	Amount   int64         `json:"amount"`
+	Currency string        `json:"currency"`
+	Rate     *ExchangeRate `json:"rate"`
+	Inverted bool          `json:"inverted"`
+}
+
+// PendingConversion es una transacción sin conversión vigente a la moneda
+// base de su usuario
+type PendingConversion struct {
+	Transaction  *transactionDomain.Transaction
+	BaseCurrency string
+}
+
+// ExchangeRepository define la interfaz del repositorio de cotizaciones
+type ExchangeRepository interface {
+	// WithContext retorna el repositorio con sus consultas atadas a ctx: se
+	// cancelan cuando ctx vence
+	WithContext(ctx context.Context) ExchangeRepository
+	// CreateRates inserta las cotizaciones nuevas y omite las que ya existen.
+	// Retorna cuántas se insertaron.
+	CreateRates(rates []*ExchangeRate) (int, error)
+	// FindRate retorna la cotización más reciente del par en o antes de date
+	FindRate(base, quote string, date time.Time) (*ExchangeRate, error)
+	ListRates(filter RateFilter) ([]*ExchangeRate, error)
+	// PendingConversions retorna las transacciones del usuario en [from, to)
+	// en otra moneda que aún no tienen conversión vigente a baseCurrency.
+	PendingConversions(userID uint, baseCurrency string, from, to time.Time) ([]*transactionDomain.Transaction, error)
+	// PendingBaseConversions retorna, por lotes ordenados por ID a partir de
+	// afterID, las transacciones de todos los usuarios sin conversión
+	// vigente a la moneda base de su usuario.
+	PendingBaseConversions(afterID uint, limit int) ([]*PendingConversion, error)
+	// SaveConversions inserta o reemplaza las conversiones
+	SaveConversions(conversions []*TransactionConversion) error
+}
+
+type ExchangeUseCase interface {
+	// ImportRates lee cotizaciones en CSV (date,base,quote,rate)
+	ImportRates(reader io.Reader, source string) (*ImportResult, error)
+	ListRates(filter RateFilter) ([]*ExchangeRate, error)
+	Convert(amount int64, from, to string, date time.Time) (*Conversion, error)
+	// BaseCurrency retorna la moneda base del usuario
+	BaseCurrency(ctx context.Context, userID uint) (string, error)
+	// PreviewConversions convierte a baseCurrency, sin registrarlas, las
+	// transacciones del usuario en [from, to) sin conversión vigente. Las
+	// que no tienen cotización quedan fuera del resultado.
+	PreviewConversions(ctx context.Context, userID uint, baseCurrency string, from, to time.Time) (transactionDomain.ConvertedAmounts, error)
+	// RecordConversions registra la conversión a la moneda base de cada
+	// usuario de las transacciones que aún no la tengan y retorna cuántas
+	// registró. Las que no tienen cotización quedan para una próxima pasada.
+	RecordConversions() (int, error)
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (ExchangeRate) TableName() string {
+	return "exchange_rates"
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (TransactionConversion) TableName() string {
+	return "transaction_conversions"
+}
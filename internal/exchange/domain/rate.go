@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// maxRateDecimals es la escala de la columna exchange_rates.rate
+const maxRateDecimals = 12
+
+// ParseRate interpreta una cotización decimal positiva ("4012.35") de forma
+// exacta. Rechaza notación científica y más de 12 decimales.
+func ParseRate(value string) (*big.Rat, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.ContainsAny(value, "eE/+-") {
+		return nil, errors.New("invalid rate")
+	}
+	if dot := strings.IndexByte(value, '.'); dot >= 0 && len(value)-dot-1 > maxRateDecimals {
+		return nil, errors.New("rate has too many decimals")
+	}
+
+	rate, ok := new(big.Rat).SetString(value)
+	if !ok || rate.Sign() <= 0 {
+		return nil, errors.New("invalid rate")
+	}
+	return rate, nil
+}
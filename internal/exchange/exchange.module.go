@@ -0,0 +1,70 @@
+package exchange
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"finanzas-api/internal/exchange/domain"
+	"finanzas-api/internal/exchange/handler"
+	"finanzas-api/internal/exchange/repository"
+	"finanzas-api/internal/exchange/scheduler"
+	"finanzas-api/internal/exchange/usecase"
+	userDomain "finanzas-api/internal/users/domain"
+
+	"gorm.io/gorm"
+)
+
+type ExchangeModule struct {
+	Handler    *handler.ExchangeHandler
+	UseCase    domain.ExchangeUseCase
+	Recorder   *scheduler.ConversionRecorder
+	repository domain.ExchangeRepository
+}
+
+// NewExchangeModule crea el módulo de cotizaciones. Si ratesFile no está
+// vacío, sus cotizaciones se importan al iniciar. Las conversiones se
+// registran cada conversionInterval.
+func NewExchangeModule(db *gorm.DB, userRepo userDomain.UserRepository, ratesFile string, conversionInterval time.Duration) *ExchangeModule {
+	var exchangeRepo domain.ExchangeRepository
+	var exchangeUseCase domain.ExchangeUseCase
+	var exchangeHandler *handler.ExchangeHandler
+
+	if err := db.AutoMigrate(&domain.ExchangeRate{}, &domain.TransactionConversion{}); err != nil {
+		panic(fmt.Sprintf("Error migrating exchange rates: %v", err))
+	}
+
+	exchangeRepo = repository.NewExchangePostgresRepository(db)
+	exchangeUseCase = usecase.NewExchangeUseCase(exchangeRepo, userRepo)
+	exchangeHandler = handler.NewExchangeHandler(exchangeUseCase)
+
+	if ratesFile != "" {
+		importRatesFile(exchangeUseCase, ratesFile)
+	}
+
+	return &ExchangeModule{
+		Handler:    exchangeHandler,
+		UseCase:    exchangeUseCase,
+		Recorder:   scheduler.NewConversionRecorder(exchangeUseCase, conversionInterval),
+		repository: exchangeRepo,
+	}
+}
+
+// importRatesFile importa el archivo local de cotizaciones. Un error no
+// detiene el servidor: las cotizaciones ya registradas siguen disponibles.
+func importRatesFile(exchangeUseCase domain.ExchangeUseCase, path string) {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Printf("⚠️ No se pudo abrir el archivo de cotizaciones %s: %v", path, err)
+		return
+	}
+	defer file.Close()
+
+	result, err := exchangeUseCase.ImportRates(file, "file:"+path)
+	if err != nil {
+		log.Printf("⚠️ Error importando cotizaciones de %s: %v", path, err)
+		return
+	}
+	log.Printf("💱 Cotizaciones importadas de %s: %d nuevas, %d existentes", path, result.Inserted, result.Skipped)
+}
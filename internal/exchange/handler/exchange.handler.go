@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"finanzas-api/internal/exchange/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+type ExchangeHandler struct {
+	exchangeUseCase domain.ExchangeUseCase
+}
+
+// NewExchangeHandler crea una nueva instancia del handler de cotizaciones
+func NewExchangeHandler(exchangeUseCase domain.ExchangeUseCase) *ExchangeHandler {
+	return &ExchangeHandler{
+		exchangeUseCase: exchangeUseCase,
+	}
+}
+
+// ImportRates carga un archivo CSV de cotizaciones (campo multipart "file")
+func (h *ExchangeHandler) ImportRates(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Could not read uploaded file",
+		})
+		return
+	}
+	defer file.Close()
+
+	result, err := h.exchangeUseCase.ImportRates(file, "upload:"+fileHeader.Filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Exchange rates imported successfully",
+		"result":  result,
+	})
+}
+
+// ListRates lista las cotizaciones registradas.
+// Parámetros opcionales: base, quote, from, to (YYYY-MM-DD), limit y offset.
+func (h *ExchangeHandler) ListRates(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 0 {
+		limit = 50
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	filter := domain.RateFilter{
+		Base:   c.Query("base"),
+		Quote:  c.Query("quote"),
+		Limit:  limit,
+		Offset: offset,
+	}
+	if v := c.Query("from"); v != "" {
+		from, err := time.Parse(dateLayout, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid from, expected YYYY-MM-DD",
+			})
+			return
+		}
+		filter.From = &from
+	}
+	if v := c.Query("to"); v != "" {
+		to, err := time.Parse(dateLayout, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid to, expected YYYY-MM-DD",
+			})
+			return
+		}
+		filter.To = &to
+	}
+
+	rates, err := h.exchangeUseCase.ListRates(filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rates": rates,
+		"pagination": gin.H{
+			"limit":  filter.Limit,
+			"offset": filter.Offset,
+			"count":  len(rates),
+		},
+	})
+}
+
+// Convert convierte un monto en unidades menores entre dos monedas
+// (?amount=&from=&to=&date=YYYY-MM-DD, por defecto hoy)
+func (h *ExchangeHandler) Convert(c *gin.Context) {
+	amount, err := strconv.ParseInt(c.Query("amount"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid amount",
+		})
+		return
+	}
+
+	date, err := time.Parse(dateLayout, c.DefaultQuery("date", time.Now().Format(dateLayout)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid date, expected YYYY-MM-DD",
+		})
+		return
+	}
+
+	conversion, err := h.exchangeUseCase.Convert(amount, c.Query("from"), c.Query("to"), date)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrRateNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"conversion": conversion,
+	})
+}
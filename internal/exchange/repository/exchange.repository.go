@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/exchange/domain"
+
+type ExchangeRepository interface {
+	domain.ExchangeRepository
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"finanzas-api/internal/exchange/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+type rateKey struct {
+	base  string
+	quote string
+	date  time.Time
+}
+
+type conversionKey struct {
+	transactionID uint
+	baseCurrency  string
+}
+
+type exchangeRepositoryMemory struct {
+	rates           map[rateKey]*domain.ExchangeRate
+	conversions     map[conversionKey]*domain.TransactionConversion
+	transactionRepo transactionDomain.TransactionRepository
+	nextID          uint
+	mutex           sync.RWMutex
+}
+
+// NewExchangeMemoryRepository recibe el repositorio de transacciones para
+// encontrar las que faltan por convertir.
+func NewExchangeMemoryRepository(transactionRepo transactionDomain.TransactionRepository) domain.ExchangeRepository {
+	return &exchangeRepositoryMemory{
+		rates:           make(map[rateKey]*domain.ExchangeRate),
+		conversions:     make(map[conversionKey]*domain.TransactionConversion),
+		transactionRepo: transactionRepo,
+		nextID:          1,
+	}
+}
+
+// WithContext retorna el mismo repositorio: en memoria no hay consultas que
+// cancelar
+func (r *exchangeRepositoryMemory) WithContext(ctx context.Context) domain.ExchangeRepository {
+	return r
+}
+
+func (r *exchangeRepositoryMemory) CreateRates(rates []*domain.ExchangeRate) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	inserted := 0
+	for _, rate := range rates {
+		key := rateKey{base: rate.Base, quote: rate.Quote, date: rate.Date}
+		if _, exists := r.rates[key]; exists {
+			continue
+		}
+
+		// Asignar ID y timestamp
+		rate.ID = r.nextID
+		r.nextID++
+		rate.CreatedAt = time.Now()
+
+		r.rates[key] = rate
+		inserted++
+	}
+	return inserted, nil
+}
+
+func (r *exchangeRepositoryMemory) FindRate(base, quote string, date time.Time) (*domain.ExchangeRate, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var found *domain.ExchangeRate
+	for key, rate := range r.rates {
+		if key.base != base || key.quote != quote || key.date.After(date) {
+			continue
+		}
+		if found == nil || key.date.After(found.Date) {
+			found = rate
+		}
+	}
+	if found == nil {
+		return nil, domain.ErrRateNotFound
+	}
+	return found, nil
+}
+
+func (r *exchangeRepositoryMemory) ListRates(filter domain.RateFilter) ([]*domain.ExchangeRate, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var rates []*domain.ExchangeRate
+	for _, rate := range r.rates {
+		if filter.Base != "" && rate.Base != filter.Base {
+			continue
+		}
+		if filter.Quote != "" && rate.Quote != filter.Quote {
+			continue
+		}
+		if filter.From != nil && rate.Date.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && rate.Date.After(*filter.To) {
+			continue
+		}
+		rates = append(rates, rate)
+	}
+
+	sort.Slice(rates, func(i, j int) bool {
+		if !rates[i].Date.Equal(rates[j].Date) {
+			return rates[i].Date.After(rates[j].Date)
+		}
+		if rates[i].Base != rates[j].Base {
+			return rates[i].Base < rates[j].Base
+		}
+		return rates[i].Quote < rates[j].Quote
+	})
+
+	if filter.Offset >= len(rates) {
+		return []*domain.ExchangeRate{}, nil
+	}
+	rates = rates[filter.Offset:]
+	if filter.Limit > 0 && len(rates) > filter.Limit {
+		rates = rates[:filter.Limit]
+	}
+	return rates, nil
+}
+
+func (r *exchangeRepositoryMemory) PendingConversions(userID uint, baseCurrency string, from, to time.Time) ([]*transactionDomain.Transaction, error) {
+	// El filtro del listado es inclusivo en To
+	last := to.AddDate(0, 0, -1)
+	transactions, err := r.transactionRepo.List(userID, transactionDomain.TransactionFilter{From: &from, To: &last})
+	if err != nil {
+		return nil, err
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var pending []*transactionDomain.Transaction
+	for _, transaction := range transactions {
+		if transaction.Currency == baseCurrency {
+			continue
+		}
+		conversion, exists := r.conversions[conversionKey{transactionID: transaction.ID, baseCurrency: baseCurrency}]
+		if !exists || conversion.SourceAmount != transaction.Amount ||
+			conversion.SourceCurrency != transaction.Currency || !conversion.Date.Equal(transaction.Date) {
+			pending = append(pending, transaction)
+		}
+	}
+	return pending, nil
+}
+
+// PendingBaseConversions no retorna nada: el repositorio en memoria no
+// conoce la moneda base de los usuarios.
+func (r *exchangeRepositoryMemory) PendingBaseConversions(afterID uint, limit int) ([]*domain.PendingConversion, error) {
+	return []*domain.PendingConversion{}, nil
+}
+
+func (r *exchangeRepositoryMemory) SaveConversions(conversions []*domain.TransactionConversion) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, conversion := range conversions {
+		key := conversionKey{transactionID: conversion.TransactionID, baseCurrency: conversion.BaseCurrency}
+		if existing, exists := r.conversions[key]; exists {
+			conversion.ID = existing.ID
+		} else {
+			conversion.ID = r.nextID
+			r.nextID++
+		}
+		conversion.CreatedAt = time.Now()
+		r.conversions[key] = conversion
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"finanzas-api/internal/exchange/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type exchangePostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewExchangePostgresRepository(db *gorm.DB) domain.ExchangeRepository {
+	return &exchangePostgresRepository{db: db}
+}
+
+func (r *exchangePostgresRepository) WithContext(ctx context.Context) domain.ExchangeRepository {
+	return &exchangePostgresRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *exchangePostgresRepository) CreateRates(rates []*domain.ExchangeRate) (int, error) {
+	if len(rates) == 0 {
+		return 0, nil
+	}
+
+	// Las cotizaciones son inmutables: las repetidas se ignoran
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(rates, 500)
+	return int(result.RowsAffected), result.Error
+}
+
+func (r *exchangePostgresRepository) FindRate(base, quote string, date time.Time) (*domain.ExchangeRate, error) {
+	var rates []*domain.ExchangeRate
+	err := r.db.Where("base = ? AND quote = ? AND date <= ?", base, quote, date).
+		Order("date DESC").
+		Limit(1).
+		Find(&rates).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rates) == 0 {
+		return nil, domain.ErrRateNotFound
+	}
+	return rates[0], nil
+}
+
+func (r *exchangePostgresRepository) ListRates(filter domain.RateFilter) ([]*domain.ExchangeRate, error) {
+	query := r.db.Model(&domain.ExchangeRate{})
+	if filter.Base != "" {
+		query = query.Where("base = ?", filter.Base)
+	}
+	if filter.Quote != "" {
+		query = query.Where("quote = ?", filter.Quote)
+	}
+	if filter.From != nil {
+		query = query.Where("date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("date <= ?", *filter.To)
+	}
+
+	var rates []*domain.ExchangeRate
+	err := query.Order("date DESC, base, quote").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&rates).Error
+	return rates, err
+}
+
+func (r *exchangePostgresRepository) PendingConversions(userID uint, baseCurrency string, from, to time.Time) ([]*transactionDomain.Transaction, error) {
+	var transactions []*transactionDomain.Transaction
+	err := r.db.Model(&transactionDomain.Transaction{}).
+		Select("transactions.*").
+		Joins("LEFT JOIN transaction_conversions tc ON tc.transaction_id = transactions.id AND tc.base_currency = ?", baseCurrency).
+		Where("transactions.user_id = ? AND transactions.currency <> ?", userID, baseCurrency).
+		Where("transactions.date >= ? AND transactions.date < ?", from, to).
+		// Sin conversión, o con una hecha antes de editar la transacción
+		Where("tc.id IS NULL OR tc.source_amount <> transactions.amount OR tc.source_currency <> transactions.currency OR tc.date <> transactions.date").
+		Order("transactions.date, transactions.id").
+		Find(&transactions).Error
+	return transactions, err
+}
+
+func (r *exchangePostgresRepository) PendingBaseConversions(afterID uint, limit int) ([]*domain.PendingConversion, error) {
+	var rows []struct {
+		TransactionID uint
+		BaseCurrency  string
+	}
+	err := r.db.Model(&transactionDomain.Transaction{}).
+		Select("transactions.id AS transaction_id, users.base_currency").
+		Joins("JOIN users ON users.id = transactions.user_id").
+		Joins("LEFT JOIN transaction_conversions tc ON tc.transaction_id = transactions.id AND tc.base_currency = users.base_currency").
+		Where("transactions.id > ? AND transactions.currency <> users.base_currency", afterID).
+		Where("tc.id IS NULL OR tc.source_amount <> transactions.amount OR tc.source_currency <> transactions.currency OR tc.date <> transactions.date").
+		Order("transactions.id").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []*domain.PendingConversion{}, nil
+	}
+
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.TransactionID
+	}
+	var transactions []*transactionDomain.Transaction
+	if err := r.db.Where("id IN ?", ids).Order("id").Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]*transactionDomain.Transaction, len(transactions))
+	for _, transaction := range transactions {
+		byID[transaction.ID] = transaction
+	}
+	pending := make([]*domain.PendingConversion, 0, len(rows))
+	for _, row := range rows {
+		// Pudo borrarse entre ambas consultas
+		if transaction, exists := byID[row.TransactionID]; exists {
+			pending = append(pending, &domain.PendingConversion{Transaction: transaction, BaseCurrency: row.BaseCurrency})
+		}
+	}
+	return pending, nil
+}
+
+func (r *exchangePostgresRepository) SaveConversions(conversions []*domain.TransactionConversion) error {
+	if len(conversions) == 0 {
+		return nil
+	}
+
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "transaction_id"}, {Name: "base_currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"source_currency", "source_amount", "date", "rate_id", "inverted", "base_amount", "created_at",
+		}),
+	}).CreateInBatches(conversions, 500).Error
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"finanzas-api/internal/exchange/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupExchangeRoutes configura las rutas para el módulo de cotizaciones
+func SetupExchangeRoutes(router *gin.Engine, exchangeHandler *handler.ExchangeHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	exchangeRoutes := router.Group("/api/v1/exchange-rates")
+	{
+		// POST /api/v1/exchange-rates/import - Cargar cotizaciones desde CSV (compartidas: solo administradores)
+		exchangeRoutes.POST("/import", authMiddleware("exchange:write"), exchangeHandler.ImportRates)
+
+		// GET /api/v1/exchange-rates - Listar cotizaciones
+		exchangeRoutes.GET("", authMiddleware("exchange:read"), exchangeHandler.ListRates)
+
+		// GET /api/v1/exchange-rates/convert - Convertir un monto a otra moneda
+		exchangeRoutes.GET("/convert", authMiddleware("exchange:read"), exchangeHandler.Convert)
+	}
+}
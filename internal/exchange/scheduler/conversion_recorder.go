@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"finanzas-api/internal/exchange/domain"
+)
+
+// ConversionRecorder registra periódicamente la conversión a la moneda base
+// de las transacciones nuevas o editadas, y de las que esperaban una
+// cotización. Los reportes solo leen estas conversiones: lo que aún no está
+// registrado lo convierten en memoria.
+type ConversionRecorder struct {
+	exchangeUseCase domain.ExchangeUseCase
+	interval        time.Duration
+}
+
+// NewConversionRecorder crea un worker que corre cada interval
+func NewConversionRecorder(exchangeUseCase domain.ExchangeUseCase, interval time.Duration) *ConversionRecorder {
+	return &ConversionRecorder{
+		exchangeUseCase: exchangeUseCase,
+		interval:        interval,
+	}
+}
+
+// Start ejecuta una pasada inmediata y luego una por intervalo, hasta que
+// ctx se cancele.
+func (r *ConversionRecorder) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.run()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Registro de conversiones detenido")
+			return
+		case <-ticker.C:
+			r.run()
+		}
+	}
+}
+
+func (r *ConversionRecorder) run() {
+	recorded, err := r.exchangeUseCase.RecordConversions()
+	if err != nil {
+		log.Printf("⚠️ Error registrando conversiones: %v", err)
+	}
+	if recorded > 0 {
+		log.Printf("💱 Conversiones registradas: %d", recorded)
+	}
+}
@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"finanzas-api/internal/exchange/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	userDomain "finanzas-api/internal/users/domain"
+	"finanzas-api/shared/money"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// recordBatchSize es cuántas transacciones convierte RecordConversions por lote
+const recordBatchSize = 500
+
+type ExchangeUseCase struct {
+	exchangeRepo domain.ExchangeRepository
+	userRepo     userDomain.UserRepository
+}
+
+func NewExchangeUseCase(exchangeRepo domain.ExchangeRepository, userRepo userDomain.UserRepository) domain.ExchangeUseCase {
+	return &ExchangeUseCase{
+		exchangeRepo: exchangeRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// ImportRates implements domain.ExchangeUseCase.
+// El archivo tiene una cotización por línea: date,base,quote,rate, con la
+// fecha en formato YYYY-MM-DD y la cotización con punto decimal. Una fila
+// de encabezado es opcional. Si alguna línea es inválida no se importa nada.
+func (uc *ExchangeUseCase) ImportRates(reader io.Reader, source string) (*domain.ImportResult, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = 4
+	csvReader.TrimLeadingSpace = true
+
+	var rates []*domain.ExchangeRate
+	for line := 1; ; line++ {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		// Encabezado opcional
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "date") {
+			continue
+		}
+
+		rate, err := parseRateRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		rate.Source = source
+		rates = append(rates, rate)
+	}
+
+	inserted, err := uc.exchangeRepo.CreateRates(rates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ImportResult{
+		Read:     len(rates),
+		Inserted: inserted,
+		Skipped:  len(rates) - inserted,
+	}, nil
+}
+
+// ListRates implements domain.ExchangeUseCase.
+func (uc *ExchangeUseCase) ListRates(filter domain.RateFilter) ([]*domain.ExchangeRate, error) {
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, errors.New("limit and offset must be non-negative")
+	}
+
+	// Valor por defecto para limit
+	if filter.Limit == 0 {
+		filter.Limit = 50
+	}
+
+	// Máximo 500 cotizaciones por página
+	if filter.Limit > 500 {
+		filter.Limit = 500
+	}
+
+	filter.Base = strings.ToUpper(strings.TrimSpace(filter.Base))
+	filter.Quote = strings.ToUpper(strings.TrimSpace(filter.Quote))
+	return uc.exchangeRepo.ListRates(filter)
+}
+
+// Convert implements domain.ExchangeUseCase.
+// Usa la cotización más reciente en o antes de date, directa (from→to) o
+// inversa (to→from).
+func (uc *ExchangeUseCase) Convert(amount int64, from, to string, date time.Time) (*domain.Conversion, error) {
+	return convert(uc.exchangeRepo, amount, from, to, date)
+}
+
+// convert hace la conversión de Convert buscando las cotizaciones en repo
+func convert(repo domain.ExchangeRepository, amount int64, from, to string, date time.Time) (*domain.Conversion, error) {
+	from = strings.ToUpper(strings.TrimSpace(from))
+	to = strings.ToUpper(strings.TrimSpace(to))
+	if !money.IsValidCurrency(from) || !money.IsValidCurrency(to) {
+		return nil, errors.New("invalid currency code")
+	}
+
+	if from == to {
+		return &domain.Conversion{Amount: amount, Currency: to}, nil
+	}
+
+	rate, inverted, err := findRate(repo, from, to, date)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := domain.ParseRate(rate.Rate)
+	if err != nil {
+		return nil, err
+	}
+	if inverted {
+		value.Inv(value)
+	}
+
+	converted, err := money.New(amount, from).Convert(value, to, money.HalfEven)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Conversion{
+		Amount:   converted.Amount,
+		Currency: to,
+		Rate:     rate,
+		Inverted: inverted,
+	}, nil
+}
+
+// BaseCurrency implements domain.ExchangeUseCase.
+func (uc *ExchangeUseCase) BaseCurrency(ctx context.Context, userID uint) (string, error) {
+	user, err := uc.userRepo.WithContext(ctx).GetByID(userID)
+	if err != nil {
+		return "", errors.New("user not found")
+	}
+
+	if user.BaseCurrency == "" {
+		return "COP", nil
+	}
+	return user.BaseCurrency, nil
+}
+
+// PreviewConversions implements domain.ExchangeUseCase.
+func (uc *ExchangeUseCase) PreviewConversions(ctx context.Context, userID uint, baseCurrency string, from, to time.Time) (transactionDomain.ConvertedAmounts, error) {
+	exchangeRepo := uc.exchangeRepo.WithContext(ctx)
+	pending, err := exchangeRepo.PendingConversions(userID, baseCurrency, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make(transactionDomain.ConvertedAmounts, len(pending))
+	for _, transaction := range pending {
+		conversion, err := convert(exchangeRepo, transaction.Amount, transaction.Currency, baseCurrency, transaction.Date)
+		if errors.Is(err, domain.ErrRateNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		converted[transaction.ID] = conversion.Amount
+	}
+	return converted, nil
+}
+
+// RecordConversions implements domain.ExchangeUseCase.
+func (uc *ExchangeUseCase) RecordConversions() (int, error) {
+	recorded := 0
+	var afterID uint
+	for {
+		pending, err := uc.exchangeRepo.PendingBaseConversions(afterID, recordBatchSize)
+		if err != nil {
+			return recorded, err
+		}
+		if len(pending) == 0 {
+			return recorded, nil
+		}
+
+		conversions := make([]*domain.TransactionConversion, 0, len(pending))
+		for _, item := range pending {
+			transaction := item.Transaction
+			afterID = transaction.ID
+
+			conversion, err := uc.Convert(transaction.Amount, transaction.Currency, item.BaseCurrency, transaction.Date)
+			if errors.Is(err, domain.ErrRateNotFound) {
+				continue
+			}
+			if err != nil {
+				return recorded, err
+			}
+
+			conversions = append(conversions, &domain.TransactionConversion{
+				TransactionID:  transaction.ID,
+				BaseCurrency:   item.BaseCurrency,
+				SourceCurrency: transaction.Currency,
+				SourceAmount:   transaction.Amount,
+				Date:           transaction.Date,
+				RateID:         conversion.Rate.ID,
+				Inverted:       conversion.Inverted,
+				BaseAmount:     conversion.Amount,
+			})
+		}
+
+		if err := uc.exchangeRepo.SaveConversions(conversions); err != nil {
+			return recorded, err
+		}
+		recorded += len(conversions)
+	}
+}
+
+// findRate busca en repo la cotización directa y la inversa y usa la más
+// reciente
+func findRate(repo domain.ExchangeRepository, from, to string, date time.Time) (*domain.ExchangeRate, bool, error) {
+	direct, err := repo.FindRate(from, to, date)
+	if err != nil && !errors.Is(err, domain.ErrRateNotFound) {
+		return nil, false, err
+	}
+
+	inverse, err := repo.FindRate(to, from, date)
+	if err != nil && !errors.Is(err, domain.ErrRateNotFound) {
+		return nil, false, err
+	}
+
+	switch {
+	case direct == nil && inverse == nil:
+		return nil, false, fmt.Errorf("%w for %s/%s on %s", domain.ErrRateNotFound, from, to, date.Format(dateLayout))
+	case inverse == nil || (direct != nil && !direct.Date.Before(inverse.Date)):
+		return direct, false, nil
+	default:
+		return inverse, true, nil
+	}
+}
+
+// parseRateRecord valida una línea del archivo de cotizaciones
+func parseRateRecord(record []string) (*domain.ExchangeRate, error) {
+	date, err := time.Parse(dateLayout, strings.TrimSpace(record[0]))
+	if err != nil {
+		return nil, errors.New("invalid date, expected YYYY-MM-DD")
+	}
+
+	base := strings.ToUpper(strings.TrimSpace(record[1]))
+	quote := strings.ToUpper(strings.TrimSpace(record[2]))
+	if !money.IsValidCurrency(base) || !money.IsValidCurrency(quote) {
+		return nil, errors.New("invalid currency code")
+	}
+	if base == quote {
+		return nil, errors.New("base and quote currencies must differ")
+	}
+
+	value := strings.TrimSpace(record[3])
+	if _, err := domain.ParseRate(value); err != nil {
+		return nil, err
+	}
+
+	return &domain.ExchangeRate{
+		Base:  base,
+		Quote: quote,
+		Date:  date,
+		Rate:  value,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"finanzas-api/internal/exchange/domain"
+	"finanzas-api/internal/exchange/repository"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	transactionRepository "finanzas-api/internal/transactions/repository"
+)
+
+func TestPreviewConversionsIsReadOnly(t *testing.T) {
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	transactions := transactionRepository.NewTransactionMemoryRepository()
+	for _, transaction := range []*transactionDomain.Transaction{
+		{UserID: 1, AccountID: 1, Amount: 1000, Currency: "USD", Direction: transactionDomain.DirectionExpense, Date: march.AddDate(0, 0, 4)},
+		{UserID: 1, AccountID: 2, Amount: 500, Currency: "EUR", Direction: transactionDomain.DirectionExpense, Date: march.AddDate(0, 0, 4)},
+		{UserID: 1, AccountID: 3, Amount: 700, Currency: "COP", Direction: transactionDomain.DirectionIncome, Date: march.AddDate(0, 0, 4)},
+	} {
+		if err := transactions.Create(transaction); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	exchangeRepo := repository.NewExchangeMemoryRepository(transactions)
+	if _, err := exchangeRepo.CreateRates([]*domain.ExchangeRate{{Base: "USD", Quote: "COP", Date: march, Rate: "4000"}}); err != nil {
+		t.Fatal(err)
+	}
+	uc := NewExchangeUseCase(exchangeRepo, nil)
+
+	converted, err := uc.PreviewConversions(context.Background(), 1, "COP", march, march.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("PreviewConversions() error = %v", err)
+	}
+	// Sin cotización EUR/COP la transacción en EUR queda fuera, sin error
+	want := transactionDomain.ConvertedAmounts{1: 4000000}
+	if len(converted) != len(want) || converted[1] != want[1] {
+		t.Fatalf("PreviewConversions() = %v, want %v", converted, want)
+	}
+
+	// Nada quedó registrado: ambas siguen pendientes
+	pending, err := exchangeRepo.PendingConversions(1, "COP", march, march.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("got %d pending conversions, want 2", len(pending))
+	}
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"time"
+
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+// Format es el formato de archivo de una exportación
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatJSON Format = "json"
+)
+
+// Language es el idioma de los encabezados de la exportación
+type Language string
+
+const (
+	LanguageSpanish Language = "es"
+	LanguageEnglish Language = "en"
+)
+
+// ExportFilter agrupa los criterios de la exportación. Las fechas son
+// inclusivas y todos los criterios son opcionales.
+type ExportFilter struct {
+	From       *time.Time
+	To         *time.Time
+	AccountID  *uint
+	CategoryID *uint
+	// ViewID limita la exportación a una vista guardada; el caso de uso la
+	// traduce a Criteria, que se combina con los demás criterios
+	ViewID   *uint
+	Criteria *transactionDomain.Criteria
+}
+
+// ExportRow es una transacción con los nombres de su cuenta y categoría
+type ExportRow struct {
+	ID           uint
+	Date         time.Time
+	AccountName  string
+	CategoryName string // Vacío si la transacción no tiene categoría
+	Direction    transactionDomain.Direction
+	IsTransfer   bool
+	Amount       int64 // En unidades menores, siempre positivo
+	Currency     string
+	Description  string
+	Payee        string
+	ExternalID   *string
+}
+
+// SignedAmount retorna el monto con signo: negativo para gastos
+func (r *ExportRow) SignedAmount() int64 {
+	if r.Direction == transactionDomain.DirectionExpense {
+		return -r.Amount
+	}
+	return r.Amount
+}
+
+// ExportWriter escribe las filas en el formato de salida a medida que se
+// leen de la base de datos, sin acumularlas en memoria.
+type ExportWriter interface {
+	WriteRow(row *ExportRow) error
+	// Close escribe el cierre del archivo; no cierra el destino
+	Close() error
+}
+
+// ViewResolver traduce una vista guardada a criterios de selección. Lo
+// implementa el módulo de vistas.
+type ViewResolver interface {
+	ResolveView(userID, id uint) (*transactionDomain.Criteria, error)
+}
+
+// ExportRepository define la interfaz del repositorio de exportaciones
+type ExportRepository interface {
+	// Stream recorre las transacciones del usuario que cumplen el filtro en
+	// orden cronológico y llama fn con cada una. Si fn falla se detiene.
+	Stream(userID uint, filter ExportFilter, fn func(row *ExportRow) error) error
+}
+
+type ExportUseCase interface {
+	// ValidateFilter verifica el filtro antes de empezar a escribir la respuesta
+	ValidateFilter(userID uint, filter ExportFilter) error
+	Export(userID uint, filter ExportFilter, writer ExportWriter) error
+}
+
+// IsValidFormat verifica si el formato es csv, xlsx o json
+func IsValidFormat(format Format) bool {
+	return format == FormatCSV || format == FormatXLSX || format == FormatJSON
+}
@@ -0,0 +1,34 @@
+package exports
+
+import (
+	accountDomain "finanzas-api/internal/accounts/domain"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/exports/domain"
+	"finanzas-api/internal/exports/handler"
+	"finanzas-api/internal/exports/repository"
+	"finanzas-api/internal/exports/usecase"
+
+	"gorm.io/gorm"
+)
+
+type ExportsModule struct {
+	Handler    *handler.ExportHandler
+	UseCase    domain.ExportUseCase
+	Repository domain.ExportRepository
+}
+
+func NewExportsModule(db *gorm.DB, accountRepo accountDomain.AccountRepository, categoryRepo categoryDomain.CategoryRepository, views domain.ViewResolver) *ExportsModule {
+	var exportRepo domain.ExportRepository
+	var exportUseCase domain.ExportUseCase
+	var exportHandler *handler.ExportHandler
+
+	exportRepo = repository.NewExportPostgresRepository(db)
+	exportUseCase = usecase.NewExportUseCase(exportRepo, accountRepo, categoryRepo, views)
+	exportHandler = handler.NewExportHandler(exportUseCase)
+
+	return &ExportsModule{
+		Handler:    exportHandler,
+		UseCase:    exportUseCase,
+		Repository: exportRepo,
+	}
+}
@@ -0,0 +1,8 @@
+package handler
+
+import "fmt"
+
+// errInvalidParam reporta un query param con formato inválido
+func errInvalidParam(name string) error {
+	return fmt.Errorf("invalid %s parameter", name)
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"finanzas-api/internal/exports/domain"
+	"finanzas-api/internal/exports/writer"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+type ExportHandler struct {
+	exportUseCase domain.ExportUseCase
+}
+
+// NewExportHandler crea una nueva instancia del handler de exportaciones
+func NewExportHandler(exportUseCase domain.ExportUseCase) *ExportHandler {
+	return &ExportHandler{
+		exportUseCase: exportUseCase,
+	}
+}
+
+// Export descarga las transacciones del usuario autenticado.
+// Parámetros: format (csv, xlsx o json; por defecto csv) y los filtros
+// opcionales from, to, account_id, category_id y view_id (vista guardada,
+// combinada con los demás). Los encabezados salen en
+// español o inglés según Accept-Language.
+func (h *ExportHandler) Export(c *gin.Context) {
+	format := domain.Format(strings.ToLower(c.DefaultQuery("format", string(domain.FormatCSV))))
+	if !domain.IsValidFormat(format) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid format, expected csv, xlsx or json",
+		})
+		return
+	}
+
+	filter, err := parseExportFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	if err := h.exportUseCase.ValidateFilter(userID, filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// A partir de aquí la respuesta se escribe en partes; un error ya no
+	// puede cambiar el código de estado
+	language := preferredLanguage(c.GetHeader("Accept-Language"))
+	fileName := fmt.Sprintf("%s-%s.%s", writer.LabelsFor(language).FileName, time.Now().Format(dateLayout), format)
+	c.Header("Content-Type", writer.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	c.Header("Content-Language", string(language))
+	c.Status(http.StatusOK)
+
+	exportWriter, err := writer.New(format, language, c.Writer)
+	if err == nil {
+		err = h.exportUseCase.Export(userID, filter, exportWriter)
+	}
+	if err != nil {
+		log.Printf("⚠️ Error exportando las transacciones del usuario %d: %v", userID, err)
+		c.Abort()
+	}
+}
+
+// parseExportFilter lee los filtros opcionales de la query
+func parseExportFilter(c *gin.Context) (domain.ExportFilter, error) {
+	var filter domain.ExportFilter
+
+	if value := c.Query("from"); value != "" {
+		from, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return filter, errInvalidParam("from")
+		}
+		filter.From = &from
+	}
+	if value := c.Query("to"); value != "" {
+		to, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return filter, errInvalidParam("to")
+		}
+		filter.To = &to
+	}
+	if value := c.Query("account_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return filter, errInvalidParam("account_id")
+		}
+		accountID := uint(id)
+		filter.AccountID = &accountID
+	}
+	if value := c.Query("category_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return filter, errInvalidParam("category_id")
+		}
+		categoryID := uint(id)
+		filter.CategoryID = &categoryID
+	}
+	if value := c.Query("view_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return filter, errInvalidParam("view_id")
+		}
+		viewID := uint(id)
+		filter.ViewID = &viewID
+	}
+
+	return filter, nil
+}
+
+// preferredLanguage elige entre español e inglés según Accept-Language,
+// p. ej. "en-US,en;q=0.9,es;q=0.8". Sin preferencia reconocible, español.
+func preferredLanguage(header string) domain.Language {
+	type option struct {
+		language domain.Language
+		quality  float64
+	}
+
+	var options []option
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		switch domain.Language(primary) {
+		case domain.LanguageSpanish, domain.LanguageEnglish:
+			if quality > 0 {
+				options = append(options, option{domain.Language(primary), quality})
+			}
+		}
+	}
+
+	if len(options) == 0 {
+		return domain.LanguageSpanish
+	}
+	// Estable: ante igual calidad gana el que aparece primero
+	sort.SliceStable(options, func(i, j int) bool { return options[i].quality > options[j].quality })
+	return options[0].language
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/exports/domain"
+
+type ExportRepository interface {
+	domain.ExportRepository
+}
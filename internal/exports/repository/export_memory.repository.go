@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"sort"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/exports/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+// exportRepositoryMemory lee de los repositorios en memoria de los demás
+// módulos y completa los nombres de cuenta y categoría.
+type exportRepositoryMemory struct {
+	transactionRepo transactionDomain.TransactionRepository
+	accountRepo     accountDomain.AccountRepository
+	categoryRepo    categoryDomain.CategoryRepository
+}
+
+func NewExportMemoryRepository(transactionRepo transactionDomain.TransactionRepository, accountRepo accountDomain.AccountRepository, categoryRepo categoryDomain.CategoryRepository) domain.ExportRepository {
+	return &exportRepositoryMemory{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		categoryRepo:    categoryRepo,
+	}
+}
+
+func (r *exportRepositoryMemory) Stream(userID uint, filter domain.ExportFilter, fn func(row *domain.ExportRow) error) error {
+	transactions, err := r.transactionRepo.List(userID, transactionDomain.TransactionFilter{
+		AccountID:  filter.AccountID,
+		CategoryID: filter.CategoryID,
+		From:       filter.From,
+		To:         filter.To,
+		Criteria:   filter.Criteria,
+	})
+	if err != nil {
+		return err
+	}
+
+	// List retorna de la más reciente a la más antigua
+	sort.Slice(transactions, func(i, j int) bool {
+		if !transactions[i].Date.Equal(transactions[j].Date) {
+			return transactions[i].Date.Before(transactions[j].Date)
+		}
+		return transactions[i].ID < transactions[j].ID
+	})
+
+	accountNames := make(map[uint]string)
+	categoryNames := make(map[uint]string)
+	for _, transaction := range transactions {
+		if _, cached := accountNames[transaction.AccountID]; !cached {
+			if account, err := r.accountRepo.GetByID(transaction.AccountID); err == nil {
+				accountNames[transaction.AccountID] = account.Name
+			}
+		}
+		row := &domain.ExportRow{
+			ID:          transaction.ID,
+			Date:        transaction.Date,
+			AccountName: accountNames[transaction.AccountID],
+			Direction:   transaction.Direction,
+			IsTransfer:  transaction.IsTransfer,
+			Amount:      transaction.Amount,
+			Currency:    transaction.Currency,
+			Description: transaction.Description,
+			Payee:       transaction.Payee,
+			ExternalID:  transaction.ExternalID,
+		}
+		if transaction.CategoryID != nil {
+			id := *transaction.CategoryID
+			if _, cached := categoryNames[id]; !cached {
+				if category, err := r.categoryRepo.GetByID(id); err == nil {
+					categoryNames[id] = category.Name
+				}
+			}
+			row.CategoryName = categoryNames[id]
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
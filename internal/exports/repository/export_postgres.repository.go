@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"finanzas-api/internal/exports/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+
+	"gorm.io/gorm"
+)
+
+type exportPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewExportPostgresRepository(db *gorm.DB) domain.ExportRepository {
+	return &exportPostgresRepository{db: db}
+}
+
+func (r *exportPostgresRepository) Stream(userID uint, filter domain.ExportFilter, fn func(row *domain.ExportRow) error) error {
+	query := r.db.Model(&transactionDomain.Transaction{}).
+		Select("transactions.id, transactions.date, accounts.name AS account_name, "+
+			"COALESCE(categories.name, '') AS category_name, transactions.direction, transactions.is_transfer, "+
+			"transactions.amount, transactions.currency, transactions.description, transactions.payee, transactions.external_id").
+		Joins("JOIN accounts ON accounts.id = transactions.account_id").
+		Joins("LEFT JOIN categories ON categories.id = transactions.category_id").
+		Where("transactions.user_id = ?", userID)
+	if filter.From != nil {
+		query = query.Where("transactions.date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("transactions.date <= ?", *filter.To)
+	}
+	if filter.AccountID != nil {
+		query = query.Where("transactions.account_id = ?", *filter.AccountID)
+	}
+	if filter.CategoryID != nil {
+		query = query.Where("transactions.category_id = ?", *filter.CategoryID)
+	}
+	query = filter.Criteria.Apply(query, "transactions")
+
+	// Rows lee con un cursor: las filas no se cargan todas en memoria
+	rows, err := query.Order("transactions.date, transactions.id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row domain.ExportRow
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
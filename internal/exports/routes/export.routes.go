@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"finanzas-api/internal/exports/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupExportRoutes configura las rutas para el módulo de exportaciones
+func SetupExportRoutes(router *gin.Engine, exportHandler *handler.ExportHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	exportRoutes := router.Group("/api/v1/exports")
+	{
+		// GET /api/v1/exports?format=csv|xlsx|json - Descargar transacciones
+		exportRoutes.GET("", authMiddleware("exports:read"), exportHandler.Export)
+	}
+}
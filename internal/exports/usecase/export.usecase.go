@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"errors"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/exports/domain"
+)
+
+type ExportUseCase struct {
+	exportRepo   domain.ExportRepository
+	accountRepo  accountDomain.AccountRepository
+	categoryRepo categoryDomain.CategoryRepository
+	views        domain.ViewResolver
+}
+
+func NewExportUseCase(exportRepo domain.ExportRepository, accountRepo accountDomain.AccountRepository, categoryRepo categoryDomain.CategoryRepository, views domain.ViewResolver) domain.ExportUseCase {
+	return &ExportUseCase{
+		exportRepo:   exportRepo,
+		accountRepo:  accountRepo,
+		categoryRepo: categoryRepo,
+		views:        views,
+	}
+}
+
+// ValidateFilter implements domain.ExportUseCase.
+func (uc *ExportUseCase) ValidateFilter(userID uint, filter domain.ExportFilter) error {
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return errors.New("invalid date range")
+	}
+
+	if filter.AccountID != nil {
+		account, err := uc.accountRepo.GetByID(*filter.AccountID)
+		if err != nil || !account.BelongsTo(userID) {
+			return errors.New("account not found")
+		}
+	}
+
+	if filter.CategoryID != nil {
+		category, err := uc.categoryRepo.GetByID(*filter.CategoryID)
+		if err != nil || !category.BelongsTo(userID) {
+			return errors.New("category not found")
+		}
+	}
+
+	if filter.ViewID != nil {
+		if _, err := uc.views.ResolveView(userID, *filter.ViewID); err != nil {
+			return errors.New("view not found")
+		}
+	}
+
+	return nil
+}
+
+// Export implements domain.ExportUseCase.
+func (uc *ExportUseCase) Export(userID uint, filter domain.ExportFilter, writer domain.ExportWriter) error {
+	if err := uc.ValidateFilter(userID, filter); err != nil {
+		return err
+	}
+
+	if filter.ViewID != nil {
+		criteria, err := uc.views.ResolveView(userID, *filter.ViewID)
+		if err != nil {
+			return err
+		}
+		filter.Criteria = criteria
+	}
+
+	if err := uc.exportRepo.Stream(userID, filter, writer.WriteRow); err != nil {
+		return err
+	}
+	return writer.Close()
+}
@@ -0,0 +1,48 @@
+package writer
+
+import (
+	"encoding/csv"
+	"io"
+
+	"finanzas-api/internal/exports/domain"
+)
+
+const dateLayout = "2006-01-02"
+
+type csvWriter struct {
+	csv    *csv.Writer
+	labels Labels
+}
+
+// newCSVWriter antepone la marca BOM para que Excel reconozca el UTF-8 de
+// las tildes
+func newCSVWriter(w io.Writer, labels Labels) (*csvWriter, error) {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+	writer := &csvWriter{csv: csv.NewWriter(w), labels: labels}
+	if err := writer.csv.Write(labels.Headers); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *csvWriter) WriteRow(row *domain.ExportRow) error {
+	// csv.Writer acumula en un búfer pequeño y lo vuelca al destino al llenarse
+	return w.csv.Write([]string{
+		row.Date.Format(dateLayout),
+		safeText(row.AccountName),
+		safeText(row.CategoryName),
+		w.labels.typeLabel(row),
+		decimalAmount(row),
+		row.Currency,
+		safeText(row.Description),
+		safeText(row.Payee),
+		safeText(externalID(row)),
+	})
+}
+
+func (w *csvWriter) Close() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
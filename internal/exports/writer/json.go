@@ -0,0 +1,67 @@
+package writer
+
+import (
+	"encoding/json"
+	"io"
+
+	"finanzas-api/internal/exports/domain"
+)
+
+// jsonRow usa las mismas claves que la API de transacciones; las claves no
+// se traducen porque el JSON lo consumen programas
+type jsonRow struct {
+	ID          uint    `json:"id"`
+	Date        string  `json:"date"`
+	Account     string  `json:"account"`
+	Category    string  `json:"category"`
+	Direction   string  `json:"direction"`
+	IsTransfer  bool    `json:"is_transfer"`
+	Amount      int64   `json:"amount"` // Unidades menores, siempre positivo
+	Currency    string  `json:"currency"`
+	Description string  `json:"description"`
+	Payee       string  `json:"payee"`
+	ExternalID  *string `json:"external_id"`
+}
+
+// jsonWriter escribe {"transactions":[...]} un elemento a la vez
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+func newJSONWriter(w io.Writer) (*jsonWriter, error) {
+	if _, err := io.WriteString(w, `{"transactions":[`); err != nil {
+		return nil, err
+	}
+	return &jsonWriter{w: w}, nil
+}
+
+func (w *jsonWriter) WriteRow(row *domain.ExportRow) error {
+	data, err := json.Marshal(jsonRow{
+		ID:          row.ID,
+		Date:        row.Date.Format(dateLayout),
+		Account:     row.AccountName,
+		Category:    row.CategoryName,
+		Direction:   string(row.Direction),
+		IsTransfer:  row.IsTransfer,
+		Amount:      row.Amount,
+		Currency:    row.Currency,
+		Description: row.Description,
+		Payee:       row.Payee,
+		ExternalID:  row.ExternalID,
+	})
+	if err != nil {
+		return err
+	}
+	if w.count > 0 {
+		data = append([]byte{','}, data...)
+	}
+	w.count++
+	_, err = w.w.Write(data)
+	return err
+}
+
+func (w *jsonWriter) Close() error {
+	_, err := io.WriteString(w.w, "]}")
+	return err
+}
@@ -0,0 +1,107 @@
+// Package writer escribe las filas de una exportación en CSV, XLSX o JSON
+// directamente sobre el destino, fila por fila, para que una exportación
+// de varios años no se acumule en memoria.
+package writer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"finanzas-api/internal/exports/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	"finanzas-api/shared/money"
+)
+
+// Labels son los textos visibles de la exportación en un idioma
+type Labels struct {
+	Headers  []string
+	Sheet    string // Nombre de la hoja en XLSX
+	FileName string // Nombre base del archivo descargado
+	Income   string
+	Expense  string
+	Transfer string
+}
+
+var labels = map[domain.Language]Labels{
+	domain.LanguageSpanish: {
+		Headers:  []string{"Fecha", "Cuenta", "Categoría", "Tipo", "Monto", "Moneda", "Descripción", "Comercio", "Referencia"},
+		Sheet:    "Transacciones",
+		FileName: "transacciones",
+		Income:   "Ingreso",
+		Expense:  "Gasto",
+		Transfer: "Transferencia",
+	},
+	domain.LanguageEnglish: {
+		Headers:  []string{"Date", "Account", "Category", "Type", "Amount", "Currency", "Description", "Payee", "Reference"},
+		Sheet:    "Transactions",
+		FileName: "transactions",
+		Income:   "Income",
+		Expense:  "Expense",
+		Transfer: "Transfer",
+	},
+}
+
+// LabelsFor retorna los textos del idioma; sin traducción, los de español
+func LabelsFor(language domain.Language) Labels {
+	if l, exists := labels[language]; exists {
+		return l
+	}
+	return labels[domain.LanguageSpanish]
+}
+
+// New crea el escritor del formato y escribe el encabezado
+func New(format domain.Format, language domain.Language, w io.Writer) (domain.ExportWriter, error) {
+	switch format {
+	case domain.FormatCSV:
+		return newCSVWriter(w, LabelsFor(language))
+	case domain.FormatXLSX:
+		return newXLSXWriter(w, LabelsFor(language))
+	case domain.FormatJSON:
+		return newJSONWriter(w)
+	}
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+// ContentType retorna el tipo MIME del formato
+func ContentType(format domain.Format) string {
+	switch format {
+	case domain.FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case domain.FormatJSON:
+		return "application/json; charset=utf-8"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// typeLabel traduce la dirección; las transferencias se distinguen aparte
+func (l Labels) typeLabel(row *domain.ExportRow) string {
+	switch {
+	case row.IsTransfer:
+		return l.Transfer
+	case row.Direction == transactionDomain.DirectionIncome:
+		return l.Income
+	}
+	return l.Expense
+}
+
+// decimalAmount formatea el monto con signo y punto decimal, p. ej. "-12.50"
+func decimalAmount(row *domain.ExportRow) string {
+	return money.New(row.SignedAmount(), row.Currency).Decimal()
+}
+
+func externalID(row *domain.ExportRow) string {
+	if row.ExternalID == nil {
+		return ""
+	}
+	return *row.ExternalID
+}
+
+// safeText evita que una hoja de cálculo interprete el texto como fórmula
+// (inyección CSV): las descripciones vienen de bancos y de terceros.
+func safeText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,190 @@
+package writer
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"finanzas-api/internal/exports/domain"
+)
+
+// Partes fijas del libro: una hoja, estilos para encabezado, fecha y monto
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`
+
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`
+
+	// Estilos: 0 general, 1 encabezado en negrita, 2 fecha (formato 14),
+	// 3 monto con separador de miles y dos decimales (formato 4)
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="4">` +
+		`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+		`<xf numFmtId="14" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`</cellXfs></styleSheet>`
+
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+const (
+	styleHeader = 1
+	styleDate   = 2
+	styleAmount = 3
+)
+
+// excelEpoch es el día cero de las fechas seriales de Excel
+var excelEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxWriter arma el libro con archive/zip. Las partes fijas se escriben
+// al crearlo y la hoja se comprime a medida que llegan las filas.
+type xlsxWriter struct {
+	zip    *zip.Writer
+	sheet  *bufio.Writer
+	labels Labels
+	row    int
+}
+
+func newXLSXWriter(w io.Writer, labels Labels) (*xlsxWriter, error) {
+	archive := zip.NewWriter(w)
+
+	workbook := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="` + escapeXML(labels.Sheet) + `" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", workbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, part := range parts {
+		entry, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(entry, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	// La hoja es la última entrada: zip solo admite escribir una a la vez
+	entry, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	writer := &xlsxWriter{zip: archive, sheet: bufio.NewWriter(entry), labels: labels}
+	if _, err := writer.sheet.WriteString(xlsxSheetHeader); err != nil {
+		return nil, err
+	}
+
+	cells := make([]string, len(labels.Headers))
+	for i, header := range labels.Headers {
+		cells[i] = textCell(header, styleHeader)
+	}
+	if err := writer.writeCells(cells); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *xlsxWriter) WriteRow(row *domain.ExportRow) error {
+	return w.writeCells([]string{
+		numberCell(fmt.Sprint(excelDate(row.Date)), styleDate),
+		textCell(row.AccountName, 0),
+		textCell(row.CategoryName, 0),
+		textCell(w.labels.typeLabel(row), 0),
+		numberCell(decimalAmount(row), styleAmount),
+		textCell(row.Currency, 0),
+		textCell(row.Description, 0),
+		textCell(row.Payee, 0),
+		textCell(externalID(row), 0),
+	})
+}
+
+func (w *xlsxWriter) Close() error {
+	if _, err := w.sheet.WriteString(xlsxSheetFooter); err != nil {
+		return err
+	}
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+func (w *xlsxWriter) writeCells(cells []string) error {
+	w.row++
+	if _, err := fmt.Fprintf(w.sheet, `<row r="%d">`, w.row); err != nil {
+		return err
+	}
+	for _, cell := range cells {
+		if _, err := w.sheet.WriteString(cell); err != nil {
+			return err
+		}
+	}
+	_, err := w.sheet.WriteString(`</row>`)
+	return err
+}
+
+// textCell usa cadenas en línea para no tener que acumular la tabla de
+// cadenas compartidas hasta el final
+func textCell(value string, style int) string {
+	if value == "" && style == 0 {
+		return `<c/>`
+	}
+	return fmt.Sprintf(`<c t="inlineStr"%s><is><t xml:space="preserve">%s</t></is></c>`, styleAttr(style), escapeXML(value))
+}
+
+// excelDate retorna el número de serie de Excel del día calendario
+func excelDate(date time.Time) int {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return int(day.Sub(excelEpoch).Hours() / 24)
+}
+
+func numberCell(value string, style int) string {
+	return fmt.Sprintf(`<c%s><v>%s</v></c>`, styleAttr(style), value)
+}
+
+func styleAttr(style int) string {
+	if style == 0 {
+		return ""
+	}
+	return fmt.Sprintf(` s="%d"`, style)
+}
+
+// escapeXML escapa el texto y reemplaza los caracteres que XML no admite
+func escapeXML(value string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(value))
+	return escaped.String()
+}
@@ -0,0 +1,352 @@
+// Package csvparser interpreta extractos bancarios en CSV según un perfil
+// de importación. No accede a la base de datos ni a la red.
+package csvparser
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"finanzas-api/internal/imports/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	"finanzas-api/shared/money"
+)
+
+// MaxRows es el número máximo de filas de datos por archivo
+const MaxRows = 5000
+
+// maxDescriptionLength coincide con el límite de las transacciones
+const maxDescriptionLength = 255
+
+// columns son las posiciones resueltas de las columnas del perfil; -1
+// indica que el perfil no usa esa columna.
+type columns struct {
+	date, description, amount, debit, credit int
+}
+
+// Parse interpreta data con el perfil. currency es la moneda de la cuenta
+// destino y define cuántos decimales admiten los montos. Los problemas de
+// una fila quedan en su campo Error; solo se retorna error si el archivo
+// completo es ilegible.
+func Parse(data []byte, profile *domain.ImportProfile, currency string) ([]*domain.ImportRow, error) {
+	text, err := decode(data, profile.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma, _ = utf8.DecodeRuneInString(profile.Delimiter)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	for i := 0; i < profile.SkipRows; i++ {
+		if _, err := reader.Read(); err != nil {
+			return nil, fmt.Errorf("file has fewer than %d rows to skip", profile.SkipRows)
+		}
+	}
+
+	var header []string
+	if profile.HasHeader {
+		header, err = reader.Read()
+		if err != nil {
+			return nil, errors.New("file has no header row")
+		}
+	}
+
+	cols, err := resolveColumns(profile, header)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := DateLayout(profile.DateFormat)
+	var rows []*domain.ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if isBlank(record) {
+			continue
+		}
+		if len(rows) == MaxRows {
+			return nil, fmt.Errorf("file exceeds %d rows", MaxRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := &domain.ImportRow{
+			LineNumber: line,
+			Raw:        strings.Join(record, profile.Delimiter),
+		}
+		if err := parseRecord(row, record, cols, layout, profile.DecimalSeparator, currency); err != nil {
+			row.Error = err.Error()
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// DateLayout convierte un formato con marcadores (DD/MM/YYYY) al layout de Go
+func DateLayout(format string) string {
+	layout := strings.ToUpper(format)
+	layout = strings.ReplaceAll(layout, "YYYY", "2006")
+	layout = strings.ReplaceAll(layout, "YY", "06")
+	layout = strings.ReplaceAll(layout, "MM", "01")
+	layout = strings.ReplaceAll(layout, "DD", "02")
+	return layout
+}
+
+// ParseAmount interpreta un monto escrito con el separador decimal indicado
+// y lo retorna en unidades menores de currency, con signo. Acepta separador
+// de miles, el símbolo $, negativos como "-1.234,50", "1234.50-" o
+// "(1,234.50)", y al inicio o al final un código de moneda ISO 4217
+// ("COP 1.000") o un indicador DR (débito, negativo) o CR (crédito). Otras
+// letras invalidan el monto.
+func ParseAmount(value, decimalSeparator, currency string) (int64, error) {
+	input := strings.TrimSpace(value)
+	if input == "" {
+		return 0, errors.New("amount is empty")
+	}
+
+	value = input
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = value[1 : len(value)-1]
+	}
+
+	var prefix, suffix string
+	value, prefix = cutLetters(value, false)
+	value, suffix = cutLetters(value, true)
+	for _, word := range []string{prefix, suffix} {
+		switch word = strings.ToUpper(word); {
+		case word == "", word == "CR":
+		case word == "DR":
+			negative = true
+		case !money.IsValidCurrency(word):
+			return 0, fmt.Errorf("invalid amount %q", input)
+		}
+	}
+
+	thousands := ","
+	if decimalSeparator == "," {
+		thousands = "."
+	}
+
+	var cleaned strings.Builder
+	for i, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			cleaned.WriteRune(r)
+		case string(r) == decimalSeparator:
+			cleaned.WriteByte('.')
+		case string(r) == thousands, r == ' ', r == '\'', r == '$', r == '\u00a0':
+			// Separador de miles o símbolo: se ignora
+		case r == '-' && (i == 0 || i == len(value)-1):
+			negative = true
+		case r == '+' && i == 0:
+		default:
+			return 0, fmt.Errorf("invalid amount %q", input)
+		}
+	}
+
+	normalized := trimExtraZeros(cleaned.String(), money.MinorUnits(currency))
+	amount, err := money.ParseDecimal(normalized, currency)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", input)
+	}
+	if negative {
+		return -amount.Amount, nil
+	}
+	return amount.Amount, nil
+}
+
+// cutLetters separa las letras ASCII al inicio de value, o al final si
+// fromEnd, y retorna el resto sin espacios en los bordes.
+func cutLetters(value string, fromEnd bool) (string, string) {
+	isLetter := func(b byte) bool {
+		return (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z')
+	}
+
+	if fromEnd {
+		i := len(value)
+		for i > 0 && isLetter(value[i-1]) {
+			i--
+		}
+		return strings.TrimSpace(value[:i]), value[i:]
+	}
+	i := 0
+	for i < len(value) && isLetter(value[i]) {
+		i++
+	}
+	return strings.TrimSpace(value[i:]), value[:i]
+}
+
+// resolveColumns ubica las columnas del perfil en el encabezado o por posición
+func resolveColumns(profile *domain.ImportProfile, header []string) (columns, error) {
+	resolve := func(name, ref string, required bool) (int, error) {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			if required {
+				return -1, fmt.Errorf("%s column is required", name)
+			}
+			return -1, nil
+		}
+		for i, title := range header {
+			if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(title, "\ufeff")), ref) {
+				return i, nil
+			}
+		}
+		if position, err := strconv.Atoi(ref); err == nil && position >= 1 {
+			return position - 1, nil
+		}
+		return -1, fmt.Errorf("%s column %q not found in header", name, ref)
+	}
+
+	var cols columns
+	var err error
+	if cols.date, err = resolve("date", profile.DateColumn, true); err != nil {
+		return cols, err
+	}
+	if cols.description, err = resolve("description", profile.DescriptionColumn, false); err != nil {
+		return cols, err
+	}
+	if cols.amount, err = resolve("amount", profile.AmountColumn, false); err != nil {
+		return cols, err
+	}
+	if cols.debit, err = resolve("debit", profile.DebitColumn, false); err != nil {
+		return cols, err
+	}
+	if cols.credit, err = resolve("credit", profile.CreditColumn, false); err != nil {
+		return cols, err
+	}
+	if cols.amount < 0 && cols.debit < 0 && cols.credit < 0 {
+		return cols, errors.New("profile needs an amount column or debit/credit columns")
+	}
+	return cols, nil
+}
+
+// parseRecord llena la fila a partir de los campos del registro
+func parseRecord(row *domain.ImportRow, record []string, cols columns, layout, decimalSeparator, currency string) error {
+	field := func(index int) string {
+		if index < 0 || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	date, err := time.Parse(layout, field(cols.date))
+	if err != nil {
+		return fmt.Errorf("invalid date %q", field(cols.date))
+	}
+	row.Date = &date
+
+	row.Description = strings.Join(strings.Fields(field(cols.description)), " ")
+	if len(row.Description) > maxDescriptionLength {
+		row.Description = Truncate(row.Description, maxDescriptionLength)
+	}
+
+	var amount int64
+	if cols.amount >= 0 {
+		if amount, err = ParseAmount(field(cols.amount), decimalSeparator, currency); err != nil {
+			return err
+		}
+	} else {
+		debit, credit := field(cols.debit), field(cols.credit)
+		var debitAmount, creditAmount int64
+		if debit != "" {
+			if debitAmount, err = ParseAmount(debit, decimalSeparator, currency); err != nil {
+				return err
+			}
+		}
+		if credit != "" {
+			if creditAmount, err = ParseAmount(credit, decimalSeparator, currency); err != nil {
+				return err
+			}
+		}
+		if debitAmount != 0 && creditAmount != 0 {
+			return errors.New("row has both debit and credit amounts")
+		}
+		// Algunos bancos escriben los débitos con signo negativo
+		amount = abs(creditAmount) - abs(debitAmount)
+	}
+
+	if amount == 0 {
+		return errors.New("amount must not be zero")
+	}
+
+	row.Direction = transactionDomain.DirectionIncome
+	row.Amount = amount
+	if amount < 0 {
+		row.Direction = transactionDomain.DirectionExpense
+		row.Amount = -amount
+	}
+	return nil
+}
+
+// decode convierte el archivo a UTF-8 según la codificación del perfil
+func decode(data []byte, encoding domain.Encoding) (string, error) {
+	if encoding == domain.EncodingLatin1 {
+		// En ISO-8859-1 cada byte es el punto de código Unicode del mismo valor
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return string(runes), nil
+	}
+
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		return "", errors.New("file is not valid UTF-8, try the latin-1 encoding")
+	}
+	return string(data), nil
+}
+
+// trimExtraZeros quita ceros finales que exceden los decimales de la moneda
+// ("1234.5000" con dos decimales pasa a "1234.50").
+func trimExtraZeros(value string, units int) string {
+	dot := strings.IndexByte(value, '.')
+	if dot < 0 {
+		return value
+	}
+	for len(value)-dot-1 > units && strings.HasSuffix(value, "0") {
+		value = value[:len(value)-1]
+	}
+	return strings.TrimSuffix(value, ".")
+}
+
+// Truncate corta s a como máximo n bytes sin partir un carácter
+func Truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,198 @@
+package csvparser
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"finanzas-api/internal/imports/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value     string
+		separator string
+		currency  string
+		want      int64
+		wantErr   bool
+	}{
+		{value: "1234.50", separator: ".", currency: "COP", want: 123450},
+		{value: "1,234.50", separator: ".", currency: "COP", want: 123450},
+		{value: "-1.234,50", separator: ",", currency: "COP", want: -123450},
+		{value: "1234.50-", separator: ".", currency: "COP", want: -123450},
+		{value: "(1,234.50)", separator: ".", currency: "COP", want: -123450},
+		{value: "+15", separator: ".", currency: "USD", want: 1500},
+		{value: "$ 1.000", separator: ",", currency: "COP", want: 100000},
+		{value: "1.234,5000", separator: ",", currency: "COP", want: 123450},
+		{value: "1500", separator: ".", currency: "JPY", want: 1500},
+		{value: "COP 1.000", separator: ",", currency: "COP", want: 100000},
+		{value: "1,234.50 USD", separator: ".", currency: "USD", want: 123450},
+		{value: "usd12.00", separator: ".", currency: "USD", want: 1200},
+		{value: "1.234,50 DR", separator: ",", currency: "COP", want: -123450},
+		{value: "1.234,50 CR", separator: ",", currency: "COP", want: 123450},
+		{value: "DR 50", separator: ".", currency: "COP", want: -5000},
+		{value: "", separator: ".", currency: "COP", wantErr: true},
+		{value: "12.345", separator: ".", currency: "USD", wantErr: true},       // Más decimales que la moneda
+		{value: "1O0", separator: ".", currency: "COP", wantErr: true},          // Letra O en lugar de cero
+		{value: "1e3", separator: ".", currency: "COP", wantErr: true},          // Notación científica
+		{value: "12 ABC", separator: ".", currency: "COP", wantErr: true},       // No es un código ISO
+		{value: "N/A", separator: ".", currency: "COP", wantErr: true},          // Texto
+		{value: "1.000 COP DR", separator: ",", currency: "COP", wantErr: true}, // Dos palabras al final
+		{value: "DR", separator: ".", currency: "COP", wantErr: true},
+		{value: "1-000", separator: ".", currency: "COP", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.value, tt.separator, tt.currency)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAmount(%q) = %d, want an error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAmount(%q) error = %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAmount(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	signed := &domain.ImportProfile{
+		Delimiter: ";", HasHeader: true, Encoding: domain.EncodingUTF8, DateFormat: "DD/MM/YYYY",
+		DecimalSeparator: ",", DateColumn: "Fecha", DescriptionColumn: "Detalle", AmountColumn: "Valor",
+	}
+	debitCredit := &domain.ImportProfile{
+		Delimiter: ",", HasHeader: false, SkipRows: 1, Encoding: domain.EncodingUTF8, DateFormat: "YYYY-MM-DD",
+		DecimalSeparator: ".", DateColumn: "1", DescriptionColumn: "2", DebitColumn: "3", CreditColumn: "4",
+	}
+
+	type wantRow struct {
+		date        string
+		description string
+		amount      int64
+		direction   transactionDomain.Direction
+		err         string
+	}
+	tests := []struct {
+		name    string
+		data    string
+		profile *domain.ImportProfile
+		want    []wantRow
+		wantErr string
+	}{
+		{
+			name:    "signed amount column with header",
+			profile: signed,
+			data:    "\ufeffFecha;Detalle;Valor\n05/03/2024;Pago   nómina;3.500.000,00\n06/03/2024;Mercado;-125.300,50\n\n",
+			want: []wantRow{
+				{date: "2024-03-05", description: "Pago nómina", amount: 350000000, direction: transactionDomain.DirectionIncome},
+				{date: "2024-03-06", description: "Mercado", amount: 12530050, direction: transactionDomain.DirectionExpense},
+			},
+		},
+		{
+			name:    "row errors do not stop the file",
+			profile: signed,
+			data:    "Fecha;Detalle;Valor\n31/02/2024;Fecha inválida;10,00\n01/03/2024;Texto;diez\n02/03/2024;Cero;0,00\n03/03/2024;Bien;10,00\n",
+			want: []wantRow{
+				{err: `invalid date "31/02/2024"`},
+				{err: `invalid amount "diez"`},
+				{err: "amount must not be zero"},
+				{date: "2024-03-03", description: "Bien", amount: 1000, direction: transactionDomain.DirectionIncome},
+			},
+		},
+		{
+			name:    "debit and credit columns by position",
+			profile: debitCredit,
+			data:    "Extracto de cuenta\n2024-03-01,Arriendo,1500000.00,\n2024-03-02,Reembolso,,20.5\n2024-03-03,Ambos,1,2\n2024-03-04,Débito negativo,-30,\n",
+			want: []wantRow{
+				{date: "2024-03-01", description: "Arriendo", amount: 150000000, direction: transactionDomain.DirectionExpense},
+				{date: "2024-03-02", description: "Reembolso", amount: 2050, direction: transactionDomain.DirectionIncome},
+				{err: "row has both debit and credit amounts"},
+				{date: "2024-03-04", description: "Débito negativo", amount: 3000, direction: transactionDomain.DirectionExpense},
+			},
+		},
+		{
+			name:    "missing header column",
+			profile: &domain.ImportProfile{Delimiter: ",", HasHeader: true, DateFormat: "YYYY-MM-DD", DecimalSeparator: ".", DateColumn: "Date", AmountColumn: "Amount"},
+			data:    "Fecha,Valor\n2024-03-01,10\n",
+			wantErr: `date column "Date" not found in header`,
+		},
+		{
+			name:    "latin-1 is required",
+			profile: signed,
+			data:    "Fecha;Detalle;Valor\n05/03/2024;Pago n\xf3mina;10,00\n",
+			wantErr: "file is not valid UTF-8, try the latin-1 encoding",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Parse([]byte(tt.data), tt.profile, "COP")
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Parse() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if len(rows) != len(tt.want) {
+				t.Fatalf("Parse() returned %d rows, want %d", len(rows), len(tt.want))
+			}
+			for i, want := range tt.want {
+				row := rows[i]
+				if row.Error != want.err {
+					t.Errorf("row %d error = %q, want %q", i, row.Error, want.err)
+					continue
+				}
+				if want.err != "" {
+					continue
+				}
+				if row.Date == nil || row.Date.Format("2006-01-02") != want.date {
+					t.Errorf("row %d date = %v, want %s", i, row.Date, want.date)
+				}
+				if row.Description != want.description || row.Amount != want.amount || row.Direction != want.direction {
+					t.Errorf("row %d = (%q, %d, %s), want (%q, %d, %s)", i,
+						row.Description, row.Amount, row.Direction, want.description, want.amount, want.direction)
+				}
+			}
+		})
+	}
+}
+
+func TestParseLatin1(t *testing.T) {
+	profile := &domain.ImportProfile{
+		Delimiter: ";", HasHeader: false, Encoding: domain.EncodingLatin1, DateFormat: "DD/MM/YY",
+		DecimalSeparator: ",", DateColumn: "1", DescriptionColumn: "2", AmountColumn: "3",
+	}
+	rows, err := Parse([]byte("05/03/24;Pago n\xf3mina;10,00\n"), profile, "COP")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(rows) != 1 || rows[0].Description != "Pago nómina" || !rows[0].Date.Equal(time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Parse() = %+v", rows[0])
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"abcdef", 3, "abc"},
+		{"añb", 2, "a"}, // ñ ocupa dos bytes
+		{"añb", 3, "añ"},
+		{strings.Repeat("é", 3), 5, "éé"},
+		{"abc", 5, "abc"},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.in, tt.n); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
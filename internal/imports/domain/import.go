@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	userDomain "finanzas-api/internal/users/domain"
+
+	"gorm.io/gorm"
+)
+
+// Encoding es la codificación de caracteres del archivo importado
+type Encoding string
+
+const (
+	EncodingUTF8   Encoding = "utf-8"
+	EncodingLatin1 Encoding = "latin-1" // ISO-8859-1, común en exportaciones de bancos locales
+)
+
+// BatchStatus es el estado de un lote de importación
+type BatchStatus string
+
+const (
+	BatchStatusPreview   BatchStatus = "preview"   // Analizado, pendiente de confirmación
+	BatchStatusCommitted BatchStatus = "committed" // Transacciones creadas
+	BatchStatusDiscarded BatchStatus = "discarded" // Descartado por el usuario
+)
+
+// ErrBatchNotPending indica que el lote ya fue confirmado o descartado
+var ErrBatchNotPending = errors.New("import batch is not pending")
+
+// ImportProfile describe el formato CSV de un banco: qué columna contiene
+// cada dato y cómo se escriben fechas y montos. Las columnas se indican por
+// nombre de encabezado o por posición (empezando en 1).
+type ImportProfile struct {
+	ID        uint            `json:"id" gorm:"primaryKey"`
+	UserID    uint            `json:"user_id" gorm:"not null;index"`
+	User      userDomain.User `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Name      string          `json:"name" gorm:"not null"`
+	Delimiter string          `json:"delimiter" gorm:"type:varchar(1);not null;default:','"`
+	HasHeader bool            `json:"has_header" gorm:"not null"`
+	SkipRows  int             `json:"skip_rows" gorm:"not null;default:0"` // Líneas previas al encabezado o a los datos
+	Encoding  Encoding        `json:"encoding" gorm:"type:varchar(10);not null;default:'utf-8'"`
+	// DateFormat usa los marcadores YYYY, MM y DD, p. ej. "DD/MM/YYYY"
+	DateFormat        string `json:"date_format" gorm:"type:varchar(20);not null"`
+	DecimalSeparator  string `json:"decimal_separator" gorm:"type:varchar(1);not null;default:'.'"`
+	DateColumn        string `json:"date_column" gorm:"not null"`
+	DescriptionColumn string `json:"description_column"`
+	// AmountColumn contiene un monto con signo (negativo = gasto). Si está
+	// vacío se usan DebitColumn (gastos) y CreditColumn (ingresos).
+	AmountColumn string         `json:"amount_column"`
+	DebitColumn  string         `json:"debit_column"`
+	CreditColumn string         `json:"credit_column"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete
+}
+
+// ImportBatch agrupa las filas de un archivo subido. Las transacciones que
+// se crean al confirmarlo guardan el ID del lote.
+type ImportBatch struct {
+	ID          uint                  `json:"id" gorm:"primaryKey"`
+	UserID      uint                  `json:"user_id" gorm:"not null;index"`
+	User        userDomain.User       `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	AccountID   uint                  `json:"account_id" gorm:"not null;index"`
+	Account     accountDomain.Account `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	ProfileID   *uint                 `json:"profile_id"`
+	Format      string                `json:"format" gorm:"type:varchar(20);not null;default:'csv'"`
+	FileName    string                `json:"file_name"`
+	Status      BatchStatus           `json:"status" gorm:"type:varchar(20);not null;index"`
+	TotalRows   int                   `json:"total_rows"`
+	ValidRows   int                   `json:"valid_rows"`
+	ErrorRows   int                   `json:"error_rows"`
+	CommittedAt *time.Time            `json:"committed_at"`
+	FlaggedRows int                   `json:"flagged_rows"` // Filas enviadas a revisión de duplicados
+	// Saldo que reporta el extracto (LEDGERBAL en OFX, saldo de cierre en
+	// camt.053 y MT940) y diferencia con el saldo de la cuenta en esa
+	// fecha. En vista previa la diferencia ya incluye las filas por
+	// importar; cero indica que el extracto cuadra.
+	StatementBalance     *int64       `json:"statement_balance"`
+	StatementBalanceDate *time.Time   `json:"statement_balance_date" gorm:"type:date"`
+	BalanceDifference    *int64       `json:"balance_difference"`
+	CreatedAt            time.Time    `json:"created_at"`
+	UpdatedAt            time.Time    `json:"updated_at"`
+	Rows                 []*ImportRow `json:"rows,omitempty" gorm:"foreignKey:BatchID;constraint:OnDelete:CASCADE"`
+}
+
+// ImportRow es una línea del archivo ya interpretada. Si no se pudo
+// interpretar, Error describe el problema y la fila no se importa.
+type ImportRow struct {
+	ID            uint                        `json:"id" gorm:"primaryKey"`
+	BatchID       uint                        `json:"batch_id" gorm:"not null;index"`
+	LineNumber    int                         `json:"line_number" gorm:"not null"`
+	Date          *time.Time                  `json:"date" gorm:"type:date"`
+	Amount        int64                       `json:"amount"` // Unidades menores, siempre positivo
+	Direction     transactionDomain.Direction `json:"direction" gorm:"type:varchar(10)"`
+	Description   string                      `json:"description"`
+	ExternalID    string                      `json:"external_id,omitempty" gorm:"type:varchar(255)"` // FITID o referencia del banco
+	Raw           string                      `json:"raw"`                                            // Línea original, para diagnóstico
+	ValueDate     *time.Time                  `json:"value_date,omitempty" gorm:"type:date"`
+	Counterparty  string                      `json:"counterparty,omitempty"`
+	Error         string                      `json:"error,omitempty"`
+	TransactionID *uint                       `json:"transaction_id"`
+	// Revisión creada al confirmar si la fila se parecía a una transacción
+	// existente; en ese caso no se creó la transacción
+	DuplicateReviewID *uint `json:"duplicate_review_id,omitempty"`
+}
+
+// ImportRepository define la interfaz del repositorio de importaciones
+type ImportRepository interface {
+	CreateProfile(profile *ImportProfile) error
+	GetProfileByID(id uint) (*ImportProfile, error)
+	UpdateProfile(profile *ImportProfile) error
+	DeleteProfile(id uint) error
+	ListProfiles(userID uint) ([]*ImportProfile, error)
+
+	// CreateBatch guarda el lote junto con sus filas
+	CreateBatch(batch *ImportBatch) error
+	// GetBatchByID retorna el lote con sus filas
+	GetBatchByID(id uint) (*ImportBatch, error)
+	ListBatches(userID uint, limit, offset int) ([]*ImportBatch, error)
+	// CommitBatch crea las transacciones y las revisiones de duplicados,
+	// enlaza cada fila con la suya y marca el lote como confirmado, todo de
+	// forma atómica. Ambos mapas van por ID de fila. Falla si el lote ya no
+	// está en vista previa (ErrBatchNotPending).
+	CommitBatch(batch *ImportBatch, transactions map[uint]*transactionDomain.Transaction, reviews map[uint]*transactionDomain.DuplicateReview) error
+	// FindExternalIDs retorna, de los identificadores dados, los que ya
+	// tiene una transacción vigente de la cuenta, con el ID de esta
+	FindExternalIDs(userID, accountID uint, externalIDs []string) (map[string]uint, error)
+	// UpdateBatch guarda los datos del lote sin modificar sus filas
+	UpdateBatch(batch *ImportBatch) error
+	UpdateRow(row *ImportRow) error
+	// DiscardBatch marca como descartado un lote en vista previa; retorna
+	// ErrBatchNotPending si ya no lo está.
+	DiscardBatch(id uint) error
+}
+
+type ImportUseCase interface {
+	CreateProfile(profile *ImportProfile) error
+	GetProfile(userID, id uint) (*ImportProfile, error)
+	UpdateProfile(userID uint, profile *ImportProfile) error
+	DeleteProfile(userID, id uint) error
+	ListProfiles(userID uint) ([]*ImportProfile, error)
+
+	// PreviewCSV interpreta el archivo con el perfil y guarda el lote en
+	// vista previa, con los errores de cada fila. No crea transacciones.
+	PreviewCSV(userID, accountID, profileID uint, fileName string, data []byte) (*ImportBatch, error)
+	// PreviewOFX interpreta un extracto OFX o QFX. Las transacciones cuyo
+	// FITID ya existe en la cuenta se marcan como duplicadas.
+	PreviewOFX(userID, accountID uint, fileName string, data []byte) (*ImportBatch, error)
+	// PreviewStatement interpreta un extracto camt.053 o MT940 (format vacío
+	// lo deduce del contenido). Rechaza el archivo si el saldo de cierre de
+	// un extracto no es el de apertura más sus líneas.
+	PreviewStatement(userID, accountID uint, format, fileName string, data []byte) (*ImportBatch, error)
+	GetBatch(userID, id uint) (*ImportBatch, error)
+	ListBatches(userID uint, limit, offset int) ([]*ImportBatch, error)
+	// CommitBatch crea las transacciones de las filas válidas; las que se
+	// parecen a una transacción existente quedan en revisión de duplicados
+	CommitBatch(userID, id uint) (*ImportBatch, error)
+	DiscardBatch(userID, id uint) error
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (ImportProfile) TableName() string {
+	return "import_profiles"
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (ImportBatch) TableName() string {
+	return "import_batches"
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (ImportRow) TableName() string {
+	return "import_rows"
+}
+
+// BelongsTo verifica si el perfil pertenece al usuario indicado
+func (p *ImportProfile) BelongsTo(userID uint) bool {
+	return p.UserID == userID
+}
+
+// BelongsTo verifica si el lote pertenece al usuario indicado
+func (b *ImportBatch) BelongsTo(userID uint) bool {
+	return b.UserID == userID
+}
+
+// IsValid indica si la fila se pudo interpretar
+func (r *ImportRow) IsValid() bool {
+	return r.Error == ""
+}
@@ -0,0 +1,86 @@
+package domain
+
+import "context"
+
+// MigrationAction es lo que la importación hace con un elemento de origen
+type MigrationAction string
+
+const (
+	MigrationCreate MigrationAction = "create" // Se crea un registro nuevo
+	MigrationMerge  MigrationAction = "merge"  // Se asocia a un registro existente
+	MigrationSkip   MigrationAction = "skip"   // No se importa
+)
+
+// MaxMigrationItems limita el detalle que se incluye en el reporte
+const MaxMigrationItems = 1000
+
+// Importer trae el historial exportado por otra aplicación de finanzas:
+// cuentas, categorías, payees y transferencias. Volver a importar el mismo
+// archivo no duplica datos: las cuentas y categorías con el mismo nombre se
+// combinan y los movimientos ya importados se omiten.
+type Importer interface {
+	// Format identifica la fuente: qif, firefly o ynab
+	Format() string
+	// Import aplica el archivo a los datos del usuario. Con DryRun no
+	// escribe nada y el reporte describe lo que se crearía, combinaría u
+	// omitiría.
+	Import(userID uint, data []byte, options MigrationOptions) (*MigrationReport, error)
+}
+
+// MigrationOptions configura una importación desde otra aplicación
+type MigrationOptions struct {
+	DryRun bool
+	// Currency es la moneda de las cuentas nuevas cuando la fuente no la
+	// indica; vacía usa la moneda base del usuario
+	Currency string
+	// DateFormat y DecimalSeparator describen fechas y montos de QIF y YNAB,
+	// que dependen de la configuración regional de quien exporta
+	DateFormat       string
+	DecimalSeparator string
+	// AccountName nombra la cuenta de un QIF que no declara ninguna
+	AccountName string
+}
+
+// MigrationCounts resume las acciones sobre un tipo de elemento
+type MigrationCounts struct {
+	Created int `json:"created"`
+	Merged  int `json:"merged"`
+	Skipped int `json:"skipped"`
+}
+
+// MigrationItem detalla la acción sobre un elemento: cuentas y categorías
+// siempre, movimientos solo cuando se omiten
+type MigrationItem struct {
+	Entity string          `json:"entity"` // account, category, transaction o transfer
+	Action MigrationAction `json:"action"`
+	Name   string          `json:"name"`
+	Line   int             `json:"line,omitempty"`
+	Reason string          `json:"reason,omitempty"`
+}
+
+// MigrationReport es el resultado de una importación o de su simulación
+type MigrationReport struct {
+	Format       string           `json:"format"`
+	DryRun       bool             `json:"dry_run"`
+	Accounts     MigrationCounts  `json:"accounts"`
+	Categories   MigrationCounts  `json:"categories"`
+	Transactions MigrationCounts  `json:"transactions"`
+	Transfers    MigrationCounts  `json:"transfers"`
+	Payees       int              `json:"payees"` // Payees distintos asignados
+	Items        []*MigrationItem `json:"items"`
+	Truncated    bool             `json:"truncated"` // Items superó MaxMigrationItems
+}
+
+// CurrencyResolver obtiene la moneda base del usuario
+type CurrencyResolver interface {
+	BaseCurrency(ctx context.Context, userID uint) (string, error)
+}
+
+// AddItem agrega un elemento al detalle respetando MaxMigrationItems
+func (r *MigrationReport) AddItem(item *MigrationItem) {
+	if len(r.Items) >= MaxMigrationItems {
+		r.Truncated = true
+		return
+	}
+	r.Items = append(r.Items, item)
+}
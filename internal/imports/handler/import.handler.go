@@ -0,0 +1,634 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"finanzas-api/internal/imports/domain"
+	"finanzas-api/internal/imports/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+type ImportHandler struct {
+	importUseCase domain.ImportUseCase
+	importers     map[string]domain.Importer
+}
+
+// NewImportHandler crea una nueva instancia del handler de importaciones.
+// importers son los formatos de migración desde otras aplicaciones.
+func NewImportHandler(importUseCase domain.ImportUseCase, importers []domain.Importer) *ImportHandler {
+	byFormat := make(map[string]domain.Importer, len(importers))
+	for _, importer := range importers {
+		byFormat[importer.Format()] = importer
+	}
+	return &ImportHandler{
+		importUseCase: importUseCase,
+		importers:     byFormat,
+	}
+}
+
+// ProfileRequest representa la estructura de la petición para crear o
+// reemplazar un perfil de importación
+type ProfileRequest struct {
+	Name              string `json:"name" binding:"required"`
+	Delimiter         string `json:"delimiter"` // "\t" para tabulador
+	HasHeader         bool   `json:"has_header"`
+	SkipRows          int    `json:"skip_rows" binding:"omitempty,min=0"`
+	Encoding          string `json:"encoding" binding:"omitempty,oneof=utf-8 latin-1"`
+	DateFormat        string `json:"date_format" binding:"required"`
+	DecimalSeparator  string `json:"decimal_separator"`
+	DateColumn        string `json:"date_column" binding:"required"`
+	DescriptionColumn string `json:"description_column"`
+	AmountColumn      string `json:"amount_column"`
+	DebitColumn       string `json:"debit_column"`
+	CreditColumn      string `json:"credit_column"`
+}
+
+// ProfileResponse representa la respuesta de un perfil de importación
+type ProfileResponse struct {
+	ID                uint   `json:"id"`
+	Name              string `json:"name"`
+	Delimiter         string `json:"delimiter"`
+	HasHeader         bool   `json:"has_header"`
+	SkipRows          int    `json:"skip_rows"`
+	Encoding          string `json:"encoding"`
+	DateFormat        string `json:"date_format"`
+	DecimalSeparator  string `json:"decimal_separator"`
+	DateColumn        string `json:"date_column"`
+	DescriptionColumn string `json:"description_column"`
+	AmountColumn      string `json:"amount_column"`
+	DebitColumn       string `json:"debit_column"`
+	CreditColumn      string `json:"credit_column"`
+	CreatedAt         string `json:"created_at"`
+	UpdatedAt         string `json:"updated_at"`
+}
+
+// BatchResponse representa la respuesta de un lote de importación
+type BatchResponse struct {
+	ID                   uint          `json:"id"`
+	AccountID            uint          `json:"account_id"`
+	ProfileID            *uint         `json:"profile_id"`
+	Format               string        `json:"format"`
+	FileName             string        `json:"file_name"`
+	Status               string        `json:"status"`
+	TotalRows            int           `json:"total_rows"`
+	ValidRows            int           `json:"valid_rows"`
+	ErrorRows            int           `json:"error_rows"`
+	FlaggedRows          int           `json:"flagged_rows"`
+	CommittedAt          *string       `json:"committed_at"`
+	StatementBalance     *int64        `json:"statement_balance,omitempty"`
+	StatementBalanceDate *string       `json:"statement_balance_date,omitempty"`
+	BalanceDifference    *int64        `json:"balance_difference,omitempty"`
+	CreatedAt            string        `json:"created_at"`
+	Rows                 []RowResponse `json:"rows,omitempty"`
+}
+
+// RowResponse representa una fila interpretada del archivo
+type RowResponse struct {
+	LineNumber        int     `json:"line_number"`
+	Date              *string `json:"date"`
+	Amount            int64   `json:"amount"`
+	Direction         string  `json:"direction"`
+	Description       string  `json:"description"`
+	ExternalID        string  `json:"external_id,omitempty"`
+	ValueDate         *string `json:"value_date,omitempty"`
+	Counterparty      string  `json:"counterparty,omitempty"`
+	Raw               string  `json:"raw"`
+	Error             string  `json:"error,omitempty"`
+	TransactionID     *uint   `json:"transaction_id"`
+	DuplicateReviewID *uint   `json:"duplicate_review_id,omitempty"`
+}
+
+// CreateProfile maneja la creación de perfiles de importación
+func (h *ImportHandler) CreateProfile(c *gin.Context) {
+	var req ProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	profile := h.toProfile(&req)
+	profile.UserID = c.GetUint("userID")
+
+	if err := h.importUseCase.CreateProfile(profile); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Import profile created successfully",
+		"profile": h.toProfileResponse(profile),
+	})
+}
+
+// GetProfile obtiene un perfil de importación del usuario autenticado
+func (h *ImportHandler) GetProfile(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid import profile ID",
+		})
+		return
+	}
+
+	profile, err := h.importUseCase.GetProfile(c.GetUint("userID"), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Import profile not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"profile": h.toProfileResponse(profile),
+	})
+}
+
+// UpdateProfile reemplaza la configuración de un perfil de importación
+func (h *ImportHandler) UpdateProfile(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid import profile ID",
+		})
+		return
+	}
+
+	var req ProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.GetUint("userID")
+
+	// Obtener perfil existente
+	existing, err := h.importUseCase.GetProfile(userID, uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Import profile not found",
+		})
+		return
+	}
+
+	profile := h.toProfile(&req)
+	profile.ID = existing.ID
+	profile.CreatedAt = existing.CreatedAt
+
+	if err := h.importUseCase.UpdateProfile(userID, profile); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Import profile updated successfully",
+		"profile": h.toProfileResponse(profile),
+	})
+}
+
+// DeleteProfile elimina un perfil de importación. Los lotes ya importados
+// con él se conservan.
+func (h *ImportHandler) DeleteProfile(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid import profile ID",
+		})
+		return
+	}
+
+	if err := h.importUseCase.DeleteProfile(c.GetUint("userID"), uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Import profile not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Import profile deleted successfully",
+	})
+}
+
+// ListProfiles lista los perfiles de importación del usuario autenticado
+func (h *ImportHandler) ListProfiles(c *gin.Context) {
+	profiles, err := h.importUseCase.ListProfiles(c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	responses := make([]ProfileResponse, len(profiles))
+	for i, profile := range profiles {
+		responses[i] = h.toProfileResponse(profile)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"profiles": responses,
+	})
+}
+
+// PreviewCSV recibe un extracto CSV (multipart: file, account_id,
+// profile_id) y retorna la vista previa de sus filas sin crear transacciones
+func (h *ImportHandler) PreviewCSV(c *gin.Context) {
+	accountID, err := strconv.ParseUint(c.PostForm("account_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid account_id",
+		})
+		return
+	}
+
+	profileID, err := strconv.ParseUint(c.PostForm("profile_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid profile_id",
+		})
+		return
+	}
+
+	fileName, data, ok := h.readUpload(c)
+	if !ok {
+		return
+	}
+
+	batch, err := h.importUseCase.PreviewCSV(c.GetUint("userID"), uint(accountID), uint(profileID), fileName, data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Import preview created successfully",
+		"batch":   h.toBatchResponse(batch, true),
+	})
+}
+
+// PreviewOFX recibe un extracto OFX o QFX (multipart: file, account_id) y
+// retorna la vista previa de sus transacciones sin crearlas
+func (h *ImportHandler) PreviewOFX(c *gin.Context) {
+	accountID, err := strconv.ParseUint(c.PostForm("account_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid account_id",
+		})
+		return
+	}
+
+	fileName, data, ok := h.readUpload(c)
+	if !ok {
+		return
+	}
+
+	batch, err := h.importUseCase.PreviewOFX(c.GetUint("userID"), uint(accountID), fileName, data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Import preview created successfully",
+		"batch":   h.toBatchResponse(batch, true),
+	})
+}
+
+// PreviewStatement recibe un extracto bancario camt.053 o MT940 (multipart:
+// file, account_id, format opcional) y retorna la vista previa de sus
+// movimientos. Un extracto que no cuadra se rechaza.
+func (h *ImportHandler) PreviewStatement(c *gin.Context) {
+	accountID, err := strconv.ParseUint(c.PostForm("account_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid account_id",
+		})
+		return
+	}
+
+	fileName, data, ok := h.readUpload(c)
+	if !ok {
+		return
+	}
+
+	batch, err := h.importUseCase.PreviewStatement(c.GetUint("userID"), uint(accountID), c.PostForm("format"), fileName, data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Import preview created successfully",
+		"batch":   h.toBatchResponse(batch, true),
+	})
+}
+
+// Migrate importa el historial exportado por otra aplicación (multipart:
+// file, currency, date_format, decimal_separator, account_name, dry_run).
+// Con dry_run=true solo reporta lo que se crearía, combinaría u omitiría.
+func (h *ImportHandler) Migrate(c *gin.Context) {
+	importer, exists := h.importers[c.Param("format")]
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Unsupported import format",
+		})
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(c.DefaultPostForm("dry_run", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid dry_run",
+		})
+		return
+	}
+
+	_, data, ok := h.readUpload(c)
+	if !ok {
+		return
+	}
+
+	report, err := importer.Import(c.GetUint("userID"), data, domain.MigrationOptions{
+		DryRun:           dryRun,
+		Currency:         c.PostForm("currency"),
+		DateFormat:       c.PostForm("date_format"),
+		DecimalSeparator: c.PostForm("decimal_separator"),
+		AccountName:      c.PostForm("account_name"),
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	message := "Import completed successfully"
+	if dryRun {
+		message = "Dry run completed, no changes were made"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"report":  report,
+	})
+}
+
+// GetBatch obtiene un lote de importación con sus filas
+func (h *ImportHandler) GetBatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid import batch ID",
+		})
+		return
+	}
+
+	batch, err := h.importUseCase.GetBatch(c.GetUint("userID"), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Import batch not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"batch": h.toBatchResponse(batch, true),
+	})
+}
+
+// ListBatches lista los lotes de importación del usuario (?limit=&offset=)
+func (h *ImportHandler) ListBatches(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid limit",
+		})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid offset",
+		})
+		return
+	}
+
+	batches, err := h.importUseCase.ListBatches(c.GetUint("userID"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	responses := make([]BatchResponse, len(batches))
+	for i, batch := range batches {
+		responses[i] = h.toBatchResponse(batch, false)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"batches": responses,
+	})
+}
+
+// CommitBatch crea las transacciones de las filas válidas del lote
+func (h *ImportHandler) CommitBatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid import batch ID",
+		})
+		return
+	}
+
+	batch, err := h.importUseCase.CommitBatch(c.GetUint("userID"), uint(id))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrBatchNotPending) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Import batch committed successfully",
+		"batch":   h.toBatchResponse(batch, true),
+	})
+}
+
+// DiscardBatch descarta un lote en vista previa
+func (h *ImportHandler) DiscardBatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid import batch ID",
+		})
+		return
+	}
+
+	if err := h.importUseCase.DiscardBatch(c.GetUint("userID"), uint(id)); err != nil {
+		if errors.Is(err, domain.ErrBatchNotPending) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Import batch not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Import batch discarded successfully",
+	})
+}
+
+// readUpload lee el archivo del campo "file". Si falla, ya respondió la
+// petición y retorna ok en false.
+func (h *ImportHandler) readUpload(c *gin.Context) (fileName string, data []byte, ok bool) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return "", nil, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Could not read uploaded file",
+		})
+		return "", nil, false
+	}
+	defer file.Close()
+
+	// Se lee un byte más del límite para detectar archivos demasiado grandes
+	data, err = io.ReadAll(io.LimitReader(file, usecase.MaxFileSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Could not read uploaded file",
+		})
+		return "", nil, false
+	}
+
+	return fileHeader.Filename, data, true
+}
+
+// toProfile convierte la petición en un perfil de importación
+func (h *ImportHandler) toProfile(req *ProfileRequest) *domain.ImportProfile {
+	return &domain.ImportProfile{
+		Name:              req.Name,
+		Delimiter:         req.Delimiter,
+		HasHeader:         req.HasHeader,
+		SkipRows:          req.SkipRows,
+		Encoding:          domain.Encoding(req.Encoding),
+		DateFormat:        req.DateFormat,
+		DecimalSeparator:  req.DecimalSeparator,
+		DateColumn:        req.DateColumn,
+		DescriptionColumn: req.DescriptionColumn,
+		AmountColumn:      req.AmountColumn,
+		DebitColumn:       req.DebitColumn,
+		CreditColumn:      req.CreditColumn,
+	}
+}
+
+// toProfileResponse convierte un perfil en su representación de respuesta
+func (h *ImportHandler) toProfileResponse(profile *domain.ImportProfile) ProfileResponse {
+	return ProfileResponse{
+		ID:                profile.ID,
+		Name:              profile.Name,
+		Delimiter:         profile.Delimiter,
+		HasHeader:         profile.HasHeader,
+		SkipRows:          profile.SkipRows,
+		Encoding:          string(profile.Encoding),
+		DateFormat:        profile.DateFormat,
+		DecimalSeparator:  profile.DecimalSeparator,
+		DateColumn:        profile.DateColumn,
+		DescriptionColumn: profile.DescriptionColumn,
+		AmountColumn:      profile.AmountColumn,
+		DebitColumn:       profile.DebitColumn,
+		CreditColumn:      profile.CreditColumn,
+		CreatedAt:         profile.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:         profile.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// toBatchResponse convierte un lote en su representación de respuesta;
+// withRows incluye las filas interpretadas
+func (h *ImportHandler) toBatchResponse(batch *domain.ImportBatch, withRows bool) BatchResponse {
+	response := BatchResponse{
+		ID:                batch.ID,
+		AccountID:         batch.AccountID,
+		ProfileID:         batch.ProfileID,
+		Format:            batch.Format,
+		FileName:          batch.FileName,
+		Status:            string(batch.Status),
+		TotalRows:         batch.TotalRows,
+		ValidRows:         batch.ValidRows,
+		ErrorRows:         batch.ErrorRows,
+		FlaggedRows:       batch.FlaggedRows,
+		StatementBalance:  batch.StatementBalance,
+		BalanceDifference: batch.BalanceDifference,
+		CreatedAt:         batch.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if batch.StatementBalanceDate != nil {
+		balanceDate := batch.StatementBalanceDate.Format(dateLayout)
+		response.StatementBalanceDate = &balanceDate
+	}
+	if batch.CommittedAt != nil {
+		committedAt := batch.CommittedAt.Format("2006-01-02T15:04:05Z")
+		response.CommittedAt = &committedAt
+	}
+	if withRows {
+		response.Rows = make([]RowResponse, len(batch.Rows))
+		for i, row := range batch.Rows {
+			response.Rows[i] = RowResponse{
+				LineNumber:        row.LineNumber,
+				Amount:            row.Amount,
+				Direction:         string(row.Direction),
+				Description:       row.Description,
+				ExternalID:        row.ExternalID,
+				Counterparty:      row.Counterparty,
+				Raw:               row.Raw,
+				Error:             row.Error,
+				TransactionID:     row.TransactionID,
+				DuplicateReviewID: row.DuplicateReviewID,
+			}
+			if row.Date != nil {
+				date := row.Date.Format(dateLayout)
+				response.Rows[i].Date = &date
+			}
+			if row.ValueDate != nil {
+				valueDate := row.ValueDate.Format(dateLayout)
+				response.Rows[i].ValueDate = &valueDate
+			}
+		}
+	}
+	return response
+}
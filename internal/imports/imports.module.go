@@ -0,0 +1,55 @@
+package imports
+
+import (
+	"fmt"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/imports/domain"
+	"finanzas-api/internal/imports/handler"
+	"finanzas-api/internal/imports/repository"
+	"finanzas-api/internal/imports/usecase"
+	ledgerDomain "finanzas-api/internal/ledger/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+
+	"gorm.io/gorm"
+)
+
+type ImportsModule struct {
+	Handler    *handler.ImportHandler
+	UseCase    domain.ImportUseCase
+	Repository domain.ImportRepository
+}
+
+// NewImportsModule recibe los casos de uso de los módulos donde las
+// migraciones desde otras aplicaciones crean cuentas, categorías,
+// transacciones y transferencias.
+func NewImportsModule(
+	db *gorm.DB,
+	accountRepo accountDomain.AccountRepository,
+	accountUseCase accountDomain.AccountUseCase,
+	categoryUseCase categoryDomain.CategoryUseCase,
+	transactionUseCase transactionDomain.TransactionUseCase,
+	duplicateUseCase transactionDomain.DuplicateUseCase,
+	ledgerUseCase ledgerDomain.LedgerUseCase,
+	currencies domain.CurrencyResolver,
+) *ImportsModule {
+	var importRepo domain.ImportRepository
+	var importUseCase domain.ImportUseCase
+	var importHandler *handler.ImportHandler
+
+	if err := db.AutoMigrate(&domain.ImportProfile{}, &domain.ImportBatch{}, &domain.ImportRow{}); err != nil {
+		panic(fmt.Sprintf("Error migrating imports: %v", err))
+	}
+
+	importRepo = repository.NewImportPostgresRepository(db)
+	importUseCase = usecase.NewImportUseCase(importRepo, accountRepo, transactionUseCase, duplicateUseCase)
+	importers := usecase.NewMigrationImporters(importRepo, accountRepo, accountUseCase, categoryUseCase, transactionUseCase, ledgerUseCase, currencies)
+	importHandler = handler.NewImportHandler(importUseCase, importers)
+
+	return &ImportsModule{
+		Handler:    importHandler,
+		UseCase:    importUseCase,
+		Repository: importRepo,
+	}
+}
@@ -0,0 +1,117 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+)
+
+// Tipos de transacción de Firefly III
+const (
+	fireflyWithdrawal     = "withdrawal"
+	fireflyDeposit        = "deposit"
+	fireflyTransfer       = "transfer"
+	fireflyOpeningBalance = "opening balance"
+)
+
+// ParseFirefly interpreta la exportación CSV de transacciones de Firefly III.
+// Las cuentas de activo y pasivo se vuelven cuentas; las de gastos e
+// ingresos, que en Firefly representan comercios, se vuelven el payee.
+// Firefly exporta fechas ISO y montos con punto decimal, así que las
+// opciones no aplican.
+func ParseFirefly(data []byte, options Options) (*Data, error) {
+	t, err := readTable(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.require("type", "amount", "date", "source_name", "destination_name"); err != nil {
+		return nil, err
+	}
+
+	result := &Data{DecimalSeparator: "."}
+	for i, record := range t.records {
+		kind := strings.ToLower(t.field(record, "type"))
+		source, destination := clean(t.field(record, "source_name")), clean(t.field(record, "destination_name"))
+		transaction := &Transaction{
+			Line:     t.lines[i],
+			Currency: strings.ToUpper(t.field(record, "currency_code")),
+			Memo:     clean(t.field(record, "description")),
+		}
+		if id := t.field(record, "journal_id"); id != "" {
+			transaction.ExternalID = "firefly:" + id
+		}
+		if category := clean(t.field(record, "category")); category != "" {
+			transaction.Category = []string{category}
+		}
+
+		// Firefly exporta los retiros con signo negativo; el signo lo define el tipo
+		amount := strings.TrimPrefix(t.field(record, "amount"), "-")
+		switch kind {
+		case fireflyWithdrawal:
+			transaction.Account = source
+			transaction.Payee = destination
+			transaction.Amount = negate(amount)
+			result.addAccount(source, fireflyAccountType(t.field(record, "source_type")), transaction.Currency)
+		case fireflyDeposit:
+			transaction.Account = destination
+			transaction.Payee = source
+			transaction.Amount = amount
+			result.addAccount(destination, fireflyAccountType(t.field(record, "destination_type")), transaction.Currency)
+		case fireflyTransfer:
+			transaction.Account = source
+			transaction.TransferAccount = destination
+			transaction.Amount = negate(amount)
+			result.addAccount(source, fireflyAccountType(t.field(record, "source_type")), transaction.Currency)
+			result.addAccount(destination, fireflyAccountType(t.field(record, "destination_type")), transaction.Currency)
+		case fireflyOpeningBalance:
+			// El saldo inicial sale de una cuenta "initial balance" hacia la
+			// cuenta real; si es negativo, el sentido se invierte
+			transaction.OpeningBalance = true
+			if strings.Contains(strings.ToLower(t.field(record, "source_type")), "initial balance") {
+				transaction.Account = destination
+				transaction.Amount = amount
+			} else {
+				transaction.Account = source
+				transaction.Amount = negate(amount)
+			}
+		default:
+			transaction.Account = source
+			transaction.Error = fmt.Sprintf("transaction type %q is not supported", t.field(record, "type"))
+		}
+
+		if transaction.Error == "" {
+			date, err := time.Parse("2006-01-02", firstN(t.field(record, "date"), 10))
+			if err != nil {
+				transaction.Error = fmt.Sprintf("invalid date %q", t.field(record, "date"))
+			}
+			transaction.Date = date
+		}
+		result.Transactions = append(result.Transactions, transaction)
+	}
+
+	result.addReferencedAccounts()
+	return result, nil
+}
+
+// fireflyAccountType traduce el tipo de cuenta de Firefly III
+func fireflyAccountType(value string) accountDomain.AccountType {
+	value = strings.ToLower(value)
+	switch {
+	case strings.Contains(value, "cash"):
+		return accountDomain.AccountTypeCash
+	case strings.Contains(value, "credit"), strings.Contains(value, "loan"),
+		strings.Contains(value, "debt"), strings.Contains(value, "mortgage"):
+		return accountDomain.AccountTypeCreditCard
+	}
+	return accountDomain.AccountTypeBank
+}
+
+// firstN retorna los primeros n bytes de s
+func firstN(s string, n int) string {
+	if len(s) < n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,269 @@
+// Package migration lee las exportaciones de otras aplicaciones de finanzas
+// (QIF, Firefly III y YNAB) y las lleva a un modelo común de cuentas y
+// movimientos. No accede a la base de datos ni a la red: aplicar los datos
+// al usuario es responsabilidad del caso de uso de importaciones.
+package migration
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+)
+
+// Options configura la lectura de formatos cuyo contenido depende de la
+// configuración regional de quien exporta (QIF y YNAB).
+type Options struct {
+	DateFormat       string // Marcadores YYYY, MM y DD, p. ej. "DD/MM/YYYY"
+	DecimalSeparator string // "." o ","
+	// AccountName nombra la cuenta de un QIF que no declara ninguna
+	AccountName string
+}
+
+// Data es el contenido de una exportación en el modelo común
+type Data struct {
+	Accounts     []*Account
+	Transactions []*Transaction
+	// DecimalSeparator es el separador con que vienen escritos los montos
+	DecimalSeparator string
+}
+
+// Account es una cuenta de la aplicación de origen
+type Account struct {
+	Name     string
+	Type     accountDomain.AccountType
+	Currency string // Vacío si la fuente no lo indica
+}
+
+// Transaction es un movimiento de la aplicación de origen
+type Transaction struct {
+	Line     int    // Línea o registro del archivo, para reportar errores
+	Account  string // Nombre de la cuenta afectada
+	Date     time.Time
+	Amount   string // Monto con signo tal como viene; negativo sale de la cuenta
+	Currency string // Vacío si la fuente no lo indica
+	Payee    string
+	Memo     string
+	// Category es la ruta desde la categoría raíz, p. ej. ["Hogar", "Luz"]
+	Category []string
+	// TransferAccount es la otra cuenta si el movimiento es una transferencia
+	TransferAccount string
+	// OpeningBalance indica que el movimiento es el saldo inicial de la cuenta
+	OpeningBalance bool
+	ExternalID     string // Identificador estable en la fuente, si existe
+	// Error describe por qué el movimiento no se puede importar
+	Error string
+}
+
+// IsTransfer indica si el movimiento es una transferencia entre cuentas
+func (t *Transaction) IsTransfer() bool {
+	return t.TransferAccount != ""
+}
+
+// addAccount registra la cuenta si no existe otra con el mismo nombre y la retorna
+func (d *Data) addAccount(name string, accountType accountDomain.AccountType, currency string) *Account {
+	for _, account := range d.Accounts {
+		if strings.EqualFold(account.Name, name) {
+			if account.Currency == "" {
+				account.Currency = currency
+			}
+			return account
+		}
+	}
+	account := &Account{Name: name, Type: accountType, Currency: currency}
+	d.Accounts = append(d.Accounts, account)
+	return account
+}
+
+// addReferencedAccounts registra las cuentas que solo aparecen en los
+// movimientos, como el destino de una transferencia
+func (d *Data) addReferencedAccounts() {
+	for _, transaction := range d.Transactions {
+		if transaction.Error != "" {
+			continue
+		}
+		d.addAccount(transaction.Account, accountDomain.AccountTypeBank, transaction.Currency)
+		if transaction.IsTransfer() {
+			d.addAccount(transaction.TransferAccount, accountDomain.AccountTypeBank, transaction.Currency)
+		}
+	}
+}
+
+// table es un archivo CSV con encabezado
+type table struct {
+	columns map[string]int
+	records [][]string
+	lines   []int
+}
+
+// readTable lee un CSV con encabezado. El delimitador se deduce de la
+// primera línea entre coma, punto y coma y tabulador.
+func readTable(data []byte) (*table, error) {
+	text := decode(data)
+	firstLine, _, _ := strings.Cut(text, "\n")
+	comma := ','
+	for _, candidate := range []rune{';', '\t'} {
+		if strings.Count(firstLine, string(candidate)) > strings.Count(firstLine, string(comma)) {
+			comma = candidate
+		}
+	}
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("file has no header row")
+	}
+	result := &table{columns: make(map[string]int, len(header))}
+	for i, name := range header {
+		result.columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		result.records = append(result.records, record)
+		result.lines = append(result.lines, line)
+	}
+	return result, nil
+}
+
+// require verifica que el encabezado tenga las columnas indicadas
+func (t *table) require(names ...string) error {
+	for _, name := range names {
+		if _, exists := t.columns[name]; !exists {
+			return fmt.Errorf("missing column %q", name)
+		}
+	}
+	return nil
+}
+
+// field retorna el valor de la columna en el registro, o "" si no existe
+func (t *table) field(record []string, name string) string {
+	index, exists := t.columns[name]
+	if !exists || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+// normalizeOptions completa las opciones con los valores por defecto del formato
+func normalizeOptions(options Options, dateFormat string) (Options, error) {
+	if options.DateFormat == "" {
+		options.DateFormat = dateFormat
+	}
+	if options.DecimalSeparator == "" {
+		options.DecimalSeparator = "."
+	}
+	if options.DecimalSeparator != "." && options.DecimalSeparator != "," {
+		return options, fmt.Errorf("decimal separator must be '.' or ','")
+	}
+	if _, err := dateOrder(options.DateFormat); err != nil {
+		return options, err
+	}
+	return options, nil
+}
+
+// dateOrder retorna el orden de año (y), mes (m) y día (d) en el formato
+func dateOrder(format string) (string, error) {
+	format = strings.ToUpper(format)
+	positions := map[byte]int{
+		'y': strings.Index(format, "YY"),
+		'm': strings.Index(format, "MM"),
+		'd': strings.Index(format, "DD"),
+	}
+	order := []byte{'y', 'm', 'd'}
+	for _, position := range positions {
+		if position < 0 {
+			return "", fmt.Errorf("date format must contain YYYY, MM and DD")
+		}
+	}
+	sort.Slice(order, func(i, j int) bool { return positions[order[i]] < positions[order[j]] })
+	return string(order), nil
+}
+
+// parseDate interpreta una fecha según el orden del formato. Acepta
+// cualquier separador y años de dos dígitos, como los de QIF ("1/15'24").
+func parseDate(value, format string) (time.Time, error) {
+	order, err := dateOrder(format)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	fields := strings.FieldsFunc(value, func(r rune) bool { return !unicode.IsDigit(r) })
+	if len(fields) != 3 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+
+	parts := make(map[byte]int, 3)
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q", value)
+		}
+		parts[order[i]] = n
+	}
+
+	year := parts['y']
+	if year < 100 {
+		// Años de dos dígitos: 70-99 son del siglo XX
+		if year < 70 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	}
+	date := time.Date(year, time.Month(parts['m']), parts['d'], 0, 0, 0, 0, time.UTC)
+	if date.Day() != parts['d'] || int(date.Month()) != parts['m'] {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
+
+// isZero indica si el monto escrito no tiene dígitos distintos de cero
+func isZero(amount string) bool {
+	return !strings.ContainsAny(amount, "123456789")
+}
+
+// negate invierte el signo de un monto escrito
+func negate(amount string) string {
+	amount = strings.TrimSpace(amount)
+	if strings.HasPrefix(amount, "-") {
+		return strings.TrimPrefix(amount, "-")
+	}
+	return "-" + amount
+}
+
+// decode convierte el archivo a UTF-8; si no lo es, se asume Latin-1
+func decode(data []byte) string {
+	if utf8.Valid(data) {
+		return strings.TrimPrefix(string(data), "\ufeff")
+	}
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+// clean quita espacios repetidos y en los extremos
+func clean(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,272 @@
+package migration
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// assertData compara cuentas y movimientos uno a uno para que el error
+// indique cuál difiere
+func assertData(t *testing.T, got *Data, want *Data) {
+	t.Helper()
+	if got.DecimalSeparator != want.DecimalSeparator {
+		t.Errorf("DecimalSeparator = %q, want %q", got.DecimalSeparator, want.DecimalSeparator)
+	}
+	if !reflect.DeepEqual(got.Accounts, want.Accounts) {
+		t.Errorf("Accounts:")
+		for _, account := range got.Accounts {
+			t.Errorf("  got  %+v", account)
+		}
+		for _, account := range want.Accounts {
+			t.Errorf("  want %+v", account)
+		}
+	}
+	if len(got.Transactions) != len(want.Transactions) {
+		t.Fatalf("got %d transactions, want %d", len(got.Transactions), len(want.Transactions))
+	}
+	for i, transaction := range got.Transactions {
+		if !reflect.DeepEqual(transaction, want.Transactions[i]) {
+			t.Errorf("transaction %d = %+v, want %+v", i, transaction, want.Transactions[i])
+		}
+	}
+}
+
+func TestParseQIF(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		options Options
+		want    *Data
+	}{
+		{
+			name: "accounts, splits, transfers and opening balance",
+			data: "!Account\nNChecking\nTBank\n^\n" +
+				"!Type:Bank\n" +
+				"D01/15'24\nT-1,234.56\nPSupermarket\nMWeekly  groceries\nLFood:Groceries/Personal\n^\n" +
+				"D1/31/2024\nT2500.00\nPEmployer\nLSalary\n^\n" +
+				"D02/01/2024\nT-300.00\nL[Savings]\n^\n" +
+				"D01/01/2024\nT100.00\nPOpening Balance\nL[Checking]\n^\n" +
+				"D02/05/2024\nT-90.00\nPStore\nMShopping\nSFood\nEBread\n$-40.00\nSHome:Cleaning\n$-50.00\n^\n" +
+				"D13/45/2024\nT-1.00\n^\n" +
+				"!Type:Invst\nD01/01/2024\n^\n" +
+				"!Type:Cat\nNFood\n^\n",
+			want: &Data{
+				DecimalSeparator: ".",
+				Accounts: []*Account{
+					{Name: "Checking", Type: accountDomain.AccountTypeBank},
+					{Name: "Savings", Type: accountDomain.AccountTypeBank},
+				},
+				Transactions: []*Transaction{
+					{Line: 6, Account: "Checking", Date: date(2024, 1, 15), Amount: "-1,234.56", Payee: "Supermarket", Memo: "Weekly groceries", Category: []string{"Food", "Groceries"}},
+					{Line: 12, Account: "Checking", Date: date(2024, 1, 31), Amount: "2500.00", Payee: "Employer", Category: []string{"Salary"}},
+					{Line: 17, Account: "Checking", Date: date(2024, 2, 1), Amount: "-300.00", TransferAccount: "Savings"},
+					{Line: 21, Account: "Checking", Date: date(2024, 1, 1), Amount: "100.00", Payee: "Opening Balance", OpeningBalance: true},
+					{Line: 26, Account: "Checking", Date: date(2024, 2, 5), Amount: "-40.00", Payee: "Store", Memo: "Bread", Category: []string{"Food"}},
+					{Line: 26, Account: "Checking", Date: date(2024, 2, 5), Amount: "-50.00", Payee: "Store", Memo: "Shopping", Category: []string{"Home", "Cleaning"}},
+					{Line: 36, Account: "Checking", Error: `invalid date "13/45/2024"`},
+					{Line: 40, Account: "QIF", Error: "investment transactions are not supported"},
+				},
+			},
+		},
+		{
+			name:    "regional options, Latin-1 and no account block",
+			data:    "!Type:CCard\r\nD15/03/2024\r\nT-45,50\r\nPCaf\xe9\r\n^\r\n",
+			options: Options{DateFormat: "DD/MM/YYYY", DecimalSeparator: ",", AccountName: "Visa"},
+			want: &Data{
+				DecimalSeparator: ",",
+				Accounts:         []*Account{{Name: "Visa", Type: accountDomain.AccountTypeCreditCard}},
+				Transactions: []*Transaction{
+					{Line: 2, Account: "Visa", Date: date(2024, 3, 15), Amount: "-45,50", Payee: "Café"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQIF([]byte(tt.data), tt.options)
+			if err != nil {
+				t.Fatalf("ParseQIF() error = %v", err)
+			}
+			assertData(t, got, tt.want)
+		})
+	}
+}
+
+func TestParseQIFErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		options Options
+		wantErr string
+	}{
+		{name: "empty file", data: "\n\n", wantErr: "file has no QIF records"},
+		{name: "only lists", data: "!Type:Cat\nNFood\n^\n", wantErr: "file has no QIF records"},
+		{name: "invalid separator", data: "!Type:Bank\n^\n", options: Options{DecimalSeparator: ";"}, wantErr: "decimal separator must be '.' or ','"},
+		{name: "invalid date format", data: "!Type:Bank\n^\n", options: Options{DateFormat: "YYYY"}, wantErr: "date format must contain YYYY, MM and DD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseQIF([]byte(tt.data), tt.options)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("ParseQIF() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseFirefly(t *testing.T) {
+	data := "journal_id,type,amount,description,date,source_name,source_type,destination_name,destination_type,currency_code,category\n" +
+		"1,Withdrawal,-12.50,Coffee,2024-03-01T10:00:00+01:00,Checking,Asset account,Cafe,Expense account,eur,Food\n" +
+		"2,Deposit,2000.00,Salary,2024-03-02T00:00:00+01:00,Employer,Revenue account,Checking,Asset account,EUR,\n" +
+		"3,Transfer,-100.00,To savings,2024-03-03,Checking,Asset account,Savings,Asset account,EUR,\n" +
+		"4,Opening balance,500.00,Initial,2024-01-01,Checking initial balance account,Initial balance account,Checking,Asset account,EUR,\n" +
+		"5,Opening balance,-300.00,Initial,2024-01-01,Savings,Asset account,Savings initial balance account,Initial balance account,EUR,\n" +
+		"6,Reconciliation,1.00,,2024-03-04,Checking,Asset account,Checking reconciliation,Reconciliation account,EUR,\n" +
+		"7,Withdrawal,5.00,Bad date,03/05/2024,Checking,Asset account,Shop,Expense account,EUR,\n" +
+		"8,Withdrawal,20.00,Books,2024-03-05,Visa,Credit card,Bookstore,Expense account,EUR,Education\n"
+
+	got, err := ParseFirefly([]byte(data), Options{})
+	if err != nil {
+		t.Fatalf("ParseFirefly() error = %v", err)
+	}
+	assertData(t, got, &Data{
+		DecimalSeparator: ".",
+		Accounts: []*Account{
+			{Name: "Checking", Type: accountDomain.AccountTypeBank, Currency: "EUR"},
+			{Name: "Savings", Type: accountDomain.AccountTypeBank, Currency: "EUR"},
+			{Name: "Visa", Type: accountDomain.AccountTypeCreditCard, Currency: "EUR"},
+		},
+		Transactions: []*Transaction{
+			{Line: 2, Account: "Checking", Date: date(2024, 3, 1), Amount: "-12.50", Currency: "EUR", Payee: "Cafe", Memo: "Coffee", Category: []string{"Food"}, ExternalID: "firefly:1"},
+			{Line: 3, Account: "Checking", Date: date(2024, 3, 2), Amount: "2000.00", Currency: "EUR", Payee: "Employer", Memo: "Salary", ExternalID: "firefly:2"},
+			{Line: 4, Account: "Checking", Date: date(2024, 3, 3), Amount: "-100.00", Currency: "EUR", Memo: "To savings", TransferAccount: "Savings", ExternalID: "firefly:3"},
+			{Line: 5, Account: "Checking", Date: date(2024, 1, 1), Amount: "500.00", Currency: "EUR", Memo: "Initial", OpeningBalance: true, ExternalID: "firefly:4"},
+			{Line: 6, Account: "Savings", Date: date(2024, 1, 1), Amount: "-300.00", Currency: "EUR", Memo: "Initial", OpeningBalance: true, ExternalID: "firefly:5"},
+			{Line: 7, Account: "Checking", Currency: "EUR", ExternalID: "firefly:6", Error: `transaction type "Reconciliation" is not supported`},
+			{Line: 8, Account: "Checking", Amount: "-5.00", Currency: "EUR", Payee: "Shop", Memo: "Bad date", ExternalID: "firefly:7", Error: `invalid date "03/05/2024"`},
+			{Line: 9, Account: "Visa", Date: date(2024, 3, 5), Amount: "-20.00", Currency: "EUR", Payee: "Bookstore", Memo: "Books", Category: []string{"Education"}, ExternalID: "firefly:8"},
+		},
+	})
+}
+
+func TestParseFireflyErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "empty file", data: "", wantErr: "file has no header row"},
+		{name: "missing column", data: "type,amount,date,source_name\nWithdrawal,1.00,2024-01-01,Checking\n", wantErr: `missing column "destination_name"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFirefly([]byte(tt.data), Options{})
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("ParseFirefly() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseYNAB(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		options Options
+		want    *Data
+	}{
+		{
+			name: "current export with regional format",
+			data: `"Account";"Flag";"Date";"Payee";"Category Group/Category";"Category Group";"Category";"Memo";"Outflow";"Inflow";"Cleared"` + "\n" +
+				`"Girokonto";"";"15.03.2024";"Supermarkt";"Alltag: Lebensmittel";"Alltag";"Lebensmittel";"Wocheneinkauf";"45,90";"0,00";"Cleared"` + "\n" +
+				`"Girokonto";"";"31.03.2024";"Arbeitgeber";"Inflow: Ready to Assign";"Inflow";"Ready to Assign";"";"0,00";"2.500,00";"Cleared"` + "\n" +
+				`"Girokonto";"";"01.04.2024";"Transfer : Tagesgeld";"";"";"";"";"500,00";"0,00";"Cleared"` + "\n" +
+				`"Tagesgeld";"";"01.01.2024";"Starting Balance";"";"";"";"";"0,00";"1.000,00";"Cleared"` + "\n" +
+				`"Girokonto";"";"32.01.2024";"Laden";"";"";"";"";"1,00";"0,00";"Cleared"` + "\n",
+			options: Options{DateFormat: "DD.MM.YYYY", DecimalSeparator: ","},
+			want: &Data{
+				DecimalSeparator: ",",
+				Accounts: []*Account{
+					{Name: "Girokonto", Type: accountDomain.AccountTypeBank},
+					{Name: "Tagesgeld", Type: accountDomain.AccountTypeBank},
+				},
+				Transactions: []*Transaction{
+					{Line: 2, Account: "Girokonto", Date: date(2024, 3, 15), Amount: "-45,90", Payee: "Supermarkt", Memo: "Wocheneinkauf", Category: []string{"Alltag", "Lebensmittel"}},
+					{Line: 3, Account: "Girokonto", Date: date(2024, 3, 31), Amount: "2.500,00", Payee: "Arbeitgeber"},
+					{Line: 4, Account: "Girokonto", Date: date(2024, 4, 1), Amount: "-500,00", TransferAccount: "Tagesgeld"},
+					{Line: 5, Account: "Tagesgeld", Date: date(2024, 1, 1), Amount: "1.000,00", OpeningBalance: true},
+					{Line: 6, Account: "Girokonto", Error: `invalid date "32.01.2024"`},
+				},
+			},
+		},
+		{
+			name: "legacy export with a combined category column",
+			data: "Account,Date,Payee,Category Group/Category,Memo,Outflow,Inflow\n" +
+				"Cash,01/02/2024,Kiosk,Everyday: Snacks,,$3.50,$0.00\n" +
+				"Cash,01/03/2024,Kiosk,Everyday,Gum,3.00,0\n",
+			want: &Data{
+				DecimalSeparator: ".",
+				Accounts:         []*Account{{Name: "Cash", Type: accountDomain.AccountTypeBank}},
+				Transactions: []*Transaction{
+					{Line: 2, Account: "Cash", Date: date(2024, 1, 2), Amount: "-$3.50", Payee: "Kiosk", Category: []string{"Everyday", "Snacks"}},
+					{Line: 3, Account: "Cash", Date: date(2024, 1, 3), Amount: "-3.00", Payee: "Kiosk", Memo: "Gum", Category: []string{"Everyday"}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseYNAB([]byte(tt.data), tt.options)
+			if err != nil {
+				t.Fatalf("ParseYNAB() error = %v", err)
+			}
+			assertData(t, got, tt.want)
+		})
+	}
+
+	if _, err := ParseYNAB([]byte("Account,Date,Payee\n"), Options{}); err == nil || err.Error() != `missing column "outflow"` {
+		t.Errorf("ParseYNAB() error = %v, want missing column", err)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		value   string
+		format  string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "01/15/2024", format: "MM/DD/YYYY", want: date(2024, 1, 15)},
+		{value: "1/5'24", format: "MM/DD/YYYY", want: date(2024, 1, 5)},
+		{value: "12/31/99", format: "MM/DD/YYYY", want: date(1999, 12, 31)},
+		{value: "15.03.2024", format: "DD.MM.YYYY", want: date(2024, 3, 15)},
+		{value: "2024-03-15", format: "YYYY-MM-DD", want: date(2024, 3, 15)},
+		{value: "02/29/2023", format: "MM/DD/YYYY", wantErr: true},
+		{value: "2024-03", format: "YYYY-MM-DD", wantErr: true},
+		{value: "", format: "MM/DD/YYYY", wantErr: true},
+		{value: "01/15/2024", format: "MM/YYYY", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseDate(tt.value, tt.format)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseDate(%q, %q) = %v, want an error", tt.value, tt.format, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseDate(%q, %q) error = %v", tt.value, tt.format, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseDate(%q, %q) = %v, want %v", tt.value, tt.format, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+)
+
+// qifRecord es un registro QIF: líneas con un código de una letra hasta "^"
+type qifRecord struct {
+	line   int
+	fields map[byte]string
+	splits []qifSplit
+}
+
+// qifSplit es una línea de una transacción dividida (S, E y $)
+type qifSplit struct {
+	category string
+	memo     string
+	amount   string
+}
+
+// ParseQIF interpreta un archivo QIF (Quicken Interchange Format). Lee los
+// bloques !Account y las secciones !Type de cuentas bancarias, efectivo y
+// tarjetas; las transacciones divididas generan un movimiento por línea.
+// Las fechas se leen con options.DateFormat (por defecto MM/DD/YYYY).
+func ParseQIF(data []byte, options Options) (*Data, error) {
+	options, err := normalizeOptions(options, "MM/DD/YYYY")
+	if err != nil {
+		return nil, err
+	}
+	if options.AccountName == "" {
+		options.AccountName = "QIF"
+	}
+
+	result := &Data{DecimalSeparator: options.DecimalSeparator}
+	lines := strings.Split(strings.ReplaceAll(decode(data), "\r\n", "\n"), "\n")
+
+	var section string
+	var account *Account
+	record := &qifRecord{fields: make(map[byte]string)}
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r ")
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "!") {
+			header := strings.ToLower(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(header, "!option:"), strings.HasPrefix(header, "!clear:"):
+				// Opciones de Quicken sin efecto en la importación
+			default:
+				section = header
+				record = &qifRecord{fields: make(map[byte]string)}
+			}
+			continue
+		}
+
+		if record.line == 0 {
+			record.line = i + 1
+		}
+		code, value := line[0], strings.TrimSpace(line[1:])
+		if code != '^' {
+			switch code {
+			case 'S':
+				record.splits = append(record.splits, qifSplit{category: value})
+			case 'E':
+				if n := len(record.splits); n > 0 {
+					record.splits[n-1].memo = value
+				}
+			case '$':
+				if n := len(record.splits); n > 0 {
+					record.splits[n-1].amount = value
+				}
+			default:
+				record.fields[code] = value
+			}
+			continue
+		}
+
+		// Fin del registro
+		switch {
+		case section == "!account":
+			name := clean(record.fields['N'])
+			if name != "" {
+				account = result.addAccount(name, qifAccountType(record.fields['T']), "")
+			}
+		case strings.HasPrefix(section, "!type:"):
+			kind := strings.TrimSpace(strings.TrimPrefix(section, "!type:"))
+			if accountType, ok := qifSectionType(kind); ok {
+				if account == nil {
+					account = result.addAccount(options.AccountName, accountType, "")
+				}
+				result.Transactions = append(result.Transactions, qifTransactions(record, account.Name, options)...)
+			} else if kind == "invst" {
+				result.Transactions = append(result.Transactions, &Transaction{
+					Line:    record.line,
+					Account: options.AccountName,
+					Error:   "investment transactions are not supported",
+				})
+			}
+			// Las listas de categorías, clases y memorizadas no generan movimientos
+		}
+		record = &qifRecord{fields: make(map[byte]string)}
+	}
+
+	if len(result.Transactions) == 0 && len(result.Accounts) == 0 {
+		return nil, errors.New("file has no QIF records")
+	}
+
+	result.addReferencedAccounts()
+	return result, nil
+}
+
+// qifTransactions convierte un registro en uno o varios movimientos
+func qifTransactions(record *qifRecord, account string, options Options) []*Transaction {
+	base := Transaction{
+		Line:    record.line,
+		Account: account,
+		Payee:   clean(record.fields['P']),
+		Memo:    clean(record.fields['M']),
+	}
+
+	date, err := parseDate(record.fields['D'], options.DateFormat)
+	if err != nil {
+		base.Error = err.Error()
+		return []*Transaction{&base}
+	}
+	base.Date = date
+
+	amount := record.fields['T']
+	if amount == "" {
+		amount = record.fields['U']
+	}
+
+	if len(record.splits) == 0 {
+		transaction := base
+		transaction.Amount = amount
+		applyQIFCategory(&transaction, record.fields['L'])
+		return []*Transaction{&transaction}
+	}
+
+	transactions := make([]*Transaction, 0, len(record.splits))
+	for _, split := range record.splits {
+		transaction := base
+		transaction.Amount = split.amount
+		if split.memo != "" {
+			transaction.Memo = clean(split.memo)
+		}
+		applyQIFCategory(&transaction, split.category)
+		transactions = append(transactions, &transaction)
+	}
+	return transactions
+}
+
+// applyQIFCategory interpreta el campo L: "[Cuenta]" es una transferencia y
+// "Padre:Hija/Clase" una categoría (la clase se descarta)
+func applyQIFCategory(transaction *Transaction, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+
+	if strings.HasPrefix(value, "[") {
+		end := strings.Index(value, "]")
+		if end < 0 {
+			transaction.Error = fmt.Sprintf("invalid transfer account %q", value)
+			return
+		}
+		other := clean(value[1:end])
+		// Quicken registra el saldo inicial como una transferencia a la misma cuenta
+		if strings.EqualFold(other, transaction.Account) {
+			transaction.OpeningBalance = true
+			return
+		}
+		transaction.TransferAccount = other
+		return
+	}
+
+	if class := strings.Index(value, "/"); class >= 0 {
+		value = value[:class]
+	}
+	for _, name := range strings.Split(value, ":") {
+		if name = clean(name); name != "" {
+			transaction.Category = append(transaction.Category, name)
+		}
+	}
+}
+
+// qifSectionType retorna el tipo de cuenta de una sección !Type
+func qifSectionType(kind string) (accountDomain.AccountType, bool) {
+	switch kind {
+	case "bank", "oth a":
+		return accountDomain.AccountTypeBank, true
+	case "cash":
+		return accountDomain.AccountTypeCash, true
+	case "ccard", "oth l":
+		return accountDomain.AccountTypeCreditCard, true
+	}
+	return "", false
+}
+
+// qifAccountType retorna el tipo de cuenta del campo T de un bloque !Account
+func qifAccountType(value string) accountDomain.AccountType {
+	if accountType, ok := qifSectionType(strings.ToLower(strings.TrimSpace(value))); ok {
+		return accountType
+	}
+	return accountDomain.AccountTypeBank
+}
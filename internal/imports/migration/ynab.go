@@ -0,0 +1,82 @@
+package migration
+
+import (
+	"strings"
+)
+
+// ParseYNAB interpreta la exportación del registro de YNAB (Register.csv).
+// Los movimientos con payee "Transfer : Cuenta" son transferencias y el de
+// "Starting Balance" es el saldo inicial. Los montos vienen en las columnas
+// Outflow e Inflow con el formato regional del presupuesto.
+func ParseYNAB(data []byte, options Options) (*Data, error) {
+	options, err := normalizeOptions(options, "MM/DD/YYYY")
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := readTable(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.require("account", "date", "payee", "outflow", "inflow"); err != nil {
+		return nil, err
+	}
+
+	result := &Data{DecimalSeparator: options.DecimalSeparator}
+	for i, record := range t.records {
+		transaction := &Transaction{
+			Line:    t.lines[i],
+			Account: clean(t.field(record, "account")),
+			Memo:    clean(t.field(record, "memo")),
+		}
+
+		date, err := parseDate(t.field(record, "date"), options.DateFormat)
+		if err != nil {
+			transaction.Error = err.Error()
+			result.Transactions = append(result.Transactions, transaction)
+			continue
+		}
+		transaction.Date = date
+
+		if outflow := t.field(record, "outflow"); !isZero(outflow) {
+			transaction.Amount = negate(outflow)
+		} else {
+			transaction.Amount = t.field(record, "inflow")
+		}
+
+		payee := clean(t.field(record, "payee"))
+		switch {
+		case strings.HasPrefix(payee, "Transfer :"):
+			transaction.TransferAccount = clean(strings.TrimPrefix(payee, "Transfer :"))
+		case payee == "Starting Balance":
+			transaction.OpeningBalance = true
+		default:
+			transaction.Payee = payee
+			transaction.Category = ynabCategory(t, record)
+		}
+
+		result.Transactions = append(result.Transactions, transaction)
+	}
+
+	result.addReferencedAccounts()
+	return result, nil
+}
+
+// ynabCategory retorna la ruta [grupo, categoría]. Los ingresos asignados a
+// "Inflow: Ready to Assign" quedan sin categoría.
+func ynabCategory(t *table, record []string) []string {
+	group, category := clean(t.field(record, "category group")), clean(t.field(record, "category"))
+	if group == "" && category == "" {
+		// Exportaciones antiguas solo traen "Category Group/Category" como "Grupo: Categoría"
+		group, category, _ = strings.Cut(t.field(record, "category group/category"), ":")
+		group, category = clean(group), clean(category)
+	}
+
+	if strings.EqualFold(group, "Inflow") || group == "" {
+		return nil
+	}
+	if category == "" {
+		return []string{group}
+	}
+	return []string{group, category}
+}
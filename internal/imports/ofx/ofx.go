@@ -0,0 +1,178 @@
+// Package ofx interpreta extractos OFX 1.x (SGML) y 2.x (XML), incluidos los
+// archivos QFX, que son OFX con etiquetas adicionales. No accede a la base
+// de datos ni a la red.
+package ofx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"finanzas-api/shared/money"
+)
+
+// Statement es el extracto de una cuenta: bancaria (STMTRS) o de tarjeta
+// de crédito (CCSTMTRS).
+type Statement struct {
+	AccountID     string // ACCTID tal como lo reporta el banco
+	Currency      string // CURDEF
+	Transactions  []*Transaction
+	LedgerBalance *Balance // LEDGERBAL, si el extracto lo incluye
+}
+
+// Transaction es un registro STMTTRN
+type Transaction struct {
+	FITID  string // Identificador único asignado por la institución
+	Type   string // TRNTYPE: DEBIT, CREDIT, POS, ATM...
+	Posted time.Time
+	Amount money.Money // Con signo: negativo es un cargo a la cuenta
+	Name   string
+	Memo   string
+	// Error describe por qué no se pudo interpretar el registro
+	Error string
+}
+
+// Balance es el saldo reportado por la institución en una fecha
+type Balance struct {
+	Amount money.Money
+	AsOf   time.Time
+}
+
+// Parse interpreta el documento y retorna sus extractos. Los problemas de
+// un registro quedan en su campo Error; solo se retorna error si el archivo
+// completo es ilegible.
+func Parse(data []byte) ([]*Statement, error) {
+	document, err := parseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var statements []*Statement
+	for _, name := range []string{"STMTRS", "CCSTMTRS"} {
+		for _, element := range document.findAll(name) {
+			statement, err := parseStatement(element)
+			if err != nil {
+				return nil, err
+			}
+			statements = append(statements, statement)
+		}
+	}
+	if len(statements) == 0 {
+		return nil, errors.New("OFX document has no bank or credit card statement")
+	}
+	return statements, nil
+}
+
+// Description retorna el texto que identifica la transacción: el nombre del
+// comercio y, si aporta información, el memo.
+func (t *Transaction) Description() string {
+	switch {
+	case t.Name == "":
+		return t.Memo
+	case t.Memo == "" || strings.Contains(t.Name, t.Memo):
+		return t.Name
+	default:
+		return t.Name + " - " + t.Memo
+	}
+}
+
+func parseStatement(element *node) (*Statement, error) {
+	currency := strings.ToUpper(element.text("CURDEF"))
+	if !money.IsValidCurrency(currency) {
+		return nil, fmt.Errorf("invalid statement currency %q", currency)
+	}
+
+	statement := &Statement{Currency: currency}
+	if account := element.child("BANKACCTFROM"); account != nil {
+		statement.AccountID = account.text("ACCTID")
+	} else if account := element.child("CCACCTFROM"); account != nil {
+		statement.AccountID = account.text("ACCTID")
+	}
+
+	if list := element.child("BANKTRANLIST"); list != nil {
+		for _, record := range list.findAll("STMTTRN") {
+			statement.Transactions = append(statement.Transactions, parseTransaction(record, currency))
+		}
+	}
+
+	if ledger := element.child("LEDGERBAL"); ledger != nil {
+		amount, err := parseAmount(ledger.text("BALAMT"), currency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LEDGERBAL: %w", err)
+		}
+		asOf, err := ParseDate(ledger.text("DTASOF"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid LEDGERBAL: %w", err)
+		}
+		statement.LedgerBalance = &Balance{Amount: amount, AsOf: asOf}
+	}
+
+	return statement, nil
+}
+
+func parseTransaction(record *node, currency string) *Transaction {
+	transaction := &Transaction{
+		FITID: record.text("FITID"),
+		Type:  strings.ToUpper(record.text("TRNTYPE")),
+		Name:  strings.Join(strings.Fields(record.text("NAME")), " "),
+		Memo:  strings.Join(strings.Fields(record.text("MEMO")), " "),
+	}
+	// Algunas instituciones envían el comercio en PAYEE en lugar de NAME
+	if transaction.Name == "" {
+		transaction.Name = strings.Join(strings.Fields(record.text("PAYEE", "NAME")), " ")
+	}
+
+	if transaction.FITID == "" {
+		transaction.Error = "missing FITID"
+		return transaction
+	}
+
+	posted, err := ParseDate(record.text("DTPOSTED"))
+	if err != nil {
+		transaction.Error = err.Error()
+		return transaction
+	}
+	transaction.Posted = posted
+
+	amount, err := parseAmount(record.text("TRNAMT"), currency)
+	if err != nil {
+		transaction.Error = err.Error()
+		return transaction
+	}
+	if amount.IsZero() {
+		transaction.Error = "amount must not be zero"
+		return transaction
+	}
+	transaction.Amount = amount
+
+	return transaction
+}
+
+// ParseDate interpreta una fecha OFX (YYYYMMDD[HHMMSS[.XXX]][[offset:TZ]]).
+// Solo se conserva el día: los extractos reportan fechas contables.
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
+
+// parseAmount interpreta un monto OFX. La especificación usa punto decimal,
+// pero algunas instituciones envían coma.
+func parseAmount(value, currency string) (money.Money, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "+")
+	if strings.Contains(value, ",") && !strings.Contains(value, ".") {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	amount, err := money.ParseDecimal(value, currency)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
@@ -0,0 +1,190 @@
+package ofx
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"finanzas-api/shared/money"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		file string
+		want []*Statement
+	}{
+		{
+			// SGML en Windows-1252, hojas sin cierre, entidades y montos con coma
+			file: "bank_v1.ofx",
+			want: []*Statement{{
+				AccountID: "123456789",
+				Currency:  "COP",
+				Transactions: []*Transaction{
+					{FITID: "202403050001", Type: "DEBIT", Posted: date(2024, 3, 5), Amount: money.New(-12000000, "COP"), Name: "ÉXITO CALLE 80", Memo: "Compra con tarjeta – débito"},
+					{FITID: "202403150002", Type: "CREDIT", Posted: date(2024, 3, 15), Amount: money.New(350000000, "COP"), Name: "NOMINA ACME & CIA"},
+				},
+				LedgerBalance: &Balance{Amount: money.New(438000000, "COP"), AsOf: date(2024, 3, 31)},
+			}},
+		},
+		{
+			// XML con comentarios, elementos vacíos y el comercio en PAYEE
+			file: "bank_v2.ofx",
+			want: []*Statement{{
+				AccountID: "0099887766",
+				Currency:  "USD",
+				Transactions: []*Transaction{
+					{FITID: "A1", Type: "POS", Posted: date(2024, 3, 2), Amount: money.New(-4215, "USD"), Name: "Corner Store", Memo: "Corner Store"},
+					{FITID: "A2", Type: "ATM", Posted: date(2024, 3, 10), Amount: money.New(-6000, "USD"), Memo: "ATM withdrawal"},
+				},
+				LedgerBalance: &Balance{Amount: money.New(89785, "USD"), AsOf: date(2024, 3, 31)},
+			}},
+		},
+		{
+			// Dos cuentas bancarias, una sin movimientos, y una tarjeta de crédito
+			file: "multiple_statements.ofx",
+			want: []*Statement{
+				{AccountID: "ES001", Currency: "EUR", Transactions: []*Transaction{
+					{FITID: "E1", Type: "DEBIT", Posted: date(2024, 1, 2), Amount: money.New(-1000, "EUR"), Name: "Panaderia"},
+				}},
+				{AccountID: "ES002", Currency: "EUR"},
+				{AccountID: "4111XXXX1111", Currency: "USD", Transactions: []*Transaction{
+					{FITID: "C1", Type: "DEBIT", Posted: date(2024, 1, 5), Amount: money.New(-2599, "USD"), Name: "Streaming"},
+				}},
+			},
+		},
+		{
+			// Los registros ilegibles se conservan con su error
+			file: "invalid_records.ofx",
+			want: []*Statement{{
+				AccountID: "1",
+				Currency:  "COP",
+				Transactions: []*Transaction{
+					{Type: "DEBIT", Name: "Sin identificador", Error: "missing FITID"},
+					{FITID: "F2", Type: "DEBIT", Error: `invalid date "2024-03"`},
+					{FITID: "F3", Type: "DEBIT", Posted: date(2024, 3, 1), Error: `invalid amount "diez"`},
+					{FITID: "F4", Type: "DEBIT", Posted: date(2024, 3, 1), Error: "amount must not be zero"},
+				},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			got, err := Parse(readFixture(t, tt.file))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d statements, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if !reflect.DeepEqual(got[i].LedgerBalance, tt.want[i].LedgerBalance) {
+					t.Errorf("statement %d: LedgerBalance = %+v, want %+v", i, got[i].LedgerBalance, tt.want[i].LedgerBalance)
+				}
+				if got[i].AccountID != tt.want[i].AccountID || got[i].Currency != tt.want[i].Currency {
+					t.Errorf("statement %d: account %q in %s, want %q in %s", i, got[i].AccountID, got[i].Currency, tt.want[i].AccountID, tt.want[i].Currency)
+				}
+				if len(got[i].Transactions) != len(tt.want[i].Transactions) {
+					t.Errorf("statement %d: got %d transactions, want %d", i, len(got[i].Transactions), len(tt.want[i].Transactions))
+					continue
+				}
+				for j, transaction := range got[i].Transactions {
+					if !reflect.DeepEqual(transaction, tt.want[i].Transactions[j]) {
+						t.Errorf("statement %d, transaction %d = %+v, want %+v", i, j, transaction, tt.want[i].Transactions[j])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		file    string
+		wantErr string
+	}{
+		{file: "not_ofx.csv", wantErr: ErrNotOFX.Error()},
+		{file: "unterminated_tag.ofx", wantErr: "malformed OFX: unterminated tag"},
+		{file: "no_statement.ofx", wantErr: "OFX document has no bank or credit card statement"},
+		{file: "invalid_currency.ofx", wantErr: `invalid statement currency "PESOS"`},
+		{file: "invalid_ledger.ofx", wantErr: `invalid LEDGERBAL: invalid amount "mucho"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			_, err := Parse(readFixture(t, tt.file))
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("Parse() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := Parse(readFixture(t, "not_ofx.csv")); !errors.Is(err, ErrNotOFX) {
+		t.Errorf("Parse() error = %v, want ErrNotOFX", err)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "20240305", want: date(2024, 3, 5)},
+		{value: "20240305120000", want: date(2024, 3, 5)},
+		{value: "20240305235959.999[-5:COT]", want: date(2024, 3, 5)},
+		{value: " 20241231 ", want: date(2024, 12, 31)},
+		{value: "2024030", wantErr: true},
+		{value: "2024-03-05", wantErr: true},
+		{value: "20241301", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseDate(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseDate(%q) = %v, want an error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseDate(%q) error = %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseDate(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestTransactionDescription(t *testing.T) {
+	tests := []struct {
+		name, memo string
+		want       string
+	}{
+		{name: "EXITO", memo: "Compra", want: "EXITO - Compra"},
+		{name: "EXITO CALLE 80", memo: "CALLE 80", want: "EXITO CALLE 80"},
+		{name: "EXITO", want: "EXITO"},
+		{memo: "Retiro", want: "Retiro"},
+	}
+	for _, tt := range tests {
+		transaction := &Transaction{Name: tt.name, Memo: tt.memo}
+		if got := transaction.Description(); got != tt.want {
+			t.Errorf("Description(%q, %q) = %q, want %q", tt.name, tt.memo, got, tt.want)
+		}
+	}
+}
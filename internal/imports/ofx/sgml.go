@@ -0,0 +1,159 @@
+package ofx
+
+import (
+	"errors"
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrNotOFX indica que el archivo no contiene un elemento <OFX>
+var ErrNotOFX = errors.New("file is not an OFX document")
+
+// node es un elemento del documento. Los elementos hoja (TRNAMT, FITID...)
+// tienen valor; los agregados (STMTTRN, LEDGERBAL...) tienen hijos.
+type node struct {
+	name     string
+	value    string
+	children []*node
+}
+
+// child retorna el primer hijo directo con el nombre indicado
+func (n *node) child(name string) *node {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// text sigue la ruta de hijos y retorna el valor del último, o "" si no existe
+func (n *node) text(path ...string) string {
+	current := n
+	for _, name := range path {
+		if current = current.child(name); current == nil {
+			return ""
+		}
+	}
+	return current.value
+}
+
+// findAll retorna los descendientes con el nombre indicado, en orden de documento
+func (n *node) findAll(name string) []*node {
+	var found []*node
+	for _, c := range n.children {
+		if c.name == name {
+			found = append(found, c)
+		}
+		found = append(found, c.findAll(name)...)
+	}
+	return found
+}
+
+// parseDocument construye el árbol del elemento <OFX>. Acepta OFX 1.x, un
+// SGML donde los elementos hoja no se cierran, y OFX 2.x, que es XML: un
+// elemento con texto se cierra implícitamente al abrir el siguiente.
+func parseDocument(data []byte) (*node, error) {
+	text := decode(data)
+	start := strings.Index(strings.ToUpper(text), "<OFX>")
+	if start < 0 {
+		return nil, ErrNotOFX
+	}
+	text = text[start:]
+
+	root := &node{}
+	stack := []*node{root}
+	top := func() *node { return stack[len(stack)-1] }
+
+	for len(text) > 0 {
+		open := strings.IndexByte(text, '<')
+		if open < 0 {
+			break
+		}
+		if value := strings.TrimSpace(text[:open]); value != "" && len(stack) > 1 {
+			top().value = html.UnescapeString(value)
+		}
+		text = text[open:]
+
+		// Comentarios e instrucciones de procesamiento se ignoran
+		if strings.HasPrefix(text, "<!--") {
+			end := strings.Index(text, "-->")
+			if end < 0 {
+				break
+			}
+			text = text[end+3:]
+			continue
+		}
+		end := strings.IndexByte(text, '>')
+		if end < 0 {
+			return nil, errors.New("malformed OFX: unterminated tag")
+		}
+		tag := text[1:end]
+		text = text[end+1:]
+		if strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!") {
+			continue
+		}
+
+		if strings.HasPrefix(tag, "/") {
+			name := tagName(tag[1:])
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+			continue
+		}
+
+		// Un elemento con valor es una hoja SGML sin cierre
+		if len(stack) > 1 && top().value != "" {
+			stack = stack[:len(stack)-1]
+		}
+		selfClosing := strings.HasSuffix(tag, "/")
+		element := &node{name: tagName(strings.TrimSuffix(tag, "/"))}
+		top().children = append(top().children, element)
+		if !selfClosing {
+			stack = append(stack, element)
+		}
+	}
+
+	document := root.child("OFX")
+	if document == nil {
+		return nil, ErrNotOFX
+	}
+	return document, nil
+}
+
+// tagName extrae el nombre de la etiqueta sin atributos, en mayúsculas
+func tagName(tag string) string {
+	if fields := strings.Fields(tag); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return ""
+}
+
+// decode convierte el archivo a UTF-8. Los archivos OFX 1.x suelen venir en
+// Windows-1252 (CHARSET:1252); si el contenido no es UTF-8 válido se
+// interpreta en esa codificación.
+func decode(data []byte) string {
+	if utf8.Valid(data) {
+		return strings.TrimPrefix(string(data), "\ufeff")
+	}
+
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+		if b >= 0x80 && b <= 0x9f && windows1252[b-0x80] != 0 {
+			runes[i] = windows1252[b-0x80]
+		}
+	}
+	return string(runes)
+}
+
+// windows1252 son los caracteres de 0x80 a 0x9F en Windows-1252, donde
+// difiere de ISO-8859-1. Cero indica una posición sin asignar.
+var windows1252 = [32]rune{
+	'€', 0, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0, 'Ž', 0,
+	0, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0, 'ž', 'Ÿ',
+}
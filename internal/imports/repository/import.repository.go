@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/imports/domain"
+
+type ImportRepository interface {
+	domain.ImportRepository
+}
@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"finanzas-api/internal/imports/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+type importRepositoryMemory struct {
+	profiles        map[uint]*domain.ImportProfile
+	batches         map[uint]*domain.ImportBatch
+	transactionRepo transactionDomain.TransactionRepository
+	reviewRepo      transactionDomain.DuplicateReviewRepository
+	nextID          uint
+	mutex           sync.RWMutex
+}
+
+// NewImportMemoryRepository recibe los repositorios donde se registran las
+// transacciones importadas y las revisiones de las que parecen duplicadas.
+func NewImportMemoryRepository(transactionRepo transactionDomain.TransactionRepository, reviewRepo transactionDomain.DuplicateReviewRepository) domain.ImportRepository {
+	return &importRepositoryMemory{
+		profiles:        make(map[uint]*domain.ImportProfile),
+		batches:         make(map[uint]*domain.ImportBatch),
+		transactionRepo: transactionRepo,
+		reviewRepo:      reviewRepo,
+		nextID:          1,
+	}
+}
+
+func (r *importRepositoryMemory) CreateProfile(profile *domain.ImportProfile) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Asignar ID y timestamps
+	profile.ID = r.nextID
+	r.nextID++
+	profile.CreatedAt = time.Now()
+	profile.UpdatedAt = time.Now()
+
+	r.profiles[profile.ID] = profile
+	return nil
+}
+
+func (r *importRepositoryMemory) GetProfileByID(id uint) (*domain.ImportProfile, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	profile, exists := r.profiles[id]
+	if !exists || !profile.DeletedAt.Time.IsZero() {
+		return nil, errors.New("import profile not found")
+	}
+
+	return profile, nil
+}
+
+func (r *importRepositoryMemory) UpdateProfile(profile *domain.ImportProfile) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existing, exists := r.profiles[profile.ID]
+	if !exists || !existing.DeletedAt.Time.IsZero() {
+		return errors.New("import profile not found")
+	}
+
+	profile.UpdatedAt = time.Now()
+	r.profiles[profile.ID] = profile
+
+	return nil
+}
+
+func (r *importRepositoryMemory) DeleteProfile(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	profile, exists := r.profiles[id]
+	if !exists || !profile.DeletedAt.Time.IsZero() {
+		return errors.New("import profile not found")
+	}
+
+	// Soft delete
+	profile.DeletedAt.Time = time.Now()
+	profile.DeletedAt.Valid = true
+	profile.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (r *importRepositoryMemory) ListProfiles(userID uint) ([]*domain.ImportProfile, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var profiles []*domain.ImportProfile
+	for _, profile := range r.profiles {
+		if profile.DeletedAt.Time.IsZero() && profile.UserID == userID {
+			profiles = append(profiles, profile)
+		}
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		if profiles[i].Name != profiles[j].Name {
+			return profiles[i].Name < profiles[j].Name
+		}
+		return profiles[i].ID < profiles[j].ID
+	})
+	return profiles, nil
+}
+
+func (r *importRepositoryMemory) CreateBatch(batch *domain.ImportBatch) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Asignar IDs y timestamps
+	batch.ID = r.nextID
+	r.nextID++
+	batch.CreatedAt = time.Now()
+	batch.UpdatedAt = time.Now()
+	for _, row := range batch.Rows {
+		row.ID = r.nextID
+		r.nextID++
+		row.BatchID = batch.ID
+	}
+
+	r.batches[batch.ID] = batch
+	return nil
+}
+
+func (r *importRepositoryMemory) GetBatchByID(id uint) (*domain.ImportBatch, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	batch, exists := r.batches[id]
+	if !exists {
+		return nil, errors.New("import batch not found")
+	}
+
+	return batch, nil
+}
+
+func (r *importRepositoryMemory) ListBatches(userID uint, limit, offset int) ([]*domain.ImportBatch, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var batches []*domain.ImportBatch
+	for _, batch := range r.batches {
+		if batch.UserID == userID {
+			batches = append(batches, batch)
+		}
+	}
+	sort.Slice(batches, func(i, j int) bool { return batches[i].ID > batches[j].ID })
+
+	// Aplicar offset y limit
+	if offset >= len(batches) {
+		return nil, nil
+	}
+	batches = batches[offset:]
+	if limit > 0 && len(batches) > limit {
+		batches = batches[:limit]
+	}
+
+	return batches, nil
+}
+
+func (r *importRepositoryMemory) CommitBatch(batch *domain.ImportBatch, transactions map[uint]*transactionDomain.Transaction, reviews map[uint]*transactionDomain.DuplicateReview) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stored, exists := r.batches[batch.ID]
+	if !exists {
+		return errors.New("import batch not found")
+	}
+	if stored.Status != domain.BatchStatusPreview {
+		return domain.ErrBatchNotPending
+	}
+
+	for _, row := range stored.Rows {
+		transaction, exists := transactions[row.ID]
+		if !exists {
+			continue
+		}
+		if transaction.ExternalID != nil {
+			existing, err := r.findExternalIDs(transaction.UserID, transaction.AccountID, []string{*transaction.ExternalID})
+			if err != nil {
+				return err
+			}
+			if len(existing) > 0 {
+				row.Error = "already imported"
+				continue
+			}
+		}
+		if err := r.transactionRepo.Create(transaction); err != nil {
+			return err
+		}
+		row.TransactionID = &transaction.ID
+	}
+	for _, row := range stored.Rows {
+		review, exists := reviews[row.ID]
+		if !exists {
+			continue
+		}
+		if err := r.reviewRepo.Create(review); err != nil {
+			return err
+		}
+		row.DuplicateReviewID = &review.ID
+		stored.FlaggedRows++
+	}
+
+	now := time.Now()
+	stored.Status = domain.BatchStatusCommitted
+	stored.CommittedAt = &now
+	stored.UpdatedAt = now
+	*batch = *stored
+	return nil
+}
+
+func (r *importRepositoryMemory) UpdateBatch(batch *domain.ImportBatch) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stored, exists := r.batches[batch.ID]
+	if !exists {
+		return errors.New("import batch not found")
+	}
+
+	rows := stored.Rows
+	*stored = *batch
+	stored.Rows = rows
+	stored.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *importRepositoryMemory) UpdateRow(row *domain.ImportRow) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	batch, exists := r.batches[row.BatchID]
+	if !exists {
+		return errors.New("import batch not found")
+	}
+	for i, stored := range batch.Rows {
+		if stored.ID == row.ID {
+			batch.Rows[i] = row
+			return nil
+		}
+	}
+	return errors.New("import row not found")
+}
+
+func (r *importRepositoryMemory) FindExternalIDs(userID, accountID uint, externalIDs []string) (map[string]uint, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.findExternalIDs(userID, accountID, externalIDs)
+}
+
+// findExternalIDs consulta el repositorio de transacciones; quien la llama
+// debe tener el mutex.
+func (r *importRepositoryMemory) findExternalIDs(userID, accountID uint, externalIDs []string) (map[string]uint, error) {
+	found := make(map[string]uint)
+	if len(externalIDs) == 0 {
+		return found, nil
+	}
+
+	wanted := make(map[string]bool, len(externalIDs))
+	for _, id := range externalIDs {
+		wanted[id] = true
+	}
+
+	transactions, err := r.transactionRepo.List(userID, transactionDomain.TransactionFilter{AccountID: &accountID})
+	if err != nil {
+		return nil, err
+	}
+	for _, transaction := range transactions {
+		if transaction.ExternalID != nil && wanted[*transaction.ExternalID] {
+			found[*transaction.ExternalID] = transaction.ID
+		}
+	}
+	return found, nil
+}
+
+func (r *importRepositoryMemory) DiscardBatch(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	batch, exists := r.batches[id]
+	if !exists {
+		return errors.New("import batch not found")
+	}
+	if batch.Status != domain.BatchStatusPreview {
+		return domain.ErrBatchNotPending
+	}
+
+	batch.Status = domain.BatchStatusDiscarded
+	batch.UpdatedAt = time.Now()
+	return nil
+}
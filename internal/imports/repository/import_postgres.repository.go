@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"time"
+
+	"finanzas-api/internal/imports/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// externalIDConflict corresponde al índice parcial único de transacciones
+// por cuenta y ExternalID: una transacción ya importada no se duplica.
+var externalIDConflict = clause.OnConflict{
+	Columns:     []clause.Column{{Name: "account_id"}, {Name: "external_id"}},
+	TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "external_id IS NOT NULL AND deleted_at IS NULL"}}},
+	DoNothing:   true,
+}
+
+type importPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewImportPostgresRepository(db *gorm.DB) domain.ImportRepository {
+	return &importPostgresRepository{db: db}
+}
+
+func (r *importPostgresRepository) CreateProfile(profile *domain.ImportProfile) error {
+	return r.db.Create(profile).Error
+}
+
+func (r *importPostgresRepository) GetProfileByID(id uint) (*domain.ImportProfile, error) {
+	var profile domain.ImportProfile
+	if err := r.db.First(&profile, id).Error; err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *importPostgresRepository) UpdateProfile(profile *domain.ImportProfile) error {
+	return r.db.Save(profile).Error
+}
+
+func (r *importPostgresRepository) DeleteProfile(id uint) error {
+	return r.db.Delete(&domain.ImportProfile{}, id).Error // soft delete
+}
+
+func (r *importPostgresRepository) ListProfiles(userID uint) ([]*domain.ImportProfile, error) {
+	var profiles []*domain.ImportProfile
+	if err := r.db.Where("user_id = ?", userID).Order("name, id").Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func (r *importPostgresRepository) CreateBatch(batch *domain.ImportBatch) error {
+	// Las filas se insertan junto con el lote por la asociación Rows
+	return r.db.Create(batch).Error
+}
+
+func (r *importPostgresRepository) GetBatchByID(id uint) (*domain.ImportBatch, error) {
+	var batch domain.ImportBatch
+	err := r.db.Preload("Rows", func(db *gorm.DB) *gorm.DB {
+		return db.Order("line_number")
+	}).First(&batch, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+func (r *importPostgresRepository) ListBatches(userID uint, limit, offset int) ([]*domain.ImportBatch, error) {
+	var batches []*domain.ImportBatch
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&batches).Error
+	if err != nil {
+		return nil, err
+	}
+	return batches, nil
+}
+
+func (r *importPostgresRepository) CommitBatch(batch *domain.ImportBatch, transactions map[uint]*transactionDomain.Transaction, reviews map[uint]*transactionDomain.DuplicateReview) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Cambiar el estado primero evita que dos confirmaciones simultáneas
+		// dupliquen las transacciones: solo una encuentra el lote en vista previa.
+		now := time.Now()
+		result := tx.Model(&domain.ImportBatch{}).
+			Where("id = ? AND status = ?", batch.ID, domain.BatchStatusPreview).
+			Updates(map[string]interface{}{"status": domain.BatchStatusCommitted, "committed_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrBatchNotPending
+		}
+
+		for _, row := range batch.Rows {
+			transaction, exists := transactions[row.ID]
+			if !exists {
+				continue
+			}
+			// Las etiquetas se enlazan solo si la fila se insertó: con el
+			// conflicto la transacción queda sin ID
+			result := tx.Clauses(externalIDConflict).Omit("Tags").Create(transaction)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				// Otra importación la registró después de la vista previa
+				row.Error = "already imported"
+				if err := tx.Model(row).Update("error", row.Error).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if len(transaction.Tags) > 0 {
+				if err := tx.Model(transaction).Association("Tags").Append(transaction.Tags); err != nil {
+					return err
+				}
+			}
+			if err := tx.Model(row).Update("transaction_id", transaction.ID).Error; err != nil {
+				return err
+			}
+			row.TransactionID = &transaction.ID
+		}
+
+		flagged := 0
+		for _, row := range batch.Rows {
+			review, exists := reviews[row.ID]
+			if !exists {
+				continue
+			}
+			if err := tx.Create(review).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(row).Update("duplicate_review_id", review.ID).Error; err != nil {
+				return err
+			}
+			row.DuplicateReviewID = &review.ID
+			flagged++
+		}
+		if flagged > 0 {
+			if err := tx.Model(&domain.ImportBatch{}).Where("id = ?", batch.ID).Update("flagged_rows", batch.FlaggedRows+flagged).Error; err != nil {
+				return err
+			}
+		}
+
+		batch.Status = domain.BatchStatusCommitted
+		batch.CommittedAt = &now
+		batch.FlaggedRows += flagged
+		return nil
+	})
+}
+
+func (r *importPostgresRepository) UpdateBatch(batch *domain.ImportBatch) error {
+	return r.db.Omit("Rows").Save(batch).Error
+}
+
+func (r *importPostgresRepository) UpdateRow(row *domain.ImportRow) error {
+	return r.db.Save(row).Error
+}
+
+func (r *importPostgresRepository) FindExternalIDs(userID, accountID uint, externalIDs []string) (map[string]uint, error) {
+	found := make(map[string]uint)
+	if len(externalIDs) == 0 {
+		return found, nil
+	}
+
+	var matches []*transactionDomain.Transaction
+	err := r.db.Select("id, external_id").
+		Where("user_id = ? AND account_id = ? AND external_id IN ?", userID, accountID, externalIDs).
+		Find(&matches).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range matches {
+		found[*match.ExternalID] = match.ID
+	}
+	return found, nil
+}
+
+func (r *importPostgresRepository) DiscardBatch(id uint) error {
+	result := r.db.Model(&domain.ImportBatch{}).
+		Where("id = ? AND status = ?", id, domain.BatchStatusPreview).
+		Update("status", domain.BatchStatusDiscarded)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrBatchNotPending
+	}
+	return nil
+}
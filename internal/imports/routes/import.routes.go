@@ -0,0 +1,52 @@
+package routes
+
+import (
+	"finanzas-api/internal/imports/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupImportRoutes configura las rutas para el módulo de importaciones
+func SetupImportRoutes(router *gin.Engine, importHandler *handler.ImportHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	importRoutes := router.Group("/api/v1/imports")
+	{
+		// POST /api/v1/imports/profiles - Crear perfil de importación
+		importRoutes.POST("/profiles", authMiddleware("imports:write"), importHandler.CreateProfile)
+
+		// GET /api/v1/imports/profiles - Listar perfiles de importación
+		importRoutes.GET("/profiles", authMiddleware("imports:read"), importHandler.ListProfiles)
+
+		// GET /api/v1/imports/profiles/:id - Obtener perfil por ID
+		importRoutes.GET("/profiles/:id", authMiddleware("imports:read"), importHandler.GetProfile)
+
+		// PUT /api/v1/imports/profiles/:id - Actualizar perfil
+		importRoutes.PUT("/profiles/:id", authMiddleware("imports:write"), importHandler.UpdateProfile)
+
+		// DELETE /api/v1/imports/profiles/:id - Eliminar perfil
+		importRoutes.DELETE("/profiles/:id", authMiddleware("imports:write"), importHandler.DeleteProfile)
+
+		// POST /api/v1/imports/csv - Subir extracto CSV y obtener vista previa
+		importRoutes.POST("/csv", authMiddleware("imports:write"), importHandler.PreviewCSV)
+
+		// POST /api/v1/imports/ofx - Subir extracto OFX/QFX y obtener vista previa
+		importRoutes.POST("/ofx", authMiddleware("imports:write"), importHandler.PreviewOFX)
+
+		// POST /api/v1/imports/statements - Subir extracto camt.053/MT940 y obtener vista previa
+		importRoutes.POST("/statements", authMiddleware("imports:write"), importHandler.PreviewStatement)
+
+		// POST /api/v1/imports/migrations/:format - Migrar desde qif, firefly o ynab
+		importRoutes.POST("/migrations/:format", authMiddleware("imports:write"), importHandler.Migrate)
+
+		// GET /api/v1/imports - Listar lotes de importación
+		importRoutes.GET("", authMiddleware("imports:read"), importHandler.ListBatches)
+
+		// GET /api/v1/imports/:id - Obtener lote con sus filas
+		importRoutes.GET("/:id", authMiddleware("imports:read"), importHandler.GetBatch)
+
+		// POST /api/v1/imports/:id/commit - Confirmar lote y crear transacciones
+		importRoutes.POST("/:id/commit", authMiddleware("imports:write"), importHandler.CommitBatch)
+
+		// POST /api/v1/imports/:id/discard - Descartar lote
+		importRoutes.POST("/:id/discard", authMiddleware("imports:write"), importHandler.DiscardBatch)
+	}
+}
@@ -0,0 +1,257 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrNotCAMT se retorna cuando el archivo no es un extracto camt.053
+var ErrNotCAMT = errors.New("file is not a camt.053 statement")
+
+// Estructura mínima de camt.053 (versiones 001.02 a 001.08). Las etiquetas
+// no llevan espacio de nombres, así que coinciden con cualquier versión.
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	ID      string `xml:"Id"`
+	Account struct {
+		IBAN     string `xml:"Id>IBAN"`
+		Other    string `xml:"Id>Othr>Id"`
+		Currency string `xml:"Ccy"`
+	} `xml:"Acct"`
+	Balances []camtBalance `xml:"Bal"`
+	Entries  []camtEntry   `xml:"Ntry"`
+}
+
+type camtBalance struct {
+	Code      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Date      camtDate   `xml:"Dt"`
+}
+
+type camtEntry struct {
+	Reference   string     `xml:"NtryRef"`
+	Amount      camtAmount `xml:"Amt"`
+	Indicator   string     `xml:"CdtDbtInd"`
+	Status      camtStatus `xml:"Sts"`
+	BookingDate camtDate   `xml:"BookgDt"`
+	ValueDate   camtDate   `xml:"ValDt"`
+	ServicerRef string     `xml:"AcctSvcrRef"`
+	Details     []struct {
+		EndToEndID  string   `xml:"Refs>EndToEndId"`
+		ServicerRef string   `xml:"Refs>AcctSvcrRef"`
+		Debtor      string   `xml:"RltdPties>Dbtr>Nm"`
+		DebtorPty   string   `xml:"RltdPties>Dbtr>Pty>Nm"`
+		Creditor    string   `xml:"RltdPties>Cdtr>Nm"`
+		CreditorPty string   `xml:"RltdPties>Cdtr>Pty>Nm"`
+		Remittance  []string `xml:"RmtInf>Ustrd"`
+	} `xml:"NtryDtls>TxDtls"`
+	Info string `xml:"AddtlNtryInf"`
+}
+
+// camtStatus admite el estado como texto (hasta 001.06) o como código
+type camtStatus struct {
+	Value string `xml:",chardata"`
+	Code  string `xml:"Cd"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+// camtDate admite fecha (Dt) o fecha y hora (DtTm)
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// ParseCAMT053 interpreta un documento camt.053 y retorna sus extractos.
+// Solo incluye los movimientos contabilizados; los pendientes no afectan el
+// saldo de cierre.
+func ParseCAMT053(data []byte) ([]*Statement, error) {
+	var document camtDocument
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&document); err != nil {
+		return nil, ErrNotCAMT
+	}
+	if len(document.Statements) == 0 {
+		return nil, ErrNotCAMT
+	}
+
+	statements := make([]*Statement, 0, len(document.Statements))
+	for i := range document.Statements {
+		statement, err := parseCAMTStatement(&document.Statements[i])
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
+
+func parseCAMTStatement(stmt *camtStatement) (*Statement, error) {
+	statement := &Statement{
+		ID:       strings.TrimSpace(stmt.ID),
+		Account:  firstNonEmpty(stmt.Account.IBAN, stmt.Account.Other),
+		Currency: strings.ToUpper(strings.TrimSpace(stmt.Account.Currency)),
+	}
+
+	balances := make(map[string]camtBalance)
+	for _, balance := range stmt.Balances {
+		code := strings.ToUpper(strings.TrimSpace(balance.Code))
+		if _, exists := balances[code]; !exists {
+			balances[code] = balance
+		}
+	}
+
+	// El saldo de apertura puede venir como OPBD o como el cierre anterior (PRCD)
+	opening, found := balances["OPBD"]
+	if !found {
+		opening, found = balances["PRCD"]
+	}
+	if !found {
+		return nil, fmt.Errorf("statement %s: opening balance is missing", statement.ID)
+	}
+	closing, found := balances["CLBD"]
+	if !found {
+		return nil, fmt.Errorf("statement %s: closing balance is missing", statement.ID)
+	}
+
+	if statement.Currency == "" {
+		statement.Currency = strings.ToUpper(strings.TrimSpace(closing.Amount.Currency))
+	}
+
+	var err error
+	if statement.Opening, err = parseCAMTBalance(opening); err != nil {
+		return nil, fmt.Errorf("statement %s: opening balance: %w", statement.ID, err)
+	}
+	if statement.Closing, err = parseCAMTBalance(closing); err != nil {
+		return nil, fmt.Errorf("statement %s: closing balance: %w", statement.ID, err)
+	}
+
+	for i := range stmt.Entries {
+		entry := &stmt.Entries[i]
+		status := strings.ToUpper(strings.TrimSpace(firstNonEmpty(entry.Status.Code, entry.Status.Value)))
+		if status != "" && status != "BOOK" {
+			continue
+		}
+		line, err := parseCAMTEntry(entry)
+		if err != nil {
+			return nil, fmt.Errorf("statement %s: entry %d: %w", statement.ID, i+1, err)
+		}
+		statement.Lines = append(statement.Lines, line)
+	}
+
+	return statement, nil
+}
+
+func parseCAMTBalance(balance camtBalance) (Balance, error) {
+	negative, err := isDebit(balance.Indicator)
+	if err != nil {
+		return Balance{}, err
+	}
+	amount, err := parseAmount(balance.Amount.Value, ".", strings.TrimSpace(balance.Amount.Currency), negative)
+	if err != nil {
+		return Balance{}, err
+	}
+	date, err := balance.Date.parse()
+	if err != nil {
+		return Balance{}, err
+	}
+	return Balance{Date: date, Amount: amount}, nil
+}
+
+func parseCAMTEntry(entry *camtEntry) (*Line, error) {
+	negative, err := isDebit(entry.Indicator)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := parseAmount(entry.Amount.Value, ".", strings.TrimSpace(entry.Amount.Currency), negative)
+	if err != nil {
+		return nil, err
+	}
+
+	line := &Line{Amount: amount}
+	if line.BookingDate, err = entry.BookingDate.parse(); err != nil {
+		return nil, fmt.Errorf("booking date: %w", err)
+	}
+	line.ValueDate = line.BookingDate
+	if entry.ValueDate.Date != "" || entry.ValueDate.DateTime != "" {
+		if line.ValueDate, err = entry.ValueDate.parse(); err != nil {
+			return nil, fmt.Errorf("value date: %w", err)
+		}
+	}
+
+	line.Reference = firstNonEmpty(entry.ServicerRef, entry.Reference)
+	var remittance []string
+	for _, details := range entry.Details {
+		// En un débito la contraparte es el acreedor; en un crédito, el deudor
+		if negative {
+			line.Counterparty = firstNonEmpty(line.Counterparty, details.Creditor, details.CreditorPty)
+		} else {
+			line.Counterparty = firstNonEmpty(line.Counterparty, details.Debtor, details.DebtorPty)
+		}
+		if !strings.EqualFold(strings.TrimSpace(details.EndToEndID), "NOTPROVIDED") {
+			line.Reference = firstNonEmpty(line.Reference, details.ServicerRef, details.EndToEndID)
+		} else {
+			line.Reference = firstNonEmpty(line.Reference, details.ServicerRef)
+		}
+		for _, text := range details.Remittance {
+			if text = strings.TrimSpace(text); text != "" {
+				remittance = append(remittance, text)
+			}
+		}
+	}
+
+	line.Description = strings.Join(remittance, " ")
+	if line.Description == "" {
+		line.Description = strings.TrimSpace(entry.Info)
+	}
+	return line, nil
+}
+
+// parse interpreta la fecha ISO; de una fecha y hora solo conserva el día
+func (d camtDate) parse() (time.Time, error) {
+	value := strings.TrimSpace(d.Date)
+	if value == "" {
+		value = strings.TrimSpace(d.DateTime)
+		if len(value) >= 10 {
+			value = value[:10]
+		}
+	}
+	if value == "" {
+		return time.Time{}, errors.New("date is missing")
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
+
+// isDebit interpreta el indicador CRDT/DBIT
+func isDebit(indicator string) (bool, error) {
+	switch strings.ToUpper(strings.TrimSpace(indicator)) {
+	case "CRDT":
+		return false, nil
+	case "DBIT":
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid credit/debit indicator %q", indicator)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
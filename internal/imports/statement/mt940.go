@@ -0,0 +1,370 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrNotMT940 se retorna cuando el archivo no es un extracto MT940
+var ErrNotMT940 = errors.New("file is not an MT940 statement")
+
+// mt940Field es un campo ":TAG:valor", con sus líneas de continuación
+type mt940Field struct {
+	tag   string
+	value string
+}
+
+// ParseMT940 interpreta un archivo MT940 con uno o varios mensajes, con o
+// sin los bloques de encabezado SWIFT ({1:...}{2:...}{4:).
+func ParseMT940(data []byte) ([]*Statement, error) {
+	fields := splitMT940(decodeMT940(data))
+	if len(fields) == 0 {
+		return nil, ErrNotMT940
+	}
+
+	var statements []*Statement
+	var current *Statement
+	var line *Line
+	hasOpening, hasClosing := false, false
+
+	finish := func() error {
+		if current == nil {
+			return nil
+		}
+		if !hasOpening {
+			return fmt.Errorf("statement %s: opening balance is missing", current.ID)
+		}
+		if !hasClosing {
+			return fmt.Errorf("statement %s: closing balance is missing", current.ID)
+		}
+		statements = append(statements, current)
+		current, line = nil, nil
+		return nil
+	}
+
+	for _, field := range fields {
+		if field.tag == "20" {
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			current = &Statement{ID: firstLine(field.value)}
+			hasOpening, hasClosing = false, false
+			continue
+		}
+		if current == nil {
+			return nil, ErrNotMT940
+		}
+
+		switch field.tag {
+		case "25":
+			current.Account = firstLine(field.value)
+		case "60F", "60M":
+			balance, currency, err := parseMT940Balance(field.value)
+			if err != nil {
+				return nil, fmt.Errorf("statement %s: opening balance: %w", current.ID, err)
+			}
+			current.Opening, current.Currency, hasOpening = balance, currency, true
+		case "61":
+			if !hasOpening {
+				return nil, fmt.Errorf("statement %s: line before opening balance", current.ID)
+			}
+			parsed, err := parseMT940Line(field.value, current.Currency)
+			if err != nil {
+				return nil, fmt.Errorf("statement %s: line %d: %w", current.ID, len(current.Lines)+1, err)
+			}
+			line = parsed
+			current.Lines = append(current.Lines, line)
+		case "86":
+			// Solo el :86: que sigue a un :61: describe el movimiento
+			if line != nil {
+				applyMT940Details(line, field.value)
+				line = nil
+			}
+		case "62F", "62M":
+			balance, _, err := parseMT940Balance(field.value)
+			if err != nil {
+				return nil, fmt.Errorf("statement %s: closing balance: %w", current.ID, err)
+			}
+			current.Closing, hasClosing = balance, true
+			line = nil
+		default:
+			// :28C:, :64:, :65: y demás campos informativos
+			line = nil
+		}
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	if len(statements) == 0 {
+		return nil, ErrNotMT940
+	}
+	return statements, nil
+}
+
+// splitMT940 separa el texto en campos. Una línea que no empieza con ":TAG:"
+// continúa el campo anterior; "-" y los bloques {n:...} cierran mensajes.
+func splitMT940(text string) []mt940Field {
+	var fields []mt940Field
+	for _, raw := range strings.Split(text, "\n") {
+		raw = strings.TrimRight(raw, "\r ")
+		trimmed := strings.TrimSpace(raw)
+
+		// Encabezados SWIFT: lo que sigue a "{4:" en la misma línea es contenido
+		if strings.HasPrefix(trimmed, "{") {
+			index := strings.Index(trimmed, "{4:")
+			if index < 0 {
+				continue
+			}
+			trimmed = strings.TrimSpace(trimmed[index+3:])
+			raw = trimmed
+		}
+		if trimmed == "" || trimmed == "-" || strings.HasPrefix(trimmed, "-}") {
+			continue
+		}
+
+		if tag, value, ok := mt940Tag(trimmed); ok {
+			fields = append(fields, mt940Field{tag: tag, value: value})
+			continue
+		}
+		if len(fields) > 0 {
+			fields[len(fields)-1].value += "\n" + raw
+		}
+	}
+	return fields
+}
+
+// mt940Tag reconoce ":TAG:" al inicio de la línea: dos dígitos y una letra opcional
+func mt940Tag(line string) (string, string, bool) {
+	if len(line) < 4 || line[0] != ':' {
+		return "", "", false
+	}
+	end := strings.IndexByte(line[1:], ':')
+	if end < 2 || end > 3 {
+		return "", "", false
+	}
+	tag := line[1 : end+1]
+	if !isDigit(tag[0]) || !isDigit(tag[1]) || (len(tag) == 3 && !isUpper(tag[2])) {
+		return "", "", false
+	}
+	return tag, line[end+2:], true
+}
+
+// parseMT940Balance interpreta "C240131EUR1234,56"
+func parseMT940Balance(value string) (Balance, string, error) {
+	value = firstLine(value)
+	if len(value) < 11 {
+		return Balance{}, "", fmt.Errorf("invalid balance %q", value)
+	}
+
+	negative := false
+	switch value[0] {
+	case 'C':
+	case 'D':
+		negative = true
+	default:
+		return Balance{}, "", fmt.Errorf("invalid balance %q", value)
+	}
+
+	date, err := parseMT940Date(value[1:7])
+	if err != nil {
+		return Balance{}, "", err
+	}
+	currency := value[7:10]
+	amount, err := parseAmount(value[10:], ",", currency, negative)
+	if err != nil {
+		return Balance{}, "", err
+	}
+	return Balance{Date: date, Amount: amount}, currency, nil
+}
+
+// parseMT940Line interpreta un campo :61:
+//
+//	YYMMDD[MMDD](C|D|RC|RD)[fondos]monto tipo referencia[//ref banco]
+//	[detalles adicionales]
+func parseMT940Line(value, currency string) (*Line, error) {
+	first, supplementary, _ := strings.Cut(value, "\n")
+	s := strings.TrimSpace(first)
+	if len(s) < 6 {
+		return nil, fmt.Errorf("invalid line %q", s)
+	}
+
+	valueDate, err := parseMT940Date(s[:6])
+	if err != nil {
+		return nil, err
+	}
+	s = s[6:]
+
+	// Fecha contable opcional (MMDD), del mismo año que la fecha valor salvo
+	// en el cambio de año
+	bookingDate := valueDate
+	if len(s) >= 4 && isDigit(s[0]) && isDigit(s[1]) && isDigit(s[2]) && isDigit(s[3]) {
+		booking, err := time.Parse("20060102", fmt.Sprintf("%04d%s", valueDate.Year(), s[:4]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid booking date %q", s[:4])
+		}
+		switch {
+		case booking.Month() == time.December && valueDate.Month() == time.January:
+			booking = booking.AddDate(-1, 0, 0)
+		case booking.Month() == time.January && valueDate.Month() == time.December:
+			booking = booking.AddDate(1, 0, 0)
+		}
+		bookingDate = booking
+		s = s[4:]
+	}
+
+	// RC (reverso de crédito) resta y RD (reverso de débito) suma
+	var negative bool
+	switch {
+	case strings.HasPrefix(s, "RC"):
+		negative, s = true, s[2:]
+	case strings.HasPrefix(s, "RD"):
+		negative, s = false, s[2:]
+	case strings.HasPrefix(s, "C"):
+		negative, s = false, s[1:]
+	case strings.HasPrefix(s, "D"):
+		negative, s = true, s[1:]
+	default:
+		return nil, fmt.Errorf("invalid credit/debit mark in %q", first)
+	}
+
+	// Código de fondos: tercera letra de la moneda, opcional
+	if s != "" && isUpper(s[0]) {
+		s = s[1:]
+	}
+
+	end := 0
+	for end < len(s) && (isDigit(s[end]) || s[end] == ',') {
+		end++
+	}
+	amount, err := parseAmount(s[:end], ",", currency, negative)
+	if err != nil {
+		return nil, err
+	}
+	s = s[end:]
+
+	// Tipo de transacción: N, S o F más tres caracteres
+	if len(s) >= 4 {
+		s = s[4:]
+	}
+	customerRef, bankRef, _ := strings.Cut(s, "//")
+
+	line := &Line{
+		BookingDate: bookingDate,
+		ValueDate:   valueDate,
+		Amount:      amount,
+		Reference:   strings.TrimSpace(bankRef),
+		Description: strings.TrimSpace(supplementary),
+	}
+	if customerRef = strings.TrimSpace(customerRef); line.Reference == "" && !strings.EqualFold(customerRef, "NONREF") {
+		line.Reference = customerRef
+	}
+	return line, nil
+}
+
+// applyMT940Details interpreta el campo :86:. Los bancos usan dos variantes
+// estructuradas: subcampos "?NN" (formato alemán) y etiquetas "/NAME/",
+// "/REMI/" (formato SEPA); cualquier otro texto se toma como descripción.
+func applyMT940Details(line *Line, value string) {
+	text := strings.ReplaceAll(value, "\n", "")
+
+	switch {
+	case strings.Contains(text, "?20") || strings.Contains(text, "?32"):
+		subfields := make(map[string]string)
+		parts := strings.Split(text, "?")
+		for _, part := range parts[1:] {
+			if len(part) >= 2 {
+				subfields[part[:2]] += part[2:]
+			}
+		}
+		var purpose []string
+		for code := 20; code <= 29; code++ {
+			if text := strings.TrimSpace(subfields[fmt.Sprint(code)]); text != "" {
+				purpose = append(purpose, text)
+			}
+		}
+		for code := 60; code <= 63; code++ {
+			if text := strings.TrimSpace(subfields[fmt.Sprint(code)]); text != "" {
+				purpose = append(purpose, text)
+			}
+		}
+		line.Counterparty = strings.TrimSpace(subfields["32"] + subfields["33"])
+		line.Description = strings.Join(purpose, " ")
+	case strings.Contains(text, "/NAME/") || strings.Contains(text, "/REMI/"):
+		tags := parseSlashTags(text)
+		line.Counterparty = tags["NAME"]
+		line.Description = firstNonEmpty(tags["REMI"], line.Description)
+		if line.Reference == "" {
+			line.Reference = firstNonEmpty(tags["EREF"], tags["TRCD"])
+		}
+	default:
+		line.Description = firstNonEmpty(strings.Join(strings.Fields(text), " "), line.Description)
+	}
+}
+
+// slashTags son las etiquetas del :86: en formato SEPA que se reconocen
+var slashTags = map[string]bool{
+	"NAME": true, "REMI": true, "EREF": true, "TRCD": true, "IBAN": true,
+	"BIC": true, "CNTP": true, "MARF": true, "CSID": true, "PURP": true,
+	"ORDP": true, "BENM": true, "ADDR": true, "ID": true,
+}
+
+// parseSlashTags separa "/TAG/valor/TAG/valor". Una "/" que no precede a una
+// etiqueta conocida forma parte del valor.
+func parseSlashTags(text string) map[string]string {
+	tags := make(map[string]string)
+	tag := ""
+	for _, part := range strings.Split(text, "/") {
+		if slashTags[strings.TrimSpace(part)] {
+			tag = strings.TrimSpace(part)
+			continue
+		}
+		if tag == "" {
+			continue
+		}
+		if tags[tag] != "" {
+			tags[tag] += "/"
+		}
+		tags[tag] += strings.TrimSpace(part)
+	}
+	return tags
+}
+
+// parseMT940Date interpreta YYMMDD; los años se asumen 2000-2099
+func parseMT940Date(value string) (time.Time, error) {
+	date, err := time.Parse("060102", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	if date.Year() < 2000 {
+		date = date.AddDate(100, 0, 0)
+	}
+	return date, nil
+}
+
+// decodeMT940 acepta UTF-8 o, si el contenido no es UTF-8 válido, Latin-1
+func decodeMT940(data []byte) string {
+	if utf8.Valid(data) {
+		return strings.TrimPrefix(string(data), "\ufeff")
+	}
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+func firstLine(value string) string {
+	first, _, _ := strings.Cut(value, "\n")
+	return strings.TrimSpace(first)
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func isUpper(b byte) bool {
+	return b >= 'A' && b <= 'Z'
+}
@@ -0,0 +1,105 @@
+// Package statement interpreta extractos bancarios de fin de día en los
+// formatos ISO 20022 camt.053 (XML) y SWIFT MT940. Ambos se normalizan a
+// líneas con fecha contable, fecha valor, monto, contraparte y referencia,
+// más los saldos de apertura y cierre. No accede a la base de datos ni a la
+// red.
+package statement
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"finanzas-api/internal/imports/csvparser"
+	"finanzas-api/shared/money"
+)
+
+// Formatos soportados
+const (
+	FormatCAMT053 = "camt053"
+	FormatMT940   = "mt940"
+)
+
+// Statement es el extracto de una cuenta en un periodo, normalmente un día
+type Statement struct {
+	ID       string // Identificador del extracto asignado por el banco
+	Account  string // IBAN o número de cuenta
+	Currency string
+	Opening  Balance
+	Closing  Balance
+	Lines    []*Line
+}
+
+// Balance es un saldo reportado por el banco
+type Balance struct {
+	Date   time.Time
+	Amount money.Money // Con signo: negativo es un saldo deudor
+}
+
+// Line es un movimiento contabilizado en el extracto
+type Line struct {
+	BookingDate  time.Time
+	ValueDate    time.Time
+	Amount       money.Money // Con signo: negativo es un débito
+	Counterparty string
+	// Reference es la referencia del banco para el movimiento, si la hay
+	Reference   string
+	Description string
+}
+
+// Validate verifica que el saldo de cierre sea el de apertura más las líneas
+func (s *Statement) Validate() error {
+	if s.Opening.Amount.Currency != s.Currency || s.Closing.Amount.Currency != s.Currency {
+		return fmt.Errorf("statement %s: balances must be in %s", s.ID, s.Currency)
+	}
+
+	total := s.Opening.Amount
+	for _, line := range s.Lines {
+		next, err := total.Add(line.Amount)
+		if err != nil {
+			return fmt.Errorf("statement %s: %w", s.ID, err)
+		}
+		total = next
+	}
+
+	if !total.Equals(s.Closing.Amount) {
+		return fmt.Errorf("statement %s: closing balance %s does not equal opening balance %s plus lines, expected %s",
+			s.ID, s.Closing.Amount, s.Opening.Amount, total)
+	}
+	return nil
+}
+
+// parseAmount interpreta un monto sin signo con el separador decimal dado
+func parseAmount(value, decimalSeparator, currency string, negative bool) (money.Money, error) {
+	if !money.IsValidCurrency(currency) {
+		return money.Money{}, fmt.Errorf("invalid currency %q", currency)
+	}
+	amount, err := csvparser.ParseAmount(value, decimalSeparator, currency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if negative {
+		amount = -amount
+	}
+	return money.New(amount, currency), nil
+}
+
+// Detect deduce el formato del contenido: camt.053 es XML y MT940 es texto
+func Detect(data []byte) string {
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	if strings.HasPrefix(strings.TrimSpace(text), "<") {
+		return FormatCAMT053
+	}
+	return FormatMT940
+}
+
+// Parse interpreta el archivo en el formato indicado
+func Parse(format string, data []byte) ([]*Statement, error) {
+	switch format {
+	case FormatCAMT053:
+		return ParseCAMT053(data)
+	case FormatMT940:
+		return ParseMT940(data)
+	}
+	return nil, fmt.Errorf("unsupported statement format %q", format)
+}
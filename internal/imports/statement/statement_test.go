@@ -0,0 +1,286 @@
+package statement
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"finanzas-api/shared/money"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// assertStatements compara extracto por extracto y línea por línea
+func assertStatements(t *testing.T, got, want []*Statement) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d statements, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i].ID != want[i].ID || got[i].Account != want[i].Account || got[i].Currency != want[i].Currency {
+			t.Errorf("statement %d = %s %s %s, want %s %s %s", i,
+				got[i].ID, got[i].Account, got[i].Currency, want[i].ID, want[i].Account, want[i].Currency)
+		}
+		if got[i].Opening != want[i].Opening || got[i].Closing != want[i].Closing {
+			t.Errorf("statement %d balances = %+v to %+v, want %+v to %+v", i,
+				got[i].Opening, got[i].Closing, want[i].Opening, want[i].Closing)
+		}
+		if len(got[i].Lines) != len(want[i].Lines) {
+			t.Errorf("statement %d: got %d lines, want %d", i, len(got[i].Lines), len(want[i].Lines))
+			continue
+		}
+		for j, line := range got[i].Lines {
+			if !reflect.DeepEqual(line, want[i].Lines[j]) {
+				t.Errorf("statement %d, line %d = %+v, want %+v", i, j, line, want[i].Lines[j])
+			}
+		}
+		if err := got[i].Validate(); err != nil {
+			t.Errorf("statement %d: Validate() error = %v", i, err)
+		}
+	}
+}
+
+func TestParseCAMT053(t *testing.T) {
+	got, err := ParseCAMT053(readFixture(t, "camt053.xml"))
+	if err != nil {
+		t.Fatalf("ParseCAMT053() error = %v", err)
+	}
+	assertStatements(t, got, []*Statement{
+		{
+			ID:       "STMT-2024-03-15",
+			Account:  "DE89370400440532013000",
+			Currency: "EUR",
+			Opening:  Balance{Date: date(2024, 3, 14), Amount: money.New(100000, "EUR")},
+			Closing:  Balance{Date: date(2024, 3, 15), Amount: money.New(88050, "EUR")},
+			Lines: []*Line{
+				// El pendiente (PDNG) no se incluye
+				{BookingDate: date(2024, 3, 15), ValueDate: date(2024, 3, 16), Amount: money.New(-15000, "EUR"), Counterparty: "Stadtwerke", Reference: "SVC1", Description: "Strom Maerz"},
+				{BookingDate: date(2024, 3, 15), ValueDate: date(2024, 3, 15), Amount: money.New(3050, "EUR"), Counterparty: "Max Muster", Reference: "E2E-9", Description: "Gutschrift"},
+			},
+		},
+		{
+			// Sin moneda en la cuenta: se toma la del saldo de cierre
+			ID:       "S2",
+			Account:  "12345",
+			Currency: "USD",
+			Opening:  Balance{Date: date(2024, 3, 14), Amount: money.New(-5000, "USD")},
+			Closing:  Balance{Date: date(2024, 3, 15), Amount: money.New(-5000, "USD")},
+		},
+	})
+}
+
+func TestParseCAMT053Errors(t *testing.T) {
+	balance := func(code, amount, indicator string) string {
+		return `<Bal><Tp><CdOrPrtry><Cd>` + code + `</Cd></CdOrPrtry></Tp><Amt Ccy="EUR">` + amount +
+			`</Amt><CdtDbtInd>` + indicator + `</CdtDbtInd><Dt><Dt>2024-03-15</Dt></Dt></Bal>`
+	}
+	document := func(content string) string {
+		return `<Document><BkToCstmrStmt><Stmt><Id>S1</Id>` + content + `</Stmt></BkToCstmrStmt></Document>`
+	}
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "not XML", data: ":20:STMT1", wantErr: ErrNotCAMT.Error()},
+		{name: "no statements", data: `<Document><BkToCstmrStmt></BkToCstmrStmt></Document>`, wantErr: ErrNotCAMT.Error()},
+		{name: "missing opening balance", data: document(balance("CLBD", "1.00", "CRDT")), wantErr: "statement S1: opening balance is missing"},
+		{name: "missing closing balance", data: document(balance("OPBD", "1.00", "CRDT")), wantErr: "statement S1: closing balance is missing"},
+		{name: "invalid indicator", data: document(balance("OPBD", "1.00", "CR") + balance("CLBD", "1.00", "CRDT")), wantErr: `statement S1: opening balance: invalid credit/debit indicator "CR"`},
+		{
+			name: "entry without booking date",
+			data: document(balance("OPBD", "1.00", "CRDT") + balance("CLBD", "1.00", "CRDT") +
+				`<Ntry><Amt Ccy="EUR">1.00</Amt><CdtDbtInd>CRDT</CdtDbtInd></Ntry>`),
+			wantErr: "statement S1: entry 1: booking date: date is missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCAMT053([]byte(tt.data))
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("ParseCAMT053() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseMT940(t *testing.T) {
+	got, err := ParseMT940(readFixture(t, "mt940.sta"))
+	if err != nil {
+		t.Fatalf("ParseMT940() error = %v", err)
+	}
+	assertStatements(t, got, []*Statement{
+		{
+			ID:       "STMT1",
+			Account:  "DE89370400440532013000",
+			Currency: "EUR",
+			Opening:  Balance{Date: date(2024, 3, 14), Amount: money.New(100000, "EUR")},
+			Closing:  Balance{Date: date(2024, 3, 15), Amount: money.New(88050, "EUR")},
+			Lines: []*Line{
+				// :86: con subcampos ?NN y una línea de continuación
+				{BookingDate: date(2024, 3, 15), ValueDate: date(2024, 3, 15), Amount: money.New(-15000, "EUR"), Counterparty: "Stadtwerke GmbH", Reference: "BANKREF1", Description: "Strom Maerz"},
+				// Código de fondos (R) y :86: con etiquetas SEPA
+				{BookingDate: date(2024, 3, 15), ValueDate: date(2024, 3, 16), Amount: money.New(3050, "EUR"), Counterparty: "Max Muster", Reference: "E2E-9", Description: "Gutschrift"},
+			},
+		},
+		{
+			ID:       "STMT2",
+			Account:  "12345",
+			Currency: "USD",
+			Opening:  Balance{Date: date(2023, 12, 31), Amount: money.New(-5000, "USD")},
+			Closing:  Balance{Date: date(2024, 1, 1), Amount: money.New(-6000, "USD")},
+			Lines: []*Line{
+				// Fecha contable del año anterior a la fecha valor
+				{BookingDate: date(2023, 12, 31), ValueDate: date(2024, 1, 1), Amount: money.New(-1000, "USD"), Description: "Bank fee"},
+			},
+		},
+	})
+}
+
+func TestParseMT940Line(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    *Line
+		wantErr string
+	}{
+		{
+			value: "240315C10,NTRFREF1",
+			want:  &Line{BookingDate: date(2024, 3, 15), ValueDate: date(2024, 3, 15), Amount: money.New(1000, "EUR"), Reference: "REF1"},
+		},
+		{
+			// Reverso de crédito: resta
+			value: "240315RC5,25NTRFNONREF\nDETALLE",
+			want:  &Line{BookingDate: date(2024, 3, 15), ValueDate: date(2024, 3, 15), Amount: money.New(-525, "EUR"), Description: "DETALLE"},
+		},
+		{
+			// Reverso de débito: suma
+			value: "2412310102RD1,00NTRFNONREF",
+			want:  &Line{BookingDate: date(2025, 1, 2), ValueDate: date(2024, 12, 31), Amount: money.New(100, "EUR")},
+		},
+		{value: "2403", wantErr: `invalid line "2403"`},
+		{value: "240399C1,00NTRF", wantErr: `invalid date "240399"`},
+		{value: "240315X1,00NTRF", wantErr: `invalid credit/debit mark in "240315X1,00NTRF"`},
+		{value: "2403151399C1,00NTRF", wantErr: `invalid booking date "1399"`},
+	}
+	for _, tt := range tests {
+		got, err := parseMT940Line(tt.value, "EUR")
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("parseMT940Line(%q) error = %v, want %q", tt.value, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseMT940Line(%q) error = %v", tt.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseMT940Line(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseMT940Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "not MT940", data: "fecha,monto\n2024-03-15,10\n", wantErr: ErrNotMT940.Error()},
+		{name: "fields before :20:", data: ":25:12345\n:20:S1\n", wantErr: ErrNotMT940.Error()},
+		{name: "missing opening balance", data: ":20:S1\n:62F:C240315EUR1,00\n", wantErr: "statement S1: opening balance is missing"},
+		{name: "missing closing balance", data: ":20:S1\n:60F:C240315EUR1,00\n", wantErr: "statement S1: closing balance is missing"},
+		{name: "line before opening balance", data: ":20:S1\n:61:240315C1,00NTRF\n", wantErr: "statement S1: line before opening balance"},
+		{name: "invalid balance", data: ":20:S1\n:60F:X240315EUR1,00\n", wantErr: `statement S1: opening balance: invalid balance "X240315EUR1,00"`},
+		{name: "invalid currency", data: ":20:S1\n:60F:C240315ABC1,00\n", wantErr: `statement S1: opening balance: invalid currency "ABC"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseMT940([]byte(tt.data))
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("ParseMT940() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	statement := func(opening, closing int64, lines ...int64) *Statement {
+		s := &Statement{
+			ID:       "S1",
+			Currency: "EUR",
+			Opening:  Balance{Amount: money.New(opening, "EUR")},
+			Closing:  Balance{Amount: money.New(closing, "EUR")},
+		}
+		for _, amount := range lines {
+			s.Lines = append(s.Lines, &Line{Amount: money.New(amount, "EUR")})
+		}
+		return s
+	}
+	mixed := statement(0, 100, 100)
+	mixed.Lines[0].Amount = money.New(100, "USD")
+	otherCurrency := statement(0, 0)
+	otherCurrency.Closing.Amount = money.New(0, "USD")
+
+	tests := []struct {
+		name      string
+		statement *Statement
+		wantErr   bool
+	}{
+		{name: "balanced", statement: statement(1000, 850, -200, 50)},
+		{name: "no lines", statement: statement(1000, 1000)},
+		{name: "negative balances", statement: statement(-500, -700, -200)},
+		{name: "closing does not match", statement: statement(1000, 900, -200), wantErr: true},
+		{name: "line in another currency", statement: mixed, wantErr: true},
+		{name: "balance in another currency", statement: otherCurrency, wantErr: true},
+	}
+	for _, tt := range tests {
+		err := tt.statement.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestDetectAndParse(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{file: "camt053.xml", want: FormatCAMT053},
+		{file: "mt940.sta", want: FormatMT940},
+	}
+	for _, tt := range tests {
+		data := readFixture(t, tt.file)
+		if got := Detect(data); got != tt.want {
+			t.Errorf("Detect(%s) = %q, want %q", tt.file, got, tt.want)
+		}
+		if _, err := Parse(Detect(data), data); err != nil {
+			t.Errorf("Parse(%s) error = %v", tt.file, err)
+		}
+	}
+
+	if got := Detect([]byte("\ufeff  <Document/>")); got != FormatCAMT053 {
+		t.Errorf("Detect() with BOM = %q, want %q", got, FormatCAMT053)
+	}
+	if _, err := Parse("csv", nil); err == nil {
+		t.Error("Parse() with an unsupported format returned no error")
+	}
+	if _, err := Parse(FormatMT940, readFixture(t, "camt053.xml")); !errors.Is(err, ErrNotMT940) {
+		t.Errorf("Parse() error = %v, want ErrNotMT940", err)
+	}
+}
@@ -0,0 +1,626 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	"finanzas-api/internal/imports/csvparser"
+	"finanzas-api/internal/imports/domain"
+	"finanzas-api/internal/imports/ofx"
+	"finanzas-api/internal/imports/statement"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+const (
+	// MaxFileSize es el tamaño máximo aceptado para un archivo importado
+	MaxFileSize = 5 << 20
+	// maxDescriptionLength coincide con el límite de las transacciones
+	maxDescriptionLength = 255
+	// maxBatchesPerPage limita los lotes que retorna ListBatches
+	maxBatchesPerPage = 100
+)
+
+type ImportUseCase struct {
+	importRepo         domain.ImportRepository
+	accountRepo        accountDomain.AccountRepository
+	transactionUseCase transactionDomain.TransactionUseCase
+	duplicateUseCase   transactionDomain.DuplicateUseCase
+}
+
+// NewImportUseCase recibe el caso de uso de transacciones para validar las
+// filas con las mismas reglas que una transacción creada a mano, y el de
+// duplicados para no crear las que ya se registraron de otra forma.
+func NewImportUseCase(importRepo domain.ImportRepository, accountRepo accountDomain.AccountRepository, transactionUseCase transactionDomain.TransactionUseCase, duplicateUseCase transactionDomain.DuplicateUseCase) domain.ImportUseCase {
+	return &ImportUseCase{
+		importRepo:         importRepo,
+		accountRepo:        accountRepo,
+		transactionUseCase: transactionUseCase,
+		duplicateUseCase:   duplicateUseCase,
+	}
+}
+
+// CreateProfile implements domain.ImportUseCase.
+func (uc *ImportUseCase) CreateProfile(profile *domain.ImportProfile) error {
+	if err := uc.validateProfileData(profile); err != nil {
+		return err
+	}
+
+	return uc.importRepo.CreateProfile(profile)
+}
+
+// GetProfile implements domain.ImportUseCase.
+func (uc *ImportUseCase) GetProfile(userID, id uint) (*domain.ImportProfile, error) {
+	if id == 0 {
+		return nil, errors.New("invalid import profile ID")
+	}
+
+	profile, err := uc.importRepo.GetProfileByID(id)
+	if err != nil || !profile.BelongsTo(userID) {
+		return nil, errors.New("import profile not found")
+	}
+
+	return profile, nil
+}
+
+// UpdateProfile implements domain.ImportUseCase.
+func (uc *ImportUseCase) UpdateProfile(userID uint, profile *domain.ImportProfile) error {
+	if profile.ID == 0 {
+		return errors.New("import profile ID is required")
+	}
+
+	existing, err := uc.GetProfile(userID, profile.ID)
+	if err != nil {
+		return err
+	}
+	profile.UserID = existing.UserID
+
+	if err := uc.validateProfileData(profile); err != nil {
+		return err
+	}
+
+	return uc.importRepo.UpdateProfile(profile)
+}
+
+// DeleteProfile implements domain.ImportUseCase.
+func (uc *ImportUseCase) DeleteProfile(userID, id uint) error {
+	if _, err := uc.GetProfile(userID, id); err != nil {
+		return err
+	}
+
+	return uc.importRepo.DeleteProfile(id)
+}
+
+// ListProfiles implements domain.ImportUseCase.
+func (uc *ImportUseCase) ListProfiles(userID uint) ([]*domain.ImportProfile, error) {
+	return uc.importRepo.ListProfiles(userID)
+}
+
+// PreviewCSV implements domain.ImportUseCase.
+func (uc *ImportUseCase) PreviewCSV(userID, accountID, profileID uint, fileName string, data []byte) (*domain.ImportBatch, error) {
+	if len(data) == 0 {
+		return nil, errors.New("file is empty")
+	}
+	if len(data) > MaxFileSize {
+		return nil, fmt.Errorf("file exceeds %d MB", MaxFileSize>>20)
+	}
+
+	profile, err := uc.GetProfile(userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := uc.ownedAccount(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := csvparser.Parse(data, profile, account.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &domain.ImportBatch{
+		UserID:    userID,
+		AccountID: account.ID,
+		ProfileID: &profile.ID,
+		Format:    "csv",
+		FileName:  fileName,
+		Rows:      rows,
+	}
+	if err := uc.createBatch(batch); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// PreviewOFX implements domain.ImportUseCase.
+func (uc *ImportUseCase) PreviewOFX(userID, accountID uint, fileName string, data []byte) (*domain.ImportBatch, error) {
+	if len(data) == 0 {
+		return nil, errors.New("file is empty")
+	}
+	if len(data) > MaxFileSize {
+		return nil, fmt.Errorf("file exceeds %d MB", MaxFileSize>>20)
+	}
+
+	account, err := uc.ownedAccount(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, err := ofx.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(statements) > 1 {
+		return nil, fmt.Errorf("file contains %d statements, import one account at a time", len(statements))
+	}
+	statement := statements[0]
+	if statement.Currency != account.Currency {
+		return nil, fmt.Errorf("statement currency %s does not match account currency %s", statement.Currency, account.Currency)
+	}
+	if len(statement.Transactions) > csvparser.MaxRows {
+		return nil, fmt.Errorf("file exceeds %d rows", csvparser.MaxRows)
+	}
+
+	rows := make([]*domain.ImportRow, len(statement.Transactions))
+	for i, transaction := range statement.Transactions {
+		row := &domain.ImportRow{
+			LineNumber:  i + 1, // Posición del STMTTRN en el extracto
+			ExternalID:  transaction.FITID,
+			Description: transaction.Description(),
+			Raw: fmt.Sprintf("TRNTYPE=%s;FITID=%s;TRNAMT=%s;NAME=%s;MEMO=%s",
+				transaction.Type, transaction.FITID, transaction.Amount.Decimal(), transaction.Name, transaction.Memo),
+			Error: transaction.Error,
+		}
+		if len(row.Description) > maxDescriptionLength {
+			row.Description = csvparser.Truncate(row.Description, maxDescriptionLength)
+		}
+		rows[i] = row
+		if !row.IsValid() {
+			continue
+		}
+
+		posted := transaction.Posted
+		row.Date = &posted
+		row.Amount = transaction.Amount.Abs().Amount
+		row.Direction = transactionDomain.DirectionIncome
+		if transaction.Amount.IsNegative() {
+			row.Direction = transactionDomain.DirectionExpense
+		}
+	}
+
+	// Los FITID ya importados en la cuenta no se vuelven a importar
+	if err := uc.markDuplicates(userID, account.ID, rows, "FITID"); err != nil {
+		return nil, err
+	}
+
+	batch := &domain.ImportBatch{
+		UserID:    userID,
+		AccountID: account.ID,
+		Format:    "ofx",
+		FileName:  fileName,
+		Rows:      rows,
+	}
+	if balance := statement.LedgerBalance; balance != nil {
+		asOf := balance.AsOf
+		batch.StatementBalance = &balance.Amount.Amount
+		batch.StatementBalanceDate = &asOf
+		if err := uc.reconcile(batch); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.createBatch(batch); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// PreviewStatement implements domain.ImportUseCase.
+func (uc *ImportUseCase) PreviewStatement(userID, accountID uint, format, fileName string, data []byte) (*domain.ImportBatch, error) {
+	if len(data) == 0 {
+		return nil, errors.New("file is empty")
+	}
+	if len(data) > MaxFileSize {
+		return nil, fmt.Errorf("file exceeds %d MB", MaxFileSize>>20)
+	}
+
+	account, err := uc.ownedAccount(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = statement.Detect(data)
+	}
+	statements, err := statement.Parse(format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Un archivo puede traer varios días de la misma cuenta; cada extracto
+	// debe cuadrar por sí solo
+	var rows []*domain.ImportRow
+	var closing *statement.Balance
+	for _, stmt := range statements {
+		if stmt.Currency != account.Currency {
+			return nil, fmt.Errorf("statement currency %s does not match account currency %s", stmt.Currency, account.Currency)
+		}
+		if statements[0].Account != stmt.Account {
+			return nil, errors.New("file contains statements for several accounts, import one account at a time")
+		}
+		if err := stmt.Validate(); err != nil {
+			return nil, err
+		}
+		if len(rows)+len(stmt.Lines) > csvparser.MaxRows {
+			return nil, fmt.Errorf("file exceeds %d rows", csvparser.MaxRows)
+		}
+
+		for _, line := range stmt.Lines {
+			bookingDate, valueDate := line.BookingDate, line.ValueDate
+			row := &domain.ImportRow{
+				LineNumber:   len(rows) + 1, // Posición de la línea en el archivo
+				Date:         &bookingDate,
+				ValueDate:    &valueDate,
+				Amount:       line.Amount.Abs().Amount,
+				Direction:    transactionDomain.DirectionIncome,
+				Description:  line.Description,
+				Counterparty: line.Counterparty,
+				ExternalID:   line.Reference,
+				Raw: fmt.Sprintf("STMT=%s;BOOKED=%s;VALUE=%s;AMOUNT=%s;REF=%s;PARTY=%s",
+					stmt.ID, bookingDate.Format("2006-01-02"), valueDate.Format("2006-01-02"),
+					line.Amount.Decimal(), line.Reference, line.Counterparty),
+			}
+			if line.Amount.IsNegative() {
+				row.Direction = transactionDomain.DirectionExpense
+			}
+			if row.Description == "" {
+				row.Description = line.Counterparty
+			}
+			if len(row.Description) > maxDescriptionLength {
+				row.Description = csvparser.Truncate(row.Description, maxDescriptionLength)
+			}
+			if len(row.Counterparty) > maxDescriptionLength {
+				row.Counterparty = csvparser.Truncate(row.Counterparty, maxDescriptionLength)
+			}
+			if len(row.ExternalID) > maxDescriptionLength {
+				row.ExternalID = csvparser.Truncate(row.ExternalID, maxDescriptionLength)
+			}
+			if row.Amount == 0 {
+				row.Error = "amount is zero"
+			}
+			rows = append(rows, row)
+		}
+		if closing == nil || !stmt.Closing.Date.Before(closing.Date) {
+			closing = &stmt.Closing
+		}
+	}
+
+	// Las referencias ya importadas en la cuenta no se vuelven a importar
+	if err := uc.markDuplicates(userID, account.ID, rows, "reference"); err != nil {
+		return nil, err
+	}
+
+	asOf := closing.Date
+	batch := &domain.ImportBatch{
+		UserID:               userID,
+		AccountID:            account.ID,
+		Format:               format,
+		FileName:             fileName,
+		Rows:                 rows,
+		StatementBalance:     &closing.Amount.Amount,
+		StatementBalanceDate: &asOf,
+	}
+	if err := uc.reconcile(batch); err != nil {
+		return nil, err
+	}
+
+	if err := uc.createBatch(batch); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// GetBatch implements domain.ImportUseCase.
+func (uc *ImportUseCase) GetBatch(userID, id uint) (*domain.ImportBatch, error) {
+	if id == 0 {
+		return nil, errors.New("invalid import batch ID")
+	}
+
+	batch, err := uc.importRepo.GetBatchByID(id)
+	if err != nil || !batch.BelongsTo(userID) {
+		return nil, errors.New("import batch not found")
+	}
+
+	return batch, nil
+}
+
+// ListBatches implements domain.ImportUseCase.
+func (uc *ImportUseCase) ListBatches(userID uint, limit, offset int) ([]*domain.ImportBatch, error) {
+	if limit <= 0 || limit > maxBatchesPerPage {
+		limit = maxBatchesPerPage
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return uc.importRepo.ListBatches(userID, limit, offset)
+}
+
+// CommitBatch implements domain.ImportUseCase.
+func (uc *ImportUseCase) CommitBatch(userID, id uint) (*domain.ImportBatch, error) {
+	batch, err := uc.GetBatch(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if batch.Status != domain.BatchStatusPreview {
+		return nil, domain.ErrBatchNotPending
+	}
+
+	// La cuenta pudo eliminarse entre la vista previa y la confirmación
+	account, err := uc.ownedAccount(userID, batch.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make(map[uint]*transactionDomain.Transaction)
+	// Filas que parecen duplicadas: la revisión con su candidata
+	reviews := make(map[uint]*transactionDomain.DuplicateReview)
+	for _, row := range batch.Rows {
+		if !row.IsValid() {
+			continue
+		}
+		transaction := &transactionDomain.Transaction{
+			UserID:        userID,
+			AccountID:     account.ID,
+			Amount:        row.Amount,
+			Currency:      account.Currency,
+			Direction:     row.Direction,
+			Description:   row.Description,
+			Payee:         row.Counterparty,
+			Date:          *row.Date,
+			ImportBatchID: &batch.ID,
+		}
+		if row.ExternalID != "" {
+			externalID := row.ExternalID
+			transaction.ExternalID = &externalID
+		}
+		// Mismas validaciones y hooks que una transacción creada a mano
+		if err := uc.transactionUseCase.PrepareTransaction(transaction); err != nil {
+			return nil, fmt.Errorf("line %d: %w", row.LineNumber, err)
+		}
+
+		// Un movimiento que ya se registró a mano o desde otro extracto
+		// queda en revisión en lugar de crearse
+		match, err := uc.duplicateUseCase.FindDuplicate(transaction)
+		if err != nil {
+			return nil, err
+		}
+		if match != nil {
+			reviews[row.ID] = uc.duplicateUseCase.NewReview(transaction, match, transactionDomain.DuplicateSourceImport, &row.ID)
+			continue
+		}
+		transactions[row.ID] = transaction
+	}
+	if len(transactions) == 0 && len(reviews) == 0 {
+		return nil, errors.New("import batch has no valid rows")
+	}
+
+	// Las revisiones se guardan en la misma confirmación, para que una
+	// confirmación rechazada no las deje huérfanas ni una fallida sin ellas
+	if err := uc.importRepo.CommitBatch(batch, transactions, reviews); err != nil {
+		return nil, err
+	}
+	// Las filas que otra importación registró antes quedan sin transacción
+	for _, row := range batch.Rows {
+		if transaction, created := transactions[row.ID]; created && row.TransactionID != nil {
+			uc.transactionUseCase.NotifyCreated(transaction)
+		}
+	}
+
+	// Con las transacciones ya creadas se recalcula la diferencia real
+	if batch.StatementBalance != nil {
+		if err := uc.reconcile(batch); err != nil {
+			return nil, err
+		}
+		if err := uc.importRepo.UpdateBatch(batch); err != nil {
+			return nil, err
+		}
+	}
+	return batch, nil
+}
+
+// DiscardBatch implements domain.ImportUseCase.
+func (uc *ImportUseCase) DiscardBatch(userID, id uint) error {
+	if _, err := uc.GetBatch(userID, id); err != nil {
+		return err
+	}
+
+	return uc.importRepo.DiscardBatch(id)
+}
+
+// markDuplicates marca como error las filas válidas cuyo identificador
+// externo ya existe en la cuenta o se repite dentro del mismo archivo.
+// label nombra el identificador en el mensaje (FITID, reference).
+func (uc *ImportUseCase) markDuplicates(userID, accountID uint, rows []*domain.ImportRow, label string) error {
+	externalIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.IsValid() && row.ExternalID != "" {
+			externalIDs = append(externalIDs, row.ExternalID)
+		}
+	}
+	if len(externalIDs) == 0 {
+		return nil
+	}
+
+	existing, err := uc.importRepo.FindExternalIDs(userID, accountID, externalIDs)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(externalIDs))
+	for _, row := range rows {
+		if !row.IsValid() || row.ExternalID == "" {
+			continue
+		}
+		switch {
+		case existing[row.ExternalID] != 0:
+			row.Error = fmt.Sprintf("already imported as transaction %d", existing[row.ExternalID])
+		case seen[row.ExternalID]:
+			row.Error = fmt.Sprintf("duplicate %s in file", label)
+		}
+		seen[row.ExternalID] = true
+	}
+	return nil
+}
+
+// createBatch cuenta las filas del lote y lo guarda en vista previa
+func (uc *ImportUseCase) createBatch(batch *domain.ImportBatch) error {
+	batch.Status = domain.BatchStatusPreview
+	batch.TotalRows = len(batch.Rows)
+	for _, row := range batch.Rows {
+		if row.IsValid() {
+			batch.ValidRows++
+		} else {
+			batch.ErrorRows++
+		}
+	}
+
+	return uc.importRepo.CreateBatch(batch)
+}
+
+// reconcile compara el saldo del extracto con el de la cuenta en la misma
+// fecha. Mientras el lote está en vista previa, suma las filas válidas
+// hasta esa fecha como si ya se hubieran importado.
+func (uc *ImportUseCase) reconcile(batch *domain.ImportBatch) error {
+	asOf := *batch.StatementBalanceDate
+	account, err := uc.ownedAccount(batch.UserID, batch.AccountID)
+	if err != nil {
+		return err
+	}
+
+	// La transacción más reciente hasta la fecha trae el saldo acumulado
+	ledger := account.OpeningBalance
+	latest, err := uc.transactionUseCase.ListTransactions(context.Background(), batch.UserID, transactionDomain.TransactionFilter{
+		AccountID: &account.ID,
+		To:        &asOf,
+		Limit:     1,
+	})
+	if err != nil {
+		return err
+	}
+	if len(latest) > 0 {
+		ledger = latest[0].RunningBalance
+	}
+
+	if batch.Status != domain.BatchStatusCommitted {
+		for _, row := range batch.Rows {
+			if !row.IsValid() || row.Date.After(asOf) {
+				continue
+			}
+			if row.Direction == transactionDomain.DirectionIncome {
+				ledger += row.Amount
+			} else {
+				ledger -= row.Amount
+			}
+		}
+	}
+
+	difference := ledger - *batch.StatementBalance
+	batch.BalanceDifference = &difference
+	return nil
+}
+
+// validateProfileData normaliza y valida un perfil de importación
+func (uc *ImportUseCase) validateProfileData(profile *domain.ImportProfile) error {
+	if profile == nil {
+		return errors.New("import profile is required")
+	}
+
+	if profile.UserID == 0 {
+		return errors.New("user ID is required")
+	}
+
+	profile.Name = strings.TrimSpace(profile.Name)
+	if profile.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(profile.Name) > 100 {
+		return errors.New("name too long")
+	}
+
+	if profile.Delimiter == "" {
+		profile.Delimiter = ","
+	}
+	if profile.Delimiter == "\\t" {
+		profile.Delimiter = "\t"
+	}
+	if len(profile.Delimiter) != 1 || profile.Delimiter == "\"" || profile.Delimiter == "\n" || profile.Delimiter == "\r" {
+		return errors.New("delimiter must be a single character")
+	}
+
+	if profile.SkipRows < 0 {
+		return errors.New("skip_rows must not be negative")
+	}
+
+	if profile.Encoding == "" {
+		profile.Encoding = domain.EncodingUTF8
+	}
+	if profile.Encoding != domain.EncodingUTF8 && profile.Encoding != domain.EncodingLatin1 {
+		return errors.New("encoding must be utf-8 or latin-1")
+	}
+
+	if profile.DecimalSeparator == "" {
+		profile.DecimalSeparator = "."
+	}
+	if profile.DecimalSeparator != "." && profile.DecimalSeparator != "," {
+		return errors.New("decimal separator must be '.' or ','")
+	}
+	if profile.DecimalSeparator == profile.Delimiter {
+		return errors.New("decimal separator must differ from the delimiter")
+	}
+
+	format := strings.ToUpper(strings.TrimSpace(profile.DateFormat))
+	if !strings.Contains(format, "YY") || !strings.Contains(format, "MM") || !strings.Contains(format, "DD") {
+		return errors.New("date format must contain YYYY, MM and DD")
+	}
+	// El formato debe poder leer su propia salida
+	layout := csvparser.DateLayout(format)
+	sample := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	if parsed, err := time.Parse(layout, sample.Format(layout)); err != nil || !parsed.Equal(sample) {
+		return errors.New("invalid date format")
+	}
+	profile.DateFormat = format
+
+	profile.DateColumn = strings.TrimSpace(profile.DateColumn)
+	profile.DescriptionColumn = strings.TrimSpace(profile.DescriptionColumn)
+	profile.AmountColumn = strings.TrimSpace(profile.AmountColumn)
+	profile.DebitColumn = strings.TrimSpace(profile.DebitColumn)
+	profile.CreditColumn = strings.TrimSpace(profile.CreditColumn)
+
+	if profile.DateColumn == "" {
+		return errors.New("date column is required")
+	}
+	if profile.AmountColumn == "" && (profile.DebitColumn == "" || profile.CreditColumn == "") {
+		return errors.New("amount column or both debit and credit columns are required")
+	}
+	if profile.AmountColumn != "" && (profile.DebitColumn != "" || profile.CreditColumn != "") {
+		return errors.New("use either an amount column or debit and credit columns, not both")
+	}
+
+	return nil
+}
+
+// ownedAccount obtiene la cuenta verificando que pertenezca al usuario
+func (uc *ImportUseCase) ownedAccount(userID, accountID uint) (*accountDomain.Account, error) {
+	account, err := uc.accountRepo.GetByID(accountID)
+	if err != nil || !account.BelongsTo(userID) {
+		return nil, errors.New("account not found")
+	}
+	return account, nil
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	accountRepository "finanzas-api/internal/accounts/repository"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	categoryRepository "finanzas-api/internal/categories/repository"
+	"finanzas-api/internal/imports/domain"
+	"finanzas-api/internal/imports/repository"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	transactionRepository "finanzas-api/internal/transactions/repository"
+	transactionUseCase "finanzas-api/internal/transactions/usecase"
+)
+
+// testImporter agrupa el caso de uso de importaciones sobre repositorios en
+// memoria y lo que registran sus hooks
+type testImporter struct {
+	uc           domain.ImportUseCase
+	transactions transactionDomain.TransactionRepository
+	reviews      transactionDomain.DuplicateReviewRepository
+	notified     []*transactionDomain.Transaction
+}
+
+// newTestImporter crea una cuenta en COP del usuario 1 y las categorías
+// 1 (gasto del usuario 1), 2 (gasto del usuario 2) y 3 (ingreso del
+// usuario 1). El hook previo asigna categoryID a cada transacción nueva.
+func newTestImporter(t *testing.T, categoryID *uint) *testImporter {
+	t.Helper()
+	accounts := accountRepository.NewAccountMemoryRepository()
+	if err := accounts.Create(&accountDomain.Account{UserID: 1, Name: "Banco", Type: accountDomain.AccountTypeBank, Currency: "COP"}); err != nil {
+		t.Fatal(err)
+	}
+	categories := categoryRepository.NewCategoryMemoryRepository()
+	for _, category := range []*categoryDomain.Category{
+		{UserID: 1, Name: "Mercado", Kind: categoryDomain.KindExpense},
+		{UserID: 2, Name: "Ajena", Kind: categoryDomain.KindExpense},
+		{UserID: 1, Name: "Salario", Kind: categoryDomain.KindIncome},
+	} {
+		if err := categories.Create(category); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	importer := &testImporter{transactions: transactionRepository.NewTransactionMemoryRepository()}
+	beforeCreate := func(transaction *transactionDomain.Transaction) error {
+		transaction.CategoryID = categoryID
+		return nil
+	}
+	categoryChange := func(before, after *transactionDomain.Transaction) error {
+		importer.notified = append(importer.notified, after)
+		return nil
+	}
+	transactions := transactionUseCase.NewTransactionUseCase(importer.transactions, accounts, categories,
+		transactionRepository.NewTagMemoryRepository(importer.transactions), beforeCreate, categoryChange)
+	importer.reviews = transactionRepository.NewDuplicateReviewMemoryRepository()
+	duplicates := transactionUseCase.NewDuplicateUseCase(importer.reviews, importer.transactions, accounts, transactions)
+	importer.uc = NewImportUseCase(repository.NewImportMemoryRepository(importer.transactions, importer.reviews), accounts, transactions, duplicates)
+	return importer
+}
+
+func TestCommitBatchValidatesLikeCreateTransaction(t *testing.T) {
+	id := func(id uint) *uint { return &id }
+	tests := []struct {
+		name       string
+		categoryID *uint
+		wantErr    string
+		wantCount  int
+	}{
+		{name: "uncategorized", wantCount: 2},
+		{name: "category assigned by a hook", categoryID: id(1), wantCount: 2},
+		{name: "category of another user", categoryID: id(2), wantErr: "line 2: category not found"},
+		{name: "category of the wrong kind", categoryID: id(3), wantErr: "line 2: category kind does not match transaction direction"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importer := newTestImporter(t, tt.categoryID)
+			profile := &domain.ImportProfile{UserID: 1, Name: "Banco", HasHeader: true, DateFormat: "YYYY-MM-DD", DateColumn: "date", DescriptionColumn: "description", AmountColumn: "amount"}
+			if err := importer.uc.CreateProfile(profile); err != nil {
+				t.Fatal(err)
+			}
+			batch, err := importer.uc.PreviewCSV(1, 1, profile.ID, "extracto.csv",
+				[]byte("date,description,amount\n2024-03-01,Mercado,-120.00\n2024-03-02,Farmacia,-35.50\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = importer.uc.CommitBatch(1, batch.ID)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("CommitBatch() error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("CommitBatch() error = %v", err)
+			}
+
+			created, err := importer.transactions.List(1, transactionDomain.TransactionFilter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(created) != tt.wantCount {
+				t.Fatalf("got %d transactions, want %d", len(created), tt.wantCount)
+			}
+
+			// Solo las categorizadas avisan al hook de cambio de categoría
+			wantNotified := 0
+			if tt.categoryID != nil && tt.wantErr == "" {
+				wantNotified = tt.wantCount
+			}
+			if len(importer.notified) != wantNotified {
+				t.Errorf("category change hook called %d times, want %d", len(importer.notified), wantNotified)
+			}
+			for _, transaction := range importer.notified {
+				if transaction.ID == 0 {
+					t.Error("category change hook received an unsaved transaction")
+				}
+			}
+		})
+	}
+}
+
+func TestCommitBatchFlagsDuplicatesWithTheCommit(t *testing.T) {
+	importer := newTestImporter(t, nil)
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	existing := &transactionDomain.Transaction{UserID: 1, AccountID: 1, Amount: 12000, Currency: "COP", Direction: transactionDomain.DirectionExpense, Description: "Mercado", Date: date}
+	if err := importer.transactions.Create(existing); err != nil {
+		t.Fatal(err)
+	}
+
+	profile := &domain.ImportProfile{UserID: 1, Name: "Banco", HasHeader: true, DateFormat: "YYYY-MM-DD", DateColumn: "date", DescriptionColumn: "description", AmountColumn: "amount"}
+	if err := importer.uc.CreateProfile(profile); err != nil {
+		t.Fatal(err)
+	}
+	batch, err := importer.uc.PreviewCSV(1, 1, profile.ID, "extracto.csv",
+		[]byte("date,description,amount\n2024-03-01,Mercado,-120.00\n2024-03-02,Farmacia,-35.50\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batch, err = importer.uc.CommitBatch(1, batch.ID)
+	if err != nil {
+		t.Fatalf("CommitBatch() error = %v", err)
+	}
+	if batch.FlaggedRows != 1 {
+		t.Errorf("FlaggedRows = %d, want 1", batch.FlaggedRows)
+	}
+
+	reviews, err := importer.reviews.ListByUser(1, transactionDomain.ReviewStatusPending, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reviews) != 1 || reviews[0].MatchID != existing.ID {
+		t.Fatalf("got %d pending reviews, want 1 matching transaction %d", len(reviews), existing.ID)
+	}
+
+	stored, err := importer.uc.GetBatch(1, batch.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range stored.Rows {
+		flagged := row.DuplicateReviewID != nil
+		if flagged != (row.Description == "Mercado") {
+			t.Errorf("row %q: DuplicateReviewID = %v", row.Description, row.DuplicateReviewID)
+		}
+		if flagged && *row.DuplicateReviewID != reviews[0].ID {
+			t.Errorf("row %q linked to review %d, want %d", row.Description, *row.DuplicateReviewID, reviews[0].ID)
+		}
+		if flagged == (row.TransactionID != nil) {
+			t.Errorf("row %q: TransactionID = %v", row.Description, row.TransactionID)
+		}
+	}
+
+	created, err := importer.transactions.List(1, transactionDomain.TransactionFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 2 {
+		t.Errorf("got %d transactions, want 2", len(created))
+	}
+}
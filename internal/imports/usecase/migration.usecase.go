@@ -0,0 +1,630 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/imports/csvparser"
+	"finanzas-api/internal/imports/domain"
+	"finanzas-api/internal/imports/migration"
+	ledgerDomain "finanzas-api/internal/ledger/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	"finanzas-api/shared/money"
+)
+
+// parseFunc lee el archivo de una aplicación de origen
+type parseFunc func(data []byte, options migration.Options) (*migration.Data, error)
+
+// MigrationImporter aplica al usuario los datos de una aplicación de
+// origen. Todos los formatos comparten la forma de combinar cuentas,
+// categorías y transferencias; solo cambia la lectura del archivo.
+type MigrationImporter struct {
+	format             string
+	parse              parseFunc
+	accountRepo        accountDomain.AccountRepository
+	accountUseCase     accountDomain.AccountUseCase
+	categoryUseCase    categoryDomain.CategoryUseCase
+	transactionUseCase transactionDomain.TransactionUseCase
+	ledgerUseCase      ledgerDomain.LedgerUseCase
+	importRepo         domain.ImportRepository
+	currencies         domain.CurrencyResolver
+}
+
+// NewMigrationImporters retorna un importador por cada formato soportado:
+// qif, firefly y ynab.
+func NewMigrationImporters(
+	importRepo domain.ImportRepository,
+	accountRepo accountDomain.AccountRepository,
+	accountUseCase accountDomain.AccountUseCase,
+	categoryUseCase categoryDomain.CategoryUseCase,
+	transactionUseCase transactionDomain.TransactionUseCase,
+	ledgerUseCase ledgerDomain.LedgerUseCase,
+	currencies domain.CurrencyResolver,
+) []domain.Importer {
+	sources := []struct {
+		format string
+		parse  parseFunc
+	}{
+		{format: "qif", parse: migration.ParseQIF},
+		{format: "firefly", parse: migration.ParseFirefly},
+		{format: "ynab", parse: migration.ParseYNAB},
+	}
+
+	importers := make([]domain.Importer, len(sources))
+	for i, source := range sources {
+		importers[i] = &MigrationImporter{
+			format:             source.format,
+			parse:              source.parse,
+			accountRepo:        accountRepo,
+			accountUseCase:     accountUseCase,
+			categoryUseCase:    categoryUseCase,
+			transactionUseCase: transactionUseCase,
+			ledgerUseCase:      ledgerUseCase,
+			importRepo:         importRepo,
+			currencies:         currencies,
+		}
+	}
+	return importers
+}
+
+// Format implements domain.Importer.
+func (im *MigrationImporter) Format() string {
+	return im.format
+}
+
+// Import implements domain.Importer. Las escrituras no comparten una
+// transacción de base de datos: si falla a mitad, volver a importar el
+// archivo completa lo que faltó sin duplicar lo ya creado.
+func (im *MigrationImporter) Import(userID uint, data []byte, options domain.MigrationOptions) (*domain.MigrationReport, error) {
+	if len(data) == 0 {
+		return nil, errors.New("file is empty")
+	}
+	if len(data) > MaxFileSize {
+		return nil, fmt.Errorf("file exceeds %d MB", MaxFileSize>>20)
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(options.Currency))
+	if currency == "" {
+		base, err := im.currencies.BaseCurrency(context.Background(), userID)
+		if err != nil {
+			return nil, err
+		}
+		currency = base
+	}
+	if !money.IsValidCurrency(currency) {
+		return nil, errors.New("invalid currency")
+	}
+
+	parsed, err := im.parse(data, migration.Options{
+		DateFormat:       options.DateFormat,
+		DecimalSeparator: options.DecimalSeparator,
+		AccountName:      strings.TrimSpace(options.AccountName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed.Transactions) > csvparser.MaxRows*4 {
+		return nil, fmt.Errorf("file exceeds %d transactions", csvparser.MaxRows*4)
+	}
+
+	run := &migrationRun{
+		importer:    im,
+		userID:      userID,
+		dryRun:      options.DryRun,
+		currency:    currency,
+		data:        parsed,
+		report:      &domain.MigrationReport{Format: im.format, DryRun: options.DryRun, Items: []*domain.MigrationItem{}},
+		accounts:    make(map[string]*accountDomain.Account),
+		merged:      make(map[string]bool),
+		skipReasons: make(map[string]string),
+		categories:  make(map[string]*categoryDomain.Category),
+		payees:      make(map[string]bool),
+		sides:       make(map[transferKey]map[string]int),
+		transfers:   make(map[transferKey]int),
+	}
+	if err := run.applyAccounts(); err != nil {
+		return nil, err
+	}
+	if err := run.applyTransactions(); err != nil {
+		return nil, err
+	}
+
+	run.report.Payees = len(run.payees)
+	return run.report, nil
+}
+
+// transferKey identifica una transferencia. Cuando la fuente exporta ambos
+// lados (QIF, YNAB), los dos registros producen la misma clave.
+type transferKey struct {
+	date   time.Time
+	amount int64
+	from   string
+	to     string
+}
+
+// pendingTransaction es un movimiento ya resuelto, listo para crearse
+type pendingTransaction struct {
+	source     *migration.Transaction
+	account    *accountDomain.Account
+	amount     int64 // Con signo, en unidades menores de la cuenta
+	externalID string
+}
+
+// migrationRun guarda el estado de una importación
+type migrationRun struct {
+	importer *MigrationImporter
+	userID   uint
+	dryRun   bool
+	currency string
+	data     *migration.Data
+	report   *domain.MigrationReport
+
+	// Por nombre de cuenta en minúsculas. En simulación las cuentas nuevas
+	// quedan sin ID.
+	accounts    map[string]*accountDomain.Account
+	merged      map[string]bool   // Cuentas que ya existían
+	skipReasons map[string]string // Cuentas que no se pudieron importar
+	// Por tipo y ruta en minúsculas ("expense:hogar:luz")
+	categories map[string]*categoryDomain.Category
+	// Categorías que ya existían y aún no se reportan como combinadas
+	existingCategories map[string]bool
+	payees             map[string]bool
+	// Lados ya vistos de cada transferencia, por cuenta que los reporta
+	sides map[transferKey]map[string]int
+	// Transferencias iguales procesadas, para distinguirlas de las existentes
+	transfers map[transferKey]int
+}
+
+// applyAccounts combina las cuentas de origen con las del usuario por
+// nombre y crea las que faltan con su saldo inicial
+func (r *migrationRun) applyAccounts() error {
+	existing, err := r.importer.accountRepo.ListByUser(r.userID, true, 0, 0)
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]*accountDomain.Account, len(existing))
+	for _, account := range existing {
+		byName[strings.ToLower(account.Name)] = account
+	}
+
+	// Los saldos iniciales se suman antes de crear la cuenta
+	openings := make(map[string]int64)
+	for _, source := range r.data.Transactions {
+		if !source.OpeningBalance || source.Error != "" {
+			continue
+		}
+		key := strings.ToLower(source.Account)
+		if byName[key] != nil {
+			continue
+		}
+		amount, err := csvparser.ParseAmount(source.Amount, r.data.DecimalSeparator, r.accountCurrency(source.Account))
+		if err != nil {
+			source.Error = err.Error()
+			continue
+		}
+		openings[key] += amount
+	}
+
+	for _, source := range r.data.Accounts {
+		key := strings.ToLower(source.Name)
+		currency := source.Currency
+		if currency == "" {
+			currency = r.currency
+		}
+
+		if account, exists := byName[key]; exists {
+			if source.Currency != "" && account.Currency != source.Currency {
+				reason := fmt.Sprintf("existing account uses %s, file uses %s", account.Currency, source.Currency)
+				r.skipReasons[key] = reason
+				r.report.Accounts.Skipped++
+				r.report.AddItem(&domain.MigrationItem{Entity: "account", Action: domain.MigrationSkip, Name: source.Name, Reason: reason})
+				continue
+			}
+			r.accounts[key] = account
+			r.merged[key] = true
+			r.report.Accounts.Merged++
+			r.report.AddItem(&domain.MigrationItem{Entity: "account", Action: domain.MigrationMerge, Name: source.Name})
+			continue
+		}
+
+		account := &accountDomain.Account{
+			UserID:         r.userID,
+			Name:           source.Name,
+			Type:           source.Type,
+			Currency:       currency,
+			OpeningBalance: openings[key],
+		}
+		if err := r.createAccount(account); err != nil {
+			r.skipReasons[key] = err.Error()
+			r.report.Accounts.Skipped++
+			r.report.AddItem(&domain.MigrationItem{Entity: "account", Action: domain.MigrationSkip, Name: source.Name, Reason: err.Error()})
+			continue
+		}
+		r.accounts[key] = account
+		r.report.Accounts.Created++
+		r.report.AddItem(&domain.MigrationItem{Entity: "account", Action: domain.MigrationCreate, Name: source.Name})
+	}
+	return nil
+}
+
+// applyTransactions crea los movimientos y transferencias. Los que ya se
+// importaron en una ejecución anterior se omiten.
+func (r *migrationRun) applyTransactions() error {
+	var pending []*pendingTransaction
+	occurrences := make(map[string]int)
+	for _, source := range r.data.Transactions {
+		if source.OpeningBalance {
+			// Los saldos de cuentas nuevas ya quedaron en la cuenta
+			key := strings.ToLower(source.Account)
+			switch {
+			case source.Error != "":
+				r.skip(source, source.Error)
+			case r.merged[key]:
+				r.skip(source, "opening balance of an existing account")
+			case r.accounts[key] == nil:
+				r.skip(source, r.accountSkipReason(key))
+			}
+			continue
+		}
+		if source.Error != "" {
+			r.skip(source, source.Error)
+			continue
+		}
+
+		key := strings.ToLower(source.Account)
+		account := r.accounts[key]
+		if account == nil {
+			r.skip(source, r.accountSkipReason(key))
+			continue
+		}
+		if source.Currency != "" && source.Currency != account.Currency {
+			r.skip(source, fmt.Sprintf("currency %s does not match account currency %s", source.Currency, account.Currency))
+			continue
+		}
+		amount, err := csvparser.ParseAmount(source.Amount, r.data.DecimalSeparator, account.Currency)
+		if err != nil {
+			r.skip(source, err.Error())
+			continue
+		}
+		if amount == 0 {
+			r.skip(source, "amount must not be zero")
+			continue
+		}
+
+		if source.IsTransfer() {
+			if err := r.applyTransfer(source, account, amount); err != nil {
+				return err
+			}
+			continue
+		}
+
+		item := &pendingTransaction{source: source, account: account, amount: amount, externalID: source.ExternalID}
+		if item.externalID == "" {
+			// Sin identificador en la fuente se usa un hash del contenido; el
+			// contador distingue movimientos idénticos del mismo archivo
+			fingerprint := fmt.Sprintf("%s|%s|%d|%s|%s|%s", key, source.Date.Format("2006-01-02"), amount,
+				source.Payee, source.Memo, strings.Join(source.Category, ":"))
+			occurrences[fingerprint]++
+			sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", fingerprint, occurrences[fingerprint])))
+			item.externalID = r.importer.format + ":" + hex.EncodeToString(sum[:12])
+		}
+		pending = append(pending, item)
+	}
+
+	imported, err := r.importedExternalIDs(pending)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range pending {
+		if imported[item.account.ID][item.externalID] {
+			r.skip(item.source, "already imported")
+			continue
+		}
+		if err := r.createTransaction(item); err != nil {
+			r.skip(item.source, err.Error())
+		}
+	}
+	return nil
+}
+
+// importedExternalIDs consulta, por cuenta existente, qué identificadores
+// ya tienen una transacción
+func (r *migrationRun) importedExternalIDs(pending []*pendingTransaction) (map[uint]map[string]bool, error) {
+	byAccount := make(map[uint][]string)
+	for _, item := range pending {
+		if item.account.ID != 0 {
+			byAccount[item.account.ID] = append(byAccount[item.account.ID], item.externalID)
+		}
+	}
+
+	imported := make(map[uint]map[string]bool, len(byAccount))
+	for accountID, externalIDs := range byAccount {
+		found, err := r.importer.importRepo.FindExternalIDs(r.userID, accountID, externalIDs)
+		if err != nil {
+			return nil, err
+		}
+		imported[accountID] = make(map[string]bool, len(found))
+		for externalID := range found {
+			imported[accountID][externalID] = true
+		}
+	}
+	return imported, nil
+}
+
+// createTransaction crea un movimiento con su categoría y payee
+func (r *migrationRun) createTransaction(item *pendingTransaction) error {
+	source := item.source
+	direction := transactionDomain.DirectionIncome
+	amount := item.amount
+	if amount < 0 {
+		direction = transactionDomain.DirectionExpense
+		amount = -amount
+	}
+
+	externalID := item.externalID
+	transaction := &transactionDomain.Transaction{
+		UserID:      r.userID,
+		AccountID:   item.account.ID,
+		Amount:      amount,
+		Direction:   direction,
+		Date:        source.Date,
+		Description: source.Memo,
+		Payee:       source.Payee,
+		ExternalID:  &externalID,
+	}
+
+	if len(source.Category) > 0 {
+		category, err := r.category(source.Category, categoryDomain.Kind(direction))
+		if err != nil {
+			return err
+		}
+		if category.ID != 0 {
+			transaction.CategoryID = &category.ID
+		}
+	}
+
+	if r.dryRun {
+		// Las cuentas por crear aún no tienen ID; se valida el resto
+		check := *transaction
+		if check.AccountID == 0 {
+			check.AccountID = ^uint(0)
+		}
+		if err := r.importer.transactionUseCase.ValidateTransactionData(&check); err != nil {
+			return err
+		}
+	} else if err := r.importer.transactionUseCase.CreateTransaction(transaction); err != nil {
+		return err
+	}
+
+	r.report.Transactions.Created++
+	if source.Payee != "" {
+		r.payees[strings.ToLower(source.Payee)] = true
+	}
+	return nil
+}
+
+// applyTransfer registra una transferencia en el libro mayor. Si la fuente
+// ya reportó el otro lado, este registro se combina con aquel.
+func (r *migrationRun) applyTransfer(source *migration.Transaction, account *accountDomain.Account, amount int64) error {
+	otherKey := strings.ToLower(source.TransferAccount)
+	key := transferKey{date: source.Date, amount: amount, from: strings.ToLower(source.Account), to: otherKey}
+	if amount < 0 {
+		key.amount = -amount
+	} else {
+		key.from, key.to = key.to, key.from
+	}
+
+	if r.sides[key] == nil {
+		r.sides[key] = make(map[string]int)
+	}
+	reporter := strings.ToLower(source.Account)
+	if r.sides[key][otherKey] > 0 {
+		r.sides[key][otherKey]--
+		r.report.Transfers.Merged++
+		return nil
+	}
+	r.sides[key][reporter]++
+	r.transfers[key]++
+
+	other := r.accounts[otherKey]
+	if other == nil {
+		r.skipTransfer(source, r.accountSkipReason(otherKey))
+		return nil
+	}
+	if other.Currency != account.Currency {
+		r.skipTransfer(source, "cross-currency transfers are not supported")
+		return nil
+	}
+
+	from, to := account, other
+	if amount > 0 {
+		from, to = other, account
+	}
+
+	// Una transferencia igual en la cuenta de origen indica una importación anterior
+	if from.ID != 0 && to.ID != 0 {
+		exists, err := r.transferExists(from.ID, key)
+		if err != nil {
+			return err
+		}
+		if exists {
+			r.skipTransfer(source, "already imported")
+			return nil
+		}
+	}
+
+	if !r.dryRun {
+		description := source.Memo
+		if description == "" {
+			description = source.Payee
+		}
+		if _, err := r.importer.ledgerUseCase.Transfer(r.userID, from.ID, to.ID, key.amount, source.Date, description); err != nil {
+			r.skipTransfer(source, err.Error())
+			return nil
+		}
+	}
+	r.report.Transfers.Created++
+	return nil
+}
+
+// transferExists busca en la cuenta de origen salidas por transferencia de
+// la misma fecha y monto que no se hayan asociado ya a otro registro
+func (r *migrationRun) transferExists(fromID uint, key transferKey) (bool, error) {
+	amount := key.amount
+	legs, err := r.importer.transactionUseCase.ListTransactions(context.Background(), r.userID, transactionDomain.TransactionFilter{
+		AccountID: &fromID,
+		From:      &key.date,
+		To:        &key.date,
+		MinAmount: &amount,
+		MaxAmount: &amount,
+		Limit:     500,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	matches := 0
+	for _, leg := range legs {
+		if leg.IsTransfer && leg.Direction == transactionDomain.DirectionExpense {
+			matches++
+		}
+	}
+	// Las transferencias iguales ya procesadas en esta ejecución consumen
+	// las coincidencias anteriores
+	return matches >= r.transfers[key], nil
+}
+
+// category resuelve la ruta de categorías del tipo indicado, combinando con
+// las existentes por nombre y creando las que faltan
+func (r *migrationRun) category(path []string, kind categoryDomain.Kind) (*categoryDomain.Category, error) {
+	if r.existingCategories == nil {
+		if err := r.loadCategories(); err != nil {
+			return nil, err
+		}
+	}
+
+	var parent *categoryDomain.Category
+	key := string(kind)
+	for i, name := range path {
+		key += ":" + strings.ToLower(name)
+		category, exists := r.categories[key]
+		if !exists {
+			category = &categoryDomain.Category{UserID: r.userID, Name: name, Kind: kind}
+			if parent != nil && parent.ID != 0 {
+				category.ParentID = &parent.ID
+			}
+			if r.dryRun {
+				if err := r.importer.categoryUseCase.ValidateCategoryData(category); err != nil {
+					return nil, err
+				}
+			} else if err := r.importer.categoryUseCase.CreateCategory(category); err != nil {
+				return nil, err
+			}
+
+			r.categories[key] = category
+			r.report.Categories.Created++
+			r.report.AddItem(&domain.MigrationItem{Entity: "category", Action: domain.MigrationCreate, Name: categoryName(path[:i+1], kind)})
+		} else if r.existingCategories[key] {
+			// Se reporta una sola vez cada categoría existente que se usa
+			r.existingCategories[key] = false
+			r.report.Categories.Merged++
+			r.report.AddItem(&domain.MigrationItem{Entity: "category", Action: domain.MigrationMerge, Name: categoryName(path[:i+1], kind)})
+		}
+		parent = category
+	}
+	return parent, nil
+}
+
+// loadCategories indexa las categorías del usuario por tipo y ruta
+func (r *migrationRun) loadCategories() error {
+	categories, err := r.importer.categoryUseCase.ListCategories(r.userID)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uint]*categoryDomain.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	r.existingCategories = make(map[string]bool, len(categories))
+	for _, category := range categories {
+		path := []string{strings.ToLower(category.Name)}
+		current := category
+		for steps := 0; current.ParentID != nil && steps < len(categories); steps++ {
+			if current = byID[*current.ParentID]; current == nil {
+				break
+			}
+			path = append([]string{strings.ToLower(current.Name)}, path...)
+		}
+		key := string(category.Kind) + ":" + strings.Join(path, ":")
+		r.categories[key] = category
+		r.existingCategories[key] = true
+	}
+	return nil
+}
+
+// createAccount crea la cuenta, o solo la valida en una simulación
+func (r *migrationRun) createAccount(account *accountDomain.Account) error {
+	if r.dryRun {
+		return r.importer.accountUseCase.ValidateAccountData(account)
+	}
+	return r.importer.accountUseCase.CreateAccount(account)
+}
+
+// accountCurrency retorna la moneda con que se creará la cuenta de origen
+func (r *migrationRun) accountCurrency(name string) string {
+	for _, account := range r.data.Accounts {
+		if strings.EqualFold(account.Name, name) && account.Currency != "" {
+			return account.Currency
+		}
+	}
+	return r.currency
+}
+
+// accountSkipReason explica por qué no hay cuenta para un movimiento
+func (r *migrationRun) accountSkipReason(key string) string {
+	if reason, exists := r.skipReasons[key]; exists {
+		return "account not imported: " + reason
+	}
+	return "account not imported"
+}
+
+// skip registra un movimiento omitido
+func (r *migrationRun) skip(source *migration.Transaction, reason string) {
+	r.report.Transactions.Skipped++
+	r.report.AddItem(&domain.MigrationItem{Entity: "transaction", Action: domain.MigrationSkip, Name: describe(source), Line: source.Line, Reason: reason})
+}
+
+// skipTransfer registra una transferencia omitida
+func (r *migrationRun) skipTransfer(source *migration.Transaction, reason string) {
+	r.report.Transfers.Skipped++
+	r.report.AddItem(&domain.MigrationItem{Entity: "transfer", Action: domain.MigrationSkip, Name: describe(source), Line: source.Line, Reason: reason})
+}
+
+// categoryName muestra la ruta y el tipo, que distingue categorías homónimas
+func categoryName(path []string, kind categoryDomain.Kind) string {
+	return fmt.Sprintf("%s (%s)", strings.Join(path, ":"), kind)
+}
+
+// describe resume un movimiento para el reporte
+func describe(source *migration.Transaction) string {
+	text := source.Payee
+	if text == "" {
+		text = source.Memo
+	}
+	if source.IsTransfer() {
+		text = source.Account + " -> " + source.TransferAccount
+	}
+	if source.Date.IsZero() {
+		return strings.TrimSpace(source.Account + " " + text)
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %s %s %s", source.Date.Format("2006-01-02"), source.Account, source.Amount, text))
+}
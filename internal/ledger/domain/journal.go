@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	userDomain "finanzas-api/internal/users/domain"
+	"finanzas-api/shared/money"
+)
+
+// JournalEntry es un asiento contable de partida doble.
+// Sus postings deben sumar cero por cada moneda.
+type JournalEntry struct {
+	ID          uint            `json:"id" gorm:"primaryKey"`
+	UserID      uint            `json:"user_id" gorm:"not null;index"`
+	User        userDomain.User `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Date        time.Time       `json:"date" gorm:"type:date;not null;index"`
+	Description string          `json:"description"`
+	Postings    []Posting       `json:"postings" gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// Posting es una línea del asiento: un monto con signo sobre una cuenta.
+// Positivo incrementa el saldo de la cuenta, negativo lo disminuye.
+type Posting struct {
+	ID             uint                  `json:"id" gorm:"primaryKey"`
+	JournalEntryID uint                  `json:"journal_entry_id" gorm:"not null;index"`
+	AccountID      uint                  `json:"account_id" gorm:"not null;index"`
+	Account        accountDomain.Account `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Amount         int64                 `json:"amount" gorm:"not null"` // En unidades menores, con signo
+	Currency       string                `json:"currency" gorm:"type:char(3);not null"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+// LedgerRepository define la interfaz del repositorio del libro mayor
+type LedgerRepository interface {
+	// Create guarda el asiento, sus postings y las transacciones que los
+	// reflejan en el libro de transacciones, todo de forma atómica.
+	Create(entry *JournalEntry, legs []*transactionDomain.Transaction) error
+	GetByID(id uint) (*JournalEntry, error)
+	ListByUser(userID uint, limit, offset int) ([]*JournalEntry, error)
+}
+
+type LedgerUseCase interface {
+	PostEntry(entry *JournalEntry) error
+	Transfer(userID, fromAccountID, toAccountID uint, amount int64, date time.Time, description string) (*JournalEntry, error)
+	GetEntry(userID, id uint) (*JournalEntry, error)
+	ListEntries(userID uint, limit, offset int) ([]*JournalEntry, error)
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (JournalEntry) TableName() string {
+	return "journal_entries"
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (Posting) TableName() string {
+	return "postings"
+}
+
+// IsBalanced verifica que los postings sumen cero en cada moneda. Una suma
+// que desborda int64 se considera descuadrada.
+func (e *JournalEntry) IsBalanced() bool {
+	sums := make(map[string]money.Money)
+	for _, p := range e.Postings {
+		sum, exists := sums[p.Currency]
+		if !exists {
+			sum = money.Zero(p.Currency)
+		}
+		next, err := sum.Add(money.New(p.Amount, p.Currency))
+		if err != nil {
+			return false
+		}
+		sums[p.Currency] = next
+	}
+	for _, sum := range sums {
+		if !sum.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// BelongsTo verifica si el asiento pertenece al usuario indicado
+func (e *JournalEntry) BelongsTo(userID uint) bool {
+	return e.UserID == userID
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestJournalEntryIsBalanced(t *testing.T) {
+	tests := []struct {
+		name     string
+		postings []Posting
+		want     bool
+	}{
+		{
+			name:     "two legs",
+			postings: []Posting{{Amount: -5000, Currency: "COP"}, {Amount: 5000, Currency: "COP"}},
+			want:     true,
+		},
+		{
+			name:     "split across three accounts",
+			postings: []Posting{{Amount: -10000, Currency: "COP"}, {Amount: 7500, Currency: "COP"}, {Amount: 2500, Currency: "COP"}},
+			want:     true,
+		},
+		{
+			name:     "off by one",
+			postings: []Posting{{Amount: -5000, Currency: "COP"}, {Amount: 4999, Currency: "COP"}},
+			want:     false,
+		},
+		{
+			name: "balanced per currency",
+			postings: []Posting{
+				{Amount: -100, Currency: "USD"}, {Amount: 100, Currency: "USD"},
+				{Amount: -400000, Currency: "COP"}, {Amount: 400000, Currency: "COP"},
+			},
+			want: true,
+		},
+		{
+			name:     "sums to zero only across currencies",
+			postings: []Posting{{Amount: -100, Currency: "USD"}, {Amount: 100, Currency: "COP"}},
+			want:     false,
+		},
+		{
+			name:     "overflowing sum",
+			postings: []Posting{{Amount: math.MaxInt64, Currency: "COP"}, {Amount: 1, Currency: "COP"}, {Amount: math.MinInt64, Currency: "COP"}},
+			want:     false,
+		},
+		{
+			name:     "extremes cancel out",
+			postings: []Posting{{Amount: math.MaxInt64, Currency: "COP"}, {Amount: -math.MaxInt64, Currency: "COP"}},
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &JournalEntry{Postings: tt.postings}
+			if got := entry.IsBalanced(); got != tt.want {
+				t.Errorf("IsBalanced() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
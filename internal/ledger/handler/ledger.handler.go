@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"finanzas-api/internal/ledger/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+type LedgerHandler struct {
+	ledgerUseCase domain.LedgerUseCase
+}
+
+// NewLedgerHandler crea una nueva instancia del handler del libro mayor
+func NewLedgerHandler(ledgerUseCase domain.LedgerUseCase) *LedgerHandler {
+	return &LedgerHandler{
+		ledgerUseCase: ledgerUseCase,
+	}
+}
+
+// PostingRequest representa una línea de un asiento
+type PostingRequest struct {
+	AccountID uint   `json:"account_id" binding:"required"`
+	Amount    int64  `json:"amount" binding:"required"`
+	Currency  string `json:"currency" binding:"omitempty,len=3"`
+}
+
+// CreateEntryRequest representa la estructura de la petición para registrar un asiento
+type CreateEntryRequest struct {
+	Date        string           `json:"date" binding:"required"`
+	Description string           `json:"description"`
+	Postings    []PostingRequest `json:"postings" binding:"required,min=2,dive"`
+}
+
+// TransferRequest representa la estructura de la petición de transferencia entre cuentas
+type TransferRequest struct {
+	FromAccountID uint   `json:"from_account_id" binding:"required"`
+	ToAccountID   uint   `json:"to_account_id" binding:"required"`
+	Amount        int64  `json:"amount" binding:"required,gt=0"`
+	Date          string `json:"date" binding:"required"`
+	Description   string `json:"description"`
+}
+
+// CreateEntry registra un asiento contable balanceado
+func (h *LedgerHandler) CreateEntry(c *gin.Context) {
+	var req CreateEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid date, expected YYYY-MM-DD",
+		})
+		return
+	}
+
+	entry := &domain.JournalEntry{
+		UserID:      c.GetUint("userID"),
+		Date:        date,
+		Description: req.Description,
+	}
+	for _, p := range req.Postings {
+		entry.Postings = append(entry.Postings, domain.Posting{
+			AccountID: p.AccountID,
+			Amount:    p.Amount,
+			Currency:  p.Currency,
+		})
+	}
+
+	if err := h.ledgerUseCase.PostEntry(entry); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Journal entry created successfully",
+		"entry":   entry,
+	})
+}
+
+// Transfer mueve dinero entre dos cuentas del usuario autenticado
+func (h *LedgerHandler) Transfer(c *gin.Context) {
+	var req TransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid date, expected YYYY-MM-DD",
+		})
+		return
+	}
+
+	entry, err := h.ledgerUseCase.Transfer(c.GetUint("userID"), req.FromAccountID, req.ToAccountID, req.Amount, date, req.Description)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Transfer created successfully",
+		"entry":   entry,
+	})
+}
+
+// GetEntry obtiene un asiento del usuario autenticado
+func (h *LedgerHandler) GetEntry(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid journal entry ID",
+		})
+		return
+	}
+
+	entry, err := h.ledgerUseCase.GetEntry(c.GetUint("userID"), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Journal entry not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entry": entry,
+	})
+}
+
+// ListEntries lista los asientos del usuario autenticado
+func (h *LedgerHandler) ListEntries(c *gin.Context) {
+	// Obtener parámetros de paginación
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 0 {
+		limit = 10
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	entries, err := h.ledgerUseCase.ListEntries(c.GetUint("userID"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(entries),
+		},
+	})
+}
@@ -0,0 +1,39 @@
+package ledger
+
+import (
+	"fmt"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	"finanzas-api/internal/ledger/domain"
+	"finanzas-api/internal/ledger/handler"
+	"finanzas-api/internal/ledger/repository"
+	"finanzas-api/internal/ledger/usecase"
+
+	"gorm.io/gorm"
+)
+
+type LedgerModule struct {
+	Handler    *handler.LedgerHandler
+	UseCase    domain.LedgerUseCase
+	repository domain.LedgerRepository
+}
+
+func NewLedgerModule(db *gorm.DB, accountRepo accountDomain.AccountRepository) *LedgerModule {
+	var ledgerRepo domain.LedgerRepository
+	var ledgerUseCase domain.LedgerUseCase
+	var ledgerHandler *handler.LedgerHandler
+
+	if err := db.AutoMigrate(&domain.JournalEntry{}, &domain.Posting{}); err != nil {
+		panic(fmt.Sprintf("Error migrating ledger: %v", err))
+	}
+
+	ledgerRepo = repository.NewLedgerPostgresRepository(db)
+	ledgerUseCase = usecase.NewLedgerUseCase(ledgerRepo, accountRepo)
+	ledgerHandler = handler.NewLedgerHandler(ledgerUseCase)
+
+	return &LedgerModule{
+		Handler:    ledgerHandler,
+		UseCase:    ledgerUseCase,
+		repository: ledgerRepo,
+	}
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/ledger/domain"
+
+type LedgerRepository interface {
+	domain.LedgerRepository
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"errors"
+	"finanzas-api/internal/ledger/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+type ledgerRepositoryMemory struct {
+	entries         map[uint]*domain.JournalEntry
+	transactionRepo transactionDomain.TransactionRepository
+	nextID          uint
+	nextPostingID   uint
+	mutex           sync.RWMutex
+}
+
+// NewLedgerMemoryRepository recibe el repositorio de transacciones donde se
+// registran las patas de cada asiento.
+func NewLedgerMemoryRepository(transactionRepo transactionDomain.TransactionRepository) domain.LedgerRepository {
+	return &ledgerRepositoryMemory{
+		entries:         make(map[uint]*domain.JournalEntry),
+		transactionRepo: transactionRepo,
+		nextID:          1,
+		nextPostingID:   1,
+	}
+}
+
+func (r *ledgerRepositoryMemory) Create(entry *domain.JournalEntry, legs []*transactionDomain.Transaction) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Asignar IDs y timestamps
+	entry.ID = r.nextID
+	r.nextID++
+	entry.CreatedAt = time.Now()
+	entry.UpdatedAt = time.Now()
+	for i := range entry.Postings {
+		entry.Postings[i].ID = r.nextPostingID
+		r.nextPostingID++
+		entry.Postings[i].JournalEntryID = entry.ID
+		entry.Postings[i].CreatedAt = entry.CreatedAt
+	}
+
+	for _, leg := range legs {
+		leg.JournalEntryID = &entry.ID
+		if err := r.transactionRepo.Create(leg); err != nil {
+			return err
+		}
+	}
+
+	r.entries[entry.ID] = entry
+	return nil
+}
+
+func (r *ledgerRepositoryMemory) GetByID(id uint) (*domain.JournalEntry, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	entry, exists := r.entries[id]
+	if !exists {
+		return nil, errors.New("journal entry not found")
+	}
+	return entry, nil
+}
+
+func (r *ledgerRepositoryMemory) ListByUser(userID uint, limit, offset int) ([]*domain.JournalEntry, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var matches []*domain.JournalEntry
+	for _, entry := range r.entries {
+		if entry.UserID == userID {
+			matches = append(matches, entry)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if !matches[i].Date.Equal(matches[j].Date) {
+			return matches[i].Date.After(matches[j].Date)
+		}
+		return matches[i].ID > matches[j].ID
+	})
+
+	// Aplicar offset y limit
+	if offset >= len(matches) {
+		return nil, nil
+	}
+	matches = matches[offset:]
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"finanzas-api/internal/ledger/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+
+	"gorm.io/gorm"
+)
+
+type ledgerPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewLedgerPostgresRepository(db *gorm.DB) domain.LedgerRepository {
+	return &ledgerPostgresRepository{db: db}
+}
+
+func (r *ledgerPostgresRepository) Create(entry *domain.JournalEntry, legs []*transactionDomain.Transaction) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Crea el asiento junto con sus postings
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+		for _, leg := range legs {
+			leg.JournalEntryID = &entry.ID
+			if err := tx.Create(leg).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *ledgerPostgresRepository) GetByID(id uint) (*domain.JournalEntry, error) {
+	var entry domain.JournalEntry
+	if err := r.db.Preload("Postings").First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *ledgerPostgresRepository) ListByUser(userID uint, limit, offset int) ([]*domain.JournalEntry, error) {
+	var entries []*domain.JournalEntry
+	err := r.db.Preload("Postings").
+		Where("user_id = ?", userID).
+		Order("date DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
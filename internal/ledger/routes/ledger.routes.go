@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"finanzas-api/internal/ledger/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupLedgerRoutes configura las rutas para el libro mayor de partida doble
+func SetupLedgerRoutes(router *gin.Engine, ledgerHandler *handler.LedgerHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	ledgerRoutes := router.Group("/api/v1/ledger")
+	{
+		// POST /api/v1/ledger/transfers - Transferir entre cuentas propias
+		ledgerRoutes.POST("/transfers", authMiddleware("ledger:write"), ledgerHandler.Transfer)
+
+		// POST /api/v1/ledger/entries - Registrar asiento balanceado
+		ledgerRoutes.POST("/entries", authMiddleware("ledger:write"), ledgerHandler.CreateEntry)
+
+		// GET /api/v1/ledger/entries - Listar asientos
+		ledgerRoutes.GET("/entries", authMiddleware("ledger:read"), ledgerHandler.ListEntries)
+
+		// GET /api/v1/ledger/entries/:id - Obtener asiento por ID
+		ledgerRoutes.GET("/entries/:id", authMiddleware("ledger:read"), ledgerHandler.GetEntry)
+	}
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"errors"
+	accountDomain "finanzas-api/internal/accounts/domain"
+	"finanzas-api/internal/ledger/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	"math"
+	"strings"
+	"time"
+)
+
+type LedgerUseCase struct {
+	ledgerRepo  domain.LedgerRepository
+	accountRepo accountDomain.AccountRepository
+}
+
+func NewLedgerUseCase(ledgerRepo domain.LedgerRepository, accountRepo accountDomain.AccountRepository) domain.LedgerUseCase {
+	return &LedgerUseCase{
+		ledgerRepo:  ledgerRepo,
+		accountRepo: accountRepo,
+	}
+}
+
+// PostEntry implements domain.LedgerUseCase.
+func (uc *LedgerUseCase) PostEntry(entry *domain.JournalEntry) error {
+	if entry == nil {
+		return errors.New("journal entry is required")
+	}
+
+	if entry.UserID == 0 {
+		return errors.New("user ID is required")
+	}
+
+	if entry.Date.IsZero() {
+		return errors.New("date is required")
+	}
+
+	entry.Description = strings.TrimSpace(entry.Description)
+	if len(entry.Description) > 255 {
+		return errors.New("description too long")
+	}
+
+	if len(entry.Postings) < 2 {
+		return errors.New("a journal entry needs at least two postings")
+	}
+
+	legs := make([]*transactionDomain.Transaction, 0, len(entry.Postings))
+	for i := range entry.Postings {
+		posting := &entry.Postings[i]
+		if posting.Amount == 0 {
+			return errors.New("posting amount cannot be zero")
+		}
+		// MinInt64 no tiene opuesto en int64: la pata quedaría negativa
+		if posting.Amount == math.MinInt64 {
+			return errors.New("posting amount out of range")
+		}
+
+		account, err := uc.accountRepo.GetByID(posting.AccountID)
+		if err != nil || !account.BelongsTo(entry.UserID) {
+			return errors.New("account not found")
+		}
+
+		// La moneda del posting es la de la cuenta
+		posting.Currency = strings.ToUpper(strings.TrimSpace(posting.Currency))
+		if posting.Currency == "" {
+			posting.Currency = account.Currency
+		}
+		if posting.Currency != account.Currency {
+			return errors.New("posting currency does not match account currency")
+		}
+
+		legs = append(legs, ledgerLeg(entry, posting))
+	}
+
+	if !entry.IsBalanced() {
+		return errors.New("journal entry is not balanced")
+	}
+
+	return uc.ledgerRepo.Create(entry, legs)
+}
+
+// Transfer implements domain.LedgerUseCase.
+func (uc *LedgerUseCase) Transfer(userID, fromAccountID, toAccountID uint, amount int64, date time.Time, description string) (*domain.JournalEntry, error) {
+	if amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+
+	if fromAccountID == toAccountID {
+		return nil, errors.New("source and destination accounts must differ")
+	}
+
+	from, err := uc.accountRepo.GetByID(fromAccountID)
+	if err != nil || !from.BelongsTo(userID) {
+		return nil, errors.New("account not found")
+	}
+
+	to, err := uc.accountRepo.GetByID(toAccountID)
+	if err != nil || !to.BelongsTo(userID) {
+		return nil, errors.New("account not found")
+	}
+
+	if from.Currency != to.Currency {
+		return nil, errors.New("cross-currency transfers are not supported")
+	}
+
+	entry := &domain.JournalEntry{
+		UserID:      userID,
+		Date:        date,
+		Description: description,
+		Postings: []domain.Posting{
+			{AccountID: from.ID, Amount: -amount, Currency: from.Currency},
+			{AccountID: to.ID, Amount: amount, Currency: to.Currency},
+		},
+	}
+
+	if err := uc.PostEntry(entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// GetEntry implements domain.LedgerUseCase.
+func (uc *LedgerUseCase) GetEntry(userID, id uint) (*domain.JournalEntry, error) {
+	if id == 0 {
+		return nil, errors.New("invalid journal entry ID")
+	}
+
+	entry, err := uc.ledgerRepo.GetByID(id)
+	if err != nil || !entry.BelongsTo(userID) {
+		return nil, errors.New("journal entry not found")
+	}
+
+	return entry, nil
+}
+
+// ListEntries implements domain.LedgerUseCase.
+func (uc *LedgerUseCase) ListEntries(userID uint, limit, offset int) ([]*domain.JournalEntry, error) {
+	if limit < 0 || offset < 0 {
+		return nil, errors.New("limit and offset must be non-negative")
+	}
+
+	// Valor por defecto para limit
+	if limit == 0 {
+		limit = 10
+	}
+
+	// Máximo 100 asientos por página
+	if limit > 100 {
+		limit = 100
+	}
+	return uc.ledgerRepo.ListByUser(userID, limit, offset)
+}
+
+// ledgerLeg refleja un posting como transacción del libro para que los
+// saldos de las cuentas lo incluyan.
+func ledgerLeg(entry *domain.JournalEntry, posting *domain.Posting) *transactionDomain.Transaction {
+	leg := &transactionDomain.Transaction{
+		UserID:      entry.UserID,
+		AccountID:   posting.AccountID,
+		Amount:      posting.Amount,
+		Currency:    posting.Currency,
+		Direction:   transactionDomain.DirectionIncome,
+		Date:        entry.Date,
+		Description: entry.Description,
+		IsTransfer:  true,
+	}
+	if posting.Amount < 0 {
+		leg.Amount = -posting.Amount
+		leg.Direction = transactionDomain.DirectionExpense
+	}
+	return leg
+}
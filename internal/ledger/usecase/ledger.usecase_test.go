@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	accountRepository "finanzas-api/internal/accounts/repository"
+	"finanzas-api/internal/ledger/domain"
+	"finanzas-api/internal/ledger/repository"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	transactionRepository "finanzas-api/internal/transactions/repository"
+)
+
+// newTestLedger crea el caso de uso sobre repositorios en memoria con dos
+// cuentas en COP y una en USD del usuario 1, y una cuenta del usuario 2
+func newTestLedger(t *testing.T) (domain.LedgerUseCase, transactionDomain.TransactionRepository) {
+	t.Helper()
+	accounts := accountRepository.NewAccountMemoryRepository()
+	for _, account := range []*accountDomain.Account{
+		{UserID: 1, Name: "Banco", Type: accountDomain.AccountTypeBank, Currency: "COP"},
+		{UserID: 1, Name: "Efectivo", Type: accountDomain.AccountTypeCash, Currency: "COP"},
+		{UserID: 1, Name: "Dólares", Type: accountDomain.AccountTypeSavings, Currency: "USD"},
+		{UserID: 2, Name: "Ajena", Type: accountDomain.AccountTypeBank, Currency: "COP"},
+	} {
+		if err := accounts.Create(account); err != nil {
+			t.Fatal(err)
+		}
+	}
+	transactions := transactionRepository.NewTransactionMemoryRepository()
+	return NewLedgerUseCase(repository.NewLedgerMemoryRepository(transactions), accounts), transactions
+}
+
+func TestPostEntryValidation(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		postings []domain.Posting
+		wantErr  string
+	}{
+		{
+			name:     "single posting",
+			postings: []domain.Posting{{AccountID: 1, Amount: 100}},
+			wantErr:  "a journal entry needs at least two postings",
+		},
+		{
+			name:     "zero amount",
+			postings: []domain.Posting{{AccountID: 1, Amount: 0}, {AccountID: 2, Amount: 0}},
+			wantErr:  "posting amount cannot be zero",
+		},
+		{
+			name:     "min int64",
+			postings: []domain.Posting{{AccountID: 1, Amount: math.MinInt64}, {AccountID: 2, Amount: math.MaxInt64}},
+			wantErr:  "posting amount out of range",
+		},
+		{
+			name:     "account of another user",
+			postings: []domain.Posting{{AccountID: 1, Amount: -100}, {AccountID: 4, Amount: 100}},
+			wantErr:  "account not found",
+		},
+		{
+			name:     "currency differs from account",
+			postings: []domain.Posting{{AccountID: 1, Amount: -100, Currency: "USD"}, {AccountID: 3, Amount: 100}},
+			wantErr:  "posting currency does not match account currency",
+		},
+		{
+			name:     "unbalanced",
+			postings: []domain.Posting{{AccountID: 1, Amount: -100}, {AccountID: 2, Amount: 90}},
+			wantErr:  "journal entry is not balanced",
+		},
+		{
+			name:     "cross currency without counterpart",
+			postings: []domain.Posting{{AccountID: 1, Amount: -100}, {AccountID: 3, Amount: 100}},
+			wantErr:  "journal entry is not balanced",
+		},
+		{
+			name:     "balanced",
+			postings: []domain.Posting{{AccountID: 1, Amount: -100}, {AccountID: 2, Amount: 60}, {AccountID: 2, Amount: 40}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _ := newTestLedger(t)
+			err := uc.PostEntry(&domain.JournalEntry{UserID: 1, Date: date, Postings: tt.postings})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("PostEntry() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("PostEntry() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransferCreatesOppositeLegs(t *testing.T) {
+	uc, transactions := newTestLedger(t)
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	entry, err := uc.Transfer(1, 1, 2, 25000, date, "Retiro")
+	if err != nil {
+		t.Fatalf("Transfer() error = %v", err)
+	}
+	if !entry.IsBalanced() {
+		t.Fatal("transfer entry is not balanced")
+	}
+
+	legs, err := transactions.List(1, transactionDomain.TransactionFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(legs) != 2 {
+		t.Fatalf("got %d legs, want 2", len(legs))
+	}
+	byAccount := make(map[uint]*transactionDomain.Transaction)
+	for _, leg := range legs {
+		byAccount[leg.AccountID] = leg
+		if !leg.IsTransfer || leg.JournalEntryID == nil || *leg.JournalEntryID != entry.ID {
+			t.Errorf("leg %d is not linked to the transfer entry", leg.ID)
+		}
+		if leg.Amount != 25000 {
+			t.Errorf("leg amount = %d, want 25000", leg.Amount)
+		}
+	}
+	if byAccount[1].Direction != transactionDomain.DirectionExpense {
+		t.Errorf("source leg direction = %s, want expense", byAccount[1].Direction)
+	}
+	if byAccount[2].Direction != transactionDomain.DirectionIncome {
+		t.Errorf("destination leg direction = %s, want income", byAccount[2].Direction)
+	}
+}
+
+func TestTransferValidation(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		from, to uint
+		amount   int64
+		wantErr  string
+	}{
+		{"non positive amount", 1, 2, 0, "amount must be positive"},
+		{"same account", 1, 1, 100, "source and destination accounts must differ"},
+		{"account of another user", 1, 4, 100, "account not found"},
+		{"different currencies", 1, 3, 100, "cross-currency transfers are not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _ := newTestLedger(t)
+			_, err := uc.Transfer(1, tt.from, tt.to, tt.amount, date, "")
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("Transfer() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	userDomain "finanzas-api/internal/users/domain"
+
+	"gorm.io/gorm"
+)
+
+// Frequency define cada cuánto se repite una regla
+type Frequency string
+
+const (
+	FrequencyDaily           Frequency = "daily"
+	FrequencyWeekly          Frequency = "weekly"
+	FrequencyMonthly         Frequency = "monthly"           // El día DayOfMonth de cada mes
+	FrequencyLastBusinessDay Frequency = "last_business_day" // Último día hábil (lunes a viernes) del mes
+	FrequencyYearly          Frequency = "yearly"
+)
+
+// RecurringRule describe una transacción que se repite (arriendo, salario,
+// suscripciones). El scheduler materializa cada ocurrencia vencida.
+type RecurringRule struct {
+	ID          uint                        `json:"id" gorm:"primaryKey"`
+	UserID      uint                        `json:"user_id" gorm:"not null;index"`
+	User        userDomain.User             `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	AccountID   uint                        `json:"account_id" gorm:"not null;index"`
+	Account     accountDomain.Account       `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	CategoryID  *uint                       `json:"category_id"`
+	Amount      int64                       `json:"amount" gorm:"not null"` // En unidades menores, siempre positivo
+	Direction   transactionDomain.Direction `json:"direction" gorm:"type:varchar(10);not null"`
+	Description string                      `json:"description"`
+	Frequency   Frequency                   `json:"frequency" gorm:"type:varchar(20);not null"`
+	Interval    int                         `json:"interval" gorm:"not null;default:1"` // Cada cuántos periodos
+	DayOfMonth  int                         `json:"day_of_month"`                       // Solo para monthly; se ajusta al fin de mes
+	StartDate   time.Time                   `json:"start_date" gorm:"type:date;not null"`
+	EndDate     *time.Time                  `json:"end_date" gorm:"type:date"`
+	// MaxOccurrences limita el número de ocurrencias; nil es ilimitado
+	MaxOccurrences  *int           `json:"max_occurrences"`
+	OccurrenceCount int            `json:"occurrence_count" gorm:"not null;default:0"`
+	NextRunDate     *time.Time     `json:"next_run_date" gorm:"type:date;index"` // nil cuando la regla terminó
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete
+}
+
+// Occurrence registra cada fecha ya materializada de una regla. El índice
+// único (rule_id, date) garantiza que una ocurrencia nunca se registre dos
+// veces, aunque haya reinicios o varias instancias del servidor.
+type Occurrence struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	RuleID        uint      `json:"rule_id" gorm:"not null;uniqueIndex:idx_recurring_occurrences_rule_date"`
+	Date          time.Time `json:"date" gorm:"type:date;not null;uniqueIndex:idx_recurring_occurrences_rule_date"`
+	TransactionID *uint     `json:"transaction_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// RecurringRepository define la interfaz del repositorio de recurrencias
+type RecurringRepository interface {
+	// WithContext retorna el repositorio con sus consultas atadas a ctx: se
+	// cancelan cuando ctx vence
+	WithContext(ctx context.Context) RecurringRepository
+	Create(rule *RecurringRule) error
+	GetByID(id uint) (*RecurringRule, error)
+	Update(rule *RecurringRule) error
+	Delete(id uint) error
+	ListByUser(userID uint) ([]*RecurringRule, error)
+	// ListDue retorna las reglas con una ocurrencia pendiente en o antes de asOf
+	ListDue(asOf time.Time, limit int) ([]*RecurringRule, error)
+	// PostOccurrence registra la ocurrencia de rule en date junto con su
+	// transacción y avanza la regla, todo de forma atómica. Retorna false sin
+	// error si la ocurrencia ya había sido registrada o si otra instancia la
+	// está procesando.
+	PostOccurrence(rule *RecurringRule, date time.Time, transaction *transactionDomain.Transaction) (bool, error)
+}
+
+type RecurringUseCase interface {
+	CreateRule(rule *RecurringRule) error
+	GetRule(userID, id uint) (*RecurringRule, error)
+	UpdateRule(userID uint, rule *RecurringRule) error
+	DeleteRule(userID, id uint) error
+	ListRules(userID uint) ([]*RecurringRule, error)
+	// PreviewOccurrences proyecta las próximas fechas sin registrarlas
+	PreviewOccurrences(userID, id uint, count int) ([]time.Time, error)
+	// Upcoming proyecta las ocurrencias de todas las reglas del usuario en [from, to]
+	Upcoming(ctx context.Context, userID uint, from, to time.Time) ([]*ScheduledPayment, error)
+	// RunDue materializa todas las ocurrencias vencidas a la fecha asOf
+	RunDue(asOf time.Time) (int, error)
+}
+
+// ScheduledPayment es una ocurrencia futura proyectada de una regla
+type ScheduledPayment struct {
+	Rule *RecurringRule
+	Date time.Time
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (RecurringRule) TableName() string {
+	return "recurring_rules"
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (Occurrence) TableName() string {
+	return "recurring_occurrences"
+}
+
+// BelongsTo verifica si la regla pertenece al usuario indicado
+func (r *RecurringRule) BelongsTo(userID uint) bool {
+	return r.UserID == userID
+}
+
+// IsValidFrequency verifica si la frecuencia es soportada
+func (r *RecurringRule) IsValidFrequency() bool {
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyLastBusinessDay, FrequencyYearly:
+		return true
+	}
+	return false
+}
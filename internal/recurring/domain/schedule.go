@@ -0,0 +1,133 @@
+package domain
+
+import "time"
+
+// FirstOccurrence retorna la primera fecha de la regla en o después de StartDate
+func (r *RecurringRule) FirstOccurrence() time.Time {
+	start := dateOnly(r.StartDate)
+	switch r.Frequency {
+	case FrequencyMonthly:
+		candidate := dayInMonth(start.Year(), start.Month(), r.DayOfMonth)
+		if candidate.Before(start) {
+			next := start.AddDate(0, 0, 1-start.Day()).AddDate(0, 1, 0)
+			candidate = dayInMonth(next.Year(), next.Month(), r.DayOfMonth)
+		}
+		return candidate
+	case FrequencyLastBusinessDay:
+		candidate := lastBusinessDay(start.Year(), start.Month())
+		if candidate.Before(start) {
+			next := start.AddDate(0, 0, 1-start.Day()).AddDate(0, 1, 0)
+			candidate = lastBusinessDay(next.Year(), next.Month())
+		}
+		return candidate
+	}
+	return start
+}
+
+// NextAfter retorna la ocurrencia que sigue a previous
+func (r *RecurringRule) NextAfter(previous time.Time) time.Time {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	previous = dateOnly(previous)
+	firstOfMonth := previous.AddDate(0, 0, 1-previous.Day())
+	switch r.Frequency {
+	case FrequencyDaily:
+		return previous.AddDate(0, 0, interval)
+	case FrequencyWeekly:
+		return previous.AddDate(0, 0, 7*interval)
+	case FrequencyMonthly:
+		next := firstOfMonth.AddDate(0, interval, 0)
+		return dayInMonth(next.Year(), next.Month(), r.DayOfMonth)
+	case FrequencyLastBusinessDay:
+		next := firstOfMonth.AddDate(0, interval, 0)
+		return lastBusinessDay(next.Year(), next.Month())
+	case FrequencyYearly:
+		// Se conserva el día de StartDate (un 29 de febrero pasa al 28)
+		start := dateOnly(r.StartDate)
+		return dayInMonth(previous.Year()+interval, start.Month(), start.Day())
+	}
+	return previous
+}
+
+// IsFinishedAt indica si la regla ya no produce la ocurrencia date,
+// considerando la fecha de fin y el máximo de ocurrencias.
+func (r *RecurringRule) IsFinishedAt(date time.Time, count int) bool {
+	if r.EndDate != nil && dateOnly(date).After(dateOnly(*r.EndDate)) {
+		return true
+	}
+	return r.MaxOccurrences != nil && count >= *r.MaxOccurrences
+}
+
+// dayInMonth retorna el día indicado del mes, ajustado al último día si el
+// mes es más corto.
+func dayInMonth(year int, month time.Month, day int) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day < 1 {
+		day = 1
+	}
+	if day > last {
+		day = last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// lastBusinessDay retorna el último día de lunes a viernes del mes
+func lastBusinessDay(year int, month time.Month) time.Time {
+	day := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Schedule calcula la primera ejecución de una regla nueva
+func (r *RecurringRule) Schedule() {
+	r.OccurrenceCount = 0
+	first := r.FirstOccurrence()
+	r.NextRunDate = nil
+	if !r.IsFinishedAt(first, 0) {
+		r.NextRunDate = &first
+	}
+}
+
+// Advance registra una ocurrencia y mueve NextRunDate a la siguiente fecha,
+// o lo deja en nil si la regla terminó.
+func (r *RecurringRule) Advance() {
+	if r.NextRunDate == nil {
+		return
+	}
+	r.OccurrenceCount++
+	next := r.NextAfter(*r.NextRunDate)
+	r.NextRunDate = nil
+	if !r.IsFinishedAt(next, r.OccurrenceCount) {
+		r.NextRunDate = &next
+	}
+}
+
+// Project retorna hasta limit ocurrencias futuras en [from, to] a partir de
+// NextRunDate, sin modificar la regla.
+func (r *RecurringRule) Project(from, to time.Time, limit int) []time.Time {
+	var dates []time.Time
+	if r.NextRunDate == nil {
+		return dates
+	}
+	from, to = dateOnly(from), dateOnly(to)
+	count := r.OccurrenceCount
+	for date := dateOnly(*r.NextRunDate); !date.After(to) && len(dates) < limit; date = r.NextAfter(date) {
+		if r.IsFinishedAt(date, count) {
+			break
+		}
+		if !date.Before(from) {
+			dates = append(dates, date)
+		}
+		count++
+	}
+	return dates
+}
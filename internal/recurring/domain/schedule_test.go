@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestFirstOccurrence(t *testing.T) {
+	tests := []struct {
+		name string
+		rule RecurringRule
+		want time.Time
+	}{
+		{
+			name: "daily drops the time of day",
+			rule: RecurringRule{Frequency: FrequencyDaily, StartDate: time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)},
+			want: date(2024, 3, 10),
+		},
+		{
+			name: "monthly later in the start month",
+			rule: RecurringRule{Frequency: FrequencyMonthly, DayOfMonth: 20, StartDate: date(2024, 3, 10)},
+			want: date(2024, 3, 20),
+		},
+		{
+			name: "monthly day already passed",
+			rule: RecurringRule{Frequency: FrequencyMonthly, DayOfMonth: 5, StartDate: date(2024, 3, 10)},
+			want: date(2024, 4, 5),
+		},
+		{
+			name: "monthly day 31 clamped in leap february",
+			rule: RecurringRule{Frequency: FrequencyMonthly, DayOfMonth: 31, StartDate: date(2024, 2, 10)},
+			want: date(2024, 2, 29),
+		},
+		{
+			name: "last business day skips the weekend",
+			rule: RecurringRule{Frequency: FrequencyLastBusinessDay, StartDate: date(2024, 3, 1)},
+			want: date(2024, 3, 29), // El 31 es domingo
+		},
+		{
+			name: "last business day already passed",
+			rule: RecurringRule{Frequency: FrequencyLastBusinessDay, StartDate: date(2024, 8, 31)},
+			want: date(2024, 9, 30),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.FirstOccurrence(); !got.Equal(tt.want) {
+				t.Errorf("FirstOccurrence() = %s, want %s", got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestNextAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     RecurringRule
+		previous time.Time
+		want     time.Time
+	}{
+		{
+			name:     "daily across leap day",
+			rule:     RecurringRule{Frequency: FrequencyDaily, Interval: 3},
+			previous: date(2024, 2, 27),
+			want:     date(2024, 3, 1),
+		},
+		{
+			name:     "biweekly across year end",
+			rule:     RecurringRule{Frequency: FrequencyWeekly, Interval: 2},
+			previous: date(2024, 12, 25),
+			want:     date(2025, 1, 8),
+		},
+		{
+			name:     "zero interval counts as one",
+			rule:     RecurringRule{Frequency: FrequencyMonthly, DayOfMonth: 15},
+			previous: date(2024, 5, 15),
+			want:     date(2024, 6, 15),
+		},
+		{
+			name:     "monthly day 31 into february",
+			rule:     RecurringRule{Frequency: FrequencyMonthly, Interval: 1, DayOfMonth: 31},
+			previous: date(2024, 1, 31),
+			want:     date(2024, 2, 29),
+		},
+		{
+			name:     "monthly day 31 recovers after february",
+			rule:     RecurringRule{Frequency: FrequencyMonthly, Interval: 1, DayOfMonth: 31},
+			previous: date(2024, 2, 29),
+			want:     date(2024, 3, 31),
+		},
+		{
+			name:     "quarterly clamped in short month",
+			rule:     RecurringRule{Frequency: FrequencyMonthly, Interval: 3, DayOfMonth: 30},
+			previous: date(2024, 11, 30),
+			want:     date(2025, 2, 28),
+		},
+		{
+			name:     "last business day of next month",
+			rule:     RecurringRule{Frequency: FrequencyLastBusinessDay, Interval: 1},
+			previous: date(2024, 5, 31),
+			want:     date(2024, 6, 28), // El 30 es domingo
+		},
+		{
+			name:     "yearly from leap day to non leap year",
+			rule:     RecurringRule{Frequency: FrequencyYearly, Interval: 1, StartDate: date(2024, 2, 29)},
+			previous: date(2024, 2, 29),
+			want:     date(2025, 2, 28),
+		},
+		{
+			name:     "yearly back to leap day",
+			rule:     RecurringRule{Frequency: FrequencyYearly, Interval: 1, StartDate: date(2024, 2, 29)},
+			previous: date(2027, 2, 28),
+			want:     date(2028, 2, 29),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.NextAfter(tt.previous); !got.Equal(tt.want) {
+				t.Errorf("NextAfter(%s) = %s, want %s", tt.previous.Format("2006-01-02"), got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestAdvanceStopsAtLimits(t *testing.T) {
+	maxOccurrences := 2
+	endDate := date(2024, 3, 15)
+	tests := []struct {
+		name string
+		rule RecurringRule
+		want []time.Time
+	}{
+		{
+			name: "max occurrences",
+			rule: RecurringRule{Frequency: FrequencyWeekly, Interval: 1, StartDate: date(2024, 1, 1), MaxOccurrences: &maxOccurrences},
+			want: []time.Time{date(2024, 1, 1), date(2024, 1, 8)},
+		},
+		{
+			name: "end date is inclusive",
+			rule: RecurringRule{Frequency: FrequencyMonthly, Interval: 1, DayOfMonth: 15, StartDate: date(2024, 1, 1), EndDate: &endDate},
+			want: []time.Time{date(2024, 1, 15), date(2024, 2, 15), date(2024, 3, 15)},
+		},
+		{
+			name: "starts after end date",
+			rule: RecurringRule{Frequency: FrequencyDaily, Interval: 1, StartDate: date(2024, 4, 1), EndDate: &endDate},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := tt.rule
+			rule.Schedule()
+			var got []time.Time
+			for steps := 0; rule.NextRunDate != nil && steps < 10; steps++ {
+				got = append(got, *rule.NextRunDate)
+				rule.Advance()
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d = %s, want %s", i, got[i].Format("2006-01-02"), tt.want[i].Format("2006-01-02"))
+				}
+			}
+			if rule.OccurrenceCount != len(tt.want) {
+				t.Errorf("OccurrenceCount = %d, want %d", rule.OccurrenceCount, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestProjectDoesNotModifyRule(t *testing.T) {
+	rule := RecurringRule{Frequency: FrequencyMonthly, Interval: 1, DayOfMonth: 10, StartDate: date(2024, 1, 1)}
+	rule.Schedule()
+
+	dates := rule.Project(date(2024, 2, 1), date(2024, 12, 31), 3)
+	want := []time.Time{date(2024, 2, 10), date(2024, 3, 10), date(2024, 4, 10)}
+	if len(dates) != len(want) {
+		t.Fatalf("Project() returned %d dates, want %d", len(dates), len(want))
+	}
+	for i := range want {
+		if !dates[i].Equal(want[i]) {
+			t.Errorf("date %d = %s, want %s", i, dates[i].Format("2006-01-02"), want[i].Format("2006-01-02"))
+		}
+	}
+	if !rule.NextRunDate.Equal(date(2024, 1, 10)) || rule.OccurrenceCount != 0 {
+		t.Errorf("Project() modified the rule: next %s, count %d", rule.NextRunDate.Format("2006-01-02"), rule.OccurrenceCount)
+	}
+}
@@ -0,0 +1,335 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"finanzas-api/internal/recurring/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+type RecurringHandler struct {
+	recurringUseCase domain.RecurringUseCase
+}
+
+// NewRecurringHandler crea una nueva instancia del handler de recurrencias
+func NewRecurringHandler(recurringUseCase domain.RecurringUseCase) *RecurringHandler {
+	return &RecurringHandler{
+		recurringUseCase: recurringUseCase,
+	}
+}
+
+// CreateRuleRequest representa la estructura de la petición para crear una regla recurrente
+type CreateRuleRequest struct {
+	AccountID      uint   `json:"account_id" binding:"required"`
+	CategoryID     *uint  `json:"category_id"`
+	Amount         int64  `json:"amount" binding:"required,gt=0"`
+	Direction      string `json:"direction" binding:"required,oneof=income expense"`
+	Description    string `json:"description"`
+	Frequency      string `json:"frequency" binding:"required,oneof=daily weekly monthly last_business_day yearly"`
+	Interval       int    `json:"interval" binding:"omitempty,gt=0"`
+	DayOfMonth     int    `json:"day_of_month" binding:"omitempty,min=1,max=31"`
+	StartDate      string `json:"start_date" binding:"required"`
+	EndDate        string `json:"end_date"`
+	MaxOccurrences *int   `json:"max_occurrences" binding:"omitempty,gt=0"`
+}
+
+// UpdateRuleRequest representa la estructura de la petición para actualizar una regla.
+// El calendario (frecuencia, intervalo, día y fecha de inicio) no se puede modificar.
+type UpdateRuleRequest struct {
+	AccountID      uint    `json:"account_id" binding:"omitempty"`
+	CategoryID     *uint   `json:"category_id"`
+	Amount         int64   `json:"amount" binding:"omitempty,gt=0"`
+	Direction      string  `json:"direction" binding:"omitempty,oneof=income expense"`
+	Description    *string `json:"description"`
+	EndDate        *string `json:"end_date"` // Cadena vacía elimina la fecha de fin
+	MaxOccurrences *int    `json:"max_occurrences" binding:"omitempty,gt=0"`
+}
+
+// RuleResponse representa la respuesta de una regla recurrente
+type RuleResponse struct {
+	ID              uint    `json:"id"`
+	AccountID       uint    `json:"account_id"`
+	CategoryID      *uint   `json:"category_id"`
+	Amount          int64   `json:"amount"`
+	Direction       string  `json:"direction"`
+	Description     string  `json:"description"`
+	Frequency       string  `json:"frequency"`
+	Interval        int     `json:"interval"`
+	DayOfMonth      int     `json:"day_of_month,omitempty"`
+	StartDate       string  `json:"start_date"`
+	EndDate         *string `json:"end_date"`
+	MaxOccurrences  *int    `json:"max_occurrences"`
+	OccurrenceCount int     `json:"occurrence_count"`
+	NextRunDate     *string `json:"next_run_date"`
+	CreatedAt       string  `json:"created_at"`
+	UpdatedAt       string  `json:"updated_at"`
+}
+
+// CreateRule maneja la creación de reglas recurrentes del usuario autenticado
+func (h *RecurringHandler) CreateRule(c *gin.Context) {
+	var req CreateRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	startDate, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid start_date, expected YYYY-MM-DD",
+		})
+		return
+	}
+
+	rule := &domain.RecurringRule{
+		UserID:         c.GetUint("userID"),
+		AccountID:      req.AccountID,
+		CategoryID:     req.CategoryID,
+		Amount:         req.Amount,
+		Direction:      transactionDomain.Direction(req.Direction),
+		Description:    req.Description,
+		Frequency:      domain.Frequency(req.Frequency),
+		Interval:       req.Interval,
+		DayOfMonth:     req.DayOfMonth,
+		StartDate:      startDate,
+		MaxOccurrences: req.MaxOccurrences,
+	}
+	if req.EndDate != "" {
+		endDate, err := time.Parse(dateLayout, req.EndDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid end_date, expected YYYY-MM-DD",
+			})
+			return
+		}
+		rule.EndDate = &endDate
+	}
+
+	if err := h.recurringUseCase.CreateRule(rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Recurring rule created successfully",
+		"rule":    h.toRuleResponse(rule),
+	})
+}
+
+// GetRule obtiene una regla recurrente del usuario autenticado
+func (h *RecurringHandler) GetRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid recurring rule ID",
+		})
+		return
+	}
+
+	rule, err := h.recurringUseCase.GetRule(c.GetUint("userID"), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Recurring rule not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rule": h.toRuleResponse(rule),
+	})
+}
+
+// UpdateRule actualiza una regla recurrente del usuario autenticado
+func (h *RecurringHandler) UpdateRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid recurring rule ID",
+		})
+		return
+	}
+
+	var req UpdateRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.GetUint("userID")
+
+	// Obtener regla existente
+	rule, err := h.recurringUseCase.GetRule(userID, uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Recurring rule not found",
+		})
+		return
+	}
+
+	// Actualizar campos si se proporcionan
+	if req.AccountID != 0 {
+		rule.AccountID = req.AccountID
+	}
+	if req.CategoryID != nil {
+		rule.CategoryID = req.CategoryID
+	}
+	if req.Amount != 0 {
+		rule.Amount = req.Amount
+	}
+	if req.Direction != "" {
+		rule.Direction = transactionDomain.Direction(req.Direction)
+	}
+	if req.Description != nil {
+		rule.Description = *req.Description
+	}
+	if req.EndDate != nil {
+		rule.EndDate = nil
+		if *req.EndDate != "" {
+			endDate, err := time.Parse(dateLayout, *req.EndDate)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": "Invalid end_date, expected YYYY-MM-DD",
+				})
+				return
+			}
+			rule.EndDate = &endDate
+		}
+	}
+	if req.MaxOccurrences != nil {
+		rule.MaxOccurrences = req.MaxOccurrences
+	}
+
+	if err := h.recurringUseCase.UpdateRule(userID, rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Recurring rule updated successfully",
+		"rule":    h.toRuleResponse(rule),
+	})
+}
+
+// DeleteRule elimina una regla recurrente del usuario autenticado.
+// Las transacciones ya registradas se conservan.
+func (h *RecurringHandler) DeleteRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid recurring rule ID",
+		})
+		return
+	}
+
+	if err := h.recurringUseCase.DeleteRule(c.GetUint("userID"), uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Recurring rule not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Recurring rule deleted successfully",
+	})
+}
+
+// ListRules lista las reglas recurrentes del usuario autenticado
+func (h *RecurringHandler) ListRules(c *gin.Context) {
+	rules, err := h.recurringUseCase.ListRules(c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	responses := make([]RuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = h.toRuleResponse(rule)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules": responses,
+	})
+}
+
+// PreviewOccurrences lista las próximas fechas de una regla (?count=N)
+func (h *RecurringHandler) PreviewOccurrences(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid recurring rule ID",
+		})
+		return
+	}
+
+	count, err := strconv.Atoi(c.DefaultQuery("count", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid count",
+		})
+		return
+	}
+
+	dates, err := h.recurringUseCase.PreviewOccurrences(c.GetUint("userID"), uint(id), count)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Recurring rule not found",
+		})
+		return
+	}
+
+	occurrences := make([]string, len(dates))
+	for i, date := range dates {
+		occurrences[i] = date.Format(dateLayout)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"occurrences": occurrences,
+	})
+}
+
+// toRuleResponse convierte una regla en su representación de respuesta
+func (h *RecurringHandler) toRuleResponse(rule *domain.RecurringRule) RuleResponse {
+	response := RuleResponse{
+		ID:              rule.ID,
+		AccountID:       rule.AccountID,
+		CategoryID:      rule.CategoryID,
+		Amount:          rule.Amount,
+		Direction:       string(rule.Direction),
+		Description:     rule.Description,
+		Frequency:       string(rule.Frequency),
+		Interval:        rule.Interval,
+		DayOfMonth:      rule.DayOfMonth,
+		StartDate:       rule.StartDate.Format(dateLayout),
+		MaxOccurrences:  rule.MaxOccurrences,
+		OccurrenceCount: rule.OccurrenceCount,
+		CreatedAt:       rule.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:       rule.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if rule.EndDate != nil {
+		endDate := rule.EndDate.Format(dateLayout)
+		response.EndDate = &endDate
+	}
+	if rule.NextRunDate != nil {
+		nextRunDate := rule.NextRunDate.Format(dateLayout)
+		response.NextRunDate = &nextRunDate
+	}
+	return response
+}
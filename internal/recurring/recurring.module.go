@@ -0,0 +1,68 @@
+package recurring
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	dashboardDomain "finanzas-api/internal/dashboard/domain"
+	"finanzas-api/internal/recurring/domain"
+	"finanzas-api/internal/recurring/handler"
+	"finanzas-api/internal/recurring/repository"
+	"finanzas-api/internal/recurring/scheduler"
+	"finanzas-api/internal/recurring/usecase"
+
+	"gorm.io/gorm"
+)
+
+type RecurringModule struct {
+	Handler    *handler.RecurringHandler
+	UseCase    domain.RecurringUseCase
+	Scheduler  *scheduler.Scheduler
+	repository domain.RecurringRepository
+}
+
+func NewRecurringModule(db *gorm.DB, accountRepo accountDomain.AccountRepository, categoryRepo categoryDomain.CategoryRepository, interval time.Duration) *RecurringModule {
+	var recurringRepo domain.RecurringRepository
+	var recurringUseCase domain.RecurringUseCase
+	var recurringHandler *handler.RecurringHandler
+
+	if err := db.AutoMigrate(&domain.RecurringRule{}, &domain.Occurrence{}); err != nil {
+		panic(fmt.Sprintf("Error migrating recurring rules: %v", err))
+	}
+
+	recurringRepo = repository.NewRecurringPostgresRepository(db)
+	recurringUseCase = usecase.NewRecurringUseCase(recurringRepo, accountRepo, categoryRepo)
+	recurringHandler = handler.NewRecurringHandler(recurringUseCase)
+
+	return &RecurringModule{
+		Handler:    recurringHandler,
+		UseCase:    recurringUseCase,
+		Scheduler:  scheduler.NewScheduler(recurringUseCase, interval),
+		repository: recurringRepo,
+	}
+}
+
+// UpcomingPayments implements dashboardDomain.UpcomingPaymentsProvider, para
+// que el dashboard muestre los próximos pagos programados.
+func (m *RecurringModule) UpcomingPayments(ctx context.Context, userID uint, from, to time.Time) ([]*dashboardDomain.UpcomingPayment, error) {
+	scheduled, err := m.UseCase.Upcoming(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	payments := make([]*dashboardDomain.UpcomingPayment, len(scheduled))
+	for i, item := range scheduled {
+		payments[i] = &dashboardDomain.UpcomingPayment{
+			ID:          item.Rule.ID,
+			AccountID:   item.Rule.AccountID,
+			Description: item.Rule.Description,
+			Amount:      item.Rule.Amount,
+			Direction:   item.Rule.Direction,
+			DueDate:     item.Date.Format("2006-01-02"),
+		}
+	}
+	return payments, nil
+}
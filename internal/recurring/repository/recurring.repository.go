@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/recurring/domain"
+
+type RecurringRepository interface {
+	domain.RecurringRepository
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"finanzas-api/internal/recurring/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+type occurrenceKey struct {
+	ruleID uint
+	date   time.Time
+}
+
+type recurringRepositoryMemory struct {
+	rules           map[uint]*domain.RecurringRule
+	occurrences     map[occurrenceKey]*domain.Occurrence
+	transactionRepo transactionDomain.TransactionRepository
+	nextID          uint
+	mutex           sync.RWMutex
+}
+
+// NewRecurringMemoryRepository recibe el repositorio donde se registran las
+// transacciones generadas.
+func NewRecurringMemoryRepository(transactionRepo transactionDomain.TransactionRepository) domain.RecurringRepository {
+	return &recurringRepositoryMemory{
+		rules:           make(map[uint]*domain.RecurringRule),
+		occurrences:     make(map[occurrenceKey]*domain.Occurrence),
+		transactionRepo: transactionRepo,
+		nextID:          1,
+	}
+}
+
+// WithContext retorna el mismo repositorio: en memoria no hay consultas que
+// cancelar
+func (r *recurringRepositoryMemory) WithContext(ctx context.Context) domain.RecurringRepository {
+	return r
+}
+
+func (r *recurringRepositoryMemory) Create(rule *domain.RecurringRule) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Asignar ID y timestamps
+	rule.ID = r.nextID
+	r.nextID++
+	rule.CreatedAt = time.Now()
+	rule.UpdatedAt = time.Now()
+
+	r.rules[rule.ID] = rule
+	return nil
+}
+
+func (r *recurringRepositoryMemory) GetByID(id uint) (*domain.RecurringRule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	rule, exists := r.rules[id]
+	if !exists || !rule.DeletedAt.Time.IsZero() {
+		return nil, errors.New("recurring rule not found")
+	}
+
+	copied := *rule
+	return &copied, nil
+}
+
+func (r *recurringRepositoryMemory) Update(rule *domain.RecurringRule) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existing, exists := r.rules[rule.ID]
+	if !exists || !existing.DeletedAt.Time.IsZero() {
+		return errors.New("recurring rule not found")
+	}
+
+	rule.UpdatedAt = time.Now()
+	copied := *rule
+	r.rules[rule.ID] = &copied
+
+	return nil
+}
+
+func (r *recurringRepositoryMemory) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	rule, exists := r.rules[id]
+	if !exists || !rule.DeletedAt.Time.IsZero() {
+		return errors.New("recurring rule not found")
+	}
+
+	// Soft delete
+	rule.DeletedAt.Time = time.Now()
+	rule.DeletedAt.Valid = true
+	rule.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (r *recurringRepositoryMemory) ListByUser(userID uint) ([]*domain.RecurringRule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var rules []*domain.RecurringRule
+	for _, rule := range r.rules {
+		if rule.DeletedAt.Time.IsZero() && rule.UserID == userID {
+			copied := *rule
+			rules = append(rules, &copied)
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules, nil
+}
+
+func (r *recurringRepositoryMemory) ListDue(asOf time.Time, limit int) ([]*domain.RecurringRule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var rules []*domain.RecurringRule
+	for _, rule := range r.rules {
+		if rule.DeletedAt.Time.IsZero() && rule.NextRunDate != nil && !rule.NextRunDate.After(asOf) {
+			copied := *rule
+			rules = append(rules, &copied)
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool { return rules[i].NextRunDate.Before(*rules[j].NextRunDate) })
+	if limit > 0 && len(rules) > limit {
+		rules = rules[:limit]
+	}
+	return rules, nil
+}
+
+func (r *recurringRepositoryMemory) PostOccurrence(rule *domain.RecurringRule, date time.Time, transaction *transactionDomain.Transaction) (bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stored, exists := r.rules[rule.ID]
+	if !exists || stored.NextRunDate == nil || !stored.NextRunDate.Equal(date) {
+		return false, nil
+	}
+
+	posted := false
+	key := occurrenceKey{ruleID: stored.ID, date: date}
+	if _, done := r.occurrences[key]; !done {
+		if err := r.transactionRepo.Create(transaction); err != nil {
+			return false, err
+		}
+		r.occurrences[key] = &domain.Occurrence{
+			RuleID:        stored.ID,
+			Date:          date,
+			TransactionID: &transaction.ID,
+			CreatedAt:     time.Now(),
+		}
+		posted = true
+	}
+
+	stored.Advance()
+	stored.UpdatedAt = time.Now()
+	*rule = *stored
+	return posted, nil
+}
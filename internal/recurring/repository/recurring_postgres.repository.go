@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"finanzas-api/internal/recurring/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type recurringPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewRecurringPostgresRepository(db *gorm.DB) domain.RecurringRepository {
+	return &recurringPostgresRepository{db: db}
+}
+
+func (r *recurringPostgresRepository) WithContext(ctx context.Context) domain.RecurringRepository {
+	return &recurringPostgresRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *recurringPostgresRepository) Create(rule *domain.RecurringRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *recurringPostgresRepository) GetByID(id uint) (*domain.RecurringRule, error) {
+	var rule domain.RecurringRule
+	if err := r.db.First(&rule, id).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *recurringPostgresRepository) Update(rule *domain.RecurringRule) error {
+	return r.db.Save(rule).Error
+}
+
+func (r *recurringPostgresRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.RecurringRule{}, id).Error // soft delete
+}
+
+func (r *recurringPostgresRepository) ListByUser(userID uint) ([]*domain.RecurringRule, error) {
+	var rules []*domain.RecurringRule
+	if err := r.db.Where("user_id = ?", userID).Order("id").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *recurringPostgresRepository) ListDue(asOf time.Time, limit int) ([]*domain.RecurringRule, error) {
+	var rules []*domain.RecurringRule
+	err := r.db.Where("next_run_date IS NOT NULL AND next_run_date <= ?", asOf).
+		Order("next_run_date, id").
+		Limit(limit).
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *recurringPostgresRepository) PostOccurrence(rule *domain.RecurringRule, date time.Time, transaction *transactionDomain.Transaction) (bool, error) {
+	posted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Bloquea la regla; si otra instancia ya la tiene, se omite sin esperar
+		var locked domain.RecurringRule
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("id = ? AND next_run_date = ?", rule.ID, date).
+			Limit(1).
+			Find(&locked)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		// El índice único descarta una ocurrencia ya registrada
+		occurrence := &domain.Occurrence{RuleID: locked.ID, Date: date}
+		result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(occurrence)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 1 {
+			if err := tx.Create(transaction).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(occurrence).Update("transaction_id", transaction.ID).Error; err != nil {
+				return err
+			}
+			posted = true
+		}
+
+		locked.Advance()
+		if err := tx.Model(&locked).Select("occurrence_count", "next_run_date").Updates(&locked).Error; err != nil {
+			return err
+		}
+		*rule = locked
+		return nil
+	})
+	return posted, err
+}
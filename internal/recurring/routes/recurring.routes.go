@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"finanzas-api/internal/recurring/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupRecurringRoutes configura las rutas para el módulo de recurrencias
+func SetupRecurringRoutes(router *gin.Engine, recurringHandler *handler.RecurringHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	recurringRoutes := router.Group("/api/v1/recurring")
+	{
+		// POST /api/v1/recurring - Crear regla recurrente
+		recurringRoutes.POST("", authMiddleware("recurring:write"), recurringHandler.CreateRule)
+
+		// GET /api/v1/recurring - Listar reglas recurrentes
+		recurringRoutes.GET("", authMiddleware("recurring:read"), recurringHandler.ListRules)
+
+		// GET /api/v1/recurring/:id - Obtener regla por ID
+		recurringRoutes.GET("/:id", authMiddleware("recurring:read"), recurringHandler.GetRule)
+
+		// GET /api/v1/recurring/:id/occurrences?count=N - Próximas fechas de la regla
+		recurringRoutes.GET("/:id/occurrences", authMiddleware("recurring:read"), recurringHandler.PreviewOccurrences)
+
+		// PUT /api/v1/recurring/:id - Actualizar regla
+		recurringRoutes.PUT("/:id", authMiddleware("recurring:write"), recurringHandler.UpdateRule)
+
+		// DELETE /api/v1/recurring/:id - Eliminar regla
+		recurringRoutes.DELETE("/:id", authMiddleware("recurring:write"), recurringHandler.DeleteRule)
+	}
+}
@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"finanzas-api/internal/recurring/domain"
+)
+
+// Scheduler materializa periódicamente las ocurrencias vencidas de las
+// reglas recurrentes. Es seguro ejecutar varias instancias a la vez: el
+// repositorio garantiza que cada ocurrencia se registre una sola vez.
+type Scheduler struct {
+	recurringUseCase domain.RecurringUseCase
+	interval         time.Duration
+}
+
+// NewScheduler crea un scheduler que corre cada interval
+func NewScheduler(recurringUseCase domain.RecurringUseCase, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		recurringUseCase: recurringUseCase,
+		interval:         interval,
+	}
+}
+
+// Start ejecuta una pasada inmediata (para recuperar lo pendiente tras un
+// reinicio) y luego una por intervalo, hasta que ctx se cancele.
+func (s *Scheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.run()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Scheduler de recurrencias detenido")
+			return
+		case <-ticker.C:
+			s.run()
+		}
+	}
+}
+
+// run materializa las ocurrencias vencidas a la fecha de hoy
+func (s *Scheduler) run() {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	posted, err := s.recurringUseCase.RunDue(today)
+	if err != nil {
+		log.Printf("⚠️ Error en el scheduler de recurrencias: %v", err)
+		return
+	}
+	if posted > 0 {
+		log.Printf("🔁 Scheduler de recurrencias registró %d transacciones", posted)
+	}
+}
@@ -0,0 +1,298 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	accountDomain "finanzas-api/internal/accounts/domain"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/recurring/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// dueBatchSize es el número de reglas vencidas que se procesan por lote
+	dueBatchSize = 100
+	// maxPreviewCount limita las fechas que retorna PreviewOccurrences
+	maxPreviewCount = 100
+	// maxUpcomingPerRule limita las fechas proyectadas por regla en Upcoming
+	maxUpcomingPerRule = 31
+)
+
+type RecurringUseCase struct {
+	recurringRepo domain.RecurringRepository
+	accountRepo   accountDomain.AccountRepository
+	categoryRepo  categoryDomain.CategoryRepository
+}
+
+func NewRecurringUseCase(recurringRepo domain.RecurringRepository, accountRepo accountDomain.AccountRepository, categoryRepo categoryDomain.CategoryRepository) domain.RecurringUseCase {
+	return &RecurringUseCase{
+		recurringRepo: recurringRepo,
+		accountRepo:   accountRepo,
+		categoryRepo:  categoryRepo,
+	}
+}
+
+// CreateRule implements domain.RecurringUseCase.
+func (uc *RecurringUseCase) CreateRule(rule *domain.RecurringRule) error {
+	if err := uc.validateRuleData(rule); err != nil {
+		return err
+	}
+
+	rule.Schedule()
+	return uc.recurringRepo.Create(rule)
+}
+
+// GetRule implements domain.RecurringUseCase.
+func (uc *RecurringUseCase) GetRule(userID, id uint) (*domain.RecurringRule, error) {
+	if id == 0 {
+		return nil, errors.New("invalid recurring rule ID")
+	}
+
+	rule, err := uc.recurringRepo.GetByID(id)
+	if err != nil || !rule.BelongsTo(userID) {
+		return nil, errors.New("recurring rule not found")
+	}
+
+	return rule, nil
+}
+
+// UpdateRule implements domain.RecurringUseCase.
+func (uc *RecurringUseCase) UpdateRule(userID uint, rule *domain.RecurringRule) error {
+	if rule.ID == 0 {
+		return errors.New("recurring rule ID is required")
+	}
+
+	existing, err := uc.GetRule(userID, rule.ID)
+	if err != nil {
+		return err
+	}
+
+	// El calendario no cambia: las ocurrencias ya registradas dependen de él.
+	// Para cambiar la frecuencia se crea una regla nueva.
+	rule.UserID = existing.UserID
+	rule.Frequency = existing.Frequency
+	rule.Interval = existing.Interval
+	rule.DayOfMonth = existing.DayOfMonth
+	rule.StartDate = existing.StartDate
+	rule.OccurrenceCount = existing.OccurrenceCount
+	rule.NextRunDate = existing.NextRunDate
+
+	if err := uc.validateRuleData(rule); err != nil {
+		return err
+	}
+
+	// Una nueva fecha de fin o límite puede terminar la regla
+	if rule.NextRunDate != nil && rule.IsFinishedAt(*rule.NextRunDate, rule.OccurrenceCount) {
+		rule.NextRunDate = nil
+	}
+
+	return uc.recurringRepo.Update(rule)
+}
+
+// DeleteRule implements domain.RecurringUseCase.
+func (uc *RecurringUseCase) DeleteRule(userID, id uint) error {
+	if _, err := uc.GetRule(userID, id); err != nil {
+		return err
+	}
+
+	return uc.recurringRepo.Delete(id)
+}
+
+// ListRules implements domain.RecurringUseCase.
+func (uc *RecurringUseCase) ListRules(userID uint) ([]*domain.RecurringRule, error) {
+	return uc.recurringRepo.ListByUser(userID)
+}
+
+// PreviewOccurrences implements domain.RecurringUseCase.
+func (uc *RecurringUseCase) PreviewOccurrences(userID, id uint, count int) ([]time.Time, error) {
+	rule, err := uc.GetRule(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if count <= 0 {
+		count = 10
+	}
+	if count > maxPreviewCount {
+		count = maxPreviewCount
+	}
+
+	// Se proyecta sin límite de fecha; el conteo y el fin de la regla cortan la serie
+	return rule.Project(time.Time{}, time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC), count), nil
+}
+
+// Upcoming implements domain.RecurringUseCase.
+func (uc *RecurringUseCase) Upcoming(ctx context.Context, userID uint, from, to time.Time) ([]*domain.ScheduledPayment, error) {
+	rules, err := uc.recurringRepo.WithContext(ctx).ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var payments []*domain.ScheduledPayment
+	for _, rule := range rules {
+		for _, date := range rule.Project(from, to, maxUpcomingPerRule) {
+			payments = append(payments, &domain.ScheduledPayment{Rule: rule, Date: date})
+		}
+	}
+
+	sort.SliceStable(payments, func(i, j int) bool {
+		return payments[i].Date.Before(payments[j].Date)
+	})
+
+	return payments, nil
+}
+
+// RunDue implements domain.RecurringUseCase.
+func (uc *RecurringUseCase) RunDue(asOf time.Time) (int, error) {
+	posted := 0
+	for {
+		rules, err := uc.recurringRepo.ListDue(asOf, dueBatchSize)
+		if err != nil {
+			return posted, err
+		}
+
+		progressed := false
+		for _, rule := range rules {
+			count, advanced, err := uc.runRule(rule, asOf)
+			posted += count
+			if err != nil {
+				// Una regla con error no detiene las demás
+				log.Printf("⚠️ Error al registrar la regla recurrente %d: %v", rule.ID, err)
+				continue
+			}
+			progressed = progressed || advanced
+		}
+
+		// Las reglas bloqueadas por otra instancia siguen vencidas; si nadie
+		// avanzó en este lote no tiene sentido volver a consultarlas.
+		if !progressed || len(rules) < dueBatchSize {
+			return posted, nil
+		}
+	}
+}
+
+// runRule registra todas las ocurrencias vencidas de una regla. Retorna
+// cuántas transacciones creó y si la regla avanzó.
+func (uc *RecurringUseCase) runRule(rule *domain.RecurringRule, asOf time.Time) (int, bool, error) {
+	// La transacción toma la moneda de la cuenta
+	account, err := uc.accountRepo.GetByID(rule.AccountID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	posted := 0
+	advanced := false
+	for rule.NextRunDate != nil && !rule.NextRunDate.After(asOf) {
+		date := *rule.NextRunDate
+
+		ok, err := uc.recurringRepo.PostOccurrence(rule, date, buildTransaction(rule, account.Currency, date))
+		if err != nil {
+			return posted, advanced, err
+		}
+		if ok {
+			posted++
+		}
+
+		// Si la regla no avanzó, otra instancia la está procesando
+		if rule.NextRunDate != nil && rule.NextRunDate.Equal(date) {
+			break
+		}
+		advanced = true
+	}
+
+	return posted, advanced, nil
+}
+
+// buildTransaction arma la transacción que materializa la ocurrencia date
+func buildTransaction(rule *domain.RecurringRule, currency string, date time.Time) *transactionDomain.Transaction {
+	return &transactionDomain.Transaction{
+		UserID:      rule.UserID,
+		AccountID:   rule.AccountID,
+		CategoryID:  rule.CategoryID,
+		Amount:      rule.Amount,
+		Currency:    currency,
+		Direction:   rule.Direction,
+		Date:        date,
+		Description: rule.Description,
+	}
+}
+
+// validateRuleData valida los datos de la regla y la pertenencia de la
+// cuenta y la categoría.
+func (uc *RecurringUseCase) validateRuleData(rule *domain.RecurringRule) error {
+	if rule == nil {
+		return errors.New("recurring rule is required")
+	}
+
+	if rule.UserID == 0 {
+		return errors.New("user ID is required")
+	}
+
+	if rule.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+
+	if rule.Direction != transactionDomain.DirectionIncome && rule.Direction != transactionDomain.DirectionExpense {
+		return errors.New("invalid direction")
+	}
+
+	if !rule.IsValidFrequency() {
+		return errors.New("invalid frequency")
+	}
+
+	if rule.Interval == 0 {
+		rule.Interval = 1
+	}
+	if rule.Interval < 0 {
+		return errors.New("interval must be positive")
+	}
+
+	if rule.StartDate.IsZero() {
+		return errors.New("start date is required")
+	}
+
+	if rule.Frequency == domain.FrequencyMonthly {
+		if rule.DayOfMonth == 0 {
+			rule.DayOfMonth = rule.StartDate.Day()
+		}
+		if rule.DayOfMonth < 1 || rule.DayOfMonth > 31 {
+			return errors.New("day of month must be between 1 and 31")
+		}
+	} else {
+		rule.DayOfMonth = 0
+	}
+
+	if rule.EndDate != nil && rule.EndDate.Before(rule.StartDate) {
+		return errors.New("end date must not be before start date")
+	}
+
+	if rule.MaxOccurrences != nil && *rule.MaxOccurrences <= 0 {
+		return errors.New("max occurrences must be positive")
+	}
+
+	rule.Description = strings.TrimSpace(rule.Description)
+	if len(rule.Description) > 255 {
+		return errors.New("description too long")
+	}
+
+	account, err := uc.accountRepo.GetByID(rule.AccountID)
+	if err != nil || !account.BelongsTo(rule.UserID) {
+		return errors.New("account not found")
+	}
+
+	if rule.CategoryID != nil {
+		category, err := uc.categoryRepo.GetByID(*rule.CategoryID)
+		if err != nil || !category.BelongsTo(rule.UserID) {
+			return errors.New("category not found")
+		}
+		if string(category.Kind) != string(rule.Direction) {
+			return errors.New("category kind does not match transaction direction")
+		}
+	}
+
+	return nil
+}
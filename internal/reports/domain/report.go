@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+var (
+	ErrInvalidCurrency = errors.New("invalid currency code")
+	ErrViewNotFound    = errors.New("view not found")
+)
+
+// PeriodTotals son los ingresos y gastos de un periodo, sin transferencias
+type PeriodTotals struct {
+	Income   int64
+	Expenses int64
+}
+
+// MonthTotals son los ingresos y gastos de un mes dentro de una serie
+type MonthTotals struct {
+	Month    time.Time `json:"-"`
+	Income   int64     `json:"income"`
+	Expenses int64     `json:"expenses"`
+}
+
+// CategoryTotal es el gasto agrupado por categoría raíz
+type CategoryTotal struct {
+	CategoryID *uint  `json:"category_id"` // nil para gastos sin categoría
+	Name       string `json:"name"`
+	Amount     int64  `json:"amount"`
+	Share      string `json:"share"` // Porcentaje del gasto total, p. ej. "23.45"
+}
+
+// UnconvertedTotal suma los montos en una moneda que no pudieron
+// convertirse a la del reporte por falta de cotización. No se incluyen en
+// los totales del reporte.
+type UnconvertedTotal struct {
+	Currency string `json:"currency"`
+	Count    int    `json:"count"`
+	Income   int64  `json:"income"`
+	Expenses int64  `json:"expenses"`
+}
+
+// PeriodSummary resume un mes
+type PeriodSummary struct {
+	Month       string              `json:"month"`
+	Income      int64               `json:"income"`
+	Expenses    int64               `json:"expenses"`
+	NetSavings  int64               `json:"net_savings"`
+	SavingsRate *string             `json:"savings_rate"` // Porcentaje; nil si no hubo ingresos
+	Unconverted []*UnconvertedTotal `json:"unconverted,omitempty"`
+}
+
+// Comparison contrasta el mes consultado contra otro periodo
+type Comparison struct {
+	PeriodSummary
+	IncomeChange     int64 `json:"income_change"`
+	ExpensesChange   int64 `json:"expenses_change"`
+	NetSavingsChange int64 `json:"net_savings_change"`
+}
+
+// MonthlyReport es el reporte financiero mensual de un usuario
+type MonthlyReport struct {
+	Currency string `json:"currency"`
+	PeriodSummary
+	TopCategories     []*CategoryTotal `json:"top_categories"`
+	PreviousMonth     *Comparison      `json:"previous_month"`
+	SameMonthLastYear *Comparison      `json:"same_month_last_year"`
+	ViewID            *uint            `json:"view_id,omitempty"` // Vista guardada que filtra el reporte
+}
+
+// ReportRepository define las agregaciones que alimentan los reportes.
+// Los rangos de fechas son semiabiertos: [from, to). criteria limita las
+// transacciones, p. ej. a las de una vista guardada; nil no filtra. Los
+// montos en otras monedas se convierten con la conversión registrada o la
+// de converted; los que no tienen ninguna solo cuentan en Unconverted.
+type ReportRepository interface {
+	// WithContext retorna el repositorio con sus consultas atadas a ctx: se
+	// cancelan cuando ctx vence
+	WithContext(ctx context.Context) ReportRepository
+	PeriodTotals(userID uint, currency string, from, to time.Time, criteria *transactionDomain.Criteria, converted transactionDomain.ConvertedAmounts) (*PeriodTotals, error)
+	Unconverted(userID uint, currency string, from, to time.Time, criteria *transactionDomain.Criteria, converted transactionDomain.ConvertedAmounts) ([]*UnconvertedTotal, error)
+	TopCategories(userID uint, currency string, from, to time.Time, limit int, criteria *transactionDomain.Criteria, converted transactionDomain.ConvertedAmounts) ([]*CategoryTotal, error)
+	// MonthlySeries retorna solo los meses con movimientos
+	MonthlySeries(userID uint, currency string, from, to time.Time, criteria *transactionDomain.Criteria, converted transactionDomain.ConvertedAmounts) ([]*MonthTotals, error)
+}
+
+// CurrencyConverter convierte, sin registrar nada, las transacciones a la
+// moneda de los reportes. Lo implementa el módulo de cotizaciones.
+type CurrencyConverter interface {
+	BaseCurrency(ctx context.Context, userID uint) (string, error)
+	PreviewConversions(ctx context.Context, userID uint, baseCurrency string, from, to time.Time) (transactionDomain.ConvertedAmounts, error)
+}
+
+// ViewResolver traduce una vista guardada a criterios de selección. Lo
+// implementa el módulo de vistas.
+type ViewResolver interface {
+	ResolveView(userID, id uint) (*transactionDomain.Criteria, error)
+}
+
+// ReportUseCase calcula los reportes en una moneda; si currency está vacío
+// se usa la moneda base del usuario.
+type ReportUseCase interface {
+	// GetMonthlyReport limita el reporte a una vista guardada si viewID no
+	// es nil; el periodo lo define el reporte, no la vista
+	GetMonthlyReport(ctx context.Context, userID uint, month time.Time, currency string, top int, viewID *uint) (*MonthlyReport, error)
+	PeriodTotals(ctx context.Context, userID uint, currency string, from, to time.Time) (*PeriodTotals, error)
+	MonthlySeries(ctx context.Context, userID uint, currency string, from, to time.Time) ([]*MonthTotals, error)
+	BaseCurrency(ctx context.Context, userID uint) (string, error)
+}
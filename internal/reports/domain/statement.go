@@ -0,0 +1,82 @@
+package domain
+
+import "time"
+
+// StatementMovement es una transacción del periodo con los nombres de su
+// categoría y de la categoría raíz, que agrupa el gráfico de gastos
+type StatementMovement struct {
+	ID             uint
+	AccountID      uint
+	Date           time.Time
+	Direction      string
+	IsTransfer     bool
+	Amount         int64 // En unidades menores, siempre positivo
+	Currency       string
+	Description    string
+	Payee          string
+	CategoryName   string // Vacío si la transacción no tiene categoría
+	RootCategoryID *uint
+	RootName       string
+}
+
+// AccountNet es la suma con signo de los movimientos de una cuenta
+type AccountNet struct {
+	AccountID uint
+	Net       int64
+}
+
+// StatementLine es un movimiento del extracto con el saldo tras aplicarlo
+type StatementLine struct {
+	Date         time.Time
+	Description  string
+	Payee        string
+	CategoryName string
+	IsTransfer   bool
+	Amount       int64 // Con signo: negativo para gastos
+	Balance      int64
+}
+
+// AccountStatement es el extracto mensual de una cuenta
+type AccountStatement struct {
+	AccountID      uint
+	Name           string
+	Currency       string
+	OpeningBalance int64
+	Income         int64 // Incluye transferencias recibidas
+	Expenses       int64 // Incluye transferencias enviadas
+	ClosingBalance int64
+	Lines          []*StatementLine
+}
+
+// CategoryBreakdown reparte el gasto de una moneda por categoría raíz. Un
+// extracto consolidado con cuentas en varias monedas tiene uno por moneda.
+type CategoryBreakdown struct {
+	Currency   string
+	Total      int64
+	Categories []*CategoryTotal
+}
+
+// MonthlyStatement es el extracto mensual de una cuenta o, si Consolidated,
+// de todas las cuentas del usuario
+type MonthlyStatement struct {
+	Month        time.Time
+	Consolidated bool
+	Accounts     []*AccountStatement
+	Categories   []*CategoryBreakdown
+}
+
+// StatementRepository define las consultas que alimentan los extractos.
+// Los rangos de fechas son semiabiertos: [from, to).
+type StatementRepository interface {
+	// NetBefore suma por cuenta los movimientos anteriores a before,
+	// transferencias incluidas
+	NetBefore(userID uint, accountID *uint, before time.Time) ([]*AccountNet, error)
+	// Movements retorna los movimientos del periodo en orden cronológico
+	Movements(userID uint, accountID *uint, from, to time.Time) ([]*StatementMovement, error)
+}
+
+// StatementUseCase arma el extracto de una cuenta o, si accountID es nil,
+// el consolidado del usuario
+type StatementUseCase interface {
+	GetMonthlyStatement(userID uint, month time.Time, accountID *uint) (*MonthlyStatement, error)
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"finanzas-api/internal/reports/domain"
+	"finanzas-api/internal/reports/pdf"
+
+	"github.com/gin-gonic/gin"
+)
+
+const monthLayout = "2006-01"
+
+type ReportHandler struct {
+	reportUseCase    domain.ReportUseCase
+	statementUseCase domain.StatementUseCase
+}
+
+// NewReportHandler crea una nueva instancia del handler de reportes
+func NewReportHandler(reportUseCase domain.ReportUseCase, statementUseCase domain.StatementUseCase) *ReportHandler {
+	return &ReportHandler{
+		reportUseCase:    reportUseCase,
+		statementUseCase: statementUseCase,
+	}
+}
+
+// GetMonthlyReport retorna el reporte del mes indicado (?month=YYYY-MM).
+// Parámetros opcionales: currency (por defecto la moneda base del usuario), top
+// y view_id (limita el reporte a las transacciones de una vista guardada).
+// Los montos en otras monedas se convierten con la cotización de su fecha;
+// los que no tienen cotización se informan aparte en "unconverted".
+func (h *ReportHandler) GetMonthlyReport(c *gin.Context) {
+	month, err := time.Parse(monthLayout, c.DefaultQuery("month", time.Now().Format(monthLayout)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid month, expected YYYY-MM",
+		})
+		return
+	}
+
+	top, err := strconv.Atoi(c.DefaultQuery("top", "5"))
+	if err != nil || top < 0 {
+		top = 5
+	}
+
+	var viewID *uint
+	if value := c.Query("view_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid view ID",
+			})
+			return
+		}
+		parsed := uint(id)
+		viewID = &parsed
+	}
+
+	report, err := h.reportUseCase.GetMonthlyReport(c.Request.Context(), c.GetUint("userID"), month, c.Query("currency"), top, viewID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCurrency):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+		case errors.Is(err, domain.ErrViewNotFound):
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to generate report",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"report": report,
+	})
+}
+
+// GetMonthlyStatementPDF descarga el extracto del mes (?month=YYYY-MM) en PDF.
+// Con account_id es el extracto de esa cuenta; sin él, el consolidado de
+// todas las cuentas del usuario.
+func (h *ReportHandler) GetMonthlyStatementPDF(c *gin.Context) {
+	month, err := time.Parse(monthLayout, c.DefaultQuery("month", time.Now().Format(monthLayout)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid month, expected YYYY-MM",
+		})
+		return
+	}
+
+	var accountID *uint
+	if value := c.Query("account_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid account ID",
+			})
+			return
+		}
+		parsed := uint(id)
+		accountID = &parsed
+	}
+
+	statement, err := h.statementUseCase.GetMonthlyStatement(c.GetUint("userID"), month, accountID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Se genera completo antes de responder para poder reportar un error
+	var document bytes.Buffer
+	if err := pdf.RenderMonthlyStatement(statement, &document); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to generate statement",
+		})
+		return
+	}
+
+	fileName := fmt.Sprintf("extracto-%s.pdf", month.Format(monthLayout))
+	if accountID != nil {
+		fileName = fmt.Sprintf("extracto-%d-%s.pdf", *accountID, month.Format(monthLayout))
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	c.Data(http.StatusOK, "application/pdf", document.Bytes())
+}
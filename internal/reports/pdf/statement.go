@@ -0,0 +1,399 @@
+// Package pdf genera los extractos mensuales en PDF con go-pdf/fpdf. Solo
+// usa las fuentes estándar de PDF, que no requieren archivos externos, y
+// no depende de la hora actual: el mismo extracto produce siempre los
+// mismos bytes.
+package pdf
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"finanzas-api/internal/reports/domain"
+	"finanzas-api/shared/money"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Medidas de la página A4 en milímetros
+const (
+	pageWidth    = 210.0
+	pageHeight   = 297.0
+	margin       = 15.0
+	contentWidth = pageWidth - 2*margin
+	footerHeight = 12.0
+	rowHeight    = 6.0
+)
+
+const (
+	fontFamily = "Helvetica"
+	// maxPieSlices es el número de porciones del gráfico; el resto de las
+	// categorías se agrupa en "Otras"
+	maxPieSlices = 7
+	pieRadius    = 28.0
+	// pieStep es el ángulo máximo en grados de cada segmento del arco
+	pieStep = 2.0
+)
+
+var monthNames = [...]string{
+	"Enero", "Febrero", "Marzo", "Abril", "Mayo", "Junio",
+	"Julio", "Agosto", "Septiembre", "Octubre", "Noviembre", "Diciembre",
+}
+
+// pieColors son los colores de las porciones, en orden
+var pieColors = [...][3]int{
+	{52, 101, 164}, {237, 125, 49}, {112, 173, 71}, {255, 192, 0},
+	{91, 155, 213}, {165, 105, 189}, {38, 166, 154}, {158, 158, 158},
+}
+
+// column es una columna de tabla: ancho en milímetros y alineación ("L" o "R")
+type column struct {
+	title string
+	width float64
+	align string
+}
+
+var summaryColumns = []column{
+	{"Cuenta", 60, "L"},
+	{"Moneda", 16, "L"},
+	{"Saldo inicial", 26, "R"},
+	{"Ingresos", 26, "R"},
+	{"Egresos", 26, "R"},
+	{"Saldo final", 26, "R"},
+}
+
+var movementColumns = []column{
+	{"Fecha", 20, "L"},
+	{"Descripción", 70, "L"},
+	{"Categoría", 36, "L"},
+	{"Monto", 27, "R"},
+	{"Saldo", 27, "R"},
+}
+
+// renderer guarda el documento y la traducción de UTF-8 a cp1252, la
+// codificación de las fuentes estándar
+type renderer struct {
+	pdf       *fpdf.Fpdf
+	tr        func(string) string
+	statement *domain.MonthlyStatement
+	// tableHeader se repite al inicio de cada página mientras haya una tabla abierta
+	tableHeader func()
+}
+
+// RenderMonthlyStatement escribe el extracto en w
+func RenderMonthlyStatement(statement *domain.MonthlyStatement, w io.Writer) error {
+	doc := fpdf.New("P", "mm", "A4", "")
+	r := &renderer{
+		pdf:       doc,
+		tr:        doc.UnicodeTranslatorFromDescriptor(""),
+		statement: statement,
+	}
+
+	// Fechas fijas y catálogo ordenado: la salida no cambia entre ejecuciones
+	closing := statement.Month.AddDate(0, 1, 0)
+	doc.SetCreationDate(closing)
+	doc.SetModificationDate(closing)
+	doc.SetCatalogSort(true)
+	doc.SetTitle(r.title()+" "+r.period(), true)
+	doc.SetMargins(margin, margin, margin)
+	doc.SetAutoPageBreak(false, 0)
+	doc.AliasNbPages("{nb}")
+	doc.SetFooterFunc(r.footer)
+
+	doc.AddPage()
+	r.header()
+	if statement.Consolidated {
+		r.summary()
+	}
+	for _, account := range statement.Accounts {
+		r.account(account)
+	}
+	r.categories()
+
+	return doc.Output(w)
+}
+
+func (r *renderer) title() string {
+	if r.statement.Consolidated {
+		return "Extracto consolidado"
+	}
+	return "Extracto de cuenta"
+}
+
+// period retorna el mes en palabras, p. ej. "Enero 2024"
+func (r *renderer) period() string {
+	return fmt.Sprintf("%s %d", monthNames[r.statement.Month.Month()-1], r.statement.Month.Year())
+}
+
+func (r *renderer) header() {
+	first := r.statement.Month
+	last := first.AddDate(0, 1, -1)
+
+	r.pdf.SetFont(fontFamily, "B", 16)
+	r.pdf.CellFormat(contentWidth, 9, r.tr(r.title()), "", 1, "L", false, 0, "")
+	r.pdf.SetFont(fontFamily, "", 10)
+	r.pdf.CellFormat(contentWidth, 5, r.tr(fmt.Sprintf("%s · del %s al %s", r.period(), formatDate(first), formatDate(last))), "", 1, "L", false, 0, "")
+	r.pdf.Ln(4)
+}
+
+func (r *renderer) footer() {
+	r.pdf.SetY(pageHeight - margin)
+	r.pdf.SetFont(fontFamily, "", 8)
+	r.pdf.SetTextColor(110, 110, 110)
+	r.pdf.CellFormat(contentWidth/2, 4, r.tr(r.title()+" "+r.statement.Month.Format("2006-01")), "", 0, "L", false, 0, "")
+	r.pdf.CellFormat(contentWidth/2, 4, r.tr(fmt.Sprintf("Página %d de {nb}", r.pdf.PageNo())), "", 0, "R", false, 0, "")
+	r.pdf.SetTextColor(0, 0, 0)
+}
+
+// ensureSpace pasa a una nueva página si no caben height milímetros más
+func (r *renderer) ensureSpace(height float64) {
+	if r.pdf.GetY()+height <= pageHeight-margin-footerHeight {
+		return
+	}
+	r.pdf.AddPage()
+	if r.tableHeader != nil {
+		r.tableHeader()
+	}
+}
+
+func (r *renderer) sectionTitle(title string) {
+	r.ensureSpace(10 + 2*rowHeight)
+	r.pdf.SetFont(fontFamily, "B", 12)
+	r.pdf.CellFormat(contentWidth, 8, r.tr(title), "", 1, "L", false, 0, "")
+}
+
+// openTable dibuja el encabezado de la tabla y lo repite en cada salto de página
+func (r *renderer) openTable(columns []column) {
+	r.tableHeader = func() {
+		r.pdf.SetFont(fontFamily, "B", 9)
+		r.pdf.SetFillColor(230, 230, 230)
+		for _, col := range columns {
+			r.pdf.CellFormat(col.width, rowHeight, r.tr(col.title), "B", 0, col.align, true, 0, "")
+		}
+		r.pdf.Ln(-1)
+		r.pdf.SetFont(fontFamily, "", 9)
+	}
+	r.ensureSpace(2 * rowHeight)
+	r.tableHeader()
+}
+
+func (r *renderer) closeTable() {
+	r.tableHeader = nil
+	r.pdf.Ln(4)
+}
+
+// row escribe una fila; el texto que no cabe en su columna se recorta
+func (r *renderer) row(columns []column, values []string, style string) {
+	r.ensureSpace(rowHeight)
+	r.pdf.SetFont(fontFamily, style, 9)
+	for i, col := range columns {
+		r.pdf.CellFormat(col.width, rowHeight, r.fit(values[i], col.width-2), "", 0, col.align, false, 0, "")
+	}
+	r.pdf.Ln(-1)
+	r.pdf.SetFont(fontFamily, "", 9)
+}
+
+// summary lista los saldos de todas las cuentas del consolidado
+func (r *renderer) summary() {
+	r.sectionTitle("Resumen por cuenta")
+	if len(r.statement.Accounts) == 0 {
+		r.note("No hay cuentas con movimientos en el periodo.")
+		return
+	}
+
+	r.openTable(summaryColumns)
+	for _, account := range r.statement.Accounts {
+		r.row(summaryColumns, []string{
+			account.Name,
+			account.Currency,
+			formatAmount(account.OpeningBalance, account.Currency),
+			formatAmount(account.Income, account.Currency),
+			formatAmount(account.Expenses, account.Currency),
+			formatAmount(account.ClosingBalance, account.Currency),
+		}, "")
+	}
+	r.closeTable()
+}
+
+// account escribe el saldo inicial, los movimientos y el saldo final de una cuenta
+func (r *renderer) account(account *domain.AccountStatement) {
+	r.sectionTitle(fmt.Sprintf("%s (%s)", account.Name, account.Currency))
+
+	r.openTable(movementColumns)
+	r.row(movementColumns, []string{
+		formatDate(r.statement.Month), "Saldo inicial", "", "",
+		formatAmount(account.OpeningBalance, account.Currency),
+	}, "B")
+	for _, line := range account.Lines {
+		description := line.Description
+		if description == "" {
+			description = line.Payee
+		}
+		category := line.CategoryName
+		if category == "" && line.IsTransfer {
+			category = "Transferencia"
+		}
+		r.row(movementColumns, []string{
+			formatDate(line.Date), description, category,
+			formatAmount(line.Amount, account.Currency),
+			formatAmount(line.Balance, account.Currency),
+		}, "")
+	}
+	r.row(movementColumns, []string{
+		formatDate(r.statement.Month.AddDate(0, 1, -1)), "Saldo final", "", "",
+		formatAmount(account.ClosingBalance, account.Currency),
+	}, "B")
+	r.closeTable()
+}
+
+// categories dibuja un gráfico de torta del gasto por cada moneda
+func (r *renderer) categories() {
+	r.sectionTitle("Gastos por categoría")
+	if len(r.statement.Categories) == 0 {
+		r.note("No hay gastos en el periodo.")
+		return
+	}
+
+	for _, breakdown := range r.statement.Categories {
+		slices := pieSlices(breakdown)
+		// Alto del bloque: el mayor entre la torta y la leyenda
+		height := math.Max(2*pieRadius, float64(len(slices)+1)*rowHeight) + 6
+		r.ensureSpace(height)
+
+		top := r.pdf.GetY()
+		r.pdf.SetFont(fontFamily, "B", 10)
+		r.pdf.CellFormat(contentWidth, rowHeight, r.tr(fmt.Sprintf("Total %s: %s", breakdown.Currency, formatAmount(breakdown.Total, breakdown.Currency))), "", 1, "L", false, 0, "")
+		r.pie(margin+pieRadius, top+rowHeight+pieRadius, slices, breakdown.Total)
+		r.legend(margin+2*pieRadius+10, top+rowHeight+2, slices, breakdown.Currency)
+		r.pdf.SetY(top + height)
+	}
+}
+
+func (r *renderer) pie(cx, cy float64, slices []*domain.CategoryTotal, total int64) {
+	r.pdf.SetDrawColor(255, 255, 255)
+	r.pdf.SetLineWidth(0.3)
+
+	// Empieza arriba (-90°) y avanza en sentido horario
+	start := -90.0
+	for i, slice := range slices {
+		color := pieColors[i%len(pieColors)]
+		r.pdf.SetFillColor(color[0], color[1], color[2])
+		if slice.Amount == total {
+			r.pdf.Circle(cx, cy, pieRadius, "F")
+			break
+		}
+
+		sweep := 360 * float64(slice.Amount) / float64(total)
+		steps := int(math.Ceil(sweep / pieStep))
+		points := []fpdf.PointType{{X: cx, Y: cy}}
+		for step := 0; step <= steps; step++ {
+			angle := (start + sweep*float64(step)/float64(steps)) * math.Pi / 180
+			points = append(points, fpdf.PointType{X: cx + pieRadius*math.Cos(angle), Y: cy + pieRadius*math.Sin(angle)})
+		}
+		r.pdf.Polygon(points, "DF")
+		start += sweep
+	}
+
+	r.pdf.SetDrawColor(0, 0, 0)
+	r.pdf.SetLineWidth(0.2)
+}
+
+func (r *renderer) legend(x, y float64, slices []*domain.CategoryTotal, currency string) {
+	r.pdf.SetFont(fontFamily, "", 9)
+	for i, slice := range slices {
+		color := pieColors[i%len(pieColors)]
+		r.pdf.SetFillColor(color[0], color[1], color[2])
+		r.pdf.Rect(x, y+1.5, 3, 3, "F")
+
+		r.pdf.SetXY(x+5, y)
+		r.pdf.CellFormat(60, rowHeight, r.fit(categoryName(slice), 58), "", 0, "L", false, 0, "")
+		r.pdf.CellFormat(30, rowHeight, r.tr(formatAmount(slice.Amount, currency)), "", 0, "R", false, 0, "")
+		r.pdf.CellFormat(20, rowHeight, r.tr(strings.Replace(slice.Share, ".", ",", 1)+" %"), "", 0, "R", false, 0, "")
+		y += rowHeight
+	}
+}
+
+func (r *renderer) note(text string) {
+	r.pdf.SetFont(fontFamily, "I", 9)
+	r.pdf.CellFormat(contentWidth, rowHeight, r.tr(text), "", 1, "L", false, 0, "")
+	r.pdf.SetFont(fontFamily, "", 9)
+	r.pdf.Ln(4)
+}
+
+// fit traduce el texto y lo recorta con "..." si supera width milímetros
+func (r *renderer) fit(text string, width float64) string {
+	text = r.tr(text)
+	if r.pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	for len(text) > 0 && r.pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
+
+// pieSlices deja las categorías más grandes y agrupa el resto en "Otras".
+// Las categorías ya vienen ordenadas de mayor a menor.
+func pieSlices(breakdown *domain.CategoryBreakdown) []*domain.CategoryTotal {
+	if len(breakdown.Categories) <= maxPieSlices {
+		return breakdown.Categories
+	}
+
+	slices := append([]*domain.CategoryTotal{}, breakdown.Categories[:maxPieSlices-1]...)
+	others := &domain.CategoryTotal{Name: "Otras"}
+	var shown int64
+	for _, slice := range slices {
+		shown += slice.Amount
+	}
+	others.Amount = breakdown.Total - shown
+	// La participación de "Otras" es el resto para que la leyenda sume 100
+	others.Share = remainingShare(slices)
+	return append(slices, others)
+}
+
+// remainingShare resta de 100.00 las participaciones mostradas
+func remainingShare(slices []*domain.CategoryTotal) string {
+	remaining := int64(10000)
+	for _, slice := range slices {
+		var whole, cents int64
+		fmt.Sscanf(slice.Share, "%d.%d", &whole, &cents)
+		remaining -= whole*100 + cents
+	}
+	return fmt.Sprintf("%d.%02d", remaining/100, remaining%100)
+}
+
+func categoryName(category *domain.CategoryTotal) string {
+	if category.CategoryID == nil && category.Name == "" {
+		return "Sin categoría"
+	}
+	return category.Name
+}
+
+func formatDate(date time.Time) string {
+	return date.Format("02/01/2006")
+}
+
+// formatAmount usa la convención en español: punto para miles y coma
+// decimal, p. ej. -1.234.567,89
+func formatAmount(amount int64, currency string) string {
+	decimal := money.New(amount, currency).Decimal()
+	sign := ""
+	if strings.HasPrefix(decimal, "-") {
+		sign, decimal = "-", decimal[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(decimal, ".")
+
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	if hasFraction {
+		return sign + grouped.String() + "," + fraction
+	}
+	return sign + grouped.String()
+}
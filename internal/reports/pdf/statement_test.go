@@ -0,0 +1,242 @@
+package pdf
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"finanzas-api/internal/reports/domain"
+)
+
+// update reescribe los archivos .golden con la salida actual:
+//
+//	go test ./internal/reports/pdf -update
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// accountStatement arma un extracto de una cuenta con un gasto, una
+// transferencia y un ingreso, con tildes y una descripción que no cabe
+func accountStatement() *domain.MonthlyStatement {
+	mercado := uint(1)
+	return &domain.MonthlyStatement{
+		Month: day(2024, 3, 1),
+		Accounts: []*domain.AccountStatement{{
+			AccountID:      1,
+			Name:           "Cuenta de ahorros",
+			Currency:       "COP",
+			OpeningBalance: 150000000,
+			Income:         350000000,
+			Expenses:       62050000,
+			ClosingBalance: 437950000,
+			Lines: []*domain.StatementLine{
+				{Date: day(2024, 3, 5), Description: "Éxito Calle 80 - compra con tarjeta débito terminada en 1234 en el almacén", CategoryName: "Mercado", Amount: -12050000, Balance: 137950000},
+				{Date: day(2024, 3, 10), Payee: "Ahorro programado", IsTransfer: true, Amount: -50000000, Balance: 87950000},
+				{Date: day(2024, 3, 15), Description: "Nómina", CategoryName: "Salario", Amount: 350000000, Balance: 437950000},
+			},
+		}},
+		Categories: []*domain.CategoryBreakdown{{
+			Currency:   "COP",
+			Total:      12050000,
+			Categories: []*domain.CategoryTotal{{CategoryID: &mercado, Name: "Mercado", Amount: 12050000, Share: "100.00"}},
+		}},
+	}
+}
+
+// consolidatedStatement arma un consolidado en dos monedas, con movimientos
+// suficientes para pasar de página y más categorías de las que muestra la torta
+func consolidatedStatement() *domain.MonthlyStatement {
+	statement := &domain.MonthlyStatement{Month: day(2024, 2, 1), Consolidated: true}
+
+	bank := &domain.AccountStatement{AccountID: 1, Name: "Banco", Currency: "COP", OpeningBalance: 500000000}
+	balance := bank.OpeningBalance
+	for i := 0; i < 45; i++ {
+		amount := -int64(1000000 + 25000*i)
+		balance += amount
+		bank.Expenses -= amount
+		bank.Lines = append(bank.Lines, &domain.StatementLine{
+			Date:         day(2024, 2, 1+i%29),
+			Description:  fmt.Sprintf("Compra %d", i+1),
+			CategoryName: fmt.Sprintf("Categoría %d", i%9+1),
+			Amount:       amount,
+			Balance:      balance,
+		})
+	}
+	bank.ClosingBalance = balance
+
+	dollars := &domain.AccountStatement{
+		AccountID: 2, Name: "Dólares", Currency: "USD",
+		OpeningBalance: -2500, Expenses: 1999, ClosingBalance: -4499,
+		Lines: []*domain.StatementLine{
+			{Date: day(2024, 2, 29), Description: "Suscripción", Amount: -1999, Balance: -4499},
+		},
+	}
+	statement.Accounts = []*domain.AccountStatement{bank, dollars}
+
+	copBreakdown := &domain.CategoryBreakdown{Currency: "COP", Total: bank.Expenses}
+	shares := []string{"20.00", "17.50", "15.00", "12.50", "10.00", "9.00", "7.00", "5.00", "4.00"}
+	var assigned int64
+	for i, share := range shares {
+		id := uint(i + 1)
+		amount := bank.Expenses * int64(len(shares)-i) / 45
+		if i == len(shares)-1 {
+			amount = bank.Expenses - assigned
+		}
+		assigned += amount
+		copBreakdown.Categories = append(copBreakdown.Categories, &domain.CategoryTotal{CategoryID: &id, Name: fmt.Sprintf("Categoría %d", i+1), Amount: amount, Share: share})
+	}
+	statement.Categories = []*domain.CategoryBreakdown{
+		copBreakdown,
+		{Currency: "USD", Total: 1999, Categories: []*domain.CategoryTotal{{Amount: 1999, Share: "100.00"}}},
+	}
+	return statement
+}
+
+func TestRenderMonthlyStatement(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement *domain.MonthlyStatement
+	}{
+		{name: "account", statement: accountStatement()},
+		{name: "consolidated", statement: consolidatedStatement()},
+		{name: "empty", statement: &domain.MonthlyStatement{Month: day(2024, 1, 1), Consolidated: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bytes.Buffer
+			if err := RenderMonthlyStatement(tt.statement, &got); err != nil {
+				t.Fatalf("RenderMonthlyStatement() error = %v", err)
+			}
+
+			// La salida no depende de la hora actual
+			var again bytes.Buffer
+			if err := RenderMonthlyStatement(tt.statement, &again); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got.Bytes(), again.Bytes()) {
+				t.Fatal("two renders of the same statement differ")
+			}
+
+			golden := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, got.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Errorf("output differs from %s at byte %d (got %d bytes, want %d); run with -update if the change is intended",
+					golden, firstDifference(got.Bytes(), want), got.Len(), len(want))
+			}
+		})
+	}
+}
+
+func firstDifference(a, b []byte) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	if len(a) < len(b) {
+		return len(a)
+	}
+	return len(b)
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		currency string
+		want     string
+	}{
+		{0, "COP", "0,00"},
+		{5, "COP", "0,05"},
+		{123456789, "COP", "1.234.567,89"},
+		{-100000, "USD", "-1.000,00"},
+		{1500, "JPY", "1.500"},
+		{-999, "JPY", "-999"},
+		{1234567, "KWD", "1.234,567"},
+	}
+	for _, tt := range tests {
+		if got := formatAmount(tt.amount, tt.currency); got != tt.want {
+			t.Errorf("formatAmount(%d, %s) = %q, want %q", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestPieSlices(t *testing.T) {
+	categories := func(shares ...string) *domain.CategoryBreakdown {
+		breakdown := &domain.CategoryBreakdown{Currency: "COP"}
+		for i, share := range shares {
+			amount := int64(100 * (len(shares) - i))
+			breakdown.Total += amount
+			breakdown.Categories = append(breakdown.Categories, &domain.CategoryTotal{Name: fmt.Sprint(i + 1), Amount: amount, Share: share})
+		}
+		return breakdown
+	}
+
+	tests := []struct {
+		name        string
+		breakdown   *domain.CategoryBreakdown
+		wantSlices  int
+		wantOthers  string
+		wantOthersN int64
+	}{
+		{name: "fewer categories than slices", breakdown: categories("60.00", "40.00"), wantSlices: 2},
+		{name: "exactly the slice limit", breakdown: categories("25.00", "21.43", "17.86", "14.29", "10.71", "7.14", "3.57"), wantSlices: maxPieSlices},
+		{
+			// 9+8+...+1 = 45 partes; "Otras" agrupa las tres menores (3+2+1)
+			name:        "grouped into others",
+			breakdown:   categories("20.00", "17.78", "15.56", "13.33", "11.11", "8.89", "6.67", "4.44", "2.22"),
+			wantSlices:  maxPieSlices,
+			wantOthers:  "13.33",
+			wantOthersN: 600,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slices := pieSlices(tt.breakdown)
+			if len(slices) != tt.wantSlices {
+				t.Fatalf("got %d slices, want %d", len(slices), tt.wantSlices)
+			}
+			if tt.wantOthers == "" {
+				return
+			}
+			others := slices[len(slices)-1]
+			if others.Name != "Otras" || others.Share != tt.wantOthers || others.Amount != tt.wantOthersN {
+				t.Errorf("others = %q %s %d, want Otras %s %d", others.Name, others.Share, others.Amount, tt.wantOthers, tt.wantOthersN)
+			}
+		})
+	}
+}
+
+func TestRemainingShare(t *testing.T) {
+	tests := []struct {
+		shares []string
+		want   string
+	}{
+		{nil, "100.00"},
+		{[]string{"33.33", "33.33"}, "33.34"},
+		{[]string{"99.99"}, "0.01"},
+		{[]string{"50.05", "25.50"}, "24.45"},
+	}
+	for _, tt := range tests {
+		slices := make([]*domain.CategoryTotal, len(tt.shares))
+		for i, share := range tt.shares {
+			slices[i] = &domain.CategoryTotal{Share: share}
+		}
+		if got := remainingShare(slices); got != tt.want {
+			t.Errorf("remainingShare(%v) = %q, want %q", tt.shares, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package reports
+
+import (
+	accountDomain "finanzas-api/internal/accounts/domain"
+	"finanzas-api/internal/reports/domain"
+	"finanzas-api/internal/reports/handler"
+	"finanzas-api/internal/reports/repository"
+	"finanzas-api/internal/reports/usecase"
+
+	"gorm.io/gorm"
+)
+
+type ReportsModule struct {
+	Handler          *handler.ReportHandler
+	UseCase          domain.ReportUseCase
+	Repository       domain.ReportRepository
+	StatementUseCase domain.StatementUseCase
+}
+
+func NewReportsModule(db *gorm.DB, converter domain.CurrencyConverter, accountRepo accountDomain.AccountRepository, views domain.ViewResolver) *ReportsModule {
+	var reportRepo domain.ReportRepository
+	var reportUseCase domain.ReportUseCase
+	var statementRepo domain.StatementRepository
+	var statementUseCase domain.StatementUseCase
+	var reportHandler *handler.ReportHandler
+
+	reportRepo = repository.NewReportPostgresRepository(db)
+	reportUseCase = usecase.NewReportUseCase(reportRepo, converter, views)
+	statementRepo = repository.NewStatementPostgresRepository(db)
+	statementUseCase = usecase.NewStatementUseCase(statementRepo, accountRepo)
+	reportHandler = handler.NewReportHandler(reportUseCase, statementUseCase)
+
+	return &ReportsModule{
+		Handler:          reportHandler,
+		UseCase:          reportUseCase,
+		Repository:       reportRepo,
+		StatementUseCase: statementUseCase,
+	}
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/reports/domain"
+
+type ReportRepository interface {
+	domain.ReportRepository
+}
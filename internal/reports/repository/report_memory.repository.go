@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	categoryDomain "finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/reports/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+// reportRepositoryMemory agrega en Go sobre los repositorios en memoria de
+// los demás módulos. No hay conversiones registradas, así que solo incluye
+// las transacciones que ya están en la moneda solicitada y las de converted.
+type reportRepositoryMemory struct {
+	transactionRepo transactionDomain.TransactionRepository
+	categoryRepo    categoryDomain.CategoryRepository
+}
+
+func NewReportMemoryRepository(transactionRepo transactionDomain.TransactionRepository, categoryRepo categoryDomain.CategoryRepository) domain.ReportRepository {
+	return &reportRepositoryMemory{
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+	}
+}
+
+// WithContext retorna el mismo repositorio: en memoria no hay consultas que
+// cancelar
+func (r *reportRepositoryMemory) WithContext(ctx context.Context) domain.ReportRepository {
+	return r
+}
+
+func (r *reportRepositoryMemory) PeriodTotals(userID uint, currency string, from, to time.Time, criteria *transactionDomain.Criteria, converted transactionDomain.ConvertedAmounts) (*domain.PeriodTotals, error) {
+	transactions, _, err := r.transactions(userID, currency, from, to, criteria, converted)
+	if err != nil {
+		return nil, err
+	}
+
+	var totals domain.PeriodTotals
+	for _, transaction := range transactions {
+		if transaction.Direction == transactionDomain.DirectionIncome {
+			totals.Income += transaction.Amount
+		} else {
+			totals.Expenses += transaction.Amount
+		}
+	}
+	return &totals, nil
+}
+
+func (r *reportRepositoryMemory) TopCategories(userID uint, currency string, from, to time.Time, limit int, criteria *transactionDomain.Criteria, converted transactionDomain.ConvertedAmounts) ([]*domain.CategoryTotal, error) {
+	transactions, _, err := r.transactions(userID, currency, from, to, criteria, converted)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := r.categoryRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*categoryDomain.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	// root sube por el árbol hasta la categoría raíz
+	root := func(id uint) *categoryDomain.Category {
+		current := byID[id]
+		for steps := 0; current != nil && current.ParentID != nil && steps < len(categories); steps++ {
+			current = byID[*current.ParentID]
+		}
+		return current
+	}
+
+	byRoot := make(map[uint]*domain.CategoryTotal)
+	uncategorized := &domain.CategoryTotal{}
+	for _, transaction := range transactions {
+		if transaction.Direction != transactionDomain.DirectionExpense {
+			continue
+		}
+		total := uncategorized
+		if transaction.CategoryID != nil {
+			if category := root(*transaction.CategoryID); category != nil {
+				if byRoot[category.ID] == nil {
+					id := category.ID
+					byRoot[category.ID] = &domain.CategoryTotal{CategoryID: &id, Name: category.Name}
+				}
+				total = byRoot[category.ID]
+			}
+		}
+		total.Amount += transaction.Amount
+	}
+
+	result := make([]*domain.CategoryTotal, 0, len(byRoot)+1)
+	for _, total := range byRoot {
+		result = append(result, total)
+	}
+	if uncategorized.Amount > 0 {
+		result = append(result, uncategorized)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Amount > result[j].Amount })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *reportRepositoryMemory) MonthlySeries(userID uint, currency string, from, to time.Time, criteria *transactionDomain.Criteria, converted transactionDomain.ConvertedAmounts) ([]*domain.MonthTotals, error) {
+	transactions, _, err := r.transactions(userID, currency, from, to, criteria, converted)
+	if err != nil {
+		return nil, err
+	}
+
+	byMonth := make(map[time.Time]*domain.MonthTotals)
+	for _, transaction := range transactions {
+		month := time.Date(transaction.Date.Year(), transaction.Date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		totals, exists := byMonth[month]
+		if !exists {
+			totals = &domain.MonthTotals{Month: month}
+			byMonth[month] = totals
+		}
+		if transaction.Direction == transactionDomain.DirectionIncome {
+			totals.Income += transaction.Amount
+		} else {
+			totals.Expenses += transaction.Amount
+		}
+	}
+
+	series := make([]*domain.MonthTotals, 0, len(byMonth))
+	for _, totals := range byMonth {
+		series = append(series, totals)
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Month.Before(series[j].Month) })
+	return series, nil
+}
+
+func (r *reportRepositoryMemory) Unconverted(userID uint, currency string, from, to time.Time, criteria *transactionDomain.Criteria, converted transactionDomain.ConvertedAmounts) ([]*domain.UnconvertedTotal, error) {
+	_, unconverted, err := r.transactions(userID, currency, from, to, criteria, converted)
+	if err != nil {
+		return nil, err
+	}
+
+	byCurrency := make(map[string]*domain.UnconvertedTotal)
+	for _, transaction := range unconverted {
+		total, exists := byCurrency[transaction.Currency]
+		if !exists {
+			total = &domain.UnconvertedTotal{Currency: transaction.Currency}
+			byCurrency[transaction.Currency] = total
+		}
+		total.Count++
+		if transaction.Direction == transactionDomain.DirectionIncome {
+			total.Income += transaction.Amount
+		} else {
+			total.Expenses += transaction.Amount
+		}
+	}
+
+	totals := make([]*domain.UnconvertedTotal, 0, len(byCurrency))
+	for _, total := range byCurrency {
+		totals = append(totals, total)
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].Currency < totals[j].Currency })
+	return totals, nil
+}
+
+// transactions retorna las transacciones del periodo sin transferencias que
+// cumplen criteria: las que tienen monto en currency, con el monto
+// convertido, y aparte las que no pudieron convertirse.
+func (r *reportRepositoryMemory) transactions(userID uint, currency string, from, to time.Time, criteria *transactionDomain.Criteria, converted transactionDomain.ConvertedAmounts) ([]*transactionDomain.Transaction, []*transactionDomain.Transaction, error) {
+	last := to.AddDate(0, 0, -1)
+	all, err := r.transactionRepo.List(userID, transactionDomain.TransactionFilter{From: &from, To: &last, Criteria: criteria})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result, unconverted []*transactionDomain.Transaction
+	for _, transaction := range all {
+		if transaction.IsTransfer {
+			continue
+		}
+		if transaction.Currency == currency {
+			result = append(result, transaction)
+			continue
+		}
+		amount, exists := converted[transaction.ID]
+		if !exists {
+			unconverted = append(unconverted, transaction)
+			continue
+		}
+		copied := *transaction
+		copied.Amount = amount
+		result = append(result, &copied)
+	}
+	return result, unconverted, nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"finanzas-api/internal/reports/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+
+	"gorm.io/gorm"
+)
+
+// convertedAmount es el monto de la transacción en la moneda del reporte;
+// NULL si no tiene conversión
+var convertedAmount = transactionDomain.ConvertedAmountSQL("transactions")
+
+type reportPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewReportPostgresRepository(db *gorm.DB) domain.ReportRepository {
+	return &reportPostgresRepository{db: db}
+}
+
+func (r *reportPostgresRepository) WithContext(ctx context.Context) domain.ReportRepository {
+	return &reportPostgresRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *reportPostgresRepository) PeriodTotals(userID uint, currency string, from, to time.Time, criteria *transactionDomain.Criteria, converted transactionDomain.ConvertedAmounts) (*domain.PeriodTotals, error) {
+	var totals domain.PeriodTotals
+	joins, joinArgs := converted.JoinSQL("transactions", currency)
+	query := r.db.Model(&transactionDomain.Transaction{}).
+		Select("COALESCE(SUM(CASE WHEN transactions.direction = ? THEN "+convertedAmount+" END), 0) AS income, "+
+			"COALESCE(SUM(CASE WHEN transactions.direction = ? THEN "+convertedAmount+" END), 0) AS expenses",
+			transactionDomain.DirectionIncome, currency, transactionDomain.DirectionExpense, currency).
+		Joins(joins, joinArgs...).
+		Where("transactions.user_id = ? AND transactions.is_transfer = ?", userID, false).
+		Where("transactions.date >= ? AND transactions.date < ?", from, to)
+	err := criteria.Apply(query, "transactions").Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return &totals, nil
+}
+
+// TopCategories agrupa el gasto por categoría raíz recorriendo el árbol con
+// un CTE recursivo.
+func (r *reportPostgresRepository) TopCategories(userID uint, currency string, from, to time.Time, limit int, criteria *transactionDomain.Criteria, converted transactionDomain.ConvertedAmounts) ([]*domain.CategoryTotal, error) {
+	joins, joinArgs := converted.JoinSQL("t", currency)
+	args := append([]interface{}{userID, currency}, joinArgs...)
+	args = append(args, userID, transactionDomain.DirectionExpense, from, to)
+
+	// Los criterios se aplican con una subconsulta de IDs para no repetir
+	// su traducción a SQL en la consulta cruda
+	filter := ""
+	if criteria != nil {
+		filter = "AND t.id IN (?)"
+		matching := r.db.Model(&transactionDomain.Transaction{}).Select("transactions.id").Where("transactions.user_id = ?", userID)
+		args = append(args, criteria.Apply(matching, "transactions"))
+	}
+	args = append(args, limit)
+
+	var totals []*domain.CategoryTotal
+	err := r.db.Raw(`
+		WITH RECURSIVE tree AS (
+			SELECT id, id AS root_id FROM categories
+			WHERE user_id = ? AND parent_id IS NULL AND deleted_at IS NULL
+			UNION ALL
+			SELECT c.id, tree.root_id FROM categories c
+			JOIN tree ON c.parent_id = tree.id
+			WHERE c.deleted_at IS NULL
+		)
+		SELECT root.id AS category_id, COALESCE(root.name, '') AS name,
+			COALESCE(SUM(`+transactionDomain.ConvertedAmountSQL("t")+`), 0) AS amount
+		FROM transactions t
+		`+joins+`
+		LEFT JOIN tree ON tree.id = t.category_id
+		LEFT JOIN categories root ON root.id = tree.root_id
+		WHERE t.user_id = ? AND t.direction = ? AND t.is_transfer = false AND t.deleted_at IS NULL
+		AND t.date >= ? AND t.date < ? `+filter+`
+		GROUP BY root.id, root.name
+		ORDER BY amount DESC
+		LIMIT ?`, args...).
+		Scan(&totals).Error
+	return totals, err
+}
+
+func (r *reportPostgresRepository) MonthlySeries(userID uint, currency string, from, to time.Time, criteria *transactionDomain.Criteria, converted transactionDomain.ConvertedAmounts) ([]*domain.MonthTotals, error) {
+	var series []*domain.MonthTotals
+	joins, joinArgs := converted.JoinSQL("transactions", currency)
+	query := r.db.Model(&transactionDomain.Transaction{}).
+		Select("date_trunc('month', transactions.date) AS month, "+
+			"COALESCE(SUM(CASE WHEN transactions.direction = ? THEN "+convertedAmount+" END), 0) AS income, "+
+			"COALESCE(SUM(CASE WHEN transactions.direction = ? THEN "+convertedAmount+" END), 0) AS expenses",
+			transactionDomain.DirectionIncome, currency, transactionDomain.DirectionExpense, currency).
+		Joins(joins, joinArgs...).
+		Where("transactions.user_id = ? AND transactions.is_transfer = ?", userID, false).
+		Where("transactions.date >= ? AND transactions.date < ?", from, to)
+	err := criteria.Apply(query, "transactions").
+		Group("date_trunc('month', transactions.date)").
+		Order("month").
+		Scan(&series).Error
+	return series, err
+}
+
+func (r *reportPostgresRepository) Unconverted(userID uint, currency string, from, to time.Time, criteria *transactionDomain.Criteria, converted transactionDomain.ConvertedAmounts) ([]*domain.UnconvertedTotal, error) {
+	var totals []*domain.UnconvertedTotal
+	joins, joinArgs := converted.JoinSQL("transactions", currency)
+	query := r.db.Model(&transactionDomain.Transaction{}).
+		Select("transactions.currency, COUNT(*) AS count, "+
+			"COALESCE(SUM(CASE WHEN transactions.direction = ? THEN transactions.amount END), 0) AS income, "+
+			"COALESCE(SUM(CASE WHEN transactions.direction = ? THEN transactions.amount END), 0) AS expenses",
+			transactionDomain.DirectionIncome, transactionDomain.DirectionExpense).
+		Joins(joins, joinArgs...).
+		Where("transactions.user_id = ? AND transactions.is_transfer = ?", userID, false).
+		Where("transactions.date >= ? AND transactions.date < ?", from, to).
+		Where("transactions.currency <> ? AND tc.id IS NULL AND pc.transaction_id IS NULL", currency)
+	err := criteria.Apply(query, "transactions").
+		Group("transactions.currency").
+		Order("transactions.currency").
+		Scan(&totals).Error
+	return totals, err
+}
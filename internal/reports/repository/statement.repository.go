@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/reports/domain"
+
+type StatementRepository interface {
+	domain.StatementRepository
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"sort"
+	"time"
+
+	categoryDomain "finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/reports/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+// statementRepositoryMemory arma los extractos sobre los repositorios en
+// memoria de los demás módulos.
+type statementRepositoryMemory struct {
+	transactionRepo transactionDomain.TransactionRepository
+	categoryRepo    categoryDomain.CategoryRepository
+}
+
+func NewStatementMemoryRepository(transactionRepo transactionDomain.TransactionRepository, categoryRepo categoryDomain.CategoryRepository) domain.StatementRepository {
+	return &statementRepositoryMemory{
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+	}
+}
+
+func (r *statementRepositoryMemory) NetBefore(userID uint, accountID *uint, before time.Time) ([]*domain.AccountNet, error) {
+	last := before.AddDate(0, 0, -1)
+	transactions, err := r.transactionRepo.List(userID, transactionDomain.TransactionFilter{AccountID: accountID, To: &last})
+	if err != nil {
+		return nil, err
+	}
+
+	byAccount := make(map[uint]*domain.AccountNet)
+	for _, transaction := range transactions {
+		net, exists := byAccount[transaction.AccountID]
+		if !exists {
+			net = &domain.AccountNet{AccountID: transaction.AccountID}
+			byAccount[transaction.AccountID] = net
+		}
+		net.Net += transaction.SignedAmount()
+	}
+
+	nets := make([]*domain.AccountNet, 0, len(byAccount))
+	for _, net := range byAccount {
+		nets = append(nets, net)
+	}
+	sort.Slice(nets, func(i, j int) bool { return nets[i].AccountID < nets[j].AccountID })
+	return nets, nil
+}
+
+func (r *statementRepositoryMemory) Movements(userID uint, accountID *uint, from, to time.Time) ([]*domain.StatementMovement, error) {
+	last := to.AddDate(0, 0, -1)
+	transactions, err := r.transactionRepo.List(userID, transactionDomain.TransactionFilter{AccountID: accountID, From: &from, To: &last})
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := r.categoryRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*categoryDomain.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	// List retorna de la más reciente a la más antigua
+	sort.Slice(transactions, func(i, j int) bool {
+		if !transactions[i].Date.Equal(transactions[j].Date) {
+			return transactions[i].Date.Before(transactions[j].Date)
+		}
+		return transactions[i].ID < transactions[j].ID
+	})
+
+	movements := make([]*domain.StatementMovement, 0, len(transactions))
+	for _, transaction := range transactions {
+		movement := &domain.StatementMovement{
+			ID:          transaction.ID,
+			AccountID:   transaction.AccountID,
+			Date:        transaction.Date,
+			Direction:   string(transaction.Direction),
+			IsTransfer:  transaction.IsTransfer,
+			Amount:      transaction.Amount,
+			Currency:    transaction.Currency,
+			Description: transaction.Description,
+			Payee:       transaction.Payee,
+		}
+		if transaction.CategoryID != nil {
+			if category := byID[*transaction.CategoryID]; category != nil {
+				movement.CategoryName = category.Name
+				// Sube por el árbol hasta la categoría raíz
+				root := category
+				for steps := 0; root.ParentID != nil && byID[*root.ParentID] != nil && steps < len(categories); steps++ {
+					root = byID[*root.ParentID]
+				}
+				id := root.ID
+				movement.RootCategoryID = &id
+				movement.RootName = root.Name
+			}
+		}
+		movements = append(movements, movement)
+	}
+	return movements, nil
+}
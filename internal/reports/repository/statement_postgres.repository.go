@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"time"
+
+	"finanzas-api/internal/reports/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+
+	"gorm.io/gorm"
+)
+
+type statementPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewStatementPostgresRepository(db *gorm.DB) domain.StatementRepository {
+	return &statementPostgresRepository{db: db}
+}
+
+func (r *statementPostgresRepository) NetBefore(userID uint, accountID *uint, before time.Time) ([]*domain.AccountNet, error) {
+	query := r.db.Model(&transactionDomain.Transaction{}).
+		Select("account_id, COALESCE(SUM(CASE WHEN direction = ? THEN amount ELSE -amount END), 0) AS net", transactionDomain.DirectionIncome).
+		Where("user_id = ? AND date < ?", userID, before)
+	if accountID != nil {
+		query = query.Where("account_id = ?", *accountID)
+	}
+
+	var nets []*domain.AccountNet
+	err := query.Group("account_id").Scan(&nets).Error
+	return nets, err
+}
+
+// Movements sube por el árbol de categorías con el mismo CTE recursivo de
+// TopCategories para que el gráfico agrupe igual que el reporte mensual.
+func (r *statementPostgresRepository) Movements(userID uint, accountID *uint, from, to time.Time) ([]*domain.StatementMovement, error) {
+	accountFilter := ""
+	args := []interface{}{userID, userID, from, to}
+	if accountID != nil {
+		accountFilter = "AND t.account_id = ?"
+		args = append(args, *accountID)
+	}
+
+	var movements []*domain.StatementMovement
+	err := r.db.Raw(`
+		WITH RECURSIVE tree AS (
+			SELECT id, id AS root_id FROM categories
+			WHERE user_id = ? AND parent_id IS NULL AND deleted_at IS NULL
+			UNION ALL
+			SELECT c.id, tree.root_id FROM categories c
+			JOIN tree ON c.parent_id = tree.id
+			WHERE c.deleted_at IS NULL
+		)
+		SELECT t.id, t.account_id, t.date, t.direction, t.is_transfer, t.amount, t.currency,
+			t.description, t.payee, COALESCE(category.name, '') AS category_name,
+			root.id AS root_category_id, COALESCE(root.name, '') AS root_name
+		FROM transactions t
+		LEFT JOIN categories category ON category.id = t.category_id
+		LEFT JOIN tree ON tree.id = t.category_id
+		LEFT JOIN categories root ON root.id = tree.root_id
+		WHERE t.user_id = ? AND t.deleted_at IS NULL AND t.date >= ? AND t.date < ? `+accountFilter+`
+		ORDER BY t.date, t.id`,
+		args...).
+		Scan(&movements).Error
+	return movements, err
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"finanzas-api/internal/reports/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupReportRoutes configura las rutas para el módulo de reportes
+func SetupReportRoutes(router *gin.Engine, reportHandler *handler.ReportHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	reportRoutes := router.Group("/api/v1/reports")
+	{
+		// GET /api/v1/reports/monthly?month=YYYY-MM - Reporte mensual
+		reportRoutes.GET("/monthly", authMiddleware("reports:read"), reportHandler.GetMonthlyReport)
+		// GET /api/v1/reports/monthly.pdf?month=YYYY-MM&account_id=N - Extracto mensual en PDF
+		reportRoutes.GET("/monthly.pdf", authMiddleware("reports:read"), reportHandler.GetMonthlyStatementPDF)
+	}
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"finanzas-api/internal/reports/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	"finanzas-api/shared/money"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type ReportUseCase struct {
+	reportRepo domain.ReportRepository
+	converter  domain.CurrencyConverter
+	views      domain.ViewResolver
+}
+
+func NewReportUseCase(reportRepo domain.ReportRepository, converter domain.CurrencyConverter, views domain.ViewResolver) domain.ReportUseCase {
+	return &ReportUseCase{
+		reportRepo: reportRepo,
+		converter:  converter,
+		views:      views,
+	}
+}
+
+// GetMonthlyReport implements domain.ReportUseCase.
+func (uc *ReportUseCase) GetMonthlyReport(ctx context.Context, userID uint, month time.Time, currency string, top int, viewID *uint) (*domain.MonthlyReport, error) {
+	if userID == 0 {
+		return nil, errors.New("user ID is required")
+	}
+
+	var criteria *transactionDomain.Criteria
+	if viewID != nil {
+		resolved, err := uc.views.ResolveView(userID, *viewID)
+		if err != nil {
+			return nil, domain.ErrViewNotFound
+		}
+		// El periodo lo definen el mes y las comparaciones del reporte
+		resolved.From, resolved.To = nil, nil
+		criteria = resolved
+	}
+
+	currency, err := uc.resolveCurrency(ctx, userID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Valor por defecto y máximo para el top de categorías
+	if top <= 0 {
+		top = 5
+	}
+	if top > 20 {
+		top = 20
+	}
+
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	// Convertir todo el rango comparado: el mismo mes del año anterior hasta el actual
+	converted, err := uc.converter.PreviewConversions(ctx, userID, currency, start.AddDate(-1, 0, 0), end)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := uc.summary(ctx, userID, currency, start, criteria, converted)
+	if err != nil {
+		return nil, err
+	}
+
+	topCategories, err := uc.reportRepo.WithContext(ctx).TopCategories(userID, currency, start, end, top, criteria, converted)
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range topCategories {
+		if share := percentage(category.Amount, current.Expenses); share != nil {
+			category.Share = *share
+		}
+	}
+
+	previous, err := uc.summary(ctx, userID, currency, start.AddDate(0, -1, 0), criteria, converted)
+	if err != nil {
+		return nil, err
+	}
+
+	lastYear, err := uc.summary(ctx, userID, currency, start.AddDate(-1, 0, 0), criteria, converted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.MonthlyReport{
+		Currency:          currency,
+		PeriodSummary:     *current,
+		TopCategories:     topCategories,
+		PreviousMonth:     compare(current, previous),
+		SameMonthLastYear: compare(current, lastYear),
+		ViewID:            viewID,
+	}, nil
+}
+
+// PeriodTotals implements domain.ReportUseCase.
+func (uc *ReportUseCase) PeriodTotals(ctx context.Context, userID uint, currency string, from, to time.Time) (*domain.PeriodTotals, error) {
+	currency, err := uc.resolveCurrency(ctx, userID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	converted, err := uc.converter.PreviewConversions(ctx, userID, currency, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.reportRepo.WithContext(ctx).PeriodTotals(userID, currency, from, to, nil, converted)
+}
+
+// MonthlySeries implements domain.ReportUseCase.
+func (uc *ReportUseCase) MonthlySeries(ctx context.Context, userID uint, currency string, from, to time.Time) ([]*domain.MonthTotals, error) {
+	currency, err := uc.resolveCurrency(ctx, userID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	converted, err := uc.converter.PreviewConversions(ctx, userID, currency, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.reportRepo.WithContext(ctx).MonthlySeries(userID, currency, from, to, nil, converted)
+}
+
+// BaseCurrency implements domain.ReportUseCase.
+func (uc *ReportUseCase) BaseCurrency(ctx context.Context, userID uint) (string, error) {
+	return uc.converter.BaseCurrency(ctx, userID)
+}
+
+// resolveCurrency normaliza currency o usa la moneda base del usuario si
+// está vacío.
+func (uc *ReportUseCase) resolveCurrency(ctx context.Context, userID uint, currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return uc.converter.BaseCurrency(ctx, userID)
+	}
+	if !money.IsValidCurrency(currency) {
+		return "", domain.ErrInvalidCurrency
+	}
+	return currency, nil
+}
+
+// summary calcula los totales de un mes que inicia en start
+func (uc *ReportUseCase) summary(ctx context.Context, userID uint, currency string, start time.Time, criteria *transactionDomain.Criteria, converted transactionDomain.ConvertedAmounts) (*domain.PeriodSummary, error) {
+	end := start.AddDate(0, 1, 0)
+	reportRepo := uc.reportRepo.WithContext(ctx)
+	totals, err := reportRepo.PeriodTotals(userID, currency, start, end, criteria, converted)
+	if err != nil {
+		return nil, err
+	}
+
+	unconverted, err := reportRepo.Unconverted(userID, currency, start, end, criteria, converted)
+	if err != nil {
+		return nil, err
+	}
+
+	net := totals.Income - totals.Expenses
+	return &domain.PeriodSummary{
+		Month:       start.Format("2006-01"),
+		Income:      totals.Income,
+		Expenses:    totals.Expenses,
+		NetSavings:  net,
+		SavingsRate: percentage(net, totals.Income),
+		Unconverted: unconverted,
+	}, nil
+}
+
+// compare calcula la variación del mes actual respecto a otro periodo
+func compare(current, other *domain.PeriodSummary) *domain.Comparison {
+	return &domain.Comparison{
+		PeriodSummary:    *other,
+		IncomeChange:     current.Income - other.Income,
+		ExpensesChange:   current.Expenses - other.Expenses,
+		NetSavingsChange: current.NetSavings - other.NetSavings,
+	}
+}
+
+// percentage retorna part/whole como porcentaje con dos decimales usando
+// aritmética entera (redondeo half-up). Retorna nil si whole es cero.
+func percentage(part, whole int64) *string {
+	if whole == 0 {
+		return nil
+	}
+
+	// Centésimas de punto porcentual
+	num := part * 10000
+	q, r := num/whole, num%whole
+	if abs(r)*2 >= abs(whole) {
+		if (num < 0) != (whole < 0) {
+			q--
+		} else {
+			q++
+		}
+	}
+
+	sign := ""
+	if q < 0 {
+		sign = "-"
+		q = -q
+	}
+	result := fmt.Sprintf("%s%d.%02d", sign, q/100, q%100)
+	return &result
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
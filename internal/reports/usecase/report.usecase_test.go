@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	categoryRepository "finanzas-api/internal/categories/repository"
+	"finanzas-api/internal/reports/domain"
+	"finanzas-api/internal/reports/repository"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	transactionRepository "finanzas-api/internal/transactions/repository"
+)
+
+// previewConverter convierte con montos fijos por ID de transacción
+type previewConverter struct {
+	converted transactionDomain.ConvertedAmounts
+}
+
+func (c *previewConverter) BaseCurrency(ctx context.Context, userID uint) (string, error) {
+	return "COP", nil
+}
+
+func (c *previewConverter) PreviewConversions(ctx context.Context, userID uint, baseCurrency string, from, to time.Time) (transactionDomain.ConvertedAmounts, error) {
+	return c.converted, nil
+}
+
+func TestGetMonthlyReportUnconverted(t *testing.T) {
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	transactions := transactionRepository.NewTransactionMemoryRepository()
+	for _, transaction := range []*transactionDomain.Transaction{
+		{UserID: 1, AccountID: 1, Amount: 300000, Currency: "COP", Direction: transactionDomain.DirectionIncome, Date: march.AddDate(0, 0, 1)},
+		{UserID: 1, AccountID: 1, Amount: 50000, Currency: "COP", Direction: transactionDomain.DirectionExpense, Date: march.AddDate(0, 0, 2)},
+		{UserID: 1, AccountID: 2, Amount: 1000, Currency: "USD", Direction: transactionDomain.DirectionExpense, Date: march.AddDate(0, 0, 3)},
+		{UserID: 1, AccountID: 3, Amount: 2500, Currency: "EUR", Direction: transactionDomain.DirectionExpense, Date: march.AddDate(0, 0, 4)},
+		{UserID: 1, AccountID: 3, Amount: 700, Currency: "EUR", Direction: transactionDomain.DirectionIncome, Date: march.AddDate(0, 0, 5)},
+	} {
+		if err := transactions.Create(transaction); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Solo la transacción en USD tiene cotización
+	converter := &previewConverter{converted: transactionDomain.ConvertedAmounts{3: 40000}}
+	reportRepo := repository.NewReportMemoryRepository(transactions, categoryRepository.NewCategoryMemoryRepository())
+	uc := NewReportUseCase(reportRepo, converter, nil)
+
+	report, err := uc.GetMonthlyReport(context.Background(), 1, march, "", 5, nil)
+	if err != nil {
+		t.Fatalf("GetMonthlyReport() error = %v", err)
+	}
+	if report.Income != 300000 || report.Expenses != 90000 {
+		t.Errorf("income, expenses = %d, %d, want 300000, 90000", report.Income, report.Expenses)
+	}
+	if len(report.Unconverted) != 1 {
+		t.Fatalf("got %d unconverted currencies, want 1", len(report.Unconverted))
+	}
+	want := domain.UnconvertedTotal{Currency: "EUR", Count: 2, Income: 700, Expenses: 2500}
+	if *report.Unconverted[0] != want {
+		t.Errorf("unconverted = %+v, want %+v", *report.Unconverted[0], want)
+	}
+}
+
+func TestGetMonthlyReportErrors(t *testing.T) {
+	reportRepo := repository.NewReportMemoryRepository(transactionRepository.NewTransactionMemoryRepository(), categoryRepository.NewCategoryMemoryRepository())
+	uc := NewReportUseCase(reportRepo, &previewConverter{}, nil)
+
+	_, err := uc.GetMonthlyReport(context.Background(), 1, time.Now(), "dollars", 5, nil)
+	if !errors.Is(err, domain.ErrInvalidCurrency) {
+		t.Errorf("GetMonthlyReport() error = %v, want %v", err, domain.ErrInvalidCurrency)
+	}
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	"finanzas-api/internal/reports/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+type StatementUseCase struct {
+	statementRepo domain.StatementRepository
+	accountRepo   accountDomain.AccountRepository
+}
+
+func NewStatementUseCase(statementRepo domain.StatementRepository, accountRepo accountDomain.AccountRepository) domain.StatementUseCase {
+	return &StatementUseCase{
+		statementRepo: statementRepo,
+		accountRepo:   accountRepo,
+	}
+}
+
+// GetMonthlyStatement implements domain.StatementUseCase.
+func (uc *StatementUseCase) GetMonthlyStatement(userID uint, month time.Time, accountID *uint) (*domain.MonthlyStatement, error) {
+	if userID == 0 {
+		return nil, errors.New("user ID is required")
+	}
+
+	var accounts []*accountDomain.Account
+	if accountID != nil {
+		account, err := uc.accountRepo.GetByID(*accountID)
+		if err != nil || !account.BelongsTo(userID) {
+			return nil, errors.New("account not found")
+		}
+		accounts = []*accountDomain.Account{account}
+	} else {
+		all, err := uc.accountRepo.ListByUser(userID, true, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		accounts = all
+	}
+
+	// Orden estable para que dos extractos del mismo mes sean idénticos
+	sort.Slice(accounts, func(i, j int) bool {
+		if accounts[i].Name != accounts[j].Name {
+			return accounts[i].Name < accounts[j].Name
+		}
+		return accounts[i].ID < accounts[j].ID
+	})
+
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	nets, err := uc.statementRepo.NetBefore(userID, accountID, start)
+	if err != nil {
+		return nil, err
+	}
+	netByAccount := make(map[uint]int64, len(nets))
+	for _, net := range nets {
+		netByAccount[net.AccountID] = net.Net
+	}
+
+	movements, err := uc.statementRepo.Movements(userID, accountID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	movementsByAccount := make(map[uint][]*domain.StatementMovement)
+	for _, movement := range movements {
+		movementsByAccount[movement.AccountID] = append(movementsByAccount[movement.AccountID], movement)
+	}
+
+	statement := &domain.MonthlyStatement{
+		Month:        start,
+		Consolidated: accountID == nil,
+		Accounts:     []*domain.AccountStatement{},
+	}
+	var expenses []*domain.StatementMovement
+	for _, account := range accounts {
+		accountMovements := movementsByAccount[account.ID]
+		// El consolidado omite las cuentas archivadas sin movimientos en el mes
+		if statement.Consolidated && account.IsArchived && len(accountMovements) == 0 {
+			continue
+		}
+
+		accountStatement := &domain.AccountStatement{
+			AccountID:      account.ID,
+			Name:           account.Name,
+			Currency:       account.Currency,
+			OpeningBalance: account.OpeningBalance + netByAccount[account.ID],
+			Lines:          make([]*domain.StatementLine, 0, len(accountMovements)),
+		}
+		balance := accountStatement.OpeningBalance
+		for _, movement := range accountMovements {
+			amount := movement.Amount
+			if movement.Direction == string(transactionDomain.DirectionExpense) {
+				amount = -amount
+				accountStatement.Expenses += movement.Amount
+				if !movement.IsTransfer {
+					expenses = append(expenses, movement)
+				}
+			} else {
+				accountStatement.Income += movement.Amount
+			}
+			balance += amount
+			accountStatement.Lines = append(accountStatement.Lines, &domain.StatementLine{
+				Date:         movement.Date,
+				Description:  movement.Description,
+				Payee:        movement.Payee,
+				CategoryName: movement.CategoryName,
+				IsTransfer:   movement.IsTransfer,
+				Amount:       amount,
+				Balance:      balance,
+			})
+		}
+		accountStatement.ClosingBalance = balance
+		statement.Accounts = append(statement.Accounts, accountStatement)
+	}
+
+	statement.Categories = breakdownByCategory(expenses)
+	return statement, nil
+}
+
+// breakdownByCategory agrupa los gastos por moneda y categoría raíz. Las
+// transferencias no son gasto y ya vienen excluidas.
+func breakdownByCategory(expenses []*domain.StatementMovement) []*domain.CategoryBreakdown {
+	byCurrency := make(map[string]*domain.CategoryBreakdown)
+	totals := make(map[string]map[uint]*domain.CategoryTotal)
+	for _, movement := range expenses {
+		breakdown, exists := byCurrency[movement.Currency]
+		if !exists {
+			breakdown = &domain.CategoryBreakdown{Currency: movement.Currency}
+			byCurrency[movement.Currency] = breakdown
+			totals[movement.Currency] = make(map[uint]*domain.CategoryTotal)
+		}
+		breakdown.Total += movement.Amount
+
+		// La clave 0 agrupa los gastos sin categoría
+		var key uint
+		if movement.RootCategoryID != nil {
+			key = *movement.RootCategoryID
+		}
+		total, exists := totals[movement.Currency][key]
+		if !exists {
+			total = &domain.CategoryTotal{CategoryID: movement.RootCategoryID, Name: movement.RootName}
+			totals[movement.Currency][key] = total
+			breakdown.Categories = append(breakdown.Categories, total)
+		}
+		total.Amount += movement.Amount
+	}
+
+	breakdowns := make([]*domain.CategoryBreakdown, 0, len(byCurrency))
+	for _, breakdown := range byCurrency {
+		sort.Slice(breakdown.Categories, func(i, j int) bool {
+			a, b := breakdown.Categories[i], breakdown.Categories[j]
+			if a.Amount != b.Amount {
+				return a.Amount > b.Amount
+			}
+			return a.Name < b.Name
+		})
+		for _, category := range breakdown.Categories {
+			if share := percentage(category.Amount, breakdown.Total); share != nil {
+				category.Share = *share
+			}
+		}
+		breakdowns = append(breakdowns, breakdown)
+	}
+	sort.Slice(breakdowns, func(i, j int) bool { return breakdowns[i].Currency < breakdowns[j].Currency })
+	return breakdowns
+}
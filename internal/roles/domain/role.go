@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	userDomain "finanzas-api/internal/users/domain"
+)
+
+var (
+	ErrRoleNotFound = errors.New("role not found")
+	ErrRoleExists   = errors.New("role already exists")
+	ErrUserNotFound = errors.New("user not found")
+	// ErrSystemRole se retorna al intentar modificar o eliminar admin o user
+	ErrSystemRole = errors.New("system roles cannot be modified")
+	// ErrLastAdmin evita quedarse sin ningún usuario con el rol admin
+	ErrLastAdmin = errors.New("cannot remove the admin role from the last admin")
+)
+
+// Roles de sistema. Se crean al iniciar con sus permisos del catálogo y no
+// se pueden modificar por la API.
+const (
+	RoleAdmin = "admin" // Todos los permisos
+	RoleUser  = "user"  // Rol por defecto: todo sobre los datos propios
+)
+
+// Permission es un permiso del catálogo de authz, guardado para poder
+// referenciarlo desde role_permissions
+type Permission struct {
+	Name        string `json:"name" gorm:"primaryKey;type:varchar(50)"`
+	Description string `json:"description" gorm:"type:varchar(255)"`
+}
+
+// Role agrupa permisos. Un usuario puede tener varios roles y sus permisos
+// son la unión de los de sus roles.
+type Role struct {
+	ID          uint         `json:"id" gorm:"primaryKey"`
+	Name        string       `json:"name" gorm:"type:varchar(50);uniqueIndex;not null"`
+	Description string       `json:"description" gorm:"type:varchar(255)"`
+	IsSystem    bool         `json:"is_system" gorm:"not null;default:false"`
+	Permissions []Permission `json:"permissions" gorm:"many2many:role_permissions;constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// UserRole asigna un rol a un usuario
+type UserRole struct {
+	UserID    uint            `gorm:"primaryKey"`
+	User      userDomain.User `gorm:"constraint:OnDelete:CASCADE"`
+	RoleID    uint            `gorm:"primaryKey;index"`
+	Role      Role            `gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time
+}
+
+// RoleRepository define la interfaz del repositorio de roles
+type RoleRepository interface {
+	// SyncPermissions crea o actualiza los permisos del catálogo
+	SyncPermissions(permissions []Permission) error
+	// EnsureSystemRole crea el rol si no existe y reemplaza sus permisos
+	EnsureSystemRole(role *Role) error
+	Create(role *Role) error
+	GetByID(id uint) (*Role, error)
+	FindByNames(names []string) ([]*Role, error)
+	// Update guarda nombre, descripción y reemplaza los permisos
+	Update(role *Role) error
+	// Delete elimina el rol con sus permisos y asignaciones
+	Delete(id uint) error
+	// List retorna los roles ordenados por nombre, con sus permisos
+	List() ([]*Role, error)
+	ListByUser(userID uint) ([]*Role, error)
+	// SetUserRoles reemplaza los roles del usuario
+	SetUserRoles(userID uint, roleIDs []uint) error
+	// CountActiveUsers cuenta los usuarios activos con el rol
+	CountActiveUsers(roleID uint) (int64, error)
+}
+
+type RoleUseCase interface {
+	ListPermissions() []Permission
+	CreateRole(role *Role) error
+	GetRole(id uint) (*Role, error)
+	ListRoles() ([]*Role, error)
+	UpdateRole(role *Role) error
+	DeleteRole(id uint) error
+	GetUserRoles(userID uint) ([]*Role, error)
+	// SetUserRoles reemplaza los roles del usuario por los de names
+	SetUserRoles(userID uint, names []string) ([]*Role, error)
+	// UserPermissions retorna los nombres de los roles del usuario y la unión
+	// ordenada de sus permisos; es lo que llevan los access tokens
+	UserPermissions(userID uint) (roles []string, permissions []string, err error)
+	AssignDefaultRole(userID uint) error
+	// IsLastAdmin indica si el usuario tiene el rol admin y ningún otro
+	// usuario activo lo tiene
+	IsLastAdmin(userID uint) (bool, error)
+	ValidateRoleData(role *Role) error
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (Permission) TableName() string {
+	return "permissions"
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (Role) TableName() string {
+	return "roles"
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (UserRole) TableName() string {
+	return "user_roles"
+}
+
+// PermissionNames retorna los nombres de los permisos del rol, ordenados
+func (r *Role) PermissionNames() []string {
+	names := make([]string, 0, len(r.Permissions))
+	for _, permission := range r.Permissions {
+		names = append(names, permission.Name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"finanzas-api/internal/roles/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoleHandler struct {
+	roleUseCase domain.RoleUseCase
+}
+
+// NewRoleHandler crea una nueva instancia del handler de roles
+func NewRoleHandler(roleUseCase domain.RoleUseCase) *RoleHandler {
+	return &RoleHandler{
+		roleUseCase: roleUseCase,
+	}
+}
+
+// RoleRequest representa la estructura de la petición para crear o
+// reemplazar un rol
+type RoleRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// UserRolesRequest reemplaza los roles de un usuario
+type UserRolesRequest struct {
+	Roles []string `json:"roles" binding:"required,min=1"`
+}
+
+// RoleResponse representa la respuesta de un rol
+type RoleResponse struct {
+	ID          uint     `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	IsSystem    bool     `json:"is_system"`
+	Permissions []string `json:"permissions"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+// ListPermissions lista el catálogo de permisos que se pueden otorgar
+func (h *RoleHandler) ListPermissions(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"permissions": h.roleUseCase.ListPermissions(),
+	})
+}
+
+// CreateRole crea un rol personalizado
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	role := toRole(req)
+	if err := h.roleUseCase.CreateRole(role); err != nil {
+		c.JSON(roleErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Role created successfully",
+		"role":    h.toRoleResponse(role),
+	})
+}
+
+// ListRoles lista todos los roles con sus permisos
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	roles, err := h.roleUseCase.ListRoles()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list roles",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"roles": h.toRoleResponses(roles),
+	})
+}
+
+// GetRole obtiene un rol por ID
+func (h *RoleHandler) GetRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid role ID",
+		})
+		return
+	}
+
+	role, err := h.roleUseCase.GetRole(uint(id))
+	if err != nil {
+		c.JSON(roleErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"role": h.toRoleResponse(role),
+	})
+}
+
+// UpdateRole reemplaza nombre, descripción y permisos de un rol personalizado
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid role ID",
+		})
+		return
+	}
+
+	var req RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	role := toRole(req)
+	role.ID = uint(id)
+	if err := h.roleUseCase.UpdateRole(role); err != nil {
+		c.JSON(roleErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Releer para responder con las fechas guardadas
+	updated, err := h.roleUseCase.GetRole(role.ID)
+	if err != nil {
+		updated = role
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role updated successfully",
+		"role":    h.toRoleResponse(updated),
+	})
+}
+
+// DeleteRole elimina un rol personalizado y lo quita de los usuarios que lo
+// tenían
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid role ID",
+		})
+		return
+	}
+
+	if err := h.roleUseCase.DeleteRole(uint(id)); err != nil {
+		c.JSON(roleErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role deleted successfully",
+	})
+}
+
+// GetUserRoles lista los roles de un usuario
+func (h *RoleHandler) GetUserRoles(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	roles, err := h.roleUseCase.GetUserRoles(uint(id))
+	if err != nil {
+		c.JSON(roleErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"roles": h.toRoleResponses(roles),
+	})
+}
+
+// SetUserRoles reemplaza los roles de un usuario. Los cambios llegan a sus
+// access tokens en el siguiente refresh.
+func (h *RoleHandler) SetUserRoles(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	var req UserRolesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	roles, err := h.roleUseCase.SetUserRoles(uint(id), req.Roles)
+	if err != nil {
+		c.JSON(roleErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User roles updated successfully",
+		"roles":   h.toRoleResponses(roles),
+	})
+}
+
+// roleErrorStatus traduce los errores del caso de uso a códigos HTTP
+func roleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrRoleNotFound), errors.Is(err, domain.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrRoleExists), errors.Is(err, domain.ErrLastAdmin):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrSystemRole):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func toRole(req RoleRequest) *domain.Role {
+	role := &domain.Role{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	for _, name := range req.Permissions {
+		role.Permissions = append(role.Permissions, domain.Permission{Name: name})
+	}
+	return role
+}
+
+func (h *RoleHandler) toRoleResponses(roles []*domain.Role) []RoleResponse {
+	responses := make([]RoleResponse, len(roles))
+	for i, role := range roles {
+		responses[i] = h.toRoleResponse(role)
+	}
+	return responses
+}
+
+// toRoleResponse convierte un rol del dominio a respuesta HTTP
+func (h *RoleHandler) toRoleResponse(role *domain.Role) RoleResponse {
+	return RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		IsSystem:    role.IsSystem,
+		Permissions: role.PermissionNames(),
+		CreatedAt:   role.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:   role.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
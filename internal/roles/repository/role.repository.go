@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/roles/domain"
+
+type RoleRepository interface {
+	domain.RoleRepository
+}
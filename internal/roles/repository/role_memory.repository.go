@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"finanzas-api/internal/roles/domain"
+)
+
+type roleRepositoryMemory struct {
+	permissions map[string]domain.Permission
+	roles       map[uint]*domain.Role
+	userRoles   map[uint][]uint // userID -> roleIDs
+	nextID      uint
+	mutex       sync.RWMutex
+}
+
+func NewRoleMemoryRepository() domain.RoleRepository {
+	return &roleRepositoryMemory{
+		permissions: make(map[string]domain.Permission),
+		roles:       make(map[uint]*domain.Role),
+		userRoles:   make(map[uint][]uint),
+		nextID:      1,
+	}
+}
+
+func (r *roleRepositoryMemory) SyncPermissions(permissions []domain.Permission) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, permission := range permissions {
+		r.permissions[permission.Name] = permission
+	}
+	return nil
+}
+
+func (r *roleRepositoryMemory) EnsureSystemRole(role *domain.Role) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, existing := range r.roles {
+		if existing.Name == role.Name {
+			role.ID = existing.ID
+			existing.Description = role.Description
+			existing.IsSystem = true
+			existing.Permissions = append([]domain.Permission(nil), role.Permissions...)
+			existing.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	r.create(role)
+	return nil
+}
+
+func (r *roleRepositoryMemory) Create(role *domain.Role) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, existing := range r.roles {
+		if existing.Name == role.Name {
+			return errors.New("duplicate role name")
+		}
+	}
+	r.create(role)
+	return nil
+}
+
+func (r *roleRepositoryMemory) GetByID(id uint) (*domain.Role, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	role, exists := r.roles[id]
+	if !exists {
+		return nil, errors.New("role not found")
+	}
+	return copyRole(role), nil
+}
+
+func (r *roleRepositoryMemory) FindByNames(names []string) ([]*domain.Role, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var roles []*domain.Role
+	for _, role := range r.roles {
+		if wanted[role.Name] {
+			roles = append(roles, copyRole(role))
+		}
+	}
+	sortRoles(roles)
+	return roles, nil
+}
+
+func (r *roleRepositoryMemory) Update(role *domain.Role) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existing, exists := r.roles[role.ID]
+	if !exists {
+		return errors.New("role not found")
+	}
+	existing.Name = role.Name
+	existing.Description = role.Description
+	existing.Permissions = append([]domain.Permission(nil), role.Permissions...)
+	existing.UpdatedAt = time.Now()
+	role.UpdatedAt = existing.UpdatedAt
+	return nil
+}
+
+func (r *roleRepositoryMemory) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.roles, id)
+	for userID, roleIDs := range r.userRoles {
+		r.userRoles[userID] = removeID(roleIDs, id)
+	}
+	return nil
+}
+
+func (r *roleRepositoryMemory) List() ([]*domain.Role, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	roles := make([]*domain.Role, 0, len(r.roles))
+	for _, role := range r.roles {
+		roles = append(roles, copyRole(role))
+	}
+	sortRoles(roles)
+	return roles, nil
+}
+
+func (r *roleRepositoryMemory) ListByUser(userID uint) ([]*domain.Role, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var roles []*domain.Role
+	for _, roleID := range r.userRoles[userID] {
+		if role, exists := r.roles[roleID]; exists {
+			roles = append(roles, copyRole(role))
+		}
+	}
+	sortRoles(roles)
+	return roles, nil
+}
+
+func (r *roleRepositoryMemory) SetUserRoles(userID uint, roleIDs []uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.userRoles[userID] = append([]uint(nil), roleIDs...)
+	return nil
+}
+
+// CountActiveUsers cuenta todas las asignaciones: el repositorio en memoria
+// no conoce el estado de los usuarios
+func (r *roleRepositoryMemory) CountActiveUsers(roleID uint) (int64, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var count int64
+	for _, roleIDs := range r.userRoles {
+		for _, id := range roleIDs {
+			if id == roleID {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+// create asigna ID y timestamps y guarda una copia; requiere el lock
+func (r *roleRepositoryMemory) create(role *domain.Role) {
+	// Asignar ID y timestamps
+	role.ID = r.nextID
+	r.nextID++
+	role.CreatedAt = time.Now()
+	role.UpdatedAt = time.Now()
+
+	r.roles[role.ID] = copyRole(role)
+}
+
+func copyRole(role *domain.Role) *domain.Role {
+	copied := *role
+	copied.Permissions = append([]domain.Permission(nil), role.Permissions...)
+	return &copied
+}
+
+func sortRoles(roles []*domain.Role) {
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Name < roles[j].Name
+	})
+}
+
+func removeID(ids []uint, id uint) []uint {
+	result := ids[:0]
+	for _, existing := range ids {
+		if existing != id {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"errors"
+
+	"finanzas-api/internal/roles/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type rolePostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewRolePostgresRepository(db *gorm.DB) domain.RoleRepository {
+	return &rolePostgresRepository{db: db}
+}
+
+func (r *rolePostgresRepository) SyncPermissions(permissions []domain.Permission) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"description"}),
+	}).Create(&permissions).Error
+}
+
+func (r *rolePostgresRepository) EnsureSystemRole(role *domain.Role) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var existing domain.Role
+		err := tx.Where("name = ?", role.Name).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(role).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		role.ID = existing.ID
+		if err := tx.Model(&existing).Updates(map[string]interface{}{
+			"description": role.Description,
+			"is_system":   true,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&existing).Association("Permissions").Replace(role.Permissions)
+	})
+}
+
+func (r *rolePostgresRepository) Create(role *domain.Role) error {
+	return r.db.Create(role).Error
+}
+
+func (r *rolePostgresRepository) GetByID(id uint) (*domain.Role, error) {
+	var role domain.Role
+	if err := r.db.Preload("Permissions").First(&role, id).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *rolePostgresRepository) FindByNames(names []string) ([]*domain.Role, error) {
+	var roles []*domain.Role
+	err := r.db.Preload("Permissions").Where("name IN ?", names).Order("name").Find(&roles).Error
+	return roles, err
+}
+
+func (r *rolePostgresRepository) Update(role *domain.Role) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(role).Select("name", "description").Updates(role).Error; err != nil {
+			return err
+		}
+		return tx.Model(role).Association("Permissions").Replace(role.Permissions)
+	})
+}
+
+func (r *rolePostgresRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM role_permissions WHERE role_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("role_id = ?", id).Delete(&domain.UserRole{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Role{}, id).Error
+	})
+}
+
+func (r *rolePostgresRepository) List() ([]*domain.Role, error) {
+	var roles []*domain.Role
+	err := r.db.Preload("Permissions").Order("name").Find(&roles).Error
+	return roles, err
+}
+
+func (r *rolePostgresRepository) ListByUser(userID uint) ([]*domain.Role, error) {
+	var roles []*domain.Role
+	err := r.db.Preload("Permissions").
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).
+		Order("roles.name").
+		Find(&roles).Error
+	return roles, err
+}
+
+func (r *rolePostgresRepository) SetUserRoles(userID uint, roleIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.UserRole{}).Error; err != nil {
+			return err
+		}
+		if len(roleIDs) == 0 {
+			return nil
+		}
+		assignments := make([]domain.UserRole, 0, len(roleIDs))
+		for _, roleID := range roleIDs {
+			assignments = append(assignments, domain.UserRole{UserID: userID, RoleID: roleID})
+		}
+		return tx.Omit(clause.Associations).Create(&assignments).Error
+	})
+}
+
+func (r *rolePostgresRepository) CountActiveUsers(roleID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.UserRole{}).
+		Joins("JOIN users ON users.id = user_roles.user_id").
+		Where("user_roles.role_id = ? AND users.is_active = true AND users.deleted_at IS NULL", roleID).
+		Count(&count).Error
+	return count, err
+}
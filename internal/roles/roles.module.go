@@ -0,0 +1,131 @@
+package roles
+
+import (
+	"fmt"
+
+	"finanzas-api/internal/roles/domain"
+	"finanzas-api/internal/roles/handler"
+	"finanzas-api/internal/roles/repository"
+	"finanzas-api/internal/roles/usecase"
+	userDomain "finanzas-api/internal/users/domain"
+	"finanzas-api/shared/authz"
+
+	"gorm.io/gorm"
+)
+
+type RolesModule struct {
+	Handler    *handler.RoleHandler
+	UseCase    domain.RoleUseCase
+	Repository domain.RoleRepository
+}
+
+func NewRolesModule(db *gorm.DB, userRepo userDomain.UserRepository) *RolesModule {
+	var roleRepo domain.RoleRepository
+	var roleUseCase domain.RoleUseCase
+	var roleHandler *handler.RoleHandler
+
+	if err := db.AutoMigrate(&domain.Permission{}, &domain.Role{}, &domain.UserRole{}); err != nil {
+		panic(fmt.Sprintf("Error migrating roles: %v", err))
+	}
+
+	roleRepo = repository.NewRolePostgresRepository(db)
+	if err := seedSystemRoles(roleRepo); err != nil {
+		panic(fmt.Sprintf("Error seeding roles: %v", err))
+	}
+
+	// Antes los roles eran el texto de users.role: los usuarios que aún no
+	// tienen asignaciones reciben el rol con ese nombre. La columna pasa a
+	// users.legacy_role para poder volver atrás; así la copia corre una sola
+	// vez y un usuario al que luego se le quiten roles no los recupera.
+	if db.Migrator().HasColumn(&userDomain.User{}, "role") {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(`INSERT INTO user_roles (user_id, role_id, created_at)
+				SELECT users.id, roles.id, NOW() FROM users
+				JOIN roles ON roles.name = COALESCE(NULLIF(users.role, ''), ?)
+				WHERE NOT EXISTS (SELECT 1 FROM user_roles WHERE user_roles.user_id = users.id)`,
+				domain.RoleUser).Error; err != nil {
+				return err
+			}
+			return tx.Migrator().RenameColumn(&userDomain.User{}, "role", "legacy_role")
+		})
+		if err != nil {
+			panic(fmt.Sprintf("Error migrating legacy user roles: %v", err))
+		}
+	}
+
+	roleUseCase = usecase.NewRoleUseCase(roleRepo, userRepo)
+	roleHandler = handler.NewRoleHandler(roleUseCase)
+
+	return &RolesModule{
+		Handler:    roleHandler,
+		UseCase:    roleUseCase,
+		Repository: roleRepo,
+	}
+}
+
+// AssignDefaultRoleHook retorna el hook que da el rol user a los usuarios
+// nuevos. Se registra con UsersModule.OnUserCreated.
+func (m *RolesModule) AssignDefaultRoleHook() userDomain.UserCreatedHook {
+	return func(user *userDomain.User) error {
+		return m.UseCase.AssignDefaultRole(user.ID)
+	}
+}
+
+// LastAdminGuardHook retorna el hook que impide desactivar o eliminar al
+// último administrador activo. Se registra con UsersModule.OnBeforeDisable.
+func (m *RolesModule) LastAdminGuardHook() userDomain.BeforeDisableHook {
+	return func(user *userDomain.User) error {
+		last, err := m.UseCase.IsLastAdmin(user.ID)
+		if err != nil {
+			return err
+		}
+		if last {
+			return userDomain.ErrLastAdmin
+		}
+		return nil
+	}
+}
+
+// seedSystemRoles sincroniza el catálogo de permisos y los roles de sistema:
+// admin con todos los permisos y user con los de sus propios datos. Se
+// ejecuta en cada inicio, así que los permisos nuevos se otorgan solos.
+func seedSystemRoles(roleRepo domain.RoleRepository) error {
+	permissions := make([]domain.Permission, 0, len(authz.Catalog))
+	for _, permission := range authz.Catalog {
+		permissions = append(permissions, domain.Permission{Name: permission.Name, Description: permission.Description})
+	}
+	if err := roleRepo.SyncPermissions(permissions); err != nil {
+		return err
+	}
+
+	// Permisos que el rol user no tiene: administrar a otros usuarios, los
+	// roles y las cotizaciones compartidas
+	adminOnly := map[string]bool{
+		"users:read":     true,
+		"users:write":    true,
+		"roles:read":     true,
+		"roles:write":    true,
+		"exchange:write": true,
+	}
+	var userPermissions []domain.Permission
+	for _, permission := range permissions {
+		if !adminOnly[permission.Name] {
+			userPermissions = append(userPermissions, permission)
+		}
+	}
+
+	if err := roleRepo.EnsureSystemRole(&domain.Role{
+		Name:        domain.RoleAdmin,
+		Description: "Full access, including users, roles and exchange rates",
+		IsSystem:    true,
+		Permissions: permissions,
+	}); err != nil {
+		return err
+	}
+	return roleRepo.EnsureSystemRole(&domain.Role{
+		Name:        domain.RoleUser,
+		Description: "Full access to the user's own data",
+		IsSystem:    true,
+		Permissions: userPermissions,
+	})
+}
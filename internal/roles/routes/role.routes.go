@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"finanzas-api/internal/roles/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupRoleRoutes configura las rutas para el módulo de roles y permisos
+func SetupRoleRoutes(router *gin.Engine, roleHandler *handler.RoleHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	// GET /api/v1/permissions - Catálogo de permisos
+	router.GET("/api/v1/permissions", authMiddleware("roles:read"), roleHandler.ListPermissions)
+
+	roleRoutes := router.Group("/api/v1/roles")
+	{
+		// POST /api/v1/roles - Crear rol personalizado
+		roleRoutes.POST("", authMiddleware("roles:write"), roleHandler.CreateRole)
+		// GET /api/v1/roles - Listar roles con sus permisos
+		roleRoutes.GET("", authMiddleware("roles:read"), roleHandler.ListRoles)
+		// GET /api/v1/roles/:id - Obtener rol
+		roleRoutes.GET("/:id", authMiddleware("roles:read"), roleHandler.GetRole)
+		// PUT /api/v1/roles/:id - Reemplazar rol personalizado
+		roleRoutes.PUT("/:id", authMiddleware("roles:write"), roleHandler.UpdateRole)
+		// DELETE /api/v1/roles/:id - Eliminar rol personalizado
+		roleRoutes.DELETE("/:id", authMiddleware("roles:write"), roleHandler.DeleteRole)
+	}
+
+	// GET /api/v1/users/:id/roles - Roles de un usuario
+	router.GET("/api/v1/users/:id/roles", authMiddleware("roles:read"), roleHandler.GetUserRoles)
+	// PUT /api/v1/users/:id/roles - Reemplazar los roles de un usuario
+	router.PUT("/api/v1/users/:id/roles", authMiddleware("roles:write"), roleHandler.SetUserRoles)
+}
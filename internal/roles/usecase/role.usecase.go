@@ -0,0 +1,283 @@
+package usecase
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"finanzas-api/internal/roles/domain"
+	userDomain "finanzas-api/internal/users/domain"
+	"finanzas-api/shared/authz"
+)
+
+// roleNamePattern: minúsculas, dígitos, guion y guion bajo; p. ej. "auditor"
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+
+type RoleUseCase struct {
+	roleRepo domain.RoleRepository
+	userRepo userDomain.UserRepository
+}
+
+func NewRoleUseCase(roleRepo domain.RoleRepository, userRepo userDomain.UserRepository) domain.RoleUseCase {
+	return &RoleUseCase{
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+	}
+}
+
+// ListPermissions implements domain.RoleUseCase.
+func (uc *RoleUseCase) ListPermissions() []domain.Permission {
+	permissions := make([]domain.Permission, 0, len(authz.Catalog))
+	for _, permission := range authz.Catalog {
+		permissions = append(permissions, domain.Permission{Name: permission.Name, Description: permission.Description})
+	}
+	return permissions
+}
+
+// CreateRole implements domain.RoleUseCase.
+func (uc *RoleUseCase) CreateRole(role *domain.Role) error {
+	// Los roles de sistema solo los crea el módulo al iniciar
+	role.IsSystem = false
+
+	if err := uc.ValidateRoleData(role); err != nil {
+		return err
+	}
+
+	return uc.roleRepo.Create(role)
+}
+
+// GetRole implements domain.RoleUseCase.
+func (uc *RoleUseCase) GetRole(id uint) (*domain.Role, error) {
+	if id == 0 {
+		return nil, errors.New("invalid role ID")
+	}
+
+	role, err := uc.roleRepo.GetByID(id)
+	if err != nil {
+		return nil, domain.ErrRoleNotFound
+	}
+
+	return role, nil
+}
+
+// ListRoles implements domain.RoleUseCase.
+func (uc *RoleUseCase) ListRoles() ([]*domain.Role, error) {
+	return uc.roleRepo.List()
+}
+
+// UpdateRole implements domain.RoleUseCase.
+func (uc *RoleUseCase) UpdateRole(role *domain.Role) error {
+	existing, err := uc.GetRole(role.ID)
+	if err != nil {
+		return err
+	}
+	if existing.IsSystem {
+		return domain.ErrSystemRole
+	}
+
+	role.IsSystem = false
+	if err := uc.ValidateRoleData(role); err != nil {
+		return err
+	}
+
+	return uc.roleRepo.Update(role)
+}
+
+// DeleteRole implements domain.RoleUseCase.
+func (uc *RoleUseCase) DeleteRole(id uint) error {
+	role, err := uc.GetRole(id)
+	if err != nil {
+		return err
+	}
+	if role.IsSystem {
+		return domain.ErrSystemRole
+	}
+
+	return uc.roleRepo.Delete(id)
+}
+
+// GetUserRoles implements domain.RoleUseCase.
+func (uc *RoleUseCase) GetUserRoles(userID uint) ([]*domain.Role, error) {
+	if _, err := uc.userRepo.GetByID(userID); err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return uc.roleRepo.ListByUser(userID)
+}
+
+// SetUserRoles implements domain.RoleUseCase.
+func (uc *RoleUseCase) SetUserRoles(userID uint, names []string) ([]*domain.Role, error) {
+	current, err := uc.GetUserRoles(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Un usuario sin roles no tendría ningún permiso, ni siquiera sobre sus
+	// propios datos
+	names = normalizeNames(names)
+	if len(names) == 0 {
+		return nil, errors.New("at least one role is required")
+	}
+	roles, err := uc.roleRepo.FindByNames(names)
+	if err != nil {
+		return nil, err
+	}
+	if len(roles) != len(names) {
+		return nil, domain.ErrRoleNotFound
+	}
+
+	// Quitar el rol admin al último administrador dejaría el sistema sin
+	// nadie que pueda gestionar usuarios y roles
+	if !hasRole(roles, domain.RoleAdmin) {
+		last, err := uc.lastAdmin(current)
+		if err != nil {
+			return nil, err
+		}
+		if last {
+			return nil, domain.ErrLastAdmin
+		}
+	}
+
+	roleIDs := make([]uint, 0, len(roles))
+	for _, role := range roles {
+		roleIDs = append(roleIDs, role.ID)
+	}
+	if err := uc.roleRepo.SetUserRoles(userID, roleIDs); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// UserPermissions implements domain.RoleUseCase.
+func (uc *RoleUseCase) UserPermissions(userID uint) ([]string, []string, error) {
+	roles, err := uc.roleRepo.ListByUser(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roleNames := make([]string, 0, len(roles))
+	seen := make(map[string]bool)
+	permissions := []string{}
+	for _, role := range roles {
+		roleNames = append(roleNames, role.Name)
+		for _, permission := range role.Permissions {
+			if !seen[permission.Name] {
+				seen[permission.Name] = true
+				permissions = append(permissions, permission.Name)
+			}
+		}
+	}
+	sort.Strings(permissions)
+
+	return roleNames, permissions, nil
+}
+
+// AssignDefaultRole implements domain.RoleUseCase.
+func (uc *RoleUseCase) AssignDefaultRole(userID uint) error {
+	roles, err := uc.roleRepo.FindByNames([]string{domain.RoleUser})
+	if err != nil {
+		return err
+	}
+	if len(roles) == 0 {
+		return domain.ErrRoleNotFound
+	}
+
+	return uc.roleRepo.SetUserRoles(userID, []uint{roles[0].ID})
+}
+
+// IsLastAdmin implements domain.RoleUseCase.
+func (uc *RoleUseCase) IsLastAdmin(userID uint) (bool, error) {
+	roles, err := uc.roleRepo.ListByUser(userID)
+	if err != nil {
+		return false, err
+	}
+
+	return uc.lastAdmin(roles)
+}
+
+// ValidateRoleData implements domain.RoleUseCase.
+func (uc *RoleUseCase) ValidateRoleData(role *domain.Role) error {
+	if role == nil {
+		return errors.New("role is required")
+	}
+
+	role.Name = strings.ToLower(strings.TrimSpace(role.Name))
+	if !roleNamePattern.MatchString(role.Name) {
+		return errors.New("role name must be 2-50 lowercase letters, digits, '-' or '_' and start with a letter")
+	}
+
+	role.Description = strings.TrimSpace(role.Description)
+	if len(role.Description) > 255 {
+		return errors.New("description too long")
+	}
+
+	// El nombre no se repite, tampoco con los roles de sistema
+	existing, err := uc.roleRepo.FindByNames([]string{role.Name})
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 && existing[0].ID != role.ID {
+		return domain.ErrRoleExists
+	}
+
+	// Solo permisos del catálogo, sin repetir
+	seen := make(map[string]bool, len(role.Permissions))
+	permissions := make([]domain.Permission, 0, len(role.Permissions))
+	for _, permission := range role.Permissions {
+		name := strings.TrimSpace(permission.Name)
+		if !authz.IsKnownPermission(name) {
+			return errors.New("unknown permission: " + name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			permissions = append(permissions, domain.Permission{Name: name})
+		}
+	}
+	role.Permissions = permissions
+
+	return nil
+}
+
+// normalizeNames pasa los nombres a minúsculas y quita vacíos y repetidos
+func normalizeNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" && !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized
+}
+
+// lastAdmin indica si roles, los de un usuario activo, incluyen admin y
+// nadie más activo lo tiene
+func (uc *RoleUseCase) lastAdmin(roles []*domain.Role) (bool, error) {
+	admin := findRole(roles, domain.RoleAdmin)
+	if admin == nil {
+		return false, nil
+	}
+
+	count, err := uc.roleRepo.CountActiveUsers(admin.ID)
+	if err != nil {
+		return false, err
+	}
+	return count <= 1, nil
+}
+
+func hasRole(roles []*domain.Role, name string) bool {
+	return findRole(roles, name) != nil
+}
+
+func findRole(roles []*domain.Role, name string) *domain.Role {
+	for _, role := range roles {
+		if role.Name == name {
+			return role
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"finanzas-api/internal/roles/domain"
+	"finanzas-api/internal/roles/repository"
+	userDomain "finanzas-api/internal/users/domain"
+	userRepository "finanzas-api/internal/users/repository"
+)
+
+// newTestRoles crea los roles admin y user y los usuarios 1 (admin) y 2 (user)
+func newTestRoles(t *testing.T) domain.RoleUseCase {
+	t.Helper()
+	roles := repository.NewRoleMemoryRepository()
+	for _, name := range []string{domain.RoleAdmin, domain.RoleUser} {
+		if err := roles.EnsureSystemRole(&domain.Role{Name: name, IsSystem: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	users := userRepository.NewUserMemoryRepository()
+	for _, email := range []string{"admin@example.com", "ana@example.com"} {
+		if err := users.Create(&userDomain.User{Email: email, FirstName: "Ana", LastName: "Gómez", IsActive: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	uc := NewRoleUseCase(roles, users)
+	if _, err := uc.SetUserRoles(1, []string{domain.RoleAdmin}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uc.SetUserRoles(2, []string{domain.RoleUser}); err != nil {
+		t.Fatal(err)
+	}
+	return uc
+}
+
+func TestSetUserRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  uint
+		roles   []string
+		wantErr error
+		wantMsg string
+	}{
+		{name: "empty set", userID: 2, roles: []string{}, wantMsg: "at least one role is required"},
+		{name: "only blank names", userID: 2, roles: []string{" ", ""}, wantMsg: "at least one role is required"},
+		{name: "unknown role", userID: 2, roles: []string{"auditor"}, wantErr: domain.ErrRoleNotFound},
+		{name: "last admin loses admin", userID: 1, roles: []string{domain.RoleUser}, wantErr: domain.ErrLastAdmin},
+		{name: "second admin", userID: 2, roles: []string{"Admin", domain.RoleUser}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newTestRoles(t)
+			_, err := uc.SetUserRoles(tt.userID, tt.roles)
+			switch {
+			case tt.wantMsg != "":
+				if err == nil || err.Error() != tt.wantMsg {
+					t.Fatalf("SetUserRoles() error = %v, want %q", err, tt.wantMsg)
+				}
+			case !errors.Is(err, tt.wantErr):
+				t.Fatalf("SetUserRoles() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsLastAdmin(t *testing.T) {
+	uc := newTestRoles(t)
+	tests := []struct {
+		userID uint
+		want   bool
+	}{
+		{userID: 1, want: true},
+		{userID: 2, want: false},
+	}
+	for _, tt := range tests {
+		if last, err := uc.IsLastAdmin(tt.userID); err != nil || last != tt.want {
+			t.Errorf("IsLastAdmin(%d) = %v, %v, want %v", tt.userID, last, err, tt.want)
+		}
+	}
+
+	// Con un segundo administrador, ninguno es el último
+	if _, err := uc.SetUserRoles(2, []string{domain.RoleAdmin}); err != nil {
+		t.Fatal(err)
+	}
+	if last, err := uc.IsLastAdmin(1); err != nil || last {
+		t.Errorf("IsLastAdmin(1) with two admins = %v, %v, want false", last, err)
+	}
+}
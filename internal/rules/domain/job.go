@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"time"
+
+	userDomain "finanzas-api/internal/users/domain"
+)
+
+// JobStatus es el estado de una aplicación de reglas al historial
+type JobStatus string
+
+const (
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+// ReapplyFilter limita las transacciones a las que se aplican las reglas.
+// Las fechas son inclusivas y todos los criterios son opcionales.
+type ReapplyFilter struct {
+	From      *time.Time
+	To        *time.Time
+	AccountID *uint
+}
+
+// RuleJob registra una aplicación de las reglas al historial del usuario
+type RuleJob struct {
+	ID         uint            `json:"id" gorm:"primaryKey"`
+	UserID     uint            `json:"user_id" gorm:"not null;index"`
+	User       userDomain.User `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Status     JobStatus       `json:"status" gorm:"type:varchar(20);not null;index"`
+	From       *time.Time      `json:"from" gorm:"type:date"`
+	To         *time.Time      `json:"to" gorm:"type:date"`
+	AccountID  *uint           `json:"account_id"`
+	Scanned    int             `json:"scanned"` // Transacciones evaluadas
+	Updated    int             `json:"updated"` // Transacciones modificadas
+	Error      string          `json:"error,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// RuleJobRepository define la interfaz del repositorio de trabajos
+type RuleJobRepository interface {
+	// Create guarda el trabajo en curso; si el usuario ya tiene uno,
+	// retorna ErrJobRunning
+	Create(job *RuleJob) error
+	GetByID(id uint) (*RuleJob, error)
+	Update(job *RuleJob) error
+	// FailRunning marca como fallidos los trabajos que quedaron en curso,
+	// p. ej. por un reinicio del servidor
+	FailRunning(reason string) error
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (RuleJob) TableName() string {
+	return "rule_jobs"
+}
+
+// BelongsTo verifica si el trabajo pertenece al usuario indicado
+func (j *RuleJob) BelongsTo(userID uint) bool {
+	return j.UserID == userID
+}
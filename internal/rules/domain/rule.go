@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	userDomain "finanzas-api/internal/users/domain"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrRuleNotFound se retorna cuando la regla no existe o es de otro usuario
+	ErrRuleNotFound = errors.New("rule not found")
+	// ErrJobRunning se retorna al pedir una nueva aplicación al historial
+	// mientras otra del mismo usuario sigue en curso
+	ErrJobRunning = errors.New("a re-apply job is already running")
+)
+
+// Rule categoriza automáticamente las transacciones que cumplen todas sus
+// condiciones. Las reglas se evalúan por prioridad ascendente y cada una
+// que coincide aplica sus acciones; una acción nunca pisa la categoría que
+// ya tiene la transacción ni el comercio que renombró una regla anterior.
+type Rule struct {
+	ID             uint            `json:"id" gorm:"primaryKey"`
+	UserID         uint            `json:"user_id" gorm:"not null;index"`
+	User           userDomain.User `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Name           string          `json:"name" gorm:"type:varchar(100);not null"`
+	Priority       int             `json:"priority" gorm:"not null"`        // Menor se evalúa primero
+	Enabled        bool            `json:"enabled" gorm:"not null"`         // Sin default: false es un valor válido
+	StopProcessing bool            `json:"stop_processing" gorm:"not null"` // Si coincide, no se evalúan las siguientes
+	// Condiciones; las vacías no filtran
+	DescriptionContains string                      `json:"description_contains" gorm:"type:varchar(255)"`
+	DescriptionPattern  string                      `json:"description_pattern" gorm:"type:varchar(255)"` // Expresión regular
+	Counterparty        string                      `json:"counterparty" gorm:"type:varchar(255)"`        // Contenido en el comercio
+	AccountID           *uint                       `json:"account_id"`
+	Direction           transactionDomain.Direction `json:"direction" gorm:"type:varchar(10)"`
+	MinAmount           *int64                      `json:"min_amount"` // En unidades menores, inclusivo
+	MaxAmount           *int64                      `json:"max_amount"`
+	// Acciones
+	CategoryID     *uint          `json:"category_id"`
+	AddTag         string         `json:"add_tag" gorm:"type:varchar(50)"`
+	RenamePayee    string         `json:"rename_payee" gorm:"type:varchar(255)"`
+	MarkAsTransfer bool           `json:"mark_as_transfer" gorm:"not null"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete
+
+	// pattern es DescriptionPattern compilada; se arma al evaluar
+	pattern *regexp.Regexp `gorm:"-"`
+}
+
+// RuleOutcome es el resultado de evaluar las reglas sobre una transacción
+type RuleOutcome struct {
+	Matched []*Rule  // Reglas que coincidieron, en el orden en que se aplicaron
+	Tags    []string // Etiquetas que se deben agregar
+	Changed bool     // Si alguna acción modificó la transacción
+}
+
+// RuleRepository define la interfaz del repositorio de reglas
+type RuleRepository interface {
+	Create(rule *Rule) error
+	GetByID(id uint) (*Rule, error)
+	Update(rule *Rule) error
+	Delete(id uint) error
+	// ListByUser retorna las reglas por prioridad ascendente y luego por ID
+	ListByUser(userID uint) ([]*Rule, error)
+}
+
+type RuleUseCase interface {
+	CreateRule(rule *Rule) error
+	GetRule(userID, id uint) (*Rule, error)
+	UpdateRule(userID uint, rule *Rule) error
+	DeleteRule(userID, id uint) error
+	ListRules(userID uint) ([]*Rule, error)
+	// Apply aplica las reglas activas a una transacción que aún no se
+	// guarda. Las etiquetas quedan en transaction.Tags.
+	Apply(transaction *transactionDomain.Transaction) (*RuleOutcome, error)
+	// Test evalúa las reglas sobre una transacción de ejemplo sin guardar nada
+	Test(sample *transactionDomain.Transaction) (*RuleOutcome, error)
+	// StartReapply crea un trabajo que aplica las reglas al historial en
+	// segundo plano y lo retorna sin esperar a que termine
+	StartReapply(userID uint, filter ReapplyFilter) (*RuleJob, error)
+	GetJob(userID, id uint) (*RuleJob, error)
+	ValidateRuleData(rule *Rule) error
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (Rule) TableName() string {
+	return "rules"
+}
+
+// BelongsTo verifica si la regla pertenece al usuario indicado
+func (r *Rule) BelongsTo(userID uint) bool {
+	return r.UserID == userID
+}
+
+// HasConditions indica si la regla tiene al menos una condición
+func (r *Rule) HasConditions() bool {
+	return r.DescriptionContains != "" || r.DescriptionPattern != "" || r.Counterparty != "" ||
+		r.AccountID != nil || r.Direction != "" || r.MinAmount != nil || r.MaxAmount != nil
+}
+
+// HasActions indica si la regla tiene al menos una acción
+func (r *Rule) HasActions() bool {
+	return r.CategoryID != nil || r.AddTag != "" || r.RenamePayee != "" || r.MarkAsTransfer
+}
+
+// Compile prepara la expresión regular de la descripción
+func (r *Rule) Compile() error {
+	if r.DescriptionPattern == "" {
+		r.pattern = nil
+		return nil
+	}
+	pattern, err := regexp.Compile(r.DescriptionPattern)
+	if err != nil {
+		return err
+	}
+	r.pattern = pattern
+	return nil
+}
+
+// Matches indica si la transacción cumple todas las condiciones. Los textos
+// se comparan sin distinguir mayúsculas; la expresión regular, tal como la
+// escribió el usuario (puede empezar con (?i)).
+func (r *Rule) Matches(transaction *transactionDomain.Transaction) bool {
+	if r.AccountID != nil && *r.AccountID != transaction.AccountID {
+		return false
+	}
+	if r.Direction != "" && r.Direction != transaction.Direction {
+		return false
+	}
+	if r.MinAmount != nil && transaction.Amount < *r.MinAmount {
+		return false
+	}
+	if r.MaxAmount != nil && transaction.Amount > *r.MaxAmount {
+		return false
+	}
+	if r.DescriptionContains != "" && !containsFold(transaction.Description, r.DescriptionContains) {
+		return false
+	}
+	if r.Counterparty != "" && !containsFold(transaction.Payee, r.Counterparty) {
+		return false
+	}
+	if r.DescriptionPattern != "" {
+		if r.pattern == nil && r.Compile() != nil {
+			return false
+		}
+		if !r.pattern.MatchString(transaction.Description) {
+			return false
+		}
+	}
+	return true
+}
+
+// Evaluate aplica sobre transaction las reglas que coinciden, en el orden
+// recibido. Solo asigna la categoría si la transacción no tiene una y solo
+// la primera regla que renombra el comercio lo hace. Las etiquetas se
+// retornan en el resultado para que el llamador las resuelva.
+func Evaluate(rules []*Rule, transaction *transactionDomain.Transaction) *RuleOutcome {
+	outcome := &RuleOutcome{}
+	renamed := false
+	for _, rule := range rules {
+		if !rule.Enabled || !rule.Matches(transaction) {
+			continue
+		}
+		outcome.Matched = append(outcome.Matched, rule)
+
+		if rule.CategoryID != nil && transaction.CategoryID == nil {
+			categoryID := *rule.CategoryID
+			transaction.CategoryID = &categoryID
+			outcome.Changed = true
+		}
+		if rule.RenamePayee != "" && !renamed {
+			renamed = true
+			if transaction.Payee != rule.RenamePayee {
+				transaction.Payee = rule.RenamePayee
+				outcome.Changed = true
+			}
+		}
+		if rule.AddTag != "" && !transaction.HasTag(rule.AddTag) && !containsTag(outcome.Tags, rule.AddTag) {
+			outcome.Tags = append(outcome.Tags, rule.AddTag)
+		}
+		if rule.MarkAsTransfer && !transaction.IsTransfer {
+			transaction.IsTransfer = true
+			outcome.Changed = true
+		}
+
+		if rule.StopProcessing {
+			break
+		}
+	}
+	return outcome
+}
+
+func containsFold(text, substring string) bool {
+	return strings.Contains(strings.ToLower(text), strings.ToLower(substring))
+}
+
+func containsTag(tags []string, name string) bool {
+	for _, tag := range tags {
+		if tag == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,467 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"finanzas-api/internal/rules/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+type RuleHandler struct {
+	ruleUseCase domain.RuleUseCase
+}
+
+// NewRuleHandler crea una nueva instancia del handler de reglas
+func NewRuleHandler(ruleUseCase domain.RuleUseCase) *RuleHandler {
+	return &RuleHandler{
+		ruleUseCase: ruleUseCase,
+	}
+}
+
+// RuleConditions agrupa las condiciones de una regla; las vacías no filtran
+type RuleConditions struct {
+	DescriptionContains string `json:"description_contains"`
+	DescriptionPattern  string `json:"description_pattern"`
+	Counterparty        string `json:"counterparty"`
+	AccountID           *uint  `json:"account_id"`
+	Direction           string `json:"direction" binding:"omitempty,oneof=income expense"`
+	MinAmount           *int64 `json:"min_amount"`
+	MaxAmount           *int64 `json:"max_amount"`
+}
+
+// RuleActions agrupa lo que hace una regla cuando coincide
+type RuleActions struct {
+	CategoryID     *uint  `json:"category_id"`
+	AddTag         string `json:"add_tag"`
+	RenamePayee    string `json:"rename_payee"`
+	MarkAsTransfer bool   `json:"mark_as_transfer"`
+}
+
+// RuleRequest representa la estructura de la petición para crear o
+// reemplazar una regla
+type RuleRequest struct {
+	Name           string         `json:"name" binding:"required"`
+	Priority       int            `json:"priority" binding:"gte=0"`
+	Enabled        *bool          `json:"enabled"` // Por defecto true
+	StopProcessing bool           `json:"stop_processing"`
+	Conditions     RuleConditions `json:"conditions"`
+	Actions        RuleActions    `json:"actions"`
+}
+
+// TestRuleRequest es la transacción de ejemplo sobre la que se evalúan las reglas
+type TestRuleRequest struct {
+	AccountID   uint   `json:"account_id"`
+	Amount      int64  `json:"amount" binding:"gte=0"`
+	Direction   string `json:"direction" binding:"required,oneof=income expense"`
+	Description string `json:"description"`
+	Payee       string `json:"payee"`
+}
+
+// ReapplyRequest limita las transacciones a las que se aplican las reglas
+type ReapplyRequest struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	AccountID *uint  `json:"account_id"`
+}
+
+// RuleResponse representa la respuesta de una regla
+type RuleResponse struct {
+	ID             uint           `json:"id"`
+	Name           string         `json:"name"`
+	Priority       int            `json:"priority"`
+	Enabled        bool           `json:"enabled"`
+	StopProcessing bool           `json:"stop_processing"`
+	Conditions     RuleConditions `json:"conditions"`
+	Actions        RuleActions    `json:"actions"`
+	CreatedAt      string         `json:"created_at"`
+	UpdatedAt      string         `json:"updated_at"`
+}
+
+// MatchedRule resume una regla que coincidió en una prueba
+type MatchedRule struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+}
+
+// TestResult es cómo quedaría la transacción de ejemplo tras aplicar las reglas
+type TestResult struct {
+	CategoryID *uint    `json:"category_id"`
+	Payee      string   `json:"payee"`
+	Tags       []string `json:"tags"`
+	IsTransfer bool     `json:"is_transfer"`
+}
+
+// JobResponse representa un trabajo de aplicación de reglas al historial
+type JobResponse struct {
+	ID         uint    `json:"id"`
+	Status     string  `json:"status"`
+	From       *string `json:"from"`
+	To         *string `json:"to"`
+	AccountID  *uint   `json:"account_id"`
+	Scanned    int     `json:"scanned"`
+	Updated    int     `json:"updated"`
+	Error      string  `json:"error,omitempty"`
+	FinishedAt *string `json:"finished_at"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+// CreateRule crea una regla del usuario autenticado
+func (h *RuleHandler) CreateRule(c *gin.Context) {
+	var req RuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule := h.toRule(c.GetUint("userID"), req)
+	if err := h.ruleUseCase.CreateRule(rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Rule created successfully",
+		"rule":    h.toRuleResponse(rule),
+	})
+}
+
+// ListRules lista las reglas del usuario autenticado en orden de evaluación
+func (h *RuleHandler) ListRules(c *gin.Context) {
+	rules, err := h.ruleUseCase.ListRules(c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list rules",
+		})
+		return
+	}
+
+	responses := make([]RuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = h.toRuleResponse(rule)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules": responses,
+	})
+}
+
+// GetRule obtiene una regla del usuario autenticado
+func (h *RuleHandler) GetRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid rule ID",
+		})
+		return
+	}
+
+	rule, err := h.ruleUseCase.GetRule(c.GetUint("userID"), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Rule not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rule": h.toRuleResponse(rule),
+	})
+}
+
+// UpdateRule reemplaza una regla del usuario autenticado: las condiciones y
+// acciones que no se envían quedan vacías
+func (h *RuleHandler) UpdateRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid rule ID",
+		})
+		return
+	}
+
+	var req RuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	existing, err := h.ruleUseCase.GetRule(userID, uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Rule not found",
+		})
+		return
+	}
+
+	rule := h.toRule(userID, req)
+	rule.ID = existing.ID
+	rule.CreatedAt = existing.CreatedAt
+	if err := h.ruleUseCase.UpdateRule(userID, rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Rule updated successfully",
+		"rule":    h.toRuleResponse(rule),
+	})
+}
+
+// DeleteRule elimina una regla del usuario autenticado
+func (h *RuleHandler) DeleteRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid rule ID",
+		})
+		return
+	}
+
+	if err := h.ruleUseCase.DeleteRule(c.GetUint("userID"), uint(id)); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrRuleNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Rule deleted successfully",
+	})
+}
+
+// TestRules muestra qué reglas se aplicarían a una transacción de ejemplo y
+// cómo quedaría, sin guardar nada
+func (h *RuleHandler) TestRules(c *gin.Context) {
+	var req TestRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	sample := &transactionDomain.Transaction{
+		UserID:      c.GetUint("userID"),
+		AccountID:   req.AccountID,
+		Amount:      req.Amount,
+		Direction:   transactionDomain.Direction(req.Direction),
+		Description: req.Description,
+		Payee:       req.Payee,
+	}
+	outcome, err := h.ruleUseCase.Test(sample)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	matched := make([]MatchedRule, len(outcome.Matched))
+	for i, rule := range outcome.Matched {
+		matched[i] = MatchedRule{ID: rule.ID, Name: rule.Name, Priority: rule.Priority}
+	}
+	// rule es la primera que coincide: la de mayor prioridad
+	var first *MatchedRule
+	if len(matched) > 0 {
+		first = &matched[0]
+	}
+	tags := outcome.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rule":          first,
+		"matched_rules": matched,
+		"result": TestResult{
+			CategoryID: sample.CategoryID,
+			Payee:      sample.Payee,
+			Tags:       tags,
+			IsTransfer: sample.IsTransfer,
+		},
+	})
+}
+
+// ReapplyRules inicia la aplicación de las reglas al historial del usuario
+// autenticado. Responde 202 con el trabajo, que se consulta con GetJob.
+// Filtros opcionales: from, to (YYYY-MM-DD) y account_id.
+func (h *RuleHandler) ReapplyRules(c *gin.Context) {
+	var req ReapplyRequest
+	// El cuerpo es opcional: sin él se recorre todo el historial
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request data",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	filter := domain.ReapplyFilter{AccountID: req.AccountID}
+	if req.From != "" {
+		from, err := time.Parse(dateLayout, req.From)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid from date, expected YYYY-MM-DD",
+			})
+			return
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := time.Parse(dateLayout, req.To)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid to date, expected YYYY-MM-DD",
+			})
+			return
+		}
+		filter.To = &to
+	}
+
+	job, err := h.ruleUseCase.StartReapply(c.GetUint("userID"), filter)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrJobRunning) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Re-apply job started",
+		"job":     h.toJobResponse(job),
+	})
+}
+
+// GetJob obtiene el estado de un trabajo de aplicación de reglas
+func (h *RuleHandler) GetJob(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID",
+		})
+		return
+	}
+
+	job, err := h.ruleUseCase.GetJob(c.GetUint("userID"), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Rule job not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job": h.toJobResponse(job),
+	})
+}
+
+// toRule convierte la petición en una regla del dominio
+func (h *RuleHandler) toRule(userID uint, req RuleRequest) *domain.Rule {
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	return &domain.Rule{
+		UserID:              userID,
+		Name:                req.Name,
+		Priority:            req.Priority,
+		Enabled:             enabled,
+		StopProcessing:      req.StopProcessing,
+		DescriptionContains: req.Conditions.DescriptionContains,
+		DescriptionPattern:  req.Conditions.DescriptionPattern,
+		Counterparty:        req.Conditions.Counterparty,
+		AccountID:           req.Conditions.AccountID,
+		Direction:           transactionDomain.Direction(req.Conditions.Direction),
+		MinAmount:           req.Conditions.MinAmount,
+		MaxAmount:           req.Conditions.MaxAmount,
+		CategoryID:          req.Actions.CategoryID,
+		AddTag:              req.Actions.AddTag,
+		RenamePayee:         req.Actions.RenamePayee,
+		MarkAsTransfer:      req.Actions.MarkAsTransfer,
+	}
+}
+
+// toRuleResponse convierte una regla del dominio a respuesta HTTP
+func (h *RuleHandler) toRuleResponse(rule *domain.Rule) RuleResponse {
+	return RuleResponse{
+		ID:             rule.ID,
+		Name:           rule.Name,
+		Priority:       rule.Priority,
+		Enabled:        rule.Enabled,
+		StopProcessing: rule.StopProcessing,
+		Conditions: RuleConditions{
+			DescriptionContains: rule.DescriptionContains,
+			DescriptionPattern:  rule.DescriptionPattern,
+			Counterparty:        rule.Counterparty,
+			AccountID:           rule.AccountID,
+			Direction:           string(rule.Direction),
+			MinAmount:           rule.MinAmount,
+			MaxAmount:           rule.MaxAmount,
+		},
+		Actions: RuleActions{
+			CategoryID:     rule.CategoryID,
+			AddTag:         rule.AddTag,
+			RenamePayee:    rule.RenamePayee,
+			MarkAsTransfer: rule.MarkAsTransfer,
+		},
+		CreatedAt: rule.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: rule.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// toJobResponse convierte un trabajo del dominio a respuesta HTTP
+func (h *RuleHandler) toJobResponse(job *domain.RuleJob) JobResponse {
+	response := JobResponse{
+		ID:        job.ID,
+		Status:    string(job.Status),
+		AccountID: job.AccountID,
+		Scanned:   job.Scanned,
+		Updated:   job.Updated,
+		Error:     job.Error,
+		CreatedAt: job.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if job.From != nil {
+		from := job.From.Format(dateLayout)
+		response.From = &from
+	}
+	if job.To != nil {
+		to := job.To.Format(dateLayout)
+		response.To = &to
+	}
+	if job.FinishedAt != nil {
+		finishedAt := job.FinishedAt.Format("2006-01-02T15:04:05Z")
+		response.FinishedAt = &finishedAt
+	}
+	return response
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "finanzas-api/internal/rules/domain"
+
+type RuleRepository interface {
+	domain.RuleRepository
+}
+
+type RuleJobRepository interface {
+	domain.RuleJobRepository
+}
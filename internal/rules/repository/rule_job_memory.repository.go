@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"finanzas-api/internal/rules/domain"
+)
+
+type ruleJobRepositoryMemory struct {
+	jobs   map[uint]*domain.RuleJob
+	nextID uint
+	mutex  sync.RWMutex
+}
+
+func NewRuleJobMemoryRepository() domain.RuleJobRepository {
+	return &ruleJobRepositoryMemory{
+		jobs:   make(map[uint]*domain.RuleJob),
+		nextID: 1,
+	}
+}
+
+func (r *ruleJobRepositoryMemory) Create(job *domain.RuleJob) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, existing := range r.jobs {
+		if existing.UserID == job.UserID && existing.Status == domain.JobStatusRunning {
+			return domain.ErrJobRunning
+		}
+	}
+
+	// Asignar ID y timestamps
+	job.ID = r.nextID
+	r.nextID++
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = time.Now()
+
+	copied := *job
+	r.jobs[job.ID] = &copied
+	return nil
+}
+
+func (r *ruleJobRepositoryMemory) GetByID(id uint) (*domain.RuleJob, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	job, exists := r.jobs[id]
+	if !exists {
+		return nil, errors.New("rule job not found")
+	}
+
+	copied := *job
+	return &copied, nil
+}
+
+func (r *ruleJobRepositoryMemory) Update(job *domain.RuleJob) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.jobs[job.ID]; !exists {
+		return errors.New("rule job not found")
+	}
+
+	job.UpdatedAt = time.Now()
+	copied := *job
+	r.jobs[job.ID] = &copied
+	return nil
+}
+
+func (r *ruleJobRepositoryMemory) FailRunning(reason string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	for _, job := range r.jobs {
+		if job.Status == domain.JobStatusRunning {
+			job.Status = domain.JobStatusFailed
+			job.Error = reason
+			job.FinishedAt = &now
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"time"
+
+	"finanzas-api/internal/rules/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ruleJobPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewRuleJobPostgresRepository(db *gorm.DB) domain.RuleJobRepository {
+	return &ruleJobPostgresRepository{db: db}
+}
+
+func (r *ruleJobPostgresRepository) Create(job *domain.RuleJob) error {
+	// El índice parcial idx_rule_jobs_running admite un solo trabajo en
+	// curso por usuario; el conflicto no inserta la fila
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(job)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrJobRunning
+	}
+	return nil
+}
+
+func (r *ruleJobPostgresRepository) GetByID(id uint) (*domain.RuleJob, error) {
+	var job domain.RuleJob
+	if err := r.db.First(&job, id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *ruleJobPostgresRepository) Update(job *domain.RuleJob) error {
+	return r.db.Save(job).Error
+}
+
+func (r *ruleJobPostgresRepository) FailRunning(reason string) error {
+	return r.db.Model(&domain.RuleJob{}).
+		Where("status = ?", domain.JobStatusRunning).
+		Updates(map[string]interface{}{"status": domain.JobStatusFailed, "error": reason, "finished_at": time.Now()}).Error
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"finanzas-api/internal/rules/domain"
+)
+
+type ruleRepositoryMemory struct {
+	rules  map[uint]*domain.Rule
+	nextID uint
+	mutex  sync.RWMutex
+}
+
+func NewRuleMemoryRepository() domain.RuleRepository {
+	return &ruleRepositoryMemory{
+		rules:  make(map[uint]*domain.Rule),
+		nextID: 1,
+	}
+}
+
+func (r *ruleRepositoryMemory) Create(rule *domain.Rule) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Asignar ID y timestamps
+	rule.ID = r.nextID
+	r.nextID++
+	rule.CreatedAt = time.Now()
+	rule.UpdatedAt = time.Now()
+
+	copied := *rule
+	r.rules[rule.ID] = &copied
+	return nil
+}
+
+func (r *ruleRepositoryMemory) GetByID(id uint) (*domain.Rule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	rule, exists := r.rules[id]
+	if !exists || !rule.DeletedAt.Time.IsZero() {
+		return nil, errors.New("rule not found")
+	}
+
+	copied := *rule
+	return &copied, nil
+}
+
+func (r *ruleRepositoryMemory) Update(rule *domain.Rule) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existing, exists := r.rules[rule.ID]
+	if !exists || !existing.DeletedAt.Time.IsZero() {
+		return errors.New("rule not found")
+	}
+
+	rule.UpdatedAt = time.Now()
+	copied := *rule
+	r.rules[rule.ID] = &copied
+	return nil
+}
+
+func (r *ruleRepositoryMemory) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	rule, exists := r.rules[id]
+	if !exists || !rule.DeletedAt.Time.IsZero() {
+		return errors.New("rule not found")
+	}
+
+	// Soft delete
+	rule.DeletedAt.Time = time.Now()
+	rule.DeletedAt.Valid = true
+	rule.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (r *ruleRepositoryMemory) ListByUser(userID uint) ([]*domain.Rule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var rules []*domain.Rule
+	for _, rule := range r.rules {
+		if rule.DeletedAt.Time.IsZero() && rule.UserID == userID {
+			copied := *rule
+			rules = append(rules, &copied)
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority < rules[j].Priority
+		}
+		return rules[i].ID < rules[j].ID
+	})
+	return rules, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"finanzas-api/internal/rules/domain"
+
+	"gorm.io/gorm"
+)
+
+type rulePostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewRulePostgresRepository(db *gorm.DB) domain.RuleRepository {
+	return &rulePostgresRepository{db: db}
+}
+
+func (r *rulePostgresRepository) Create(rule *domain.Rule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *rulePostgresRepository) GetByID(id uint) (*domain.Rule, error) {
+	var rule domain.Rule
+	if err := r.db.First(&rule, id).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *rulePostgresRepository) Update(rule *domain.Rule) error {
+	return r.db.Save(rule).Error
+}
+
+func (r *rulePostgresRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Rule{}, id).Error // soft delete
+}
+
+func (r *rulePostgresRepository) ListByUser(userID uint) ([]*domain.Rule, error) {
+	var rules []*domain.Rule
+	if err := r.db.Where("user_id = ?", userID).Order("priority, id").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"finanzas-api/internal/rules/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupRuleRoutes configura las rutas para el módulo de reglas
+func SetupRuleRoutes(router *gin.Engine, ruleHandler *handler.RuleHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	ruleRoutes := router.Group("/api/v1/rules")
+	{
+		// POST /api/v1/rules - Crear regla
+		ruleRoutes.POST("", authMiddleware("rules:write"), ruleHandler.CreateRule)
+		// GET /api/v1/rules - Listar reglas en orden de evaluación
+		ruleRoutes.GET("", authMiddleware("rules:read"), ruleHandler.ListRules)
+		// POST /api/v1/rules/test - Probar las reglas con una transacción de ejemplo
+		ruleRoutes.POST("/test", authMiddleware("rules:read"), ruleHandler.TestRules)
+		// POST /api/v1/rules/apply - Aplicar las reglas al historial en segundo plano
+		ruleRoutes.POST("/apply", authMiddleware("rules:write"), ruleHandler.ReapplyRules)
+		// GET /api/v1/rules/jobs/:id - Estado de una aplicación al historial
+		ruleRoutes.GET("/jobs/:id", authMiddleware("rules:read"), ruleHandler.GetJob)
+		// GET /api/v1/rules/:id - Obtener regla
+		ruleRoutes.GET("/:id", authMiddleware("rules:read"), ruleHandler.GetRule)
+		// PUT /api/v1/rules/:id - Reemplazar regla
+		ruleRoutes.PUT("/:id", authMiddleware("rules:write"), ruleHandler.UpdateRule)
+		// DELETE /api/v1/rules/:id - Eliminar regla
+		ruleRoutes.DELETE("/:id", authMiddleware("rules:write"), ruleHandler.DeleteRule)
+	}
+}
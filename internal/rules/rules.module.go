@@ -0,0 +1,71 @@
+package rules
+
+import (
+	"fmt"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/rules/domain"
+	"finanzas-api/internal/rules/handler"
+	"finanzas-api/internal/rules/repository"
+	"finanzas-api/internal/rules/usecase"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+
+	"gorm.io/gorm"
+)
+
+type RulesModule struct {
+	Handler    *handler.RuleHandler
+	UseCase    domain.RuleUseCase
+	Repository domain.RuleRepository
+}
+
+func NewRulesModule(
+	db *gorm.DB,
+	transactionRepo transactionDomain.TransactionRepository,
+	tagRepo transactionDomain.TagRepository,
+	accountRepo accountDomain.AccountRepository,
+	categoryRepo categoryDomain.CategoryRepository,
+) *RulesModule {
+	var ruleRepo domain.RuleRepository
+	var jobRepo domain.RuleJobRepository
+	var ruleUseCase domain.RuleUseCase
+	var ruleHandler *handler.RuleHandler
+
+	if err := db.AutoMigrate(&domain.Rule{}, &domain.RuleJob{}); err != nil {
+		panic(fmt.Sprintf("Error migrating rules: %v", err))
+	}
+
+	// Índice parcial: un solo trabajo en curso por usuario
+	if err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_rule_jobs_running
+		ON rule_jobs (user_id) WHERE status = 'running'`).Error; err != nil {
+		panic(fmt.Sprintf("Error creating rule job index: %v", err))
+	}
+
+	ruleRepo = repository.NewRulePostgresRepository(db)
+	jobRepo = repository.NewRuleJobPostgresRepository(db)
+
+	// Los trabajos corren en memoria del proceso: si quedaron en curso, el
+	// servidor se reinició antes de que terminaran
+	if err := jobRepo.FailRunning("interrupted by server restart"); err != nil {
+		panic(fmt.Sprintf("Error closing interrupted rule jobs: %v", err))
+	}
+
+	ruleUseCase = usecase.NewRuleUseCase(ruleRepo, jobRepo, transactionRepo, tagRepo, accountRepo, categoryRepo)
+	ruleHandler = handler.NewRuleHandler(ruleUseCase)
+
+	return &RulesModule{
+		Handler:    ruleHandler,
+		UseCase:    ruleUseCase,
+		Repository: ruleRepo,
+	}
+}
+
+// ApplyRulesHook retorna el hook que aplica las reglas del usuario a cada
+// transacción nueva, tanto manual como importada
+func (m *RulesModule) ApplyRulesHook() transactionDomain.BeforeCreateHook {
+	return func(transaction *transactionDomain.Transaction) error {
+		_, err := m.UseCase.Apply(transaction)
+		return err
+	}
+}
@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/rules/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+)
+
+// jobProgressEvery es cada cuántas transacciones se guarda el avance de un trabajo
+const jobProgressEvery = 200
+
+type RuleUseCase struct {
+	ruleRepo        domain.RuleRepository
+	jobRepo         domain.RuleJobRepository
+	transactionRepo transactionDomain.TransactionRepository
+	tagRepo         transactionDomain.TagRepository
+	accountRepo     accountDomain.AccountRepository
+	categoryRepo    categoryDomain.CategoryRepository
+}
+
+func NewRuleUseCase(
+	ruleRepo domain.RuleRepository,
+	jobRepo domain.RuleJobRepository,
+	transactionRepo transactionDomain.TransactionRepository,
+	tagRepo transactionDomain.TagRepository,
+	accountRepo accountDomain.AccountRepository,
+	categoryRepo categoryDomain.CategoryRepository,
+) domain.RuleUseCase {
+	return &RuleUseCase{
+		ruleRepo:        ruleRepo,
+		jobRepo:         jobRepo,
+		transactionRepo: transactionRepo,
+		tagRepo:         tagRepo,
+		accountRepo:     accountRepo,
+		categoryRepo:    categoryRepo,
+	}
+}
+
+// CreateRule implements domain.RuleUseCase.
+func (uc *RuleUseCase) CreateRule(rule *domain.Rule) error {
+	if err := uc.ValidateRuleData(rule); err != nil {
+		return err
+	}
+
+	return uc.ruleRepo.Create(rule)
+}
+
+// GetRule implements domain.RuleUseCase.
+func (uc *RuleUseCase) GetRule(userID, id uint) (*domain.Rule, error) {
+	if id == 0 {
+		return nil, errors.New("invalid rule ID")
+	}
+
+	rule, err := uc.ruleRepo.GetByID(id)
+	if err != nil || !rule.BelongsTo(userID) {
+		return nil, domain.ErrRuleNotFound
+	}
+
+	return rule, nil
+}
+
+// UpdateRule implements domain.RuleUseCase.
+func (uc *RuleUseCase) UpdateRule(userID uint, rule *domain.Rule) error {
+	if rule.ID == 0 {
+		return errors.New("rule ID is required")
+	}
+
+	if _, err := uc.GetRule(userID, rule.ID); err != nil {
+		return err
+	}
+
+	// El propietario no puede cambiar
+	rule.UserID = userID
+
+	if err := uc.ValidateRuleData(rule); err != nil {
+		return err
+	}
+
+	return uc.ruleRepo.Update(rule)
+}
+
+// DeleteRule implements domain.RuleUseCase.
+func (uc *RuleUseCase) DeleteRule(userID, id uint) error {
+	if _, err := uc.GetRule(userID, id); err != nil {
+		return err
+	}
+
+	return uc.ruleRepo.Delete(id)
+}
+
+// ListRules implements domain.RuleUseCase.
+func (uc *RuleUseCase) ListRules(userID uint) ([]*domain.Rule, error) {
+	return uc.ruleRepo.ListByUser(userID)
+}
+
+// Apply implements domain.RuleUseCase.
+func (uc *RuleUseCase) Apply(transaction *transactionDomain.Transaction) (*domain.RuleOutcome, error) {
+	rules, err := uc.ruleRepo.ListByUser(transaction.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	outcome := domain.Evaluate(rules, transaction)
+	for _, name := range outcome.Tags {
+		tag, err := uc.tagRepo.FindOrCreate(transaction.UserID, name)
+		if err != nil {
+			return nil, err
+		}
+		transaction.Tags = append(transaction.Tags, *tag)
+	}
+	return outcome, nil
+}
+
+// Test implements domain.RuleUseCase.
+func (uc *RuleUseCase) Test(sample *transactionDomain.Transaction) (*domain.RuleOutcome, error) {
+	if sample.UserID == 0 {
+		return nil, errors.New("user ID is required")
+	}
+	if sample.Direction != "" && !sample.IsValidDirection() {
+		return nil, errors.New("invalid direction")
+	}
+
+	rules, err := uc.ruleRepo.ListByUser(sample.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.Evaluate(rules, sample), nil
+}
+
+// StartReapply implements domain.RuleUseCase.
+func (uc *RuleUseCase) StartReapply(userID uint, filter domain.ReapplyFilter) (*domain.RuleJob, error) {
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return nil, errors.New("invalid date range")
+	}
+
+	if filter.AccountID != nil {
+		account, err := uc.accountRepo.GetByID(*filter.AccountID)
+		if err != nil || !account.BelongsTo(userID) {
+			return nil, errors.New("account not found")
+		}
+	}
+
+	job := &domain.RuleJob{
+		UserID:    userID,
+		Status:    domain.JobStatusRunning,
+		From:      filter.From,
+		To:        filter.To,
+		AccountID: filter.AccountID,
+	}
+	if err := uc.jobRepo.Create(job); err != nil {
+		return nil, err
+	}
+
+	// El trabajo sigue aunque la petición termine; se consulta con GetJob
+	started := *job
+	go uc.runReapply(job, filter)
+	return &started, nil
+}
+
+// GetJob implements domain.RuleUseCase.
+func (uc *RuleUseCase) GetJob(userID, id uint) (*domain.RuleJob, error) {
+	if id == 0 {
+		return nil, errors.New("invalid job ID")
+	}
+
+	job, err := uc.jobRepo.GetByID(id)
+	if err != nil || !job.BelongsTo(userID) {
+		return nil, errors.New("rule job not found")
+	}
+
+	return job, nil
+}
+
+// ValidateRuleData implements domain.RuleUseCase.
+func (uc *RuleUseCase) ValidateRuleData(rule *domain.Rule) error {
+	if rule == nil {
+		return errors.New("rule is required")
+	}
+
+	if rule.UserID == 0 {
+		return errors.New("user ID is required")
+	}
+
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(rule.Name) > 100 {
+		return errors.New("name too long")
+	}
+
+	if rule.Priority < 0 {
+		return errors.New("priority must be non-negative")
+	}
+
+	// Condiciones
+	rule.DescriptionContains = strings.TrimSpace(rule.DescriptionContains)
+	rule.Counterparty = strings.TrimSpace(rule.Counterparty)
+	if len(rule.DescriptionContains) > 255 || len(rule.Counterparty) > 255 || len(rule.DescriptionPattern) > 255 {
+		return errors.New("condition text too long")
+	}
+	if err := rule.Compile(); err != nil {
+		return errors.New("invalid description pattern")
+	}
+
+	if rule.Direction != "" && rule.Direction != transactionDomain.DirectionIncome && rule.Direction != transactionDomain.DirectionExpense {
+		return errors.New("invalid direction")
+	}
+
+	if (rule.MinAmount != nil && *rule.MinAmount < 0) || (rule.MaxAmount != nil && *rule.MaxAmount < 0) {
+		return errors.New("amounts must be non-negative")
+	}
+	if rule.MinAmount != nil && rule.MaxAmount != nil && *rule.MinAmount > *rule.MaxAmount {
+		return errors.New("invalid amount range")
+	}
+
+	if rule.AccountID != nil {
+		account, err := uc.accountRepo.GetByID(*rule.AccountID)
+		if err != nil || !account.BelongsTo(rule.UserID) {
+			return errors.New("account not found")
+		}
+	}
+
+	// Acciones
+	rule.AddTag = transactionDomain.NormalizeTagName(rule.AddTag)
+	if len(rule.AddTag) > transactionDomain.MaxTagLength {
+		return errors.New("tag too long")
+	}
+
+	rule.RenamePayee = strings.TrimSpace(rule.RenamePayee)
+	if len(rule.RenamePayee) > 255 {
+		return errors.New("payee too long")
+	}
+
+	if rule.CategoryID != nil {
+		category, err := uc.categoryRepo.GetByID(*rule.CategoryID)
+		if err != nil || !category.BelongsTo(rule.UserID) {
+			return errors.New("category not found")
+		}
+		// Una categoría de gastos solo se asigna a gastos: la dirección
+		// pasa a ser una condición implícita de la regla
+		kind := transactionDomain.Direction(category.Kind)
+		if rule.Direction == "" {
+			rule.Direction = kind
+		} else if rule.Direction != kind {
+			return errors.New("category kind does not match rule direction")
+		}
+	}
+
+	if !rule.HasConditions() {
+		return errors.New("rule needs at least one condition")
+	}
+	if !rule.HasActions() {
+		return errors.New("rule needs at least one action")
+	}
+
+	return nil
+}
+
+// runReapply aplica las reglas a las transacciones guardadas que cumplen el
+// filtro. Las patas de asientos contables no se modifican.
+func (uc *RuleUseCase) runReapply(job *domain.RuleJob, filter domain.ReapplyFilter) {
+	err := uc.reapply(job, filter)
+
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Status = domain.JobStatusCompleted
+	if err != nil {
+		job.Status = domain.JobStatusFailed
+		job.Error = err.Error()
+	}
+	if err := uc.jobRepo.Update(job); err != nil {
+		log.Printf("⚠️ Error guardando el trabajo de reglas %d: %v", job.ID, err)
+	}
+}
+
+func (uc *RuleUseCase) reapply(job *domain.RuleJob, filter domain.ReapplyFilter) error {
+	rules, err := uc.ruleRepo.ListByUser(job.UserID)
+	if err != nil {
+		return err
+	}
+
+	transactions, err := uc.transactionRepo.List(job.UserID, transactionDomain.TransactionFilter{
+		AccountID: filter.AccountID,
+		From:      filter.From,
+		To:        filter.To,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, transaction := range transactions {
+		if transaction.IsLedgerLeg() {
+			continue
+		}
+		job.Scanned++
+
+		outcome := domain.Evaluate(rules, transaction)
+		if outcome.Changed {
+			if err := uc.transactionRepo.Update(transaction); err != nil {
+				return err
+			}
+		}
+		if len(outcome.Tags) > 0 {
+			tags := make([]*transactionDomain.Tag, 0, len(outcome.Tags))
+			for _, name := range outcome.Tags {
+				tag, err := uc.tagRepo.FindOrCreate(job.UserID, name)
+				if err != nil {
+					return err
+				}
+				tags = append(tags, tag)
+			}
+			if err := uc.tagRepo.Attach(transaction.ID, tags); err != nil {
+				return err
+			}
+		}
+		if outcome.Changed || len(outcome.Tags) > 0 {
+			job.Updated++
+		}
+
+		if job.Scanned%jobProgressEvery == 0 {
+			if err := uc.jobRepo.Update(job); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
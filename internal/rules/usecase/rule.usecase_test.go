@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"testing"
+
+	accountRepository "finanzas-api/internal/accounts/repository"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	categoryRepository "finanzas-api/internal/categories/repository"
+	"finanzas-api/internal/rules/domain"
+	"finanzas-api/internal/rules/repository"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	transactionRepository "finanzas-api/internal/transactions/repository"
+)
+
+// newTestRules crea el caso de uso sobre repositorios en memoria con las
+// categorías de gastos 1 (Servicios) y 2 (Restaurantes) del usuario 1
+func newTestRules(t *testing.T) domain.RuleUseCase {
+	t.Helper()
+	categories := categoryRepository.NewCategoryMemoryRepository()
+	for _, category := range []*categoryDomain.Category{
+		{UserID: 1, Name: "Servicios", Kind: categoryDomain.KindExpense},
+		{UserID: 1, Name: "Restaurantes", Kind: categoryDomain.KindExpense},
+	} {
+		if err := categories.Create(category); err != nil {
+			t.Fatal(err)
+		}
+	}
+	transactions := transactionRepository.NewTransactionMemoryRepository()
+	return NewRuleUseCase(repository.NewRuleMemoryRepository(), repository.NewRuleJobMemoryRepository(), transactions,
+		transactionRepository.NewTagMemoryRepository(transactions), accountRepository.NewAccountMemoryRepository(), categories)
+}
+
+func uintPtr(value uint) *uint {
+	return &value
+}
+
+// TestRulePriority crea las reglas en desorden: se evalúan por prioridad y
+// la primera que coincide decide la categoría y el comercio
+func TestRulePriority(t *testing.T) {
+	tests := []struct {
+		name         string
+		description  string
+		stop         bool // StopProcessing en la regla específica
+		wantCategory uint
+		wantPayee    string
+		wantMatched  []string
+	}{
+		{name: "specific rule first", description: "Pago restaurante centro", wantCategory: 2, wantPayee: "Restaurante", wantMatched: []string{"Específica", "Genérica"}},
+		{name: "only the generic rule", description: "Pago luz", wantCategory: 1, wantPayee: "Servicios públicos", wantMatched: []string{"Genérica"}},
+		{name: "stop processing", description: "Pago restaurante centro", stop: true, wantCategory: 2, wantPayee: "Restaurante", wantMatched: []string{"Específica"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newTestRules(t)
+			for _, rule := range []*domain.Rule{
+				{UserID: 1, Name: "Genérica", Priority: 10, Enabled: true, DescriptionContains: "pago", CategoryID: uintPtr(1), RenamePayee: "Servicios públicos"},
+				{UserID: 1, Name: "Específica", Priority: 1, Enabled: true, StopProcessing: tt.stop, DescriptionContains: "pago restaurante", CategoryID: uintPtr(2), RenamePayee: "Restaurante"},
+				// La desactivada tendría la prioridad más alta
+				{UserID: 1, Name: "Desactivada", Priority: 0, DescriptionContains: "pago", CategoryID: uintPtr(1)},
+			} {
+				if err := uc.CreateRule(rule); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			sample := &transactionDomain.Transaction{UserID: 1, Amount: 45000, Direction: transactionDomain.DirectionExpense, Description: tt.description}
+			outcome, err := uc.Test(sample)
+			if err != nil {
+				t.Fatalf("Test() error = %v", err)
+			}
+			if sample.CategoryID == nil || *sample.CategoryID != tt.wantCategory {
+				t.Errorf("category = %v, want %d", sample.CategoryID, tt.wantCategory)
+			}
+			if sample.Payee != tt.wantPayee {
+				t.Errorf("payee = %q, want %q", sample.Payee, tt.wantPayee)
+			}
+			var matched []string
+			for _, rule := range outcome.Matched {
+				matched = append(matched, rule.Name)
+			}
+			if len(matched) != len(tt.wantMatched) {
+				t.Fatalf("matched %v, want %v", matched, tt.wantMatched)
+			}
+			for i := range matched {
+				if matched[i] != tt.wantMatched[i] {
+					t.Fatalf("matched %v, want %v", matched, tt.wantMatched)
+				}
+			}
+		})
+	}
+}
+
+func TestRuleDescriptionPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		wantErr bool
+	}{
+		{pattern: `(?i)^uber\s+trip`},
+		{pattern: `\d{4}$`},
+		{pattern: `(`, wantErr: true},
+		{pattern: `[a-`, wantErr: true},
+		{pattern: `*uber`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			uc := newTestRules(t)
+			err := uc.CreateRule(&domain.Rule{UserID: 1, Name: "Patrón", Enabled: true, DescriptionPattern: tt.pattern, CategoryID: uintPtr(1)})
+			if tt.wantErr {
+				if err == nil || err.Error() != "invalid description pattern" {
+					t.Fatalf("CreateRule() error = %v, want invalid description pattern", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateRule() error = %v", err)
+			}
+		})
+	}
+
+	// Una actualización con un patrón inválido no se guarda
+	uc := newTestRules(t)
+	rule := &domain.Rule{UserID: 1, Name: "Uber", Enabled: true, DescriptionPattern: `(?i)uber`, CategoryID: uintPtr(1)}
+	if err := uc.CreateRule(rule); err != nil {
+		t.Fatal(err)
+	}
+	update := *rule
+	update.DescriptionPattern = `(`
+	if err := uc.UpdateRule(1, &update); err == nil || err.Error() != "invalid description pattern" {
+		t.Fatalf("UpdateRule() error = %v, want invalid description pattern", err)
+	}
+	stored, err := uc.GetRule(1, rule.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.DescriptionPattern != `(?i)uber` {
+		t.Errorf("stored pattern = %q, want (?i)uber", stored.DescriptionPattern)
+	}
+}
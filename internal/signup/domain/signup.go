@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	userDomain "finanzas-api/internal/users/domain"
+)
+
+// ErrInvalidVerificationToken se retorna cuando el enlace no existe, venció,
+// ya se usó o fue reemplazado por uno más reciente
+var ErrInvalidVerificationToken = errors.New("invalid verification token")
+
+// ErrPasswordMismatch se retorna cuando la contraseña con la que se confirma
+// el enlace no es la de la cuenta; el enlace sigue vigente
+var ErrPasswordMismatch = errors.New("password does not match the account")
+
+// ErrVerificationNotSent se retorna cuando la cuenta quedó creada pero el
+// correo de verificación no se pudo enviar; el usuario puede pedir otro
+var ErrVerificationNotSent = errors.New("verification email could not be sent")
+
+// EmailVerification es un enlace de verificación de un solo uso. Solo se
+// guarda el hash SHA-256 del token que viaja en el correo.
+type EmailVerification struct {
+	ID        uint            `gorm:"primaryKey"`
+	UserID    uint            `gorm:"not null;index"`
+	User      userDomain.User `gorm:"constraint:OnDelete:CASCADE"`
+	TokenHash string          `gorm:"type:char(64);uniqueIndex;not null"`
+	ExpiresAt time.Time       `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"index"`
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (EmailVerification) TableName() string {
+	return "email_verifications"
+}
+
+// IsUsable indica si el enlace todavía puede confirmar la cuenta
+func (v *EmailVerification) IsUsable(now time.Time) bool {
+	return v.UsedAt == nil && now.Before(v.ExpiresAt)
+}
+
+// VerificationRepository define la interfaz del repositorio de enlaces de
+// verificación
+type VerificationRepository interface {
+	Create(verification *EmailVerification) error
+	GetByTokenHash(tokenHash string) (*EmailVerification, error)
+	// Consume marca el enlace como usado si sigue vigente. Retorna false si
+	// otra petición lo usó antes o ya venció.
+	Consume(id uint, now time.Time) (bool, error)
+	// ExpirePending vence los enlaces sin usar del usuario
+	ExpirePending(userID uint, now time.Time) error
+	// IssuedSince retorna en orden cronológico cuándo se emitieron los
+	// enlaces del usuario desde since; alimenta el límite de reenvíos
+	IssuedSince(userID uint, since time.Time) ([]time.Time, error)
+	// PurgeExpired elimina los enlaces que vencieron antes de before
+	PurgeExpired(before time.Time) error
+}
+
+type SignupUseCase interface {
+	// Signup crea la cuenta inactiva y envía el enlace de verificación. Si
+	// el email tiene una cuenta sin verificar, la reemplaza e invalida sus
+	// enlaces. Si solo falla el envío retorna ErrVerificationNotSent con la
+	// cuenta creada.
+	Signup(user *userDomain.User) error
+	// Verify consume el enlace y activa la cuenta. Pide la contraseña de la
+	// cuenta: abrir el enlace no basta para activar una cuenta que otra
+	// persona registró con el email.
+	Verify(token, password string) (*userDomain.User, error)
+	// ResendVerification envía un enlace nuevo e invalida los anteriores.
+	// Para no revelar qué emails tienen cuenta, no falla si el email no
+	// existe, ya está verificado, alcanzó el límite de envíos o el correo no
+	// sale; solo retorna los errores de la base de datos.
+	ResendVerification(email string) error
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"net/http"
+
+	"finanzas-api/internal/signup/domain"
+	userDomain "finanzas-api/internal/users/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// confirmPage pide la contraseña antes de activar la cuenta y envía el token
+// por POST a la misma ruta
+var confirmPage = template.Must(template.New("confirm").Parse(`<!DOCTYPE html>
+<html lang="es">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Confirma tu cuenta</title>
+</head>
+<body>
+<h1>Confirma tu cuenta de Finanzas</h1>
+<p>Escribe la contraseña con la que te registraste para activar la cuenta.</p>
+<form method="post">
+<input type="hidden" name="token" value="{{.}}">
+<label>Contraseña <input type="password" name="password" autocomplete="current-password" required></label>
+<button type="submit">Activar cuenta</button>
+</form>
+</body>
+</html>
+`))
+
+type SignupHandler struct {
+	signupUseCase domain.SignupUseCase
+}
+
+// NewSignupHandler crea una nueva instancia del handler de registro
+func NewSignupHandler(signupUseCase domain.SignupUseCase) *SignupHandler {
+	return &SignupHandler{
+		signupUseCase: signupUseCase,
+	}
+}
+
+// SignupRequest representa la estructura de la petición de registro
+type SignupRequest struct {
+	Email        string `json:"email" binding:"required,email"`
+	FirstName    string `json:"first_name" binding:"required"`
+	LastName     string `json:"last_name" binding:"required"`
+	Password     string `json:"password" binding:"required,min=8"`
+	Locale       string `json:"locale" binding:"omitempty,oneof=es en"`
+	BaseCurrency string `json:"base_currency" binding:"omitempty,len=3"`
+}
+
+// VerifyRequest representa la confirmación de un enlace, en JSON desde un
+// cliente o como formulario desde la página de confirmación
+type VerifyRequest struct {
+	Token    string `json:"token" form:"token" binding:"required"`
+	Password string `json:"password" form:"password" binding:"required"`
+}
+
+// ResendRequest representa la petición de un nuevo enlace de verificación
+type ResendRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// SignupResponse representa la cuenta registrada
+type SignupResponse struct {
+	ID            uint   `json:"id"`
+	Email         string `json:"email"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+	IsActive      bool   `json:"is_active"`
+	EmailVerified bool   `json:"email_verified"`
+	Locale        string `json:"locale"`
+	BaseCurrency  string `json:"base_currency"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// Signup registra una cuenta inactiva y envía el enlace de verificación
+func (h *SignupHandler) Signup(c *gin.Context) {
+	var req SignupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user := &userDomain.User{
+		Email:        req.Email,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		Password:     req.Password,
+		Locale:       req.Locale,
+		BaseCurrency: req.BaseCurrency,
+	}
+
+	err := h.signupUseCase.Signup(user)
+	if err != nil && !errors.Is(err, domain.ErrVerificationNotSent) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// La cuenta existe aunque el correo no haya salido: el cliente debe
+	// ofrecer el reenvío
+	message := "Account created, check your email to activate it"
+	if err != nil {
+		message = "Account created, but the verification email could not be sent; request a new one"
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"message":           message,
+		"verification_sent": err == nil,
+		"user":              h.toSignupResponse(user),
+	})
+}
+
+// VerifyLink muestra la página de confirmación del enlace del correo
+// (?token=). No consume el token: los escáneres de correo abren los enlaces
+// por su cuenta, así que la cuenta solo se activa con el POST del formulario.
+func (h *SignupHandler) VerifyLink(c *gin.Context) {
+	var page bytes.Buffer
+	if err := confirmPage.Execute(&page, c.Query("token")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to render confirmation page",
+		})
+		return
+	}
+
+	// El token viaja en la URL: que no se guarde ni se filtre a otros sitios
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
+
+// Verify confirma la cuenta con el token y la contraseña, en JSON o desde
+// el formulario de VerifyLink
+func (h *SignupHandler) Verify(c *gin.Context) {
+	var req VerifyRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user, err := h.signupUseCase.Verify(req.Token, req.Password)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, domain.ErrInvalidVerificationToken):
+			status = http.StatusBadRequest
+		case errors.Is(err, domain.ErrPasswordMismatch):
+			status = http.StatusUnauthorized
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email verified, the account is active",
+		"user":    h.toSignupResponse(user),
+	})
+}
+
+// ResendVerification envía un enlace nuevo. Responde igual exista o no la
+// cuenta, también cuando alcanzó el límite de envíos.
+func (h *SignupHandler) ResendVerification(c *gin.Context) {
+	var req ResendRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.signupUseCase.ResendVerification(req.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to resend verification email",
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "If the account exists and is pending verification, a new email is sent unless one was requested recently",
+	})
+}
+
+// toSignupResponse convierte un usuario del dominio a respuesta HTTP
+func (h *SignupHandler) toSignupResponse(user *userDomain.User) SignupResponse {
+	return SignupResponse{
+		ID:            user.ID,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		IsActive:      user.IsActive,
+		EmailVerified: user.IsEmailVerified(),
+		Locale:        user.Locale,
+		BaseCurrency:  user.BaseCurrency,
+		CreatedAt:     user.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/signup/domain"
+
+type VerificationRepository interface {
+	domain.VerificationRepository
+}
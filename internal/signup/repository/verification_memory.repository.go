@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"finanzas-api/internal/signup/domain"
+)
+
+type verificationRepositoryMemory struct {
+	verifications map[uint]*domain.EmailVerification
+	nextID        uint
+	mutex         sync.RWMutex
+}
+
+func NewVerificationMemoryRepository() domain.VerificationRepository {
+	return &verificationRepositoryMemory{
+		verifications: make(map[uint]*domain.EmailVerification),
+		nextID:        1,
+	}
+}
+
+func (r *verificationRepositoryMemory) Create(verification *domain.EmailVerification) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, existing := range r.verifications {
+		if existing.TokenHash == verification.TokenHash {
+			return errors.New("verification token already exists")
+		}
+	}
+
+	// Asignar ID y timestamps
+	verification.ID = r.nextID
+	r.nextID++
+	verification.CreatedAt = time.Now()
+
+	copied := *verification
+	r.verifications[verification.ID] = &copied
+	return nil
+}
+
+func (r *verificationRepositoryMemory) GetByTokenHash(tokenHash string) (*domain.EmailVerification, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, verification := range r.verifications {
+		if verification.TokenHash == tokenHash {
+			copied := *verification
+			return &copied, nil
+		}
+	}
+	return nil, errors.New("verification token not found")
+}
+
+func (r *verificationRepositoryMemory) Consume(id uint, now time.Time) (bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	verification, exists := r.verifications[id]
+	if !exists || !verification.IsUsable(now) {
+		return false, nil
+	}
+
+	usedAt := now
+	verification.UsedAt = &usedAt
+	return true, nil
+}
+
+func (r *verificationRepositoryMemory) ExpirePending(userID uint, now time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, verification := range r.verifications {
+		if verification.UserID == userID && verification.IsUsable(now) {
+			verification.ExpiresAt = now
+		}
+	}
+	return nil
+}
+
+func (r *verificationRepositoryMemory) IssuedSince(userID uint, since time.Time) ([]time.Time, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var issued []time.Time
+	for _, verification := range r.verifications {
+		if verification.UserID == userID && !verification.CreatedAt.Before(since) {
+			issued = append(issued, verification.CreatedAt)
+		}
+	}
+	sort.Slice(issued, func(i, j int) bool { return issued[i].Before(issued[j]) })
+	return issued, nil
+}
+
+func (r *verificationRepositoryMemory) PurgeExpired(before time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for id, verification := range r.verifications {
+		if verification.ExpiresAt.Before(before) {
+			delete(r.verifications, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"time"
+
+	"finanzas-api/internal/signup/domain"
+
+	"gorm.io/gorm"
+)
+
+type verificationPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewVerificationPostgresRepository(db *gorm.DB) domain.VerificationRepository {
+	return &verificationPostgresRepository{db: db}
+}
+
+func (r *verificationPostgresRepository) Create(verification *domain.EmailVerification) error {
+	return r.db.Create(verification).Error
+}
+
+func (r *verificationPostgresRepository) GetByTokenHash(tokenHash string) (*domain.EmailVerification, error) {
+	var verification domain.EmailVerification
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&verification).Error; err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+func (r *verificationPostgresRepository) Consume(id uint, now time.Time) (bool, error) {
+	// La condición used_at IS NULL hace que de dos confirmaciones simultáneas
+	// del mismo enlace solo una gane
+	result := r.db.Model(&domain.EmailVerification{}).
+		Where("id = ? AND used_at IS NULL AND expires_at > ?", id, now).
+		Update("used_at", now)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *verificationPostgresRepository) ExpirePending(userID uint, now time.Time) error {
+	return r.db.Model(&domain.EmailVerification{}).
+		Where("user_id = ? AND used_at IS NULL AND expires_at > ?", userID, now).
+		Update("expires_at", now).Error
+}
+
+func (r *verificationPostgresRepository) IssuedSince(userID uint, since time.Time) ([]time.Time, error) {
+	var issued []time.Time
+	err := r.db.Model(&domain.EmailVerification{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Order("created_at").
+		Pluck("created_at", &issued).Error
+	return issued, err
+}
+
+func (r *verificationPostgresRepository) PurgeExpired(before time.Time) error {
+	return r.db.Where("expires_at < ?", before).Delete(&domain.EmailVerification{}).Error
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"finanzas-api/internal/signup/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupSignupRoutes configura las rutas públicas del registro de cuentas
+func SetupSignupRoutes(router *gin.Engine, signupHandler *handler.SignupHandler) {
+	signupRoutes := router.Group("/api/v1/signup")
+	{
+		// POST /api/v1/signup - Registrar una cuenta inactiva
+		signupRoutes.POST("", signupHandler.Signup)
+		// GET /api/v1/signup/verify?token= - Página de confirmación del enlace del correo
+		signupRoutes.GET("/verify", signupHandler.VerifyLink)
+		// POST /api/v1/signup/verify - Confirmar con el token y la contraseña
+		signupRoutes.POST("/verify", signupHandler.Verify)
+		// POST /api/v1/signup/resend - Pedir un nuevo enlace de verificación
+		signupRoutes.POST("/resend", signupHandler.ResendVerification)
+	}
+}
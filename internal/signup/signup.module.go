@@ -0,0 +1,56 @@
+package signup
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"finanzas-api/config"
+	"finanzas-api/internal/signup/domain"
+	"finanzas-api/internal/signup/handler"
+	"finanzas-api/internal/signup/repository"
+	"finanzas-api/internal/signup/usecase"
+	userDomain "finanzas-api/internal/users/domain"
+	"finanzas-api/shared/mailer"
+
+	"gorm.io/gorm"
+)
+
+type SignupModule struct {
+	Handler    *handler.SignupHandler
+	UseCase    domain.SignupUseCase
+	Repository domain.VerificationRepository
+}
+
+// NewSignupModule crea el registro público. Usa el caso de uso de usuarios
+// para que las cuentas nuevas pasen por los mismos hooks de alta.
+func NewSignupModule(db *gorm.DB, cfg *config.Config, userUseCase userDomain.UserUseCase, userRepo userDomain.UserRepository) *SignupModule {
+	if err := db.AutoMigrate(&domain.EmailVerification{}); err != nil {
+		panic(fmt.Sprintf("Error migrating email verifications: %v", err))
+	}
+
+	if _, err := url.ParseRequestURI(cfg.Signup.VerifyURL); err != nil {
+		panic(fmt.Sprintf("Invalid signup verify URL: %v", err))
+	}
+	sender, err := mailer.New(cfg.Mail)
+	if err != nil {
+		panic(fmt.Sprintf("Error configuring mailer: %v", err))
+	}
+
+	verificationRepo := repository.NewVerificationPostgresRepository(db)
+
+	// Los enlaces vencidos hace más de un día ya no cuentan para el límite
+	// de reenvíos
+	if err := verificationRepo.PurgeExpired(time.Now().Add(-24 * time.Hour)); err != nil {
+		panic(fmt.Sprintf("Error purging expired verifications: %v", err))
+	}
+
+	signupUseCase := usecase.NewSignupUseCase(userUseCase, userRepo, verificationRepo, sender, cfg.Signup)
+	signupHandler := handler.NewSignupHandler(signupUseCase)
+
+	return &SignupModule{
+		Handler:    signupHandler,
+		UseCase:    signupUseCase,
+		Repository: verificationRepo,
+	}
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"finanzas-api/config"
+	"finanzas-api/internal/signup/domain"
+	userDomain "finanzas-api/internal/users/domain"
+	"finanzas-api/shared/mailer"
+	"finanzas-api/shared/security"
+)
+
+// throttleWindow es la ventana sobre la que se cuentan los envíos por usuario
+const throttleWindow = 24 * time.Hour
+
+// verificationEmail es el texto del correo en un idioma. El cuerpo recibe el
+// nombre, el enlace y la vigencia.
+type verificationEmail struct {
+	subject string
+	body    string
+	hours   string
+	minutes string
+}
+
+var verificationEmails = map[string]verificationEmail{
+	"es": {
+		subject: "Confirma tu cuenta de Finanzas",
+		body: "Hola %s,\n\nPara activar tu cuenta abre este enlace y confirma tu contraseña:\n\n%s\n\n" +
+			"El enlace vence en %s y solo se puede usar una vez. Si no creaste una cuenta, ignora este correo.\n",
+		hours:   "%d horas",
+		minutes: "%d minutos",
+	},
+	"en": {
+		subject: "Confirm your Finanzas account",
+		body: "Hi %s,\n\nTo activate your account open this link and confirm your password:\n\n%s\n\n" +
+			"The link expires in %s and can only be used once. If you did not sign up, ignore this email.\n",
+		hours:   "%d hours",
+		minutes: "%d minutes",
+	},
+}
+
+type SignupUseCase struct {
+	userUseCase      userDomain.UserUseCase
+	userRepo         userDomain.UserRepository
+	verificationRepo domain.VerificationRepository
+	mailer           mailer.Mailer
+	cfg              config.SignupConfig
+}
+
+func NewSignupUseCase(userUseCase userDomain.UserUseCase, userRepo userDomain.UserRepository, verificationRepo domain.VerificationRepository, sender mailer.Mailer, cfg config.SignupConfig) domain.SignupUseCase {
+	return &SignupUseCase{
+		userUseCase:      userUseCase,
+		userRepo:         userRepo,
+		verificationRepo: verificationRepo,
+		mailer:           sender,
+		cfg:              cfg,
+	}
+}
+
+// Signup implements domain.SignupUseCase.
+func (uc *SignupUseCase) Signup(user *userDomain.User) error {
+	// Registrar de nuevo una cuenta pendiente cuenta para el límite de envíos.
+	// Se revisa antes de reemplazarla: un registro limitado no cambia la
+	// contraseña de la cuenta existente.
+	now := time.Now()
+	pending, err := uc.userRepo.GetByEmail(strings.ToLower(strings.TrimSpace(user.Email)))
+	if err != nil || pending.IsEmailVerified() {
+		pending = nil
+	}
+	if pending != nil {
+		wait, err := uc.throttle(pending.ID, now)
+		if err != nil {
+			return err
+		}
+		if wait > 0 {
+			log.Printf("⚠️ Verificación del usuario %d limitada por %s", pending.ID, wait.Round(time.Second))
+			return domain.ErrVerificationNotSent
+		}
+	}
+
+	// La cuenta queda inactiva hasta que se confirme el email. Se crea con el
+	// caso de uso de usuarios para que corran los hooks de alta (rol por
+	// defecto, categorías) y para que reemplace una cuenta sin verificar.
+	user.IsActive = false
+	user.EmailVerifiedAt = nil
+	if err := uc.userUseCase.CreateUser(user); err != nil {
+		return err
+	}
+
+	// Con la contraseña reemplazada, los enlaces anteriores dejan de servir
+	// aunque después falle el envío
+	if pending != nil {
+		if err := uc.verificationRepo.ExpirePending(user.ID, now); err != nil {
+			return err
+		}
+	}
+	if err := uc.sendVerification(user, now); err != nil {
+		log.Printf("⚠️ Error enviando la verificación del usuario %d: %v", user.ID, err)
+		return domain.ErrVerificationNotSent
+	}
+	return nil
+}
+
+// Verify implements domain.SignupUseCase.
+func (uc *SignupUseCase) Verify(token, password string) (*userDomain.User, error) {
+	if token == "" {
+		return nil, domain.ErrInvalidVerificationToken
+	}
+
+	now := time.Now()
+	verification, err := uc.verificationRepo.GetByTokenHash(security.HashOpaqueToken(token))
+	if err != nil || !verification.IsUsable(now) {
+		return nil, domain.ErrInvalidVerificationToken
+	}
+
+	// Un usuario eliminado no se puede activar
+	user, err := uc.userRepo.GetByID(verification.UserID)
+	if err != nil || user.IsEmailVerified() {
+		return nil, domain.ErrInvalidVerificationToken
+	}
+	if !security.CheckPasswordHash(password, user.Password) {
+		return nil, domain.ErrPasswordMismatch
+	}
+
+	consumed, err := uc.verificationRepo.Consume(verification.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, domain.ErrInvalidVerificationToken
+	}
+
+	user.IsActive = true
+	user.EmailVerifiedAt = &now
+	if err := uc.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ResendVerification implements domain.SignupUseCase.
+func (uc *SignupUseCase) ResendVerification(email string) error {
+	user, err := uc.userRepo.GetByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil || user.IsEmailVerified() {
+		return nil
+	}
+
+	// El límite y los fallos de envío no se informan: la respuesta sería
+	// distinta a la de un email sin cuenta
+	now := time.Now()
+	wait, err := uc.throttle(user.ID, now)
+	if err != nil {
+		return err
+	}
+	if wait > 0 {
+		log.Printf("⚠️ Reenvío de la verificación del usuario %d limitado por %s", user.ID, wait.Round(time.Second))
+		return nil
+	}
+	if err := uc.sendVerification(user, now); err != nil {
+		log.Printf("⚠️ Error reenviando la verificación del usuario %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+// throttle exige una espera mínima entre envíos y un máximo de envíos por
+// ventana, y retorna cuánto falta para el próximo. Cuenta también el correo
+// del registro.
+func (uc *SignupUseCase) throttle(userID uint, now time.Time) (time.Duration, error) {
+	issued, err := uc.verificationRepo.IssuedSince(userID, now.Add(-throttleWindow))
+	if err != nil {
+		return 0, err
+	}
+
+	n := len(issued)
+	if n == 0 {
+		return 0, nil
+	}
+	if wait := issued[n-1].Add(uc.cfg.ResendInterval).Sub(now); wait > 0 {
+		return wait, nil
+	}
+	if n >= uc.cfg.MaxDailyEmails {
+		// Se libera un envío cuando el más antiguo de los últimos
+		// MaxDailyEmails sale de la ventana
+		oldest := issued[n-uc.cfg.MaxDailyEmails]
+		return oldest.Add(throttleWindow).Sub(now), nil
+	}
+	return 0, nil
+}
+
+// sendVerification invalida los enlaces anteriores del usuario, emite uno
+// nuevo y lo envía por correo
+func (uc *SignupUseCase) sendVerification(user *userDomain.User, now time.Time) error {
+	raw, err := security.NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+	link, err := uc.verificationLink(raw)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.verificationRepo.ExpirePending(user.ID, now); err != nil {
+		return err
+	}
+	verification := &domain.EmailVerification{
+		UserID:    user.ID,
+		TokenHash: security.HashOpaqueToken(raw),
+		ExpiresAt: now.Add(uc.cfg.VerificationTTL),
+	}
+	if err := uc.verificationRepo.Create(verification); err != nil {
+		return err
+	}
+
+	text, ok := verificationEmails[user.Locale]
+	if !ok {
+		text = verificationEmails["es"]
+	}
+	return uc.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: text.subject,
+		Body:    fmt.Sprintf(text.body, user.FirstName, link, text.duration(uc.cfg.VerificationTTL)),
+	})
+}
+
+// verificationLink agrega el token a la URL de verificación configurada
+func (uc *SignupUseCase) verificationLink(token string) (string, error) {
+	link, err := url.Parse(uc.cfg.VerifyURL)
+	if err != nil {
+		return "", err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
+
+// duration expresa la vigencia en horas, o en minutos si es menor a una hora
+func (e verificationEmail) duration(d time.Duration) string {
+	if d >= time.Hour {
+		return fmt.Sprintf(e.hours, int(d/time.Hour))
+	}
+	return fmt.Sprintf(e.minutes, int(d/time.Minute))
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"finanzas-api/config"
+	"finanzas-api/internal/signup/domain"
+	"finanzas-api/internal/signup/repository"
+	userDomain "finanzas-api/internal/users/domain"
+	userRepository "finanzas-api/internal/users/repository"
+	userUseCase "finanzas-api/internal/users/usecase"
+	"finanzas-api/shared/mailer"
+)
+
+// testMailer guarda los correos enviados; con fail, los rechaza
+type testMailer struct {
+	sent []mailer.Message
+	fail bool
+}
+
+func (m *testMailer) Send(msg mailer.Message) error {
+	if m.fail {
+		return errors.New("smtp unavailable")
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+type testSignup struct {
+	uc     domain.SignupUseCase
+	users  userDomain.UserRepository
+	mailer *testMailer
+}
+
+// newTestSignup permite reenviar sin espera, pero solo dos correos por día
+// contando el del registro
+func newTestSignup() *testSignup {
+	s := &testSignup{users: userRepository.NewUserMemoryRepository(), mailer: &testMailer{}}
+	s.uc = NewSignupUseCase(userUseCase.NewUserUseCase(s.users, nil), s.users, repository.NewVerificationMemoryRepository(), s.mailer, config.SignupConfig{
+		VerifyURL:       "https://finanzas.example/verify",
+		VerificationTTL: time.Hour,
+		MaxDailyEmails:  2,
+	})
+	return s
+}
+
+// lastToken extrae el token del último enlace enviado
+func (s *testSignup) lastToken(t *testing.T) string {
+	t.Helper()
+	if len(s.mailer.sent) == 0 {
+		t.Fatal("no verification email was sent")
+	}
+	body := s.mailer.sent[len(s.mailer.sent)-1].Body
+	for _, field := range strings.Fields(body) {
+		if link, err := url.Parse(field); err == nil && link.Query().Get("token") != "" {
+			return link.Query().Get("token")
+		}
+	}
+	t.Fatalf("no verification link in %q", body)
+	return ""
+}
+
+func signupUser(email, password string) *userDomain.User {
+	return &userDomain.User{Email: email, FirstName: "Ana", LastName: "Gómez", Password: password}
+}
+
+func TestResendVerification(t *testing.T) {
+	tests := []struct {
+		name      string
+		email     string
+		verified  bool
+		resends   int
+		mailFails bool
+		wantSent  int // Correos enviados sin contar el del registro
+	}{
+		{name: "pending account", email: "ana@example.com", resends: 1, wantSent: 1},
+		{name: "unknown email", email: "nadie@example.com", resends: 1},
+		{name: "verified account", email: "ana@example.com", verified: true, resends: 1},
+		// El segundo reenvío supera MaxDailyEmails y no se envía
+		{name: "throttled", email: "ana@example.com", resends: 2, wantSent: 1},
+		{name: "mail fails", email: "ana@example.com", resends: 1, mailFails: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSignup()
+			if err := s.uc.Signup(signupUser("ana@example.com", "secreta123")); err != nil {
+				t.Fatal(err)
+			}
+			if tt.verified {
+				if _, err := s.uc.Verify(s.lastToken(t), "secreta123"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			registered := len(s.mailer.sent)
+			s.mailer.fail = tt.mailFails
+
+			// Todas las respuestas son iguales: no revelan si el email tiene cuenta
+			for i := 0; i < tt.resends; i++ {
+				if err := s.uc.ResendVerification(tt.email); err != nil {
+					t.Fatalf("ResendVerification() #%d error = %v", i+1, err)
+				}
+			}
+			if sent := len(s.mailer.sent) - registered; sent != tt.wantSent {
+				t.Errorf("sent %d emails, want %d", sent, tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string // Vacío usa el del correo
+		password   string
+		wantErr    error
+		wantActive bool
+	}{
+		{name: "token and password", password: "secreta123", wantActive: true},
+		{name: "wrong password", password: "otra-clave", wantErr: domain.ErrPasswordMismatch},
+		{name: "unknown token", token: "no-existe", password: "secreta123", wantErr: domain.ErrInvalidVerificationToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSignup()
+			user := signupUser("ana@example.com", "secreta123")
+			if err := s.uc.Signup(user); err != nil {
+				t.Fatal(err)
+			}
+			token := tt.token
+			if token == "" {
+				token = s.lastToken(t)
+			}
+
+			_, err := s.uc.Verify(token, tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			stored, err := s.users.GetByID(user.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.IsValidForAuth() != tt.wantActive {
+				t.Errorf("IsValidForAuth() = %v, want %v", stored.IsValidForAuth(), tt.wantActive)
+			}
+
+			// Una contraseña equivocada no gasta el enlace; uno usado no sirve otra vez
+			_, err = s.uc.Verify(s.lastToken(t), "secreta123")
+			if tt.wantActive != errors.Is(err, domain.ErrInvalidVerificationToken) {
+				t.Errorf("second Verify() error = %v", err)
+			}
+		})
+	}
+}
+
+// TestSignupReplacesPendingAccount cubre a alguien que registra un email
+// ajeno: cuando el dueño se registra, la contraseña anterior ya no activa la
+// cuenta y el enlace anterior deja de servir
+func TestSignupReplacesPendingAccount(t *testing.T) {
+	s := newTestSignup()
+	if err := s.uc.Signup(signupUser("ana@example.com", "del-atacante")); err != nil {
+		t.Fatal(err)
+	}
+	previous := s.lastToken(t)
+
+	owner := signupUser("ana@example.com", "de-la-duena")
+	if err := s.uc.Signup(owner); err != nil {
+		t.Fatalf("second Signup() error = %v", err)
+	}
+	if _, err := s.uc.Verify(previous, "de-la-duena"); !errors.Is(err, domain.ErrInvalidVerificationToken) {
+		t.Errorf("Verify() with the previous link error = %v, want ErrInvalidVerificationToken", err)
+	}
+	if _, err := s.uc.Verify(s.lastToken(t), "del-atacante"); !errors.Is(err, domain.ErrPasswordMismatch) {
+		t.Errorf("Verify() with the previous password error = %v, want ErrPasswordMismatch", err)
+	}
+	verified, err := s.uc.Verify(s.lastToken(t), "de-la-duena")
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if verified.ID != owner.ID || !verified.IsValidForAuth() {
+		t.Errorf("verified user %d active = %v, want user %d active", verified.ID, verified.IsValidForAuth(), owner.ID)
+	}
+
+	// Una cuenta verificada sí reserva el email
+	if err := s.uc.Signup(signupUser("ana@example.com", "otra-clave")); err == nil || err.Error() != "email already exists" {
+		t.Errorf("Signup() over a verified account error = %v, want email already exists", err)
+	}
+}
+
+func TestSignupPendingAccountLimits(t *testing.T) {
+	tests := []struct {
+		name         string
+		mailFails    bool
+		throttled    bool // El registro anterior agotó MaxDailyEmails
+		wantErr      error
+		wantPassword string // Contraseña que activa la cuenta después
+	}{
+		{name: "replaced", wantPassword: "de-la-duena"},
+		// El enlace anterior se invalida aunque el correo nuevo no salga
+		{name: "mail fails", mailFails: true, wantErr: domain.ErrVerificationNotSent, wantPassword: "de-la-duena"},
+		// Un registro limitado no reemplaza la contraseña
+		{name: "throttled", throttled: true, wantErr: domain.ErrVerificationNotSent, wantPassword: "del-atacante"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSignup()
+			if err := s.uc.Signup(signupUser("ana@example.com", "del-atacante")); err != nil {
+				t.Fatal(err)
+			}
+			if tt.throttled {
+				if err := s.uc.ResendVerification("ana@example.com"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			previous := s.lastToken(t)
+			s.mailer.fail = tt.mailFails
+
+			if err := s.uc.Signup(signupUser("ana@example.com", "de-la-duena")); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("second Signup() error = %v, want %v", err, tt.wantErr)
+			}
+			_, err := s.uc.Verify(previous, tt.wantPassword)
+			if tt.throttled {
+				if err != nil {
+					t.Errorf("Verify() with the previous link error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, domain.ErrInvalidVerificationToken) {
+				t.Errorf("Verify() with the previous link error = %v, want ErrInvalidVerificationToken", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ConvertedAmounts son montos en otra moneda calculados al consultar, por ID
+// de transacción, para las transacciones sin conversión registrada vigente.
+// Permiten agregar en una moneda sin escribir en transaction_conversions.
+type ConvertedAmounts map[uint]int64
+
+// ConvertedAmountSQL es el monto de la transacción en la moneda de la
+// consulta: el propio, el registrado en tc o el calculado en pc; NULL si no
+// hay conversión. table es el nombre o alias de transactions en la consulta
+// y la moneda se pasa como argumento.
+func ConvertedAmountSQL(table string) string {
+	return "CASE WHEN " + table + ".currency = ? THEN " + table + ".amount ELSE COALESCE(pc.base_amount, tc.base_amount) END"
+}
+
+// JoinSQL retorna, con sus argumentos, los LEFT JOIN que agregan a una
+// consulta sobre table la conversión registrada a currency (tc), solo si
+// sigue vigente, y la calculada al consultar (pc). Los montos calculados
+// viajan como dos arreglos, así la cantidad de parámetros no crece con el
+// número de transacciones.
+func (a ConvertedAmounts) JoinSQL(table, currency string) (string, []interface{}) {
+	column := func(name string) string {
+		return table + "." + name
+	}
+
+	ids := make([]string, 0, len(a))
+	amounts := make([]string, 0, len(a))
+	for id, amount := range a {
+		ids = append(ids, strconv.FormatUint(uint64(id), 10))
+		amounts = append(amounts, strconv.FormatInt(amount, 10))
+	}
+
+	sql := "LEFT JOIN transaction_conversions tc ON tc.transaction_id = " + column("id") + " AND tc.base_currency = ? " +
+		"AND tc.source_amount = " + column("amount") + " AND tc.source_currency = " + column("currency") +
+		" AND tc.date = " + column("date") + " " +
+		"LEFT JOIN unnest(?::bigint[], ?::bigint[]) AS pc(transaction_id, base_amount) " +
+		"ON pc.transaction_id = " + column("id")
+	return sql, []interface{}{currency, "{" + strings.Join(ids, ",") + "}", "{" + strings.Join(amounts, ",") + "}"}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestConvertedAmountsJoinSQL(t *testing.T) {
+	tests := []struct {
+		name      string
+		converted ConvertedAmounts
+	}{
+		{name: "empty", converted: ConvertedAmounts{}},
+		{name: "one", converted: ConvertedAmounts{7: 40000}},
+		// Más transacciones que el límite de 65535 parámetros de Postgres
+		{name: "many", converted: manyConverted(70000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.converted.JoinSQL("t", "COP")
+			if got := strings.Count(sql, "?"); got != len(args) || len(args) != 3 {
+				t.Fatalf("got %d placeholders and %d args, want 3 of each", got, len(args))
+			}
+			if args[0] != "COP" {
+				t.Errorf("currency arg = %v, want COP", args[0])
+			}
+
+			// Los arreglos de IDs y montos van en el mismo orden
+			ids, amounts := parseArray(t, args[1]), parseArray(t, args[2])
+			if len(ids) != len(tt.converted) || len(amounts) != len(tt.converted) {
+				t.Fatalf("got %d ids and %d amounts, want %d", len(ids), len(amounts), len(tt.converted))
+			}
+			for i, id := range ids {
+				if want, ok := tt.converted[uint(id)]; !ok || amounts[i] != want {
+					t.Errorf("transaction %d amount = %d, want %d", id, amounts[i], want)
+				}
+			}
+		})
+	}
+}
+
+func manyConverted(n int) ConvertedAmounts {
+	converted := make(ConvertedAmounts, n)
+	for i := 1; i <= n; i++ {
+		converted[uint(i)] = int64(i) * 100
+	}
+	return converted
+}
+
+// parseArray lee un arreglo de Postgres como {1,2,3}
+func parseArray(t *testing.T, arg interface{}) []int64 {
+	t.Helper()
+	literal, ok := arg.(string)
+	if !ok || !strings.HasPrefix(literal, "{") || !strings.HasSuffix(literal, "}") {
+		t.Fatalf("arg %v is not an array literal", arg)
+	}
+	literal = strings.Trim(literal, "{}")
+	if literal == "" {
+		return nil
+	}
+
+	var values []int64
+	for _, field := range strings.Split(literal, ",") {
+		value, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, value)
+	}
+	return values
+}
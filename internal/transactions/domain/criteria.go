@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Criteria son criterios de selección de transacciones que comparten las
+// vistas guardadas, los reportes y las exportaciones. Todos son opcionales;
+// las listas coinciden con cualquiera de sus valores y las fechas son
+// inclusivas.
+type Criteria struct {
+	AccountIDs  []uint
+	CategoryIDs []uint
+	Tags        []string // Nombres normalizados
+	Direction   Direction
+	MinAmount   *int64 // En unidades menores
+	MaxAmount   *int64
+	Query       string // Contenido en la descripción o el comercio
+	From        *time.Time
+	To          *time.Time
+}
+
+// CurrencyTotals resume un conjunto de transacciones en una moneda. Los
+// ingresos y gastos no incluyen transferencias.
+type CurrencyTotals struct {
+	Currency string
+	Count    int
+	Income   int64
+	Expenses int64
+}
+
+// Apply agrega los criterios a una consulta sobre transacciones; table es el
+// nombre o alias de la tabla en la consulta
+func (c *Criteria) Apply(query *gorm.DB, table string) *gorm.DB {
+	if c == nil {
+		return query
+	}
+	column := func(name string) string {
+		return table + "." + name
+	}
+
+	if len(c.AccountIDs) > 0 {
+		query = query.Where(column("account_id")+" IN ?", c.AccountIDs)
+	}
+	if len(c.CategoryIDs) > 0 {
+		query = query.Where(column("category_id")+" IN ?", c.CategoryIDs)
+	}
+	if len(c.Tags) > 0 {
+		query = query.Where(column("id")+` IN (SELECT transaction_tags.transaction_id FROM transaction_tags
+			JOIN tags ON tags.id = transaction_tags.tag_id WHERE tags.name IN ?)`, c.Tags)
+	}
+	if c.Direction != "" {
+		query = query.Where(column("direction")+" = ?", c.Direction)
+	}
+	if c.MinAmount != nil {
+		query = query.Where(column("amount")+" >= ?", *c.MinAmount)
+	}
+	if c.MaxAmount != nil {
+		query = query.Where(column("amount")+" <= ?", *c.MaxAmount)
+	}
+	if c.Query != "" {
+		// % y _ del usuario se buscan literalmente
+		pattern := "%" + escapeLike(c.Query) + "%"
+		query = query.Where("("+column("description")+" ILIKE ? OR "+column("payee")+" ILIKE ?)", pattern, pattern)
+	}
+	if c.From != nil {
+		query = query.Where(column("date")+" >= ?", *c.From)
+	}
+	if c.To != nil {
+		query = query.Where(column("date")+" <= ?", *c.To)
+	}
+	return query
+}
+
+// Matches indica si la transacción cumple los criterios. Lo usan los
+// repositorios en memoria; las etiquetas se toman de transaction.Tags.
+func (c *Criteria) Matches(transaction *Transaction) bool {
+	if c == nil {
+		return true
+	}
+	if len(c.AccountIDs) > 0 && !containsID(c.AccountIDs, transaction.AccountID) {
+		return false
+	}
+	if len(c.CategoryIDs) > 0 && (transaction.CategoryID == nil || !containsID(c.CategoryIDs, *transaction.CategoryID)) {
+		return false
+	}
+	if len(c.Tags) > 0 {
+		tagged := false
+		for _, name := range c.Tags {
+			if transaction.HasTag(name) {
+				tagged = true
+				break
+			}
+		}
+		if !tagged {
+			return false
+		}
+	}
+	if c.Direction != "" && transaction.Direction != c.Direction {
+		return false
+	}
+	if c.MinAmount != nil && transaction.Amount < *c.MinAmount {
+		return false
+	}
+	if c.MaxAmount != nil && transaction.Amount > *c.MaxAmount {
+		return false
+	}
+	if c.Query != "" {
+		query := strings.ToLower(c.Query)
+		if !strings.Contains(strings.ToLower(transaction.Description), query) && !strings.Contains(strings.ToLower(transaction.Payee), query) {
+			return false
+		}
+	}
+	if c.From != nil && transaction.Date.Before(*c.From) {
+		return false
+	}
+	if c.To != nil && transaction.Date.After(*c.To) {
+		return false
+	}
+	return true
+}
+
+// escapeLike escapa los comodines de LIKE con el escape por defecto de
+// Postgres, la barra invertida
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func containsID(ids []uint, id uint) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "testing"
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"coffee", "coffee"},
+		{"100%", `100\%`},
+		{"a_b", `a\_b`},
+		{`C:\temp`, `C:\\temp`},
+		{`%_\`, `\%\_\\`},
+	}
+	for _, tt := range tests {
+		if got := escapeLike(tt.in); got != tt.want {
+			t.Errorf("escapeLike(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCriteriaMatchesQuery(t *testing.T) {
+	transaction := &Transaction{Description: "Pago tarjeta", Payee: "Café 100% Colombia"}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"pago", true},
+		{"CAFÉ", true},     // Sin distinguir mayúsculas
+		{"100%", true},     // El comercio también cuenta
+		{"100_", false},    // _ no es comodín
+		{"mercado", false}, // No aparece en ninguno
+	}
+	for _, tt := range tests {
+		criteria := &Criteria{Query: tt.query}
+		if got := criteria.Matches(transaction); got != tt.want {
+			t.Errorf("Matches(query=%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	userDomain "finanzas-api/internal/users/domain"
+)
+
+const (
+	// DuplicateDateWindow es la diferencia máxima en días entre dos
+	// transacciones para considerarlas el mismo movimiento
+	DuplicateDateWindow = 3
+	// LikelyDuplicateScore es el puntaje desde el cual una transacción se
+	// envía a revisión en lugar de crearse
+	LikelyDuplicateScore = 70
+)
+
+// Puntaje máximo de cada criterio; la suma de los tres es 100
+const (
+	amountScore      = 40
+	dateScore        = 30
+	descriptionScore = 30
+)
+
+var (
+	// ErrReviewNotFound se retorna cuando la revisión no existe o es de otro usuario
+	ErrReviewNotFound = errors.New("duplicate review not found")
+	// ErrReviewResolved se retorna al resolver una revisión que ya no está pendiente
+	ErrReviewResolved = errors.New("duplicate review is already resolved")
+)
+
+// DuplicateSource indica dónde se detectó el posible duplicado
+type DuplicateSource string
+
+const (
+	DuplicateSourceManual DuplicateSource = "manual"
+	DuplicateSourceImport DuplicateSource = "import"
+)
+
+// ReviewStatus es el estado de una revisión de duplicado
+type ReviewStatus string
+
+const (
+	ReviewStatusPending   ReviewStatus = "pending"
+	ReviewStatusMerged    ReviewStatus = "merged"
+	ReviewStatusKept      ReviewStatus = "kept"
+	ReviewStatusDiscarded ReviewStatus = "discarded"
+)
+
+// ReviewAction es la decisión del usuario sobre un posible duplicado
+type ReviewAction string
+
+const (
+	// ReviewActionMerge descarta la candidata y completa la transacción
+	// existente con los datos que le falten (referencia, comercio, categoría)
+	ReviewActionMerge ReviewAction = "merge"
+	// ReviewActionKeep crea la candidata: son dos movimientos distintos
+	ReviewActionKeep ReviewAction = "keep"
+	// ReviewActionDiscard descarta la candidata sin tocar la existente
+	ReviewActionDiscard ReviewAction = "discard"
+)
+
+// DuplicateReview guarda una transacción que no se creó por parecerse a
+// una existente, hasta que el usuario decida qué hacer con ella.
+type DuplicateReview struct {
+	ID      uint            `json:"id" gorm:"primaryKey"`
+	UserID  uint            `json:"user_id" gorm:"not null;index"`
+	User    userDomain.User `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Status  ReviewStatus    `json:"status" gorm:"type:varchar(20);not null;index"`
+	Source  DuplicateSource `json:"source" gorm:"type:varchar(20);not null"`
+	Score   int             `json:"score"`
+	Reasons string          `json:"reasons"` // Criterios que coincidieron, separados por coma
+	// Transacción existente que parece duplicada
+	MatchID uint        `json:"match_id" gorm:"not null;index"`
+	Match   Transaction `json:"-" gorm:"foreignKey:MatchID;constraint:OnDelete:CASCADE"`
+	// Datos de la transacción candidata, que aún no existe
+	AccountID     uint      `json:"account_id" gorm:"not null"`
+	CategoryID    *uint     `json:"category_id"`
+	Amount        int64     `json:"amount" gorm:"not null"`
+	Currency      string    `json:"currency" gorm:"type:char(3);not null"`
+	Direction     Direction `json:"direction" gorm:"type:varchar(10);not null"`
+	Date          time.Time `json:"date" gorm:"type:date;not null"`
+	Description   string    `json:"description"`
+	Payee         string    `json:"payee" gorm:"type:varchar(255)"`
+	ExternalID    *string   `json:"external_id,omitempty" gorm:"type:varchar(255)"`
+	ImportBatchID *uint     `json:"import_batch_id,omitempty" gorm:"index"`
+	ImportRowID   *uint     `json:"import_row_id,omitempty"`
+	// Transacción que quedó tras resolver: la existente al fusionar o la
+	// nueva al conservar ambas
+	ResolvedTransactionID *uint      `json:"resolved_transaction_id"`
+	ResolvedAt            *time.Time `json:"resolved_at"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
+
+// DuplicateMatch es una transacción existente y qué tanto se parece a la candidata
+type DuplicateMatch struct {
+	Transaction *Transaction
+	Score       int
+	Reasons     []string
+}
+
+// DuplicateReviewRepository define la interfaz del repositorio de revisiones
+type DuplicateReviewRepository interface {
+	Create(review *DuplicateReview) error
+	GetByID(id uint) (*DuplicateReview, error)
+	ListByUser(userID uint, status ReviewStatus, limit, offset int) ([]*DuplicateReview, error)
+	// Claim guarda review.Status y review.ResolvedAt solo si la revisión
+	// sigue pendiente; si no, retorna ErrReviewResolved. Se llama antes de
+	// aplicar la decisión para que dos solicitudes simultáneas no creen ni
+	// fusionen la candidata dos veces
+	Claim(review *DuplicateReview) error
+	// Release devuelve a pendiente una revisión reclamada cuya decisión falló
+	Release(review *DuplicateReview) error
+	// Resolve guarda la transacción que quedó tras aplicar la decisión
+	Resolve(review *DuplicateReview) error
+}
+
+type DuplicateUseCase interface {
+	// FindDuplicate busca en la cuenta la transacción más parecida a la
+	// candidata. Retorna nil si ninguna alcanza LikelyDuplicateScore.
+	FindDuplicate(transaction *Transaction) (*DuplicateMatch, error)
+	// CreateOrFlag crea la transacción o, si parece duplicada, la guarda
+	// para revisión y retorna la revisión sin crearla
+	CreateOrFlag(transaction *Transaction) (*DuplicateReview, error)
+	// NewReview arma la revisión pendiente de la candidata y su coincidencia
+	// sin guardarla, para quien la guarda junto con otros cambios
+	NewReview(transaction *Transaction, match *DuplicateMatch, source DuplicateSource, importRowID *uint) *DuplicateReview
+	GetReview(userID, id uint) (*DuplicateReview, error)
+	ListReviews(userID uint, status ReviewStatus, limit, offset int) ([]*DuplicateReview, error)
+	ResolveReview(userID, id uint, action ReviewAction) (*DuplicateReview, error)
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (DuplicateReview) TableName() string {
+	return "duplicate_reviews"
+}
+
+// BelongsTo verifica si la revisión pertenece al usuario indicado
+func (r *DuplicateReview) BelongsTo(userID uint) bool {
+	return r.UserID == userID
+}
+
+// IsPending indica si la revisión espera una decisión del usuario
+func (r *DuplicateReview) IsPending() bool {
+	return r.Status == ReviewStatusPending
+}
+
+// Candidate reconstruye la transacción candidata guardada en la revisión
+func (r *DuplicateReview) Candidate() *Transaction {
+	return &Transaction{
+		UserID:        r.UserID,
+		AccountID:     r.AccountID,
+		CategoryID:    r.CategoryID,
+		Amount:        r.Amount,
+		Currency:      r.Currency,
+		Direction:     r.Direction,
+		Date:          r.Date,
+		Description:   r.Description,
+		Payee:         r.Payee,
+		ExternalID:    r.ExternalID,
+		ImportBatchID: r.ImportBatchID,
+	}
+}
+
+// IsValidReviewAction verifica si la acción es merge, keep o discard
+func IsValidReviewAction(action ReviewAction) bool {
+	return action == ReviewActionMerge || action == ReviewActionKeep || action == ReviewActionDiscard
+}
+
+// ScoreDuplicate puntúa de 0 a 100 qué tanto se parece candidate a existing:
+// hasta 40 por monto, 30 por cercanía de fecha y 30 por descripción. Dos
+// transacciones con el mismo identificador externo son el mismo movimiento
+// y dos con identificadores distintos no lo son. Solo se comparan
+// transacciones de la misma cuenta, moneda y dirección, y sin ninguna
+// palabra en común entre descripción y comercio no son duplicadas: el mismo
+// monto el mismo día es frecuente en compras repetidas.
+func ScoreDuplicate(candidate, existing *Transaction) (int, []string) {
+	if candidate.AccountID != existing.AccountID || candidate.Currency != existing.Currency ||
+		candidate.Direction != existing.Direction {
+		return 0, nil
+	}
+
+	if candidate.ExternalID != nil && existing.ExternalID != nil {
+		if *candidate.ExternalID == *existing.ExternalID {
+			return 100, []string{"external_id"}
+		}
+		return 0, nil
+	}
+
+	// El monto debe coincidir o diferir en menos de 1%
+	var score int
+	var reasons []string
+	difference := candidate.Amount - existing.Amount
+	if difference < 0 {
+		difference = -difference
+	}
+	switch {
+	case difference == 0:
+		score += amountScore
+		reasons = append(reasons, "amount")
+	case difference*100 < existing.Amount:
+		score += amountScore / 2
+		reasons = append(reasons, "similar_amount")
+	default:
+		return 0, nil
+	}
+
+	days := int(math.Abs(candidate.Date.Sub(existing.Date).Hours()/24) + 0.5)
+	if days > DuplicateDateWindow {
+		return 0, nil
+	}
+	score += dateScore * (DuplicateDateWindow + 1 - days) / (DuplicateDateWindow + 1)
+	if days == 0 {
+		reasons = append(reasons, "date")
+	} else {
+		reasons = append(reasons, "near_date")
+	}
+
+	similarity := descriptionSimilarity(candidate.Description+" "+candidate.Payee, existing.Description+" "+existing.Payee)
+	if similarity == 0 {
+		return 0, nil
+	}
+	score += int(math.Round(descriptionScore * similarity))
+	if similarity >= 0.5 {
+		reasons = append(reasons, "description")
+	}
+
+	return score, reasons
+}
+
+// accentFolder quita las tildes más comunes para comparar descripciones
+var accentFolder = strings.NewReplacer(
+	"á", "a", "à", "a", "ä", "a", "â", "a", "é", "e", "è", "e", "ë", "e", "ê", "e",
+	"í", "i", "ì", "i", "ï", "i", "î", "i", "ó", "o", "ò", "o", "ö", "o", "ô", "o",
+	"ú", "u", "ù", "u", "ü", "u", "û", "u", "ñ", "n", "ç", "c",
+)
+
+// NormalizeDescription pasa a minúsculas, quita tildes y deja solo las
+// palabras: los números (fechas, referencias, últimos dígitos de tarjeta)
+// cambian entre el banco y lo que escribe el usuario.
+func NormalizeDescription(description string) []string {
+	folded := accentFolder.Replace(strings.ToLower(description))
+	words := strings.FieldsFunc(folded, func(r rune) bool { return !unicode.IsLetter(r) })
+
+	tokens := words[:0]
+	for _, word := range words {
+		if len(word) >= 2 {
+			tokens = append(tokens, word)
+		}
+	}
+	return tokens
+}
+
+// descriptionSimilarity es el índice de Jaccard entre las palabras de ambas
+// descripciones. Dos descripciones vacías no aportan evidencia.
+func descriptionSimilarity(a, b string) float64 {
+	wordsA, wordsB := NormalizeDescription(a), NormalizeDescription(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	set := make(map[string]bool, len(wordsA))
+	for _, word := range wordsA {
+		set[word] = true
+	}
+	union := len(set)
+	intersection := 0
+	seen := make(map[string]bool, len(wordsB))
+	for _, word := range wordsB {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		if set[word] {
+			intersection++
+		} else {
+			union++
+		}
+	}
+	return float64(intersection) / float64(union)
+}
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScoreDuplicate(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	existing := &Transaction{AccountID: 1, Currency: "COP", Direction: DirectionExpense, Amount: 10000, Date: day, Description: "Mercado Exito"}
+
+	tests := []struct {
+		name        string
+		candidate   Transaction
+		want        int
+		wantReasons []string
+	}{
+		{
+			name:        "same movement",
+			candidate:   Transaction{Amount: 10000, Date: day, Description: "Mercado Exito"},
+			want:        100,
+			wantReasons: []string{"amount", "date", "description"},
+		},
+		{
+			name:        "case, accents and numbers are ignored",
+			candidate:   Transaction{Amount: 10000, Date: day, Description: "MERCADO ÉXITO 0123"},
+			want:        100,
+			wantReasons: []string{"amount", "date", "description"},
+		},
+		{
+			name:        "similar amount",
+			candidate:   Transaction{Amount: 10050, Date: day, Description: "Mercado Exito"},
+			want:        80,
+			wantReasons: []string{"similar_amount", "date", "description"},
+		},
+		{
+			name:        "two days apart",
+			candidate:   Transaction{Amount: 10000, Date: day.AddDate(0, 0, 2), Description: "Mercado Exito"},
+			want:        85,
+			wantReasons: []string{"amount", "near_date", "description"},
+		},
+		{
+			// Una palabra en común de cuatro: 30 * 0.25 redondeado
+			name:        "payee shares a word",
+			candidate:   Transaction{Amount: 10000, Date: day, Description: "Compra POS", Payee: "Exito"},
+			want:        78,
+			wantReasons: []string{"amount", "date"},
+		},
+		{
+			name:      "same amount and day without common words",
+			candidate: Transaction{Amount: 10000, Date: day, Description: "Farmacia"},
+		},
+		{
+			name:      "no descriptions",
+			candidate: Transaction{Amount: 10000, Date: day},
+		},
+		{
+			name:      "amount differs by one percent",
+			candidate: Transaction{Amount: 10100, Date: day, Description: "Mercado Exito"},
+		},
+		{
+			name:      "outside the date window",
+			candidate: Transaction{Amount: 10000, Date: day.AddDate(0, 0, DuplicateDateWindow+1), Description: "Mercado Exito"},
+		},
+		{
+			name:      "another account",
+			candidate: Transaction{AccountID: 2, Amount: 10000, Date: day, Description: "Mercado Exito"},
+		},
+		{
+			name:      "another direction",
+			candidate: Transaction{Direction: DirectionIncome, Amount: 10000, Date: day, Description: "Mercado Exito"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate := tt.candidate
+			if candidate.AccountID == 0 {
+				candidate.AccountID = existing.AccountID
+			}
+			if candidate.Direction == "" {
+				candidate.Direction = existing.Direction
+			}
+			candidate.Currency = existing.Currency
+
+			got, reasons := ScoreDuplicate(&candidate, existing)
+			if got != tt.want || !reflect.DeepEqual(reasons, tt.wantReasons) {
+				t.Errorf("ScoreDuplicate() = %d %v, want %d %v", got, reasons, tt.want, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestScoreDuplicateExternalID(t *testing.T) {
+	reference := func(value string) *string { return &value }
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	existing := &Transaction{AccountID: 1, Currency: "COP", Direction: DirectionExpense, Amount: 10000, Date: day, Description: "Mercado", ExternalID: reference("A1")}
+
+	tests := []struct {
+		name       string
+		externalID *string
+		want       int
+	}{
+		// El identificador decide aunque el resto no coincida
+		{name: "same reference", externalID: reference("A1"), want: 100},
+		{name: "different reference", externalID: reference("A2"), want: 0},
+		{name: "candidate without reference", externalID: nil, want: 0},
+	}
+	for _, tt := range tests {
+		candidate := &Transaction{AccountID: 1, Currency: "COP", Direction: DirectionExpense, Amount: 99999, Date: day.AddDate(0, 1, 0), Description: "Otra", ExternalID: tt.externalID}
+		if got, _ := ScoreDuplicate(candidate, existing); got != tt.want {
+			t.Errorf("%s: ScoreDuplicate() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScoreDuplicateThreshold(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	existing := &Transaction{AccountID: 1, Currency: "COP", Direction: DirectionExpense, Amount: 10000, Date: day, Description: "Netflix"}
+
+	tests := []struct {
+		name      string
+		candidate *Transaction
+		want      bool
+	}{
+		{name: "same description three days later", candidate: &Transaction{Amount: 10000, Date: day.AddDate(0, 0, 3), Description: "NETFLIX"}, want: true},
+		{name: "similar amount and description two days later", candidate: &Transaction{Amount: 10050, Date: day.AddDate(0, 0, 2), Description: "Netflix"}, want: false},
+		{name: "same amount and day, unrelated description", candidate: &Transaction{Amount: 10000, Date: day, Description: "Spotify"}, want: false},
+	}
+	for _, tt := range tests {
+		tt.candidate.AccountID, tt.candidate.Currency, tt.candidate.Direction = 1, "COP", DirectionExpense
+		score, _ := ScoreDuplicate(tt.candidate, existing)
+		if got := score >= LikelyDuplicateScore; got != tt.want {
+			t.Errorf("%s: score %d flagged = %v, want %v", tt.name, score, got, tt.want)
+		}
+	}
+}
+
+func TestDescriptionSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"Mercado Exito", "mercado éxito", 1},
+		{"Mercado Exito", "Exito", 0.5},
+		{"Pago PSE 123", "PSE pago 456", 1},
+		{"Uber", "Rappi", 0},
+		{"", "Rappi", 0},
+		{"123 456", "123 456", 0}, // Solo números: sin palabras que comparar
+	}
+	for _, tt := range tests {
+		if got := descriptionSimilarity(tt.a, tt.b); got != tt.want {
+			t.Errorf("descriptionSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
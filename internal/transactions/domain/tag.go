@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	userDomain "finanzas-api/internal/users/domain"
+)
+
+// MaxTagLength es el largo máximo del nombre de una etiqueta
+const MaxTagLength = 50
+
+var (
+	// ErrTagNotFound se retorna cuando la etiqueta no existe o es de otro usuario
+	ErrTagNotFound = errors.New("tag not found")
+	// ErrTagExists se retorna al crear o renombrar con un nombre en uso
+	ErrTagExists = errors.New("tag already exists")
+)
+
+// Tag es una etiqueta libre del usuario, p. ej. "reembolsable". Una
+// transacción puede tener varias.
+type Tag struct {
+	ID        uint            `json:"id" gorm:"primaryKey"`
+	UserID    uint            `json:"user_id" gorm:"not null;uniqueIndex:idx_tags_user_name"`
+	User      userDomain.User `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Name      string          `json:"name" gorm:"type:varchar(50);not null;uniqueIndex:idx_tags_user_name"`
+	CreatedAt time.Time       `json:"created_at"`
+
+	// Usage es la cantidad de transacciones con la etiqueta. Se calcula al
+	// listar y no se persiste.
+	Usage int `json:"usage" gorm:"->;-:migration"`
+}
+
+// TagRepository define la interfaz del repositorio de etiquetas
+type TagRepository interface {
+	// FindOrCreate retorna la etiqueta del usuario con ese nombre y la crea
+	// si no existe. El nombre ya debe venir normalizado.
+	FindOrCreate(userID uint, name string) (*Tag, error)
+	// Attach agrega etiquetas a una transacción guardada; las que ya tiene
+	// se ignoran
+	Attach(transactionID uint, tags []*Tag) error
+	// Replace deja a la transacción exactamente con las etiquetas indicadas
+	Replace(transactionID uint, tags []*Tag) error
+	GetByID(id uint) (*Tag, error)
+	FindByName(userID uint, name string) (*Tag, error)
+	Update(tag *Tag) error
+	// Delete elimina la etiqueta y la quita de sus transacciones
+	Delete(id uint) error
+	// ListByUser retorna las etiquetas ordenadas por nombre, con su uso
+	ListByUser(userID uint) ([]*Tag, error)
+}
+
+type TagUseCase interface {
+	CreateTag(userID uint, name string) (*Tag, error)
+	ListTags(userID uint) ([]*Tag, error)
+	// RenameTag cambia el nombre en todas las transacciones que la usan
+	RenameTag(userID, id uint, name string) (*Tag, error)
+	DeleteTag(userID, id uint) error
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// NormalizeTagName pasa el nombre a minúsculas y une las palabras con
+// guiones: "Viaje Cartagena 2026" queda "viaje-cartagena-2026"
+func NormalizeTagName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
+
+// NormalizeTagNames normaliza los nombres, descarta vacíos y repetidos y
+// verifica el largo de cada uno
+func NormalizeTagNames(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeTagName(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if len(name) > MaxTagLength {
+			return nil, errors.New("tag too long")
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+	return normalized, nil
+}
+
+// BelongsTo verifica si la etiqueta pertenece al usuario indicado
+func (t *Tag) BelongsTo(userID uint) bool {
+	return t.UserID == userID
+}
+
+// TagNames retorna los nombres de las etiquetas de la transacción
+func (t *Transaction) TagNames() []string {
+	names := make([]string, len(t.Tags))
+	for i, tag := range t.Tags {
+		names[i] = tag.Name
+	}
+	return names
+}
+
+// HasTag indica si la transacción ya tiene la etiqueta con ese nombre
+func (t *Transaction) HasTag(name string) bool {
+	for _, tag := range t.Tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
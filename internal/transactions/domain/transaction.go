@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	userDomain "finanzas-api/internal/users/domain"
+
+	"gorm.io/gorm"
+)
+
+// ErrTransactionNotFound se retorna cuando la transacción no existe o es de otro usuario
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// Direction indica si la transacción es un ingreso o un gasto
+type Direction string
+
+const (
+	DirectionIncome  Direction = "income"
+	DirectionExpense Direction = "expense"
+)
+
+type Transaction struct {
+	ID          uint                  `json:"id" gorm:"primaryKey"`
+	UserID      uint                  `json:"user_id" gorm:"not null;index"`
+	User        userDomain.User       `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	AccountID   uint                  `json:"account_id" gorm:"not null;index"`
+	Account     accountDomain.Account `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	CategoryID  *uint                 `json:"category_id" gorm:"index"`
+	Amount      int64                 `json:"amount" gorm:"not null"`                              // En unidades menores (centavos), siempre positivo
+	Currency    string                `json:"currency" gorm:"type:char(3);not null;default:'COP'"` // ISO 4217, se toma de la cuenta
+	Direction   Direction             `json:"direction" gorm:"type:varchar(10);not null"`
+	Date        time.Time             `json:"date" gorm:"type:date;not null;index"`
+	Description string                `json:"description"`
+	Payee       string                `json:"payee" gorm:"type:varchar(255)"` // Comercio o contraparte
+	// Las transacciones generadas por un asiento contable (transferencias)
+	// quedan enlazadas a él y no cuentan como ingreso ni gasto en reportes.
+	JournalEntryID *uint          `json:"journal_entry_id,omitempty" gorm:"index"`
+	IsTransfer     bool           `json:"is_transfer" gorm:"default:false"`
+	ImportBatchID  *uint          `json:"import_batch_id,omitempty" gorm:"index"`         // Lote de importación que la creó
+	ExternalID     *string        `json:"external_id,omitempty" gorm:"type:varchar(255)"` // Identificador del banco (FITID), único por cuenta
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete
+
+	// RunningBalance es el saldo acumulado de la cuenta tras esta transacción.
+	// Se calcula al consultar y no se persiste.
+	RunningBalance int64 `json:"running_balance" gorm:"->;-:migration"`
+
+	Tags []Tag `json:"tags,omitempty" gorm:"many2many:transaction_tags;constraint:OnDelete:CASCADE"`
+}
+
+// TransactionFilter agrupa los criterios de búsqueda del listado
+type TransactionFilter struct {
+	AccountID  *uint
+	CategoryID *uint
+	From       *time.Time
+	To         *time.Time
+	MinAmount  *int64
+	MaxAmount  *int64
+	Query      string
+	Limit      int
+	Offset     int
+	Tag        string    // Nombre normalizado de una etiqueta
+	Criteria   *Criteria // Criterios de una vista guardada
+}
+
+// AccountTotals contiene los totales de ingresos y gastos de una cuenta
+type AccountTotals struct {
+	AccountID uint
+	Income    int64
+	Expense   int64
+}
+
+// CategoryMonthTotal es el gasto de una categoría en un mes
+type CategoryMonthTotal struct {
+	CategoryID uint
+	Month      time.Time
+	Amount     int64
+}
+
+// AccountBalance representa el saldo calculado de una cuenta
+type AccountBalance struct {
+	AccountID      uint   `json:"account_id"`
+	Name           string `json:"name"`
+	Currency       string `json:"currency"`
+	OpeningBalance int64  `json:"opening_balance"`
+	Income         int64  `json:"income"`
+	Expense        int64  `json:"expense"`
+	Balance        int64  `json:"balance"`
+}
+
+// TransactionRepository define la interfaz del repositorio de transacciones
+type TransactionRepository interface {
+	// WithContext retorna el repositorio con sus consultas atadas a ctx: se
+	// cancelan cuando ctx vence
+	WithContext(ctx context.Context) TransactionRepository
+	Create(transaction *Transaction) error
+	GetByID(id uint) (*Transaction, error)
+	Update(transaction *Transaction) error
+	Delete(id uint) error
+	List(userID uint, filter TransactionFilter) ([]*Transaction, error)
+	TotalsByAccount(userID uint) ([]*AccountTotals, error)
+	// MonthlyExpensesByCategory suma los gastos categorizados por categoría y
+	// mes en el rango [from, to), excluyendo transferencias. Los montos en
+	// otra moneda se suman convertidos a currency con la conversión
+	// registrada o la de converted; los que no tienen ninguna se omiten.
+	MonthlyExpensesByCategory(userID uint, currency string, from, to time.Time, converted ConvertedAmounts) ([]*CategoryMonthTotal, error)
+	// Totals resume por moneda las transacciones que cumplen los criterios
+	Totals(userID uint, criteria *Criteria) ([]*CurrencyTotals, error)
+}
+
+// BeforeCreateHook se ejecuta antes de guardar una transacción nueva y
+// puede completarla, p. ej. con las reglas de categorización del usuario
+type BeforeCreateHook func(transaction *Transaction) error
+
+// CategoryChangeHook se ejecuta después de guardar un cambio que afecta la
+// categorización de una transacción: su categoría, descripción o comercio.
+// before es nil al crear y after es nil al eliminar.
+type CategoryChangeHook func(before, after *Transaction) error
+
+type TransactionUseCase interface {
+	CreateTransaction(transaction *Transaction) error
+	GetTransaction(userID, id uint) (*Transaction, error)
+	UpdateTransaction(userID uint, transaction *Transaction) error
+	DeleteTransaction(userID, id uint) error
+	ListTransactions(ctx context.Context, userID uint, filter TransactionFilter) ([]*Transaction, error)
+	GetAccountBalances(ctx context.Context, userID uint) ([]*AccountBalance, error)
+	ValidateTransactionData(transaction *Transaction) error
+	// PrepareTransaction hace las validaciones de CreateTransaction (cuenta,
+	// moneda, hooks previos, categoría y etiquetas) sin guardar, para las
+	// transacciones que se guardan por otra vía, como la confirmación de una
+	// importación. Una vez guardadas se debe llamar a NotifyCreated.
+	PrepareTransaction(transaction *Transaction) error
+	// NotifyCreated avisa a los hooks de una transacción ya guardada
+	NotifyCreated(transaction *Transaction)
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (Transaction) TableName() string {
+	return "transactions"
+}
+
+// IsValidDirection verifica si la dirección es ingreso o gasto
+func (t *Transaction) IsValidDirection() bool {
+	return t.Direction == DirectionIncome || t.Direction == DirectionExpense
+}
+
+// SignedAmount retorna el monto con signo: positivo para ingresos, negativo para gastos
+func (t *Transaction) SignedAmount() int64 {
+	if t.Direction == DirectionExpense {
+		return -t.Amount
+	}
+	return t.Amount
+}
+
+// IsLedgerLeg indica si la transacción fue generada por un asiento contable
+func (t *Transaction) IsLedgerLeg() bool {
+	return t.JournalEntryID != nil
+}
+
+// BelongsTo verifica si la transacción pertenece al usuario indicado
+func (t *Transaction) BelongsTo(userID uint) bool {
+	return t.UserID == userID
+}
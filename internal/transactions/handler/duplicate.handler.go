@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"finanzas-api/internal/transactions/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResolveReviewRequest representa la decisión sobre un posible duplicado
+type ResolveReviewRequest struct {
+	Action string `json:"action" binding:"required,oneof=merge keep discard"`
+}
+
+// ReviewResponse representa una revisión de posible duplicado
+type ReviewResponse struct {
+	ID                    uint     `json:"id"`
+	Status                string   `json:"status"`
+	Source                string   `json:"source"`
+	Score                 int      `json:"score"`
+	Reasons               []string `json:"reasons"`
+	MatchID               uint     `json:"match_id"`
+	AccountID             uint     `json:"account_id"`
+	CategoryID            *uint    `json:"category_id"`
+	Amount                int64    `json:"amount"`
+	Currency              string   `json:"currency"`
+	Direction             string   `json:"direction"`
+	Date                  string   `json:"date"`
+	Description           string   `json:"description"`
+	Payee                 string   `json:"payee,omitempty"`
+	ExternalID            *string  `json:"external_id,omitempty"`
+	ImportBatchID         *uint    `json:"import_batch_id,omitempty"`
+	ResolvedTransactionID *uint    `json:"resolved_transaction_id"`
+	ResolvedAt            *string  `json:"resolved_at"`
+	CreatedAt             string   `json:"created_at"`
+}
+
+// ListReviews lista los posibles duplicados del usuario autenticado.
+// Filtros opcionales: status (pending, merged, kept, discarded), limit y offset.
+func (h *TransactionHandler) ListReviews(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid limit",
+		})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid offset",
+		})
+		return
+	}
+
+	status := domain.ReviewStatus(c.Query("status"))
+	reviews, err := h.duplicateUseCase.ListReviews(c.GetUint("userID"), status, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	responses := make([]ReviewResponse, len(reviews))
+	for i, review := range reviews {
+		responses[i] = h.toReviewResponse(review)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reviews": responses,
+	})
+}
+
+// GetReview obtiene un posible duplicado del usuario autenticado
+func (h *TransactionHandler) GetReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid review ID",
+		})
+		return
+	}
+
+	review, err := h.duplicateUseCase.GetReview(c.GetUint("userID"), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Duplicate review not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"review": h.toReviewResponse(review),
+	})
+}
+
+// ResolveReview aplica la decisión del usuario: merge fusiona la candidata
+// con la transacción existente, keep la crea y discard la descarta
+func (h *TransactionHandler) ResolveReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid review ID",
+		})
+		return
+	}
+
+	var req ResolveReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	review, err := h.duplicateUseCase.ResolveReview(c.GetUint("userID"), uint(id), domain.ReviewAction(req.Action))
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, domain.ErrReviewNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, domain.ErrReviewResolved):
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Duplicate review resolved successfully",
+		"review":  h.toReviewResponse(review),
+	})
+}
+
+func (h *TransactionHandler) toReviewResponse(review *domain.DuplicateReview) ReviewResponse {
+	response := ReviewResponse{
+		ID:                    review.ID,
+		Status:                string(review.Status),
+		Source:                string(review.Source),
+		Score:                 review.Score,
+		Reasons:               []string{},
+		MatchID:               review.MatchID,
+		AccountID:             review.AccountID,
+		CategoryID:            review.CategoryID,
+		Amount:                review.Amount,
+		Currency:              review.Currency,
+		Direction:             string(review.Direction),
+		Date:                  review.Date.Format(dateLayout),
+		Description:           review.Description,
+		Payee:                 review.Payee,
+		ExternalID:            review.ExternalID,
+		ImportBatchID:         review.ImportBatchID,
+		ResolvedTransactionID: review.ResolvedTransactionID,
+		CreatedAt:             review.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if review.Reasons != "" {
+		response.Reasons = strings.Split(review.Reasons, ",")
+	}
+	if review.ResolvedAt != nil {
+		resolvedAt := review.ResolvedAt.Format("2006-01-02T15:04:05Z")
+		response.ResolvedAt = &resolvedAt
+	}
+	return response
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"finanzas-api/internal/transactions/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	tagUseCase domain.TagUseCase
+}
+
+// NewTagHandler crea una nueva instancia del handler de etiquetas
+func NewTagHandler(tagUseCase domain.TagUseCase) *TagHandler {
+	return &TagHandler{
+		tagUseCase: tagUseCase,
+	}
+}
+
+// TagRequest representa la estructura de la petición para crear o renombrar una etiqueta
+type TagRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// TagResponse representa la respuesta de una etiqueta
+type TagResponse struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	Usage     int    `json:"usage"` // Transacciones con la etiqueta
+	CreatedAt string `json:"created_at"`
+}
+
+// CreateTag crea una etiqueta del usuario autenticado. El nombre se guarda
+// en minúsculas y con guiones entre palabras.
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var req TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	tag, err := h.tagUseCase.CreateTag(c.GetUint("userID"), req.Name)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrTagExists) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Tag created successfully",
+		"tag":     h.toTagResponse(tag),
+	})
+}
+
+// ListTags lista las etiquetas del usuario autenticado con su uso
+func (h *TagHandler) ListTags(c *gin.Context) {
+	tags, err := h.tagUseCase.ListTags(c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list tags",
+		})
+		return
+	}
+
+	responses := make([]TagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = h.toTagResponse(tag)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tags": responses,
+	})
+}
+
+// RenameTag renombra una etiqueta del usuario autenticado
+func (h *TagHandler) RenameTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tag ID",
+		})
+		return
+	}
+
+	var req TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	tag, err := h.tagUseCase.RenameTag(c.GetUint("userID"), uint(id), req.Name)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, domain.ErrTagNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, domain.ErrTagExists):
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tag renamed successfully",
+		"tag":     h.toTagResponse(tag),
+	})
+}
+
+// DeleteTag elimina una etiqueta y la quita de sus transacciones
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tag ID",
+		})
+		return
+	}
+
+	if err := h.tagUseCase.DeleteTag(c.GetUint("userID"), uint(id)); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrTagNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tag deleted successfully",
+	})
+}
+
+// toTagResponse convierte una etiqueta del dominio a respuesta HTTP
+func (h *TagHandler) toTagResponse(tag *domain.Tag) TagResponse {
+	return TagResponse{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		Usage:     tag.Usage,
+		CreatedAt: tag.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -0,0 +1,412 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"finanzas-api/internal/transactions/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+type TransactionHandler struct {
+	transactionUseCase domain.TransactionUseCase
+	duplicateUseCase   domain.DuplicateUseCase
+}
+
+// NewTransactionHandler crea una nueva instancia del handler de transacciones
+func NewTransactionHandler(transactionUseCase domain.TransactionUseCase, duplicateUseCase domain.DuplicateUseCase) *TransactionHandler {
+	return &TransactionHandler{
+		transactionUseCase: transactionUseCase,
+		duplicateUseCase:   duplicateUseCase,
+	}
+}
+
+// CreateTransactionRequest representa la estructura de la petición para crear una transacción
+type CreateTransactionRequest struct {
+	AccountID   uint     `json:"account_id" binding:"required"`
+	CategoryID  *uint    `json:"category_id"`
+	Amount      int64    `json:"amount" binding:"required,gt=0"`
+	Direction   string   `json:"direction" binding:"required,oneof=income expense"`
+	Date        string   `json:"date" binding:"required"`
+	Description string   `json:"description"`
+	Payee       string   `json:"payee"`
+	Tags        []string `json:"tags"`
+}
+
+// UpdateTransactionRequest representa la estructura de la petición para actualizar una transacción
+type UpdateTransactionRequest struct {
+	AccountID   uint      `json:"account_id" binding:"omitempty"`
+	CategoryID  *uint     `json:"category_id"`
+	Amount      int64     `json:"amount" binding:"omitempty,gt=0"`
+	Direction   string    `json:"direction" binding:"omitempty,oneof=income expense"`
+	Date        string    `json:"date" binding:"omitempty"`
+	Description *string   `json:"description"`
+	Payee       *string   `json:"payee"`
+	Tags        *[]string `json:"tags"` // Reemplaza todas las etiquetas; [] las quita
+}
+
+// TransactionResponse representa la respuesta de una transacción
+type TransactionResponse struct {
+	ID             uint     `json:"id"`
+	AccountID      uint     `json:"account_id"`
+	CategoryID     *uint    `json:"category_id"`
+	Amount         int64    `json:"amount"`
+	Currency       string   `json:"currency"`
+	Direction      string   `json:"direction"`
+	Date           string   `json:"date"`
+	Description    string   `json:"description"`
+	Payee          string   `json:"payee,omitempty"`
+	JournalEntryID *uint    `json:"journal_entry_id,omitempty"`
+	IsTransfer     bool     `json:"is_transfer"`
+	ImportBatchID  *uint    `json:"import_batch_id,omitempty"`
+	ExternalID     *string  `json:"external_id,omitempty"`
+	RunningBalance *int64   `json:"running_balance,omitempty"`
+	Tags           []string `json:"tags"`
+	CreatedAt      string   `json:"created_at"`
+	UpdatedAt      string   `json:"updated_at"`
+}
+
+// CreateTransaction maneja el registro de transacciones del usuario
+// autenticado. Si se parece a una transacción existente no se crea: queda
+// en revisión y se responde 202 con la revisión.
+func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
+	var req CreateTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid date, expected YYYY-MM-DD",
+		})
+		return
+	}
+
+	transaction := &domain.Transaction{
+		UserID:      c.GetUint("userID"),
+		AccountID:   req.AccountID,
+		CategoryID:  req.CategoryID,
+		Amount:      req.Amount,
+		Direction:   domain.Direction(req.Direction),
+		Date:        date,
+		Description: req.Description,
+		Payee:       req.Payee,
+		Tags:        tagsFromNames(req.Tags),
+	}
+
+	review, err := h.duplicateUseCase.CreateOrFlag(transaction)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if review != nil {
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": "Possible duplicate flagged for review",
+			"review":  h.toReviewResponse(review),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":     "Transaction created successfully",
+		"transaction": h.toTransactionResponse(transaction, false),
+	})
+}
+
+// GetTransaction obtiene una transacción del usuario autenticado
+func (h *TransactionHandler) GetTransaction(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid transaction ID",
+		})
+		return
+	}
+
+	transaction, err := h.transactionUseCase.GetTransaction(c.GetUint("userID"), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Transaction not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transaction": h.toTransactionResponse(transaction, false),
+	})
+}
+
+// UpdateTransaction actualiza una transacción del usuario autenticado
+func (h *TransactionHandler) UpdateTransaction(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid transaction ID",
+		})
+		return
+	}
+
+	var req UpdateTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.GetUint("userID")
+
+	// Obtener transacción existente
+	transaction, err := h.transactionUseCase.GetTransaction(userID, uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Transaction not found",
+		})
+		return
+	}
+
+	// Actualizar campos si se proporcionan
+	if req.AccountID != 0 {
+		transaction.AccountID = req.AccountID
+	}
+	if req.CategoryID != nil {
+		// category_id 0 deja la transacción sin categoría
+		transaction.CategoryID = req.CategoryID
+		if *req.CategoryID == 0 {
+			transaction.CategoryID = nil
+		}
+	}
+	if req.Amount != 0 {
+		transaction.Amount = req.Amount
+	}
+	if req.Direction != "" {
+		transaction.Direction = domain.Direction(req.Direction)
+	}
+	if req.Date != "" {
+		date, err := time.Parse(dateLayout, req.Date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid date, expected YYYY-MM-DD",
+			})
+			return
+		}
+		transaction.Date = date
+	}
+	if req.Description != nil {
+		transaction.Description = *req.Description
+	}
+	if req.Payee != nil {
+		transaction.Payee = *req.Payee
+	}
+	if req.Tags != nil {
+		transaction.Tags = tagsFromNames(*req.Tags)
+	}
+
+	if err := h.transactionUseCase.UpdateTransaction(userID, transaction); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Transaction updated successfully",
+		"transaction": h.toTransactionResponse(transaction, false),
+	})
+}
+
+// DeleteTransaction elimina una transacción del usuario autenticado
+func (h *TransactionHandler) DeleteTransaction(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid transaction ID",
+		})
+		return
+	}
+
+	if err := h.transactionUseCase.DeleteTransaction(c.GetUint("userID"), uint(id)); err != nil {
+		if errors.Is(err, domain.ErrTransactionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Transaction not found",
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Transaction deleted successfully",
+	})
+}
+
+// ListTransactions lista las transacciones del usuario autenticado.
+// Filtros opcionales: account_id, category_id, tag, from, to, min_amount, max_amount y q (texto).
+func (h *TransactionHandler) ListTransactions(c *gin.Context) {
+	filter, err := parseTransactionFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	transactions, err := h.transactionUseCase.ListTransactions(c.Request.Context(), c.GetUint("userID"), filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Convertir a respuesta
+	transactionResponses := make([]TransactionResponse, 0, len(transactions))
+	for _, transaction := range transactions {
+		transactionResponses = append(transactionResponses, h.toTransactionResponse(transaction, true))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transactions": transactionResponses,
+		"pagination": gin.H{
+			"limit":  filter.Limit,
+			"offset": filter.Offset,
+			"count":  len(transactionResponses),
+		},
+	})
+}
+
+// GetBalances retorna el saldo calculado de cada cuenta del usuario autenticado
+func (h *TransactionHandler) GetBalances(c *gin.Context) {
+	balances, err := h.transactionUseCase.GetAccountBalances(c.Request.Context(), c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"balances": balances,
+	})
+}
+
+// parseTransactionFilter construye el filtro a partir de los query params
+func parseTransactionFilter(c *gin.Context) (domain.TransactionFilter, error) {
+	var filter domain.TransactionFilter
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 0 {
+		limit = 50
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	filter.Limit = limit
+	filter.Offset = offset
+
+	if v := c.Query("account_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return filter, errInvalidParam("account_id")
+		}
+		accountID := uint(id)
+		filter.AccountID = &accountID
+	}
+	if v := c.Query("category_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return filter, errInvalidParam("category_id")
+		}
+		categoryID := uint(id)
+		filter.CategoryID = &categoryID
+	}
+	if v := c.Query("from"); v != "" {
+		from, err := time.Parse(dateLayout, v)
+		if err != nil {
+			return filter, errInvalidParam("from")
+		}
+		filter.From = &from
+	}
+	if v := c.Query("to"); v != "" {
+		to, err := time.Parse(dateLayout, v)
+		if err != nil {
+			return filter, errInvalidParam("to")
+		}
+		filter.To = &to
+	}
+	if v := c.Query("min_amount"); v != "" {
+		minAmount, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return filter, errInvalidParam("min_amount")
+		}
+		filter.MinAmount = &minAmount
+	}
+	if v := c.Query("max_amount"); v != "" {
+		maxAmount, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return filter, errInvalidParam("max_amount")
+		}
+		filter.MaxAmount = &maxAmount
+	}
+	filter.Query = c.Query("q")
+	filter.Tag = domain.NormalizeTagName(c.Query("tag"))
+
+	return filter, nil
+}
+
+// tagsFromNames arma las etiquetas de una petición; el caso de uso las
+// normaliza y las resuelve contra las del usuario
+func tagsFromNames(names []string) []domain.Tag {
+	tags := make([]domain.Tag, len(names))
+	for i, name := range names {
+		tags[i] = domain.Tag{Name: name}
+	}
+	return tags
+}
+
+// toTransactionResponse convierte una transacción del dominio a respuesta HTTP
+func (h *TransactionHandler) toTransactionResponse(transaction *domain.Transaction, withBalance bool) TransactionResponse {
+	response := TransactionResponse{
+		ID:             transaction.ID,
+		AccountID:      transaction.AccountID,
+		CategoryID:     transaction.CategoryID,
+		Amount:         transaction.Amount,
+		Currency:       transaction.Currency,
+		Direction:      string(transaction.Direction),
+		Date:           transaction.Date.Format(dateLayout),
+		Description:    transaction.Description,
+		Payee:          transaction.Payee,
+		JournalEntryID: transaction.JournalEntryID,
+		IsTransfer:     transaction.IsTransfer,
+		ImportBatchID:  transaction.ImportBatchID,
+		ExternalID:     transaction.ExternalID,
+		Tags:           make([]string, len(transaction.Tags)),
+		CreatedAt:      transaction.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:      transaction.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	for i, tag := range transaction.Tags {
+		response.Tags[i] = tag.Name
+	}
+	if withBalance {
+		balance := transaction.RunningBalance
+		response.RunningBalance = &balance
+	}
+	return response
+}
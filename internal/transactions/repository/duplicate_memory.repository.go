@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"finanzas-api/internal/transactions/domain"
+)
+
+type duplicateReviewRepositoryMemory struct {
+	reviews map[uint]*domain.DuplicateReview
+	nextID  uint
+	mutex   sync.RWMutex
+}
+
+func NewDuplicateReviewMemoryRepository() domain.DuplicateReviewRepository {
+	return &duplicateReviewRepositoryMemory{
+		reviews: make(map[uint]*domain.DuplicateReview),
+		nextID:  1,
+	}
+}
+
+func (r *duplicateReviewRepositoryMemory) Create(review *domain.DuplicateReview) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Asignar ID y timestamps
+	review.ID = r.nextID
+	r.nextID++
+	review.CreatedAt = time.Now()
+	review.UpdatedAt = time.Now()
+
+	r.reviews[review.ID] = review
+	return nil
+}
+
+func (r *duplicateReviewRepositoryMemory) GetByID(id uint) (*domain.DuplicateReview, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	review, exists := r.reviews[id]
+	if !exists {
+		return nil, errors.New("duplicate review not found")
+	}
+
+	copied := *review
+	return &copied, nil
+}
+
+func (r *duplicateReviewRepositoryMemory) ListByUser(userID uint, status domain.ReviewStatus, limit, offset int) ([]*domain.DuplicateReview, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var reviews []*domain.DuplicateReview
+	for _, review := range r.reviews {
+		if review.UserID == userID && (status == "" || review.Status == status) {
+			copied := *review
+			reviews = append(reviews, &copied)
+		}
+	}
+
+	sort.Slice(reviews, func(i, j int) bool { return reviews[i].ID > reviews[j].ID })
+
+	// Aplicar offset y limit
+	if offset >= len(reviews) {
+		return nil, nil
+	}
+	reviews = reviews[offset:]
+	if limit > 0 && len(reviews) > limit {
+		reviews = reviews[:limit]
+	}
+
+	return reviews, nil
+}
+
+func (r *duplicateReviewRepositoryMemory) Claim(review *domain.DuplicateReview) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existing, exists := r.reviews[review.ID]
+	if !exists {
+		return errors.New("duplicate review not found")
+	}
+	if !existing.IsPending() {
+		return domain.ErrReviewResolved
+	}
+
+	existing.Status = review.Status
+	existing.ResolvedAt = review.ResolvedAt
+	existing.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *duplicateReviewRepositoryMemory) Release(review *domain.DuplicateReview) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existing, exists := r.reviews[review.ID]
+	if !exists {
+		return errors.New("duplicate review not found")
+	}
+	if existing.Status != review.Status || existing.ResolvedTransactionID != nil {
+		return nil
+	}
+
+	existing.Status = domain.ReviewStatusPending
+	existing.ResolvedAt = nil
+	existing.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *duplicateReviewRepositoryMemory) Resolve(review *domain.DuplicateReview) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existing, exists := r.reviews[review.ID]
+	if !exists {
+		return errors.New("duplicate review not found")
+	}
+
+	existing.ResolvedTransactionID = review.ResolvedTransactionID
+	existing.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"finanzas-api/internal/transactions/domain"
+
+	"gorm.io/gorm"
+)
+
+type duplicateReviewPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewDuplicateReviewPostgresRepository(db *gorm.DB) domain.DuplicateReviewRepository {
+	return &duplicateReviewPostgresRepository{db: db}
+}
+
+func (r *duplicateReviewPostgresRepository) Create(review *domain.DuplicateReview) error {
+	return r.db.Omit("Match").Create(review).Error
+}
+
+func (r *duplicateReviewPostgresRepository) GetByID(id uint) (*domain.DuplicateReview, error) {
+	var review domain.DuplicateReview
+	if err := r.db.First(&review, id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *duplicateReviewPostgresRepository) ListByUser(userID uint, status domain.ReviewStatus, limit, offset int) ([]*domain.DuplicateReview, error) {
+	var reviews []*domain.DuplicateReview
+	query := r.db.Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (r *duplicateReviewPostgresRepository) Claim(review *domain.DuplicateReview) error {
+	// La condición sobre el estado evita que dos resoluciones simultáneas
+	// creen o fusionen la candidata dos veces
+	result := r.db.Model(&domain.DuplicateReview{}).
+		Where("id = ? AND status = ?", review.ID, domain.ReviewStatusPending).
+		Updates(map[string]interface{}{
+			"status":      review.Status,
+			"resolved_at": review.ResolvedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrReviewResolved
+	}
+	return nil
+}
+
+func (r *duplicateReviewPostgresRepository) Release(review *domain.DuplicateReview) error {
+	return r.db.Model(&domain.DuplicateReview{}).
+		Where("id = ? AND status = ? AND resolved_transaction_id IS NULL", review.ID, review.Status).
+		Updates(map[string]interface{}{
+			"status":      domain.ReviewStatusPending,
+			"resolved_at": nil,
+		}).Error
+}
+
+func (r *duplicateReviewPostgresRepository) Resolve(review *domain.DuplicateReview) error {
+	return r.db.Model(&domain.DuplicateReview{}).
+		Where("id = ?", review.ID).
+		Update("resolved_transaction_id", review.ResolvedTransactionID).Error
+}
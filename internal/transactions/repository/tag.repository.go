@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/transactions/domain"
+
+type TagRepository interface {
+	domain.TagRepository
+}
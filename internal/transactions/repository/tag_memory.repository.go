@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"finanzas-api/internal/transactions/domain"
+)
+
+type tagRepositoryMemory struct {
+	tags            map[uint]*domain.Tag
+	nextID          uint
+	mutex           sync.RWMutex
+	transactionRepo domain.TransactionRepository
+}
+
+// NewTagMemoryRepository guarda las etiquetas de cada transacción en la
+// propia transacción del repositorio en memoria
+func NewTagMemoryRepository(transactionRepo domain.TransactionRepository) domain.TagRepository {
+	return &tagRepositoryMemory{
+		tags:            make(map[uint]*domain.Tag),
+		nextID:          1,
+		transactionRepo: transactionRepo,
+	}
+}
+
+func (r *tagRepositoryMemory) FindOrCreate(userID uint, name string) (*domain.Tag, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, tag := range r.tags {
+		if tag.UserID == userID && tag.Name == name {
+			return tag, nil
+		}
+	}
+
+	// Asignar ID y timestamps
+	tag := &domain.Tag{ID: r.nextID, UserID: userID, Name: name, CreatedAt: time.Now()}
+	r.nextID++
+	r.tags[tag.ID] = tag
+	return tag, nil
+}
+
+func (r *tagRepositoryMemory) Attach(transactionID uint, tags []*domain.Tag) error {
+	transaction, err := r.transactionRepo.GetByID(transactionID)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if !transaction.HasTag(tag.Name) {
+			transaction.Tags = append(transaction.Tags, *tag)
+		}
+	}
+	return r.transactionRepo.Update(transaction)
+}
+
+func (r *tagRepositoryMemory) Replace(transactionID uint, tags []*domain.Tag) error {
+	transaction, err := r.transactionRepo.GetByID(transactionID)
+	if err != nil {
+		return err
+	}
+
+	transaction.Tags = make([]domain.Tag, len(tags))
+	for i, tag := range tags {
+		transaction.Tags[i] = *tag
+	}
+	return r.transactionRepo.Update(transaction)
+}
+
+func (r *tagRepositoryMemory) GetByID(id uint) (*domain.Tag, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	tag, exists := r.tags[id]
+	if !exists {
+		return nil, errors.New("tag not found")
+	}
+	copied := *tag
+	return &copied, nil
+}
+
+func (r *tagRepositoryMemory) FindByName(userID uint, name string) (*domain.Tag, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, tag := range r.tags {
+		if tag.UserID == userID && tag.Name == name {
+			copied := *tag
+			return &copied, nil
+		}
+	}
+	return nil, errors.New("tag not found")
+}
+
+func (r *tagRepositoryMemory) Update(tag *domain.Tag) error {
+	r.mutex.Lock()
+	existing, exists := r.tags[tag.ID]
+	if !exists {
+		r.mutex.Unlock()
+		return errors.New("tag not found")
+	}
+	existing.Name = tag.Name
+	userID := existing.UserID
+	r.mutex.Unlock()
+
+	// Las transacciones guardan una copia de la etiqueta
+	return r.rewrite(userID, tag.ID, func(tags []domain.Tag, i int) []domain.Tag {
+		tags[i].Name = tag.Name
+		return tags
+	})
+}
+
+func (r *tagRepositoryMemory) Delete(id uint) error {
+	r.mutex.Lock()
+	tag, exists := r.tags[id]
+	if !exists {
+		r.mutex.Unlock()
+		return errors.New("tag not found")
+	}
+	delete(r.tags, id)
+	r.mutex.Unlock()
+
+	return r.rewrite(tag.UserID, id, func(tags []domain.Tag, i int) []domain.Tag {
+		return append(tags[:i], tags[i+1:]...)
+	})
+}
+
+func (r *tagRepositoryMemory) ListByUser(userID uint) ([]*domain.Tag, error) {
+	transactions, err := r.transactionRepo.List(userID, domain.TransactionFilter{})
+	if err != nil {
+		return nil, err
+	}
+	usage := make(map[uint]int)
+	for _, transaction := range transactions {
+		for _, tag := range transaction.Tags {
+			usage[tag.ID]++
+		}
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var tags []*domain.Tag
+	for _, tag := range r.tags {
+		if tag.UserID == userID {
+			copied := *tag
+			copied.Usage = usage[tag.ID]
+			tags = append(tags, &copied)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+	return tags, nil
+}
+
+// rewrite aplica change a la etiqueta tagID en cada transacción del usuario que la tiene
+func (r *tagRepositoryMemory) rewrite(userID, tagID uint, change func(tags []domain.Tag, i int) []domain.Tag) error {
+	transactions, err := r.transactionRepo.List(userID, domain.TransactionFilter{})
+	if err != nil {
+		return err
+	}
+	for _, transaction := range transactions {
+		for i, tag := range transaction.Tags {
+			if tag.ID == tagID {
+				transaction.Tags = change(transaction.Tags, i)
+				if err := r.transactionRepo.Update(transaction); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"finanzas-api/internal/transactions/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type tagPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewTagPostgresRepository(db *gorm.DB) domain.TagRepository {
+	return &tagPostgresRepository{db: db}
+}
+
+func (r *tagPostgresRepository) FindOrCreate(userID uint, name string) (*domain.Tag, error) {
+	// DoNothing evita el error de índice único si otra petición la creó a la vez
+	tag := &domain.Tag{UserID: userID, Name: name}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(tag).Error; err != nil {
+		return nil, err
+	}
+	if tag.ID == 0 {
+		if err := r.db.Where("user_id = ? AND name = ?", userID, name).First(tag).Error; err != nil {
+			return nil, err
+		}
+	}
+	return tag, nil
+}
+
+func (r *tagPostgresRepository) Attach(transactionID uint, tags []*domain.Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	return r.db.Model(&domain.Transaction{ID: transactionID}).Association("Tags").Append(tags)
+}
+
+func (r *tagPostgresRepository) Replace(transactionID uint, tags []*domain.Tag) error {
+	return r.db.Model(&domain.Transaction{ID: transactionID}).Association("Tags").Replace(tags)
+}
+
+func (r *tagPostgresRepository) GetByID(id uint) (*domain.Tag, error) {
+	var tag domain.Tag
+	if err := r.db.First(&tag, id).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagPostgresRepository) FindByName(userID uint, name string) (*domain.Tag, error) {
+	var tag domain.Tag
+	if err := r.db.Where("user_id = ? AND name = ?", userID, name).First(&tag).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagPostgresRepository) Update(tag *domain.Tag) error {
+	return r.db.Model(tag).Update("name", tag.Name).Error
+}
+
+func (r *tagPostgresRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM transaction_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Tag{}, id).Error
+	})
+}
+
+func (r *tagPostgresRepository) ListByUser(userID uint) ([]*domain.Tag, error) {
+	var tags []*domain.Tag
+	// Solo cuentan las transacciones vigentes
+	err := r.db.Model(&domain.Tag{}).
+		Select("tags.*, COUNT(transactions.id) AS usage").
+		Joins("LEFT JOIN transaction_tags ON transaction_tags.tag_id = tags.id").
+		Joins("LEFT JOIN transactions ON transactions.id = transaction_tags.transaction_id AND transactions.deleted_at IS NULL").
+		Where("tags.user_id = ?", userID).
+		Group("tags.id").
+		Order("tags.name").
+		Find(&tags).Error
+	return tags, err
+}
@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"finanzas-api/internal/transactions/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+type transactionRepositoryMemory struct {
+	transactions map[uint]*domain.Transaction
+	nextID       uint
+	mutex        sync.RWMutex
+}
+
+func NewTransactionMemoryRepository() domain.TransactionRepository {
+	return &transactionRepositoryMemory{
+		transactions: make(map[uint]*domain.Transaction),
+		nextID:       1,
+	}
+}
+
+// WithContext retorna el mismo repositorio: en memoria no hay consultas que
+// cancelar
+func (r *transactionRepositoryMemory) WithContext(ctx context.Context) domain.TransactionRepository {
+	return r
+}
+
+func (r *transactionRepositoryMemory) Create(transaction *domain.Transaction) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Asignar ID y timestamps
+	transaction.ID = r.nextID
+	r.nextID++
+	transaction.CreatedAt = time.Now()
+	transaction.UpdatedAt = time.Now()
+
+	r.transactions[transaction.ID] = transaction
+	return nil
+}
+
+func (r *transactionRepositoryMemory) GetByID(id uint) (*domain.Transaction, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	transaction, exists := r.transactions[id]
+	if !exists || !transaction.DeletedAt.Time.IsZero() {
+		return nil, errors.New("transaction not found")
+	}
+
+	return transaction, nil
+}
+
+func (r *transactionRepositoryMemory) Update(transaction *domain.Transaction) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existing, exists := r.transactions[transaction.ID]
+	if !exists || !existing.DeletedAt.Time.IsZero() {
+		return errors.New("transaction not found")
+	}
+
+	transaction.UpdatedAt = time.Now()
+	r.transactions[transaction.ID] = transaction
+
+	return nil
+}
+
+func (r *transactionRepositoryMemory) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	transaction, exists := r.transactions[id]
+	if !exists || !transaction.DeletedAt.Time.IsZero() {
+		return errors.New("transaction not found")
+	}
+
+	// Soft delete
+	transaction.DeletedAt.Time = time.Now()
+	transaction.DeletedAt.Valid = true
+	transaction.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (r *transactionRepositoryMemory) List(userID uint, filter domain.TransactionFilter) ([]*domain.Transaction, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var owned []*domain.Transaction
+	for _, transaction := range r.transactions {
+		if transaction.DeletedAt.Time.IsZero() && transaction.UserID == userID {
+			owned = append(owned, transaction)
+		}
+	}
+
+	// Calcular el saldo acumulado por cuenta en orden cronológico
+	sort.Slice(owned, func(i, j int) bool {
+		if !owned[i].Date.Equal(owned[j].Date) {
+			return owned[i].Date.Before(owned[j].Date)
+		}
+		return owned[i].ID < owned[j].ID
+	})
+	running := make(map[uint]int64)
+	for _, transaction := range owned {
+		running[transaction.AccountID] += transaction.SignedAmount()
+		transaction.RunningBalance = running[transaction.AccountID]
+	}
+
+	var matches []*domain.Transaction
+	search := &domain.Criteria{Query: filter.Query}
+	for i := len(owned) - 1; i >= 0; i-- {
+		transaction := owned[i]
+		if filter.AccountID != nil && transaction.AccountID != *filter.AccountID {
+			continue
+		}
+		if filter.CategoryID != nil && (transaction.CategoryID == nil || *transaction.CategoryID != *filter.CategoryID) {
+			continue
+		}
+		if filter.From != nil && transaction.Date.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && transaction.Date.After(*filter.To) {
+			continue
+		}
+		if filter.MinAmount != nil && transaction.Amount < *filter.MinAmount {
+			continue
+		}
+		if filter.MaxAmount != nil && transaction.Amount > *filter.MaxAmount {
+			continue
+		}
+		if !search.Matches(transaction) {
+			continue
+		}
+		if filter.Tag != "" && !transaction.HasTag(filter.Tag) {
+			continue
+		}
+		if !filter.Criteria.Matches(transaction) {
+			continue
+		}
+		matches = append(matches, transaction)
+	}
+
+	// Aplicar offset y limit
+	if filter.Offset >= len(matches) {
+		return nil, nil
+	}
+	matches = matches[filter.Offset:]
+	if filter.Limit > 0 && len(matches) > filter.Limit {
+		matches = matches[:filter.Limit]
+	}
+
+	return matches, nil
+}
+
+func (r *transactionRepositoryMemory) TotalsByAccount(userID uint) ([]*domain.AccountTotals, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	byAccount := make(map[uint]*domain.AccountTotals)
+	for _, transaction := range r.transactions {
+		if !transaction.DeletedAt.Time.IsZero() || transaction.UserID != userID {
+			continue
+		}
+		totals, exists := byAccount[transaction.AccountID]
+		if !exists {
+			totals = &domain.AccountTotals{AccountID: transaction.AccountID}
+			byAccount[transaction.AccountID] = totals
+		}
+		if transaction.Direction == domain.DirectionIncome {
+			totals.Income += transaction.Amount
+		} else {
+			totals.Expense += transaction.Amount
+		}
+	}
+
+	result := make([]*domain.AccountTotals, 0, len(byAccount))
+	for _, totals := range byAccount {
+		result = append(result, totals)
+	}
+	return result, nil
+}
+
+// MonthlyExpensesByCategory suma las transacciones en currency y las de
+// converted: el repositorio en memoria no registra conversiones.
+func (r *transactionRepositoryMemory) MonthlyExpensesByCategory(userID uint, currency string, from, to time.Time, converted domain.ConvertedAmounts) ([]*domain.CategoryMonthTotal, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	type key struct {
+		categoryID uint
+		month      time.Time
+	}
+	byKey := make(map[key]*domain.CategoryMonthTotal)
+	for _, transaction := range r.transactions {
+		if !transaction.DeletedAt.Time.IsZero() || transaction.UserID != userID {
+			continue
+		}
+		if transaction.Direction != domain.DirectionExpense || transaction.IsTransfer || transaction.CategoryID == nil {
+			continue
+		}
+		amount, convertible := transaction.Amount, transaction.Currency == currency
+		if !convertible {
+			amount, convertible = converted[transaction.ID]
+		}
+		if !convertible {
+			continue
+		}
+		if transaction.Date.Before(from) || !transaction.Date.Before(to) {
+			continue
+		}
+		month := time.Date(transaction.Date.Year(), transaction.Date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		k := key{categoryID: *transaction.CategoryID, month: month}
+		total, exists := byKey[k]
+		if !exists {
+			total = &domain.CategoryMonthTotal{CategoryID: k.categoryID, Month: month}
+			byKey[k] = total
+		}
+		total.Amount += amount
+	}
+
+	result := make([]*domain.CategoryMonthTotal, 0, len(byKey))
+	for _, total := range byKey {
+		result = append(result, total)
+	}
+	return result, nil
+}
+
+func (r *transactionRepositoryMemory) Totals(userID uint, criteria *domain.Criteria) ([]*domain.CurrencyTotals, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	byCurrency := make(map[string]*domain.CurrencyTotals)
+	for _, transaction := range r.transactions {
+		if !transaction.DeletedAt.Time.IsZero() || transaction.UserID != userID || !criteria.Matches(transaction) {
+			continue
+		}
+		totals, exists := byCurrency[transaction.Currency]
+		if !exists {
+			totals = &domain.CurrencyTotals{Currency: transaction.Currency}
+			byCurrency[transaction.Currency] = totals
+		}
+		totals.Count++
+		if transaction.IsTransfer {
+			continue
+		}
+		if transaction.Direction == domain.DirectionIncome {
+			totals.Income += transaction.Amount
+		} else {
+			totals.Expenses += transaction.Amount
+		}
+	}
+
+	result := make([]*domain.CurrencyTotals, 0, len(byCurrency))
+	for _, totals := range byCurrency {
+		result = append(result, totals)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Currency < result[j].Currency
+	})
+	return result, nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"finanzas-api/internal/transactions/domain"
+
+	"gorm.io/gorm"
+)
+
+type transactionPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewTransactionPostgresRepository(db *gorm.DB) domain.TransactionRepository {
+	return &transactionPostgresRepository{db: db}
+}
+
+func (r *transactionPostgresRepository) WithContext(ctx context.Context) domain.TransactionRepository {
+	return &transactionPostgresRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *transactionPostgresRepository) Create(transaction *domain.Transaction) error {
+	return r.db.Create(transaction).Error
+}
+
+func (r *transactionPostgresRepository) GetByID(id uint) (*domain.Transaction, error) {
+	var transaction domain.Transaction
+	if err := r.db.Preload("Tags").First(&transaction, id).Error; err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+func (r *transactionPostgresRepository) Update(transaction *domain.Transaction) error {
+	return r.db.Save(transaction).Error
+}
+
+func (r *transactionPostgresRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Transaction{}, id).Error // soft delete
+}
+
+func (r *transactionPostgresRepository) List(userID uint, filter domain.TransactionFilter) ([]*domain.Transaction, error) {
+	var transactions []*domain.Transaction
+
+	// El saldo acumulado se calcula sobre todo el historial de la cuenta,
+	// antes de aplicar los filtros, para que no dependa de la página consultada.
+	withBalance := r.db.Model(&domain.Transaction{}).
+		Select("transactions.*, SUM(CASE WHEN direction = ? THEN amount ELSE -amount END) OVER (PARTITION BY account_id ORDER BY date, id) AS running_balance", domain.DirectionIncome).
+		Where("user_id = ?", userID)
+
+	query := r.db.Table("(?) AS transactions", withBalance)
+	if filter.AccountID != nil {
+		query = query.Where("account_id = ?", *filter.AccountID)
+	}
+	if filter.CategoryID != nil {
+		query = query.Where("category_id = ?", *filter.CategoryID)
+	}
+	if filter.From != nil {
+		query = query.Where("date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("date <= ?", *filter.To)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("amount >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("amount <= ?", *filter.MaxAmount)
+	}
+	// La búsqueda y la etiqueta usan las mismas cláusulas que las vistas
+	if filter.Query != "" || filter.Tag != "" {
+		search := &domain.Criteria{Query: filter.Query}
+		if filter.Tag != "" {
+			search.Tags = []string{filter.Tag}
+		}
+		query = search.Apply(query, "transactions")
+	}
+	query = filter.Criteria.Apply(query, "transactions")
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if err := query.Preload("Tags").Order("date DESC, id DESC").Offset(filter.Offset).Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func (r *transactionPostgresRepository) TotalsByAccount(userID uint) ([]*domain.AccountTotals, error) {
+	var totals []*domain.AccountTotals
+	err := r.db.Model(&domain.Transaction{}).
+		Select("account_id, "+
+			"COALESCE(SUM(CASE WHEN direction = ? THEN amount END), 0) AS income, "+
+			"COALESCE(SUM(CASE WHEN direction = ? THEN amount END), 0) AS expense",
+			domain.DirectionIncome, domain.DirectionExpense).
+		Where("user_id = ?", userID).
+		Group("account_id").
+		Scan(&totals).Error
+	return totals, err
+}
+
+func (r *transactionPostgresRepository) MonthlyExpensesByCategory(userID uint, currency string, from, to time.Time, converted domain.ConvertedAmounts) ([]*domain.CategoryMonthTotal, error) {
+	var totals []*domain.CategoryMonthTotal
+	joins, args := converted.JoinSQL("transactions", currency)
+	err := r.db.Model(&domain.Transaction{}).
+		Select("transactions.category_id, date_trunc('month', transactions.date) AS month, "+
+			"COALESCE(SUM("+domain.ConvertedAmountSQL("transactions")+"), 0) AS amount", currency).
+		Joins(joins, args...).
+		Where("transactions.user_id = ? AND transactions.direction = ? AND transactions.is_transfer = ?", userID, domain.DirectionExpense, false).
+		Where("transactions.category_id IS NOT NULL AND transactions.date >= ? AND transactions.date < ?", from, to).
+		Group("transactions.category_id, date_trunc('month', transactions.date)").
+		Scan(&totals).Error
+	return totals, err
+}
+
+func (r *transactionPostgresRepository) Totals(userID uint, criteria *domain.Criteria) ([]*domain.CurrencyTotals, error) {
+	var totals []*domain.CurrencyTotals
+	query := r.db.Model(&domain.Transaction{}).
+		Select("transactions.currency, COUNT(*) AS count, "+
+			"COALESCE(SUM(CASE WHEN transactions.direction = ? AND NOT transactions.is_transfer THEN transactions.amount END), 0) AS income, "+
+			"COALESCE(SUM(CASE WHEN transactions.direction = ? AND NOT transactions.is_transfer THEN transactions.amount END), 0) AS expenses",
+			domain.DirectionIncome, domain.DirectionExpense).
+		Where("transactions.user_id = ?", userID)
+	err := criteria.Apply(query, "transactions").
+		Group("transactions.currency").
+		Order("transactions.currency").
+		Scan(&totals).Error
+	return totals, err
+}
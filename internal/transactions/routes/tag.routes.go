@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"finanzas-api/internal/transactions/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupTagRoutes configura las rutas de etiquetas
+func SetupTagRoutes(router *gin.Engine, tagHandler *handler.TagHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	tagRoutes := router.Group("/api/v1/tags")
+	{
+		// POST /api/v1/tags - Crear etiqueta
+		tagRoutes.POST("", authMiddleware("transactions:write"), tagHandler.CreateTag)
+
+		// GET /api/v1/tags - Listar etiquetas con su uso
+		tagRoutes.GET("", authMiddleware("transactions:read"), tagHandler.ListTags)
+
+		// PUT /api/v1/tags/:id - Renombrar etiqueta
+		tagRoutes.PUT("/:id", authMiddleware("transactions:write"), tagHandler.RenameTag)
+
+		// DELETE /api/v1/tags/:id - Eliminar etiqueta
+		tagRoutes.DELETE("/:id", authMiddleware("transactions:write"), tagHandler.DeleteTag)
+	}
+}
@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"finanzas-api/internal/transactions/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupTransactionRoutes configura las rutas para el módulo de transacciones
+func SetupTransactionRoutes(router *gin.Engine, transactionHandler *handler.TransactionHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	// Grupo de rutas para transacciones; cada usuario solo ve las suyas
+	transactionRoutes := router.Group("/api/v1/transactions")
+	{
+		// POST /api/v1/transactions - Registrar transacción
+		transactionRoutes.POST("", authMiddleware("transactions:write"), transactionHandler.CreateTransaction)
+
+		// GET /api/v1/transactions - Listar transacciones con filtros
+		transactionRoutes.GET("", authMiddleware("transactions:read"), transactionHandler.ListTransactions)
+
+		// GET /api/v1/transactions/balances - Saldo calculado por cuenta
+		transactionRoutes.GET("/balances", authMiddleware("transactions:read"), transactionHandler.GetBalances)
+
+		// GET /api/v1/transactions/duplicates - Listar posibles duplicados en revisión
+		transactionRoutes.GET("/duplicates", authMiddleware("transactions:read"), transactionHandler.ListReviews)
+
+		// GET /api/v1/transactions/duplicates/:id - Obtener posible duplicado
+		transactionRoutes.GET("/duplicates/:id", authMiddleware("transactions:read"), transactionHandler.GetReview)
+
+		// POST /api/v1/transactions/duplicates/:id/resolve - Fusionar, conservar ambas o descartar
+		transactionRoutes.POST("/duplicates/:id/resolve", authMiddleware("transactions:write"), transactionHandler.ResolveReview)
+
+		// GET /api/v1/transactions/:id - Obtener transacción por ID
+		transactionRoutes.GET("/:id", authMiddleware("transactions:read"), transactionHandler.GetTransaction)
+
+		// PUT /api/v1/transactions/:id - Actualizar transacción
+		transactionRoutes.PUT("/:id", authMiddleware("transactions:write"), transactionHandler.UpdateTransaction)
+
+		// DELETE /api/v1/transactions/:id - Eliminar transacción
+		transactionRoutes.DELETE("/:id", authMiddleware("transactions:write"), transactionHandler.DeleteTransaction)
+	}
+}
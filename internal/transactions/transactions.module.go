@@ -0,0 +1,106 @@
+package transactions
+
+import (
+	"errors"
+	"fmt"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/transactions/domain"
+	"finanzas-api/internal/transactions/handler"
+	"finanzas-api/internal/transactions/repository"
+	"finanzas-api/internal/transactions/usecase"
+
+	"gorm.io/gorm"
+)
+
+type TransactionsModule struct {
+	Handler           *handler.TransactionHandler
+	UseCase           domain.TransactionUseCase
+	Repository        domain.TransactionRepository
+	DuplicateUseCase  domain.DuplicateUseCase
+	TagRepository     domain.TagRepository
+	TagHandler        *handler.TagHandler
+	beforeCreateHooks []domain.BeforeCreateHook
+	categoryHooks     []domain.CategoryChangeHook
+}
+
+func NewTransactionsModule(db *gorm.DB, accountRepo accountDomain.AccountRepository, categoryRepo categoryDomain.CategoryRepository) *TransactionsModule {
+	var transactionRepo domain.TransactionRepository
+	var transactionUseCase domain.TransactionUseCase
+	var duplicateUseCase domain.DuplicateUseCase
+	var tagRepo domain.TagRepository
+	var transactionHandler *handler.TransactionHandler
+	var tagHandler *handler.TagHandler
+
+	if err := db.AutoMigrate(&domain.Tag{}, &domain.Transaction{}, &domain.DuplicateReview{}); err != nil {
+		panic(fmt.Sprintf("Error migrating transactions: %v", err))
+	}
+
+	// Las transacciones creadas antes de la columna currency toman la moneda de su cuenta
+	if err := db.Exec(`UPDATE transactions SET currency = accounts.currency FROM accounts
+		WHERE accounts.id = transactions.account_id AND transactions.currency <> accounts.currency`).Error; err != nil {
+		panic(fmt.Sprintf("Error backfilling transaction currencies: %v", err))
+	}
+
+	// Índice parcial: solo aplica a transacciones importadas y vigentes, para
+	// que una eliminada pueda volver a importarse
+	if err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_account_external
+		ON transactions (account_id, external_id) WHERE external_id IS NOT NULL AND deleted_at IS NULL`).Error; err != nil {
+		panic(fmt.Sprintf("Error creating transaction external ID index: %v", err))
+	}
+
+	module := &TransactionsModule{}
+
+	transactionRepo = repository.NewTransactionPostgresRepository(db)
+	tagRepo = repository.NewTagPostgresRepository(db)
+	transactionUseCase = usecase.NewTransactionUseCase(transactionRepo, accountRepo, categoryRepo, tagRepo, module.runBeforeCreateHooks, module.runCategoryChangeHooks)
+	duplicateUseCase = usecase.NewDuplicateUseCase(repository.NewDuplicateReviewPostgresRepository(db), transactionRepo, accountRepo, transactionUseCase)
+	transactionHandler = handler.NewTransactionHandler(transactionUseCase, duplicateUseCase)
+	tagHandler = handler.NewTagHandler(usecase.NewTagUseCase(tagRepo))
+
+	module.Handler = transactionHandler
+	module.UseCase = transactionUseCase
+	module.Repository = transactionRepo
+	module.DuplicateUseCase = duplicateUseCase
+	module.TagRepository = tagRepo
+	module.TagHandler = tagHandler
+	return module
+}
+
+// OnBeforeCreate registra un hook que se ejecuta antes de guardar cada
+// transacción nueva. Permite que módulos creados después (p. ej. reglas)
+// completen la transacción.
+func (m *TransactionsModule) OnBeforeCreate(hook domain.BeforeCreateHook) {
+	m.beforeCreateHooks = append(m.beforeCreateHooks, hook)
+}
+
+// OnCategoryChange registra un hook que se ejecuta después de crear,
+// recategorizar o eliminar una transacción categorizada, p. ej. para que el
+// categorizador aprenda de las correcciones del usuario.
+func (m *TransactionsModule) OnCategoryChange(hook domain.CategoryChangeHook) {
+	m.categoryHooks = append(m.categoryHooks, hook)
+}
+
+// runBeforeCreateHooks ejecuta en orden los hooks registrados; uno que falla
+// no impide que corran los siguientes
+func (m *TransactionsModule) runBeforeCreateHooks(transaction *domain.Transaction) error {
+	var errs []error
+	for _, hook := range m.beforeCreateHooks {
+		if err := hook(transaction); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runCategoryChangeHooks ejecuta en orden los hooks registrados
+func (m *TransactionsModule) runCategoryChangeHooks(before, after *domain.Transaction) error {
+	var errs []error
+	for _, hook := range m.categoryHooks {
+		if err := hook(before, after); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
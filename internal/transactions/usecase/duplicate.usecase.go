@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	"finanzas-api/internal/transactions/domain"
+)
+
+// maxReviewsPerPage limita las revisiones que retorna ListReviews
+const maxReviewsPerPage = 100
+
+type DuplicateUseCase struct {
+	reviewRepo         domain.DuplicateReviewRepository
+	transactionRepo    domain.TransactionRepository
+	accountRepo        accountDomain.AccountRepository
+	transactionUseCase domain.TransactionUseCase
+}
+
+// NewDuplicateUseCase recibe el caso de uso de transacciones para crear las
+// candidatas con las mismas validaciones que una transacción manual.
+func NewDuplicateUseCase(reviewRepo domain.DuplicateReviewRepository, transactionRepo domain.TransactionRepository, accountRepo accountDomain.AccountRepository, transactionUseCase domain.TransactionUseCase) domain.DuplicateUseCase {
+	return &DuplicateUseCase{
+		reviewRepo:         reviewRepo,
+		transactionRepo:    transactionRepo,
+		accountRepo:        accountRepo,
+		transactionUseCase: transactionUseCase,
+	}
+}
+
+// FindDuplicate implements domain.DuplicateUseCase.
+func (uc *DuplicateUseCase) FindDuplicate(transaction *domain.Transaction) (*domain.DuplicateMatch, error) {
+	// Solo se consultan las transacciones de la cuenta que caen en la
+	// ventana de fechas y en el margen de monto
+	from := transaction.Date.AddDate(0, 0, -domain.DuplicateDateWindow)
+	to := transaction.Date.AddDate(0, 0, domain.DuplicateDateWindow)
+	minAmount := transaction.Amount - transaction.Amount/100
+	maxAmount := transaction.Amount + transaction.Amount/100
+	candidates, err := uc.transactionRepo.List(transaction.UserID, domain.TransactionFilter{
+		AccountID: &transaction.AccountID,
+		From:      &from,
+		To:        &to,
+		MinAmount: &minAmount,
+		MaxAmount: &maxAmount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var best *domain.DuplicateMatch
+	for _, existing := range candidates {
+		score, reasons := domain.ScoreDuplicate(transaction, existing)
+		if score >= domain.LikelyDuplicateScore && (best == nil || score > best.Score) {
+			best = &domain.DuplicateMatch{Transaction: existing, Score: score, Reasons: reasons}
+		}
+	}
+	return best, nil
+}
+
+// CreateOrFlag implements domain.DuplicateUseCase.
+func (uc *DuplicateUseCase) CreateOrFlag(transaction *domain.Transaction) (*domain.DuplicateReview, error) {
+	if err := uc.transactionUseCase.ValidateTransactionData(transaction); err != nil {
+		return nil, err
+	}
+
+	// La moneda de la cuenta es parte de la comparación
+	account, err := uc.accountRepo.GetByID(transaction.AccountID)
+	if err != nil || !account.BelongsTo(transaction.UserID) {
+		return nil, errors.New("account not found")
+	}
+	transaction.Currency = account.Currency
+
+	match, err := uc.FindDuplicate(transaction)
+	if err != nil {
+		return nil, err
+	}
+	if match == nil {
+		return nil, uc.transactionUseCase.CreateTransaction(transaction)
+	}
+
+	review := uc.NewReview(transaction, match, domain.DuplicateSourceManual, nil)
+	if err := uc.reviewRepo.Create(review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// NewReview implements domain.DuplicateUseCase.
+func (uc *DuplicateUseCase) NewReview(transaction *domain.Transaction, match *domain.DuplicateMatch, source domain.DuplicateSource, importRowID *uint) *domain.DuplicateReview {
+	return &domain.DuplicateReview{
+		UserID:        transaction.UserID,
+		Status:        domain.ReviewStatusPending,
+		Source:        source,
+		Score:         match.Score,
+		Reasons:       strings.Join(match.Reasons, ","),
+		MatchID:       match.Transaction.ID,
+		AccountID:     transaction.AccountID,
+		CategoryID:    transaction.CategoryID,
+		Amount:        transaction.Amount,
+		Currency:      transaction.Currency,
+		Direction:     transaction.Direction,
+		Date:          transaction.Date,
+		Description:   transaction.Description,
+		Payee:         transaction.Payee,
+		ExternalID:    transaction.ExternalID,
+		ImportBatchID: transaction.ImportBatchID,
+		ImportRowID:   importRowID,
+	}
+}
+
+// GetReview implements domain.DuplicateUseCase.
+func (uc *DuplicateUseCase) GetReview(userID, id uint) (*domain.DuplicateReview, error) {
+	if id == 0 {
+		return nil, errors.New("invalid duplicate review ID")
+	}
+
+	review, err := uc.reviewRepo.GetByID(id)
+	if err != nil || !review.BelongsTo(userID) {
+		return nil, domain.ErrReviewNotFound
+	}
+
+	return review, nil
+}
+
+// ListReviews implements domain.DuplicateUseCase.
+func (uc *DuplicateUseCase) ListReviews(userID uint, status domain.ReviewStatus, limit, offset int) ([]*domain.DuplicateReview, error) {
+	switch status {
+	case "", domain.ReviewStatusPending, domain.ReviewStatusMerged, domain.ReviewStatusKept, domain.ReviewStatusDiscarded:
+	default:
+		return nil, errors.New("invalid review status")
+	}
+
+	if limit <= 0 || limit > maxReviewsPerPage {
+		limit = maxReviewsPerPage
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return uc.reviewRepo.ListByUser(userID, status, limit, offset)
+}
+
+// ResolveReview implements domain.DuplicateUseCase.
+func (uc *DuplicateUseCase) ResolveReview(userID, id uint, action domain.ReviewAction) (*domain.DuplicateReview, error) {
+	if !domain.IsValidReviewAction(action) {
+		return nil, errors.New("invalid action, expected merge, keep or discard")
+	}
+
+	review, err := uc.GetReview(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !review.IsPending() {
+		return nil, domain.ErrReviewResolved
+	}
+
+	// La revisión se reclama antes de crear o fusionar la candidata, así
+	// una segunda solicitud simultánea falla sin aplicarla otra vez
+	switch action {
+	case domain.ReviewActionMerge:
+		review.Status = domain.ReviewStatusMerged
+	case domain.ReviewActionKeep:
+		review.Status = domain.ReviewStatusKept
+	case domain.ReviewActionDiscard:
+		review.Status = domain.ReviewStatusDiscarded
+	}
+	now := time.Now()
+	review.ResolvedAt = &now
+	if err := uc.reviewRepo.Claim(review); err != nil {
+		return nil, err
+	}
+
+	resolvedID, err := uc.apply(userID, review, action)
+	if err != nil {
+		// Vuelve a quedar pendiente para que el usuario decida de nuevo
+		if releaseErr := uc.reviewRepo.Release(review); releaseErr != nil {
+			log.Printf("⚠️ Error liberando la revisión de duplicado %d: %v", review.ID, releaseErr)
+		}
+		return nil, err
+	}
+
+	review.ResolvedTransactionID = resolvedID
+	if resolvedID != nil {
+		if err := uc.reviewRepo.Resolve(review); err != nil {
+			return nil, err
+		}
+	}
+	return review, nil
+}
+
+// apply ejecuta la decisión sobre una revisión ya reclamada y retorna la
+// transacción que quedó, si alguna
+func (uc *DuplicateUseCase) apply(userID uint, review *domain.DuplicateReview, action domain.ReviewAction) (*uint, error) {
+	switch action {
+	case domain.ReviewActionMerge:
+		existing, err := uc.transactionRepo.GetByID(review.MatchID)
+		if err != nil || !existing.BelongsTo(userID) {
+			return nil, errors.New("matched transaction no longer exists, keep or discard instead")
+		}
+		// Las patas de un asiento solo se modifican a través del libro mayor
+		if existing.IsLedgerLeg() {
+			return nil, errors.New("matched transaction belongs to a journal entry, keep or discard instead")
+		}
+		if uc.mergeInto(existing, review) {
+			if err := uc.transactionRepo.Update(existing); err != nil {
+				return nil, err
+			}
+		}
+		return &existing.ID, nil
+	case domain.ReviewActionKeep:
+		candidate := review.Candidate()
+		if err := uc.transactionUseCase.CreateTransaction(candidate); err != nil {
+			return nil, err
+		}
+		return &candidate.ID, nil
+	}
+	return nil, nil
+}
+
+// mergeInto completa la transacción existente con los datos de la candidata
+// que le faltan. La referencia del banco es la más importante: con ella una
+// nueva importación del mismo extracto reconoce el movimiento. Retorna si
+// hubo cambios.
+func (uc *DuplicateUseCase) mergeInto(existing *domain.Transaction, review *domain.DuplicateReview) bool {
+	changed := false
+	if existing.ExternalID == nil && review.ExternalID != nil {
+		existing.ExternalID = review.ExternalID
+		changed = true
+	}
+	if existing.Payee == "" && review.Payee != "" {
+		existing.Payee = review.Payee
+		changed = true
+	}
+	if existing.Description == "" && review.Description != "" {
+		existing.Description = review.Description
+		changed = true
+	}
+	if existing.CategoryID == nil && review.CategoryID != nil {
+		existing.CategoryID = review.CategoryID
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	accountRepository "finanzas-api/internal/accounts/repository"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	categoryRepository "finanzas-api/internal/categories/repository"
+	"finanzas-api/internal/transactions/domain"
+	"finanzas-api/internal/transactions/repository"
+)
+
+// staleReviews simula una solicitud que leyó la revisión antes de que otra
+// la resolviera: GetByID siempre la retorna pendiente
+type staleReviews struct {
+	domain.DuplicateReviewRepository
+}
+
+func (r staleReviews) GetByID(id uint) (*domain.DuplicateReview, error) {
+	review, err := r.DuplicateReviewRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	review.Status = domain.ReviewStatusPending
+	return review, nil
+}
+
+// testDuplicates agrupa el caso de uso de duplicados sobre repositorios en
+// memoria, con una transacción existente y una revisión pendiente sobre ella
+type testDuplicates struct {
+	uc           domain.DuplicateUseCase
+	transactions domain.TransactionRepository
+	reviews      domain.DuplicateReviewRepository
+	existing     *domain.Transaction
+	review       *domain.DuplicateReview
+}
+
+// newTestDuplicates crea una cuenta en COP del usuario 1, la categoría 1
+// del usuario 1 y la 2 del usuario 2. La candidata de la revisión lleva
+// categoryID; journalEntryID convierte la existente en pata de un asiento.
+func newTestDuplicates(t *testing.T, categoryID, journalEntryID *uint, stale bool) *testDuplicates {
+	t.Helper()
+	accounts := accountRepository.NewAccountMemoryRepository()
+	if err := accounts.Create(&accountDomain.Account{UserID: 1, Name: "Banco", Type: accountDomain.AccountTypeBank, Currency: "COP"}); err != nil {
+		t.Fatal(err)
+	}
+	categories := categoryRepository.NewCategoryMemoryRepository()
+	for _, category := range []*categoryDomain.Category{
+		{UserID: 1, Name: "Mercado", Kind: categoryDomain.KindExpense},
+		{UserID: 2, Name: "Ajena", Kind: categoryDomain.KindExpense},
+	} {
+		if err := categories.Create(category); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := &testDuplicates{transactions: repository.NewTransactionMemoryRepository()}
+	d.reviews = repository.NewDuplicateReviewMemoryRepository()
+	reviews := d.reviews
+	if stale {
+		reviews = staleReviews{d.reviews}
+	}
+	transactions := NewTransactionUseCase(d.transactions, accounts, categories, repository.NewTagMemoryRepository(d.transactions), nil, nil)
+	d.uc = NewDuplicateUseCase(reviews, d.transactions, accounts, transactions)
+
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	d.existing = &domain.Transaction{UserID: 1, AccountID: 1, Amount: 12000, Currency: "COP", Direction: domain.DirectionExpense, Description: "Mercado", Date: date, JournalEntryID: journalEntryID}
+	if err := d.transactions.Create(d.existing); err != nil {
+		t.Fatal(err)
+	}
+	candidate := &domain.Transaction{UserID: 1, AccountID: 1, CategoryID: categoryID, Amount: 12000, Currency: "COP", Direction: domain.DirectionExpense, Description: "Mercado", Payee: "Exito", Date: date}
+	match := &domain.DuplicateMatch{Transaction: d.existing, Score: 100, Reasons: []string{"amount", "date", "description"}}
+	d.review = d.uc.NewReview(candidate, match, domain.DuplicateSourceManual, nil)
+	if err := d.reviews.Create(d.review); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestResolveReview(t *testing.T) {
+	id := func(id uint) *uint { return &id }
+	tests := []struct {
+		name             string
+		action           domain.ReviewAction
+		categoryID       *uint
+		journalEntryID   *uint
+		wantErr          string
+		wantStatus       domain.ReviewStatus
+		wantTransactions int
+	}{
+		{name: "keep", action: domain.ReviewActionKeep, categoryID: id(1), wantStatus: domain.ReviewStatusKept, wantTransactions: 2},
+		{name: "merge", action: domain.ReviewActionMerge, wantStatus: domain.ReviewStatusMerged, wantTransactions: 1},
+		{name: "discard", action: domain.ReviewActionDiscard, wantStatus: domain.ReviewStatusDiscarded, wantTransactions: 1},
+		{
+			// La revisión vuelve a quedar pendiente si la candidata no se puede crear
+			name:             "keep with a category of another user",
+			action:           domain.ReviewActionKeep,
+			categoryID:       id(2),
+			wantErr:          "category not found",
+			wantStatus:       domain.ReviewStatusPending,
+			wantTransactions: 1,
+		},
+		{
+			name:             "merge into a ledger leg",
+			action:           domain.ReviewActionMerge,
+			journalEntryID:   id(7),
+			wantErr:          "matched transaction belongs to a journal entry, keep or discard instead",
+			wantStatus:       domain.ReviewStatusPending,
+			wantTransactions: 1,
+		},
+		{name: "discard a ledger leg match", action: domain.ReviewActionDiscard, journalEntryID: id(7), wantStatus: domain.ReviewStatusDiscarded, wantTransactions: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDuplicates(t, tt.categoryID, tt.journalEntryID, false)
+
+			resolved, resolveErr := d.uc.ResolveReview(1, d.review.ID, tt.action)
+			if tt.wantErr != "" {
+				if resolveErr == nil || resolveErr.Error() != tt.wantErr {
+					t.Fatalf("ResolveReview() error = %v, want %q", resolveErr, tt.wantErr)
+				}
+			} else if resolveErr != nil {
+				t.Fatalf("ResolveReview() error = %v", resolveErr)
+			}
+
+			stored, err := d.reviews.GetByID(d.review.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", stored.Status, tt.wantStatus)
+			}
+			if stored.IsPending() != (stored.ResolvedAt == nil) {
+				t.Errorf("status %q with ResolvedAt = %v", stored.Status, stored.ResolvedAt)
+			}
+
+			all, err := d.transactions.List(1, domain.TransactionFilter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(all) != tt.wantTransactions {
+				t.Errorf("got %d transactions, want %d", len(all), tt.wantTransactions)
+			}
+
+			switch tt.wantStatus {
+			case domain.ReviewStatusKept:
+				if stored.ResolvedTransactionID == nil || *stored.ResolvedTransactionID == d.existing.ID {
+					t.Errorf("ResolvedTransactionID = %v, want the new transaction", stored.ResolvedTransactionID)
+				}
+			case domain.ReviewStatusMerged:
+				if stored.ResolvedTransactionID == nil || *stored.ResolvedTransactionID != d.existing.ID {
+					t.Errorf("ResolvedTransactionID = %v, want %d", stored.ResolvedTransactionID, d.existing.ID)
+				}
+				merged, err := d.transactions.GetByID(d.existing.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if merged.Payee != "Exito" {
+					t.Errorf("merged Payee = %q, want %q", merged.Payee, "Exito")
+				}
+			}
+			if resolveErr == nil && resolved.Status != tt.wantStatus {
+				t.Errorf("returned status = %q, want %q", resolved.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestResolveReviewClaimsBeforeApplying(t *testing.T) {
+	d := newTestDuplicates(t, nil, nil, true)
+
+	if _, err := d.uc.ResolveReview(1, d.review.ID, domain.ReviewActionKeep); err != nil {
+		t.Fatalf("first ResolveReview() error = %v", err)
+	}
+	// La segunda solicitud lee la revisión como pendiente, pero no la reclama
+	_, err := d.uc.ResolveReview(1, d.review.ID, domain.ReviewActionKeep)
+	if !errors.Is(err, domain.ErrReviewResolved) {
+		t.Fatalf("second ResolveReview() error = %v, want ErrReviewResolved", err)
+	}
+
+	all, err := d.transactions.List(1, domain.TransactionFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Errorf("got %d transactions, want 2", len(all))
+	}
+	stored, err := d.reviews.GetByID(d.review.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != domain.ReviewStatusKept || stored.ResolvedTransactionID == nil {
+		t.Errorf("review = %s with transaction %v, want kept with a transaction", stored.Status, stored.ResolvedTransactionID)
+	}
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"errors"
+
+	"finanzas-api/internal/transactions/domain"
+)
+
+type TagUseCase struct {
+	tagRepo domain.TagRepository
+}
+
+func NewTagUseCase(tagRepo domain.TagRepository) domain.TagUseCase {
+	return &TagUseCase{
+		tagRepo: tagRepo,
+	}
+}
+
+// CreateTag implements domain.TagUseCase.
+func (uc *TagUseCase) CreateTag(userID uint, name string) (*domain.Tag, error) {
+	name, err := uc.validateName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.tagRepo.FindByName(userID, name); err == nil {
+		return nil, domain.ErrTagExists
+	}
+
+	return uc.tagRepo.FindOrCreate(userID, name)
+}
+
+// ListTags implements domain.TagUseCase.
+func (uc *TagUseCase) ListTags(userID uint) ([]*domain.Tag, error) {
+	return uc.tagRepo.ListByUser(userID)
+}
+
+// RenameTag implements domain.TagUseCase.
+func (uc *TagUseCase) RenameTag(userID, id uint, name string) (*domain.Tag, error) {
+	tag, err := uc.ownedTag(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err = uc.validateName(name)
+	if err != nil {
+		return nil, err
+	}
+	if name == tag.Name {
+		return tag, nil
+	}
+
+	if _, err := uc.tagRepo.FindByName(userID, name); err == nil {
+		return nil, domain.ErrTagExists
+	}
+
+	tag.Name = name
+	if err := uc.tagRepo.Update(tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// DeleteTag implements domain.TagUseCase.
+func (uc *TagUseCase) DeleteTag(userID, id uint) error {
+	if _, err := uc.ownedTag(userID, id); err != nil {
+		return err
+	}
+
+	return uc.tagRepo.Delete(id)
+}
+
+// ownedTag obtiene la etiqueta verificando que pertenezca al usuario
+func (uc *TagUseCase) ownedTag(userID, id uint) (*domain.Tag, error) {
+	if id == 0 {
+		return nil, errors.New("invalid tag ID")
+	}
+
+	tag, err := uc.tagRepo.GetByID(id)
+	if err != nil || !tag.BelongsTo(userID) {
+		return nil, domain.ErrTagNotFound
+	}
+	return tag, nil
+}
+
+// validateName normaliza el nombre y verifica que no quede vacío ni sea muy largo
+func (uc *TagUseCase) validateName(name string) (string, error) {
+	name = domain.NormalizeTagName(name)
+	if name == "" {
+		return "", errors.New("name is required")
+	}
+	if len(name) > domain.MaxTagLength {
+		return "", errors.New("tag too long")
+	}
+	return name, nil
+}
@@ -0,0 +1,402 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	accountDomain "finanzas-api/internal/accounts/domain"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	"finanzas-api/internal/transactions/domain"
+	"log"
+	"strings"
+)
+
+type TransactionUseCase struct {
+	transactionRepo domain.TransactionRepository
+	accountRepo     accountDomain.AccountRepository
+	categoryRepo    categoryDomain.CategoryRepository
+	tagRepo         domain.TagRepository
+	beforeCreate    domain.BeforeCreateHook
+	categoryChange  domain.CategoryChangeHook
+}
+
+// NewTransactionUseCase recibe los hooks opcionales (pueden ser nil) que se
+// ejecutan antes de crear y después de cambiar la categorización
+func NewTransactionUseCase(
+	transactionRepo domain.TransactionRepository,
+	accountRepo accountDomain.AccountRepository,
+	categoryRepo categoryDomain.CategoryRepository,
+	tagRepo domain.TagRepository,
+	beforeCreate domain.BeforeCreateHook,
+	categoryChange domain.CategoryChangeHook,
+) domain.TransactionUseCase {
+	return &TransactionUseCase{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		categoryRepo:    categoryRepo,
+		tagRepo:         tagRepo,
+		beforeCreate:    beforeCreate,
+		categoryChange:  categoryChange,
+	}
+}
+
+// CreateTransaction implements domain.TransactionUseCase.
+func (uc *TransactionUseCase) CreateTransaction(transaction *domain.Transaction) error {
+	if err := uc.PrepareTransaction(transaction); err != nil {
+		return err
+	}
+
+	if err := uc.transactionRepo.Create(transaction); err != nil {
+		return err
+	}
+
+	uc.NotifyCreated(transaction)
+	return nil
+}
+
+// PrepareTransaction implements domain.TransactionUseCase.
+func (uc *TransactionUseCase) PrepareTransaction(transaction *domain.Transaction) error {
+	if err := uc.ValidateTransactionData(transaction); err != nil {
+		return err
+	}
+
+	// La cuenta debe pertenecer al mismo usuario y define la moneda
+	account, err := uc.ownedAccount(transaction.UserID, transaction.AccountID)
+	if err != nil {
+		return err
+	}
+	transaction.Currency = account.Currency
+
+	// Los hooks pueden asignar la categoría, que se valida a continuación
+	uc.runBeforeCreateHooks(transaction)
+
+	if err := uc.checkCategory(transaction); err != nil {
+		return err
+	}
+
+	return uc.resolveTags(transaction)
+}
+
+// NotifyCreated implements domain.TransactionUseCase.
+func (uc *TransactionUseCase) NotifyCreated(transaction *domain.Transaction) {
+	if transaction.CategoryID != nil {
+		uc.notifyCategoryChange(nil, transaction)
+	}
+}
+
+// GetTransaction implements domain.TransactionUseCase.
+func (uc *TransactionUseCase) GetTransaction(userID, id uint) (*domain.Transaction, error) {
+	if id == 0 {
+		return nil, errors.New("invalid transaction ID")
+	}
+
+	transaction, err := uc.transactionRepo.GetByID(id)
+	if err != nil || !transaction.BelongsTo(userID) {
+		return nil, domain.ErrTransactionNotFound
+	}
+
+	return transaction, nil
+}
+
+// UpdateTransaction implements domain.TransactionUseCase.
+func (uc *TransactionUseCase) UpdateTransaction(userID uint, transaction *domain.Transaction) error {
+	if transaction.ID == 0 {
+		return errors.New("transaction ID is required")
+	}
+
+	existing, err := uc.GetTransaction(userID, transaction.ID)
+	if err != nil {
+		return err
+	}
+
+	// Las patas de un asiento solo se modifican a través del libro mayor
+	if existing.IsLedgerLeg() {
+		return errors.New("ledger transactions cannot be modified directly")
+	}
+
+	// El propietario no puede cambiar
+	transaction.UserID = userID
+
+	// Copia del estado guardado para comparar la categorización
+	previous := *existing
+
+	if err := uc.ValidateTransactionData(transaction); err != nil {
+		return err
+	}
+
+	account, err := uc.ownedAccount(userID, transaction.AccountID)
+	if err != nil {
+		return err
+	}
+
+	// El monto está en la moneda de la cuenta: moverla a una cuenta en otra
+	// moneda cambiaría su valor sin convertirlo
+	if account.Currency != existing.Currency {
+		return errors.New("cannot move a transaction to an account in another currency")
+	}
+	transaction.Currency = account.Currency
+
+	if err := uc.checkCategory(transaction); err != nil {
+		return err
+	}
+
+	if err := uc.resolveTags(transaction); err != nil {
+		return err
+	}
+
+	if err := uc.transactionRepo.Update(transaction); err != nil {
+		return err
+	}
+
+	// Update solo agrega etiquetas; Replace quita las que ya no están
+	tags := make([]*domain.Tag, len(transaction.Tags))
+	for i := range transaction.Tags {
+		tags[i] = &transaction.Tags[i]
+	}
+	if err := uc.tagRepo.Replace(transaction.ID, tags); err != nil {
+		return err
+	}
+
+	if categorizationChanged(&previous, transaction) {
+		uc.notifyCategoryChange(&previous, transaction)
+	}
+	return nil
+}
+
+// DeleteTransaction implements domain.TransactionUseCase.
+func (uc *TransactionUseCase) DeleteTransaction(userID, id uint) error {
+	transaction, err := uc.GetTransaction(userID, id)
+	if err != nil {
+		return err
+	}
+
+	if transaction.IsLedgerLeg() {
+		return errors.New("ledger transactions cannot be deleted directly")
+	}
+
+	if err := uc.transactionRepo.Delete(id); err != nil {
+		return err
+	}
+
+	if transaction.CategoryID != nil {
+		uc.notifyCategoryChange(transaction, nil)
+	}
+	return nil
+}
+
+// ListTransactions implements domain.TransactionUseCase.
+func (uc *TransactionUseCase) ListTransactions(ctx context.Context, userID uint, filter domain.TransactionFilter) ([]*domain.Transaction, error) {
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, errors.New("limit and offset must be non-negative")
+	}
+
+	// Valor por defecto para limit
+	if filter.Limit == 0 {
+		filter.Limit = 50
+	}
+
+	// Máximo 500 transacciones por página
+	if filter.Limit > 500 {
+		filter.Limit = 500
+	}
+
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return nil, errors.New("invalid date range")
+	}
+
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return nil, errors.New("invalid amount range")
+	}
+
+	if filter.AccountID != nil {
+		if _, err := uc.ownedAccount(userID, *filter.AccountID); err != nil {
+			return nil, err
+		}
+	}
+
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	transactions, err := uc.transactionRepo.WithContext(ctx).List(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// El repositorio acumula desde cero; se suma el saldo inicial de cada cuenta
+	accountRepo := uc.accountRepo.WithContext(ctx)
+	openingBalances := make(map[uint]int64)
+	for _, transaction := range transactions {
+		opening, cached := openingBalances[transaction.AccountID]
+		if !cached {
+			account, err := accountRepo.GetByID(transaction.AccountID)
+			if err != nil {
+				return nil, err
+			}
+			opening = account.OpeningBalance
+			openingBalances[transaction.AccountID] = opening
+		}
+		transaction.RunningBalance += opening
+	}
+
+	return transactions, nil
+}
+
+// GetAccountBalances implements domain.TransactionUseCase.
+func (uc *TransactionUseCase) GetAccountBalances(ctx context.Context, userID uint) ([]*domain.AccountBalance, error) {
+	accounts, err := uc.accountRepo.WithContext(ctx).ListByUser(userID, true, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := uc.transactionRepo.WithContext(ctx).TotalsByAccount(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	totalsByAccount := make(map[uint]*domain.AccountTotals, len(totals))
+	for _, t := range totals {
+		totalsByAccount[t.AccountID] = t
+	}
+
+	balances := make([]*domain.AccountBalance, 0, len(accounts))
+	for _, account := range accounts {
+		balance := &domain.AccountBalance{
+			AccountID:      account.ID,
+			Name:           account.Name,
+			Currency:       account.Currency,
+			OpeningBalance: account.OpeningBalance,
+		}
+		if t, exists := totalsByAccount[account.ID]; exists {
+			balance.Income = t.Income
+			balance.Expense = t.Expense
+		}
+		balance.Balance = balance.OpeningBalance + balance.Income - balance.Expense
+		balances = append(balances, balance)
+	}
+
+	return balances, nil
+}
+
+// ValidateTransactionData implements domain.TransactionUseCase.
+func (uc *TransactionUseCase) ValidateTransactionData(transaction *domain.Transaction) error {
+	if transaction == nil {
+		return errors.New("transaction is required")
+	}
+
+	if transaction.UserID == 0 {
+		return errors.New("user ID is required")
+	}
+
+	if transaction.AccountID == 0 {
+		return errors.New("account ID is required")
+	}
+
+	if transaction.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+
+	if !transaction.IsValidDirection() {
+		return errors.New("invalid direction")
+	}
+
+	if transaction.Date.IsZero() {
+		return errors.New("date is required")
+	}
+
+	transaction.Description = strings.TrimSpace(transaction.Description)
+	if len(transaction.Description) > 255 {
+		return errors.New("description too long")
+	}
+
+	transaction.Payee = strings.TrimSpace(transaction.Payee)
+	if len(transaction.Payee) > 255 {
+		return errors.New("payee too long")
+	}
+
+	return nil
+}
+
+// runBeforeCreateHooks aplica los hooks previos a la creación
+func (uc *TransactionUseCase) runBeforeCreateHooks(transaction *domain.Transaction) {
+	if uc.beforeCreate == nil {
+		return
+	}
+	// Los hooks completan la transacción: si uno falla se crea igual
+	if err := uc.beforeCreate(transaction); err != nil {
+		log.Printf("⚠️ Error en hook previo a crear una transacción del usuario %d: %v", transaction.UserID, err)
+	}
+}
+
+// resolveTags normaliza las etiquetas de la transacción por nombre y las
+// reemplaza por las del usuario, creando las que no existen
+func (uc *TransactionUseCase) resolveTags(transaction *domain.Transaction) error {
+	names, err := domain.NormalizeTagNames(transaction.TagNames())
+	if err != nil {
+		return err
+	}
+
+	tags := make([]domain.Tag, 0, len(names))
+	for _, name := range names {
+		tag, err := uc.tagRepo.FindOrCreate(transaction.UserID, name)
+		if err != nil {
+			return err
+		}
+		tags = append(tags, *tag)
+	}
+	transaction.Tags = tags
+	return nil
+}
+
+// notifyCategoryChange avisa a los hooks de un cambio ya guardado; sus
+// errores no deshacen la operación
+func (uc *TransactionUseCase) notifyCategoryChange(before, after *domain.Transaction) {
+	if uc.categoryChange == nil {
+		return
+	}
+	if err := uc.categoryChange(before, after); err != nil {
+		transaction := after
+		if transaction == nil {
+			transaction = before
+		}
+		log.Printf("⚠️ Error en hook de cambio de categoría del usuario %d: %v", transaction.UserID, err)
+	}
+}
+
+// categorizationChanged indica si cambió algún dato del que depende la
+// categorización de la transacción
+func categorizationChanged(before, after *domain.Transaction) bool {
+	if (before.CategoryID == nil) != (after.CategoryID == nil) {
+		return true
+	}
+	if before.CategoryID != nil && *before.CategoryID != *after.CategoryID {
+		return true
+	}
+	// Solo importa el texto de las transacciones categorizadas
+	return after.CategoryID != nil && (before.Description != after.Description || before.Payee != after.Payee)
+}
+
+// ownedAccount obtiene la cuenta verificando que pertenezca al usuario
+func (uc *TransactionUseCase) ownedAccount(userID, accountID uint) (*accountDomain.Account, error) {
+	account, err := uc.accountRepo.GetByID(accountID)
+	if err != nil || !account.BelongsTo(userID) {
+		return nil, errors.New("account not found")
+	}
+	return account, nil
+}
+
+// checkCategory verifica que la categoría sea del usuario y del mismo tipo
+// que la transacción.
+func (uc *TransactionUseCase) checkCategory(transaction *domain.Transaction) error {
+	if transaction.CategoryID == nil {
+		return nil
+	}
+
+	category, err := uc.categoryRepo.GetByID(*transaction.CategoryID)
+	if err != nil || !category.BelongsTo(transaction.UserID) {
+		return errors.New("category not found")
+	}
+
+	if string(category.Kind) != string(transaction.Direction) {
+		return errors.New("category kind does not match transaction direction")
+	}
+
+	return nil
+}
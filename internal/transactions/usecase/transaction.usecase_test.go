@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	accountRepository "finanzas-api/internal/accounts/repository"
+	categoryRepository "finanzas-api/internal/categories/repository"
+	"finanzas-api/internal/transactions/domain"
+	"finanzas-api/internal/transactions/repository"
+)
+
+// TestUpdateTransactionMovesAccount parte de una transacción en la cuenta 1
+// (COP) del usuario 1; la 2 también está en COP y la 3 en USD
+func TestUpdateTransactionMovesAccount(t *testing.T) {
+	tests := []struct {
+		name      string
+		accountID uint
+		wantErr   string
+	}{
+		{name: "same currency", accountID: 2},
+		{name: "another currency", accountID: 3, wantErr: "cannot move a transaction to an account in another currency"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts := accountRepository.NewAccountMemoryRepository()
+			for _, account := range []*accountDomain.Account{
+				{UserID: 1, Name: "Banco", Type: accountDomain.AccountTypeBank, Currency: "COP"},
+				{UserID: 1, Name: "Efectivo", Type: accountDomain.AccountTypeCash, Currency: "COP"},
+				{UserID: 1, Name: "Dólares", Type: accountDomain.AccountTypeBank, Currency: "USD"},
+			} {
+				if err := accounts.Create(account); err != nil {
+					t.Fatal(err)
+				}
+			}
+			transactions := repository.NewTransactionMemoryRepository()
+			uc := NewTransactionUseCase(transactions, accounts, categoryRepository.NewCategoryMemoryRepository(), repository.NewTagMemoryRepository(transactions), nil, nil)
+
+			date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+			transaction := &domain.Transaction{UserID: 1, AccountID: 1, Amount: 12000, Direction: domain.DirectionExpense, Description: "Mercado", Date: date}
+			if err := uc.CreateTransaction(transaction); err != nil {
+				t.Fatal(err)
+			}
+
+			update := &domain.Transaction{ID: transaction.ID, AccountID: tt.accountID, Amount: 12000, Direction: domain.DirectionExpense, Description: "Mercado", Date: date}
+			err := uc.UpdateTransaction(1, update)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("UpdateTransaction() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateTransaction() error = %v", err)
+			}
+
+			stored, err := uc.GetTransaction(1, transaction.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.AccountID != tt.accountID || stored.Currency != "COP" {
+				t.Errorf("stored account, currency = %d, %s, want %d, COP", stored.AccountID, stored.Currency, tt.accountID)
+			}
+		})
+	}
+}
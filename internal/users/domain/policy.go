@@ -0,0 +1,45 @@
+package domain
+
+import "finanzas-api/shared/authz"
+
+// ResourceUser es el tipo de recurso de los usuarios en la política
+const ResourceUser = "user"
+
+// Campos que solo quien tiene users:write puede cambiar. El email queda
+// fuera del autoservicio porque la cuenta ya está verificada con el anterior.
+const (
+	FieldIsActive = "is_active"
+	FieldEmail    = "email"
+)
+
+// Permisos que dan acceso a cualquier usuario, no solo al propio
+const (
+	permissionUsersRead  = "users:read"
+	permissionUsersWrite = "users:write"
+)
+
+// UserPolicy autoriza las acciones sobre usuarios: cada usuario puede leer y
+// editar su propio registro, salvo activarse o desactivarse y cambiar su
+// email; sobre los demás deciden los permisos users:read y users:write.
+func UserPolicy(subject authz.Subject, action authz.Action, resource authz.Resource) bool {
+	switch action {
+	case authz.ActionRead:
+		return resource.Owns(subject) || subject.Has(permissionUsersRead)
+	case authz.ActionList:
+		return subject.Has(permissionUsersRead)
+	case authz.ActionUpdate:
+		if subject.Has(permissionUsersWrite) {
+			return true
+		}
+		return resource.Owns(subject) && !resource.Changes(FieldIsActive, FieldEmail)
+	case authz.ActionCreate, authz.ActionDelete:
+		return subject.Has(permissionUsersWrite)
+	default:
+		return false
+	}
+}
+
+// AsResource describe al usuario para la política
+func (u *User) AsResource(fields ...string) authz.Resource {
+	return authz.Resource{Type: ResourceUser, ID: u.ID, OwnerID: u.ID, Fields: fields}
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+
+	"finanzas-api/shared/authz"
+)
+
+func TestUserPolicyUpdate(t *testing.T) {
+	owner := authz.Subject{UserID: 1}
+	admin := authz.Subject{UserID: 2, Permissions: []string{permissionUsersWrite}}
+	user := &User{ID: 1}
+
+	tests := []struct {
+		name    string
+		subject authz.Subject
+		fields  []string
+		want    bool
+	}{
+		{name: "owner changes the name", subject: owner, want: true},
+		{name: "owner activates", subject: owner, fields: []string{FieldIsActive}, want: false},
+		{name: "owner changes the email", subject: owner, fields: []string{FieldEmail}, want: false},
+		{name: "another user", subject: authz.Subject{UserID: 3}, want: false},
+		{name: "users:write changes the email", subject: admin, fields: []string{FieldEmail, FieldIsActive}, want: true},
+	}
+	for _, tt := range tests {
+		if got := UserPolicy(tt.subject, authz.ActionUpdate, user.AsResource(tt.fields...)); got != tt.want {
+			t.Errorf("%s: UserPolicy() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -1,26 +1,33 @@
 package domain
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"gorm.io/gorm"
 )
 
 type User struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Email     string         `json:"email" gorm:"uniqueIndex;not null"`
-	Password  string         `json:"-" gorm:"not null"` // El "-" oculta la contraseña en JSON
-	FirstName string         `json:"first_name" gorm:"not null"`
-	LastName  string         `json:"last_name" gorm:"not null"`
-	Role      string         `json:"role" gorm:"default:'user'"`
-	IsActive  bool           `json:"is_active" gorm:"default:true"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	Email           string         `json:"email" gorm:"uniqueIndex;not null"`
+	Password        string         `json:"-" gorm:"not null"` // El "-" oculta la contraseña en JSON
+	FirstName       string         `json:"first_name" gorm:"not null"`
+	LastName        string         `json:"last_name" gorm:"not null"`
+	IsActive        bool           `json:"is_active" gorm:"not null"`                                // Sin default: el registro público crea usuarios inactivos
+	Locale          string         `json:"locale" gorm:"type:varchar(5);default:'es'"`               // Idioma preferido: es | en
+	BaseCurrency    string         `json:"base_currency" gorm:"type:char(3);not null;default:'COP'"` // Moneda ISO 4217 de los reportes
+	EmailVerifiedAt *time.Time     `json:"email_verified_at"`                                        // nil mientras el usuario no confirme su email
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete
 }
 
 // UserRepository define la interfaz del repositorio de usuarios
 type UserRepository interface {
+	// WithContext retorna el repositorio con sus consultas atadas a ctx: se
+	// cancelan cuando ctx vence
+	WithContext(ctx context.Context) UserRepository
 	Create(user *User) error
 	GetByID(id uint) (*User, error)
 	GetByEmail(email string) (*User, error)
@@ -30,6 +37,17 @@ type UserRepository interface {
 	EmailExists(email string) (bool, error)
 }
 
+// ErrLastAdmin se retorna al desactivar o eliminar al último administrador
+// activo
+var ErrLastAdmin = errors.New("cannot deactivate or delete the last admin")
+
+// UserCreatedHook se ejecuta después de crear un usuario con éxito
+type UserCreatedHook func(user *User) error
+
+// BeforeDisableHook se ejecuta antes de desactivar o eliminar un usuario
+// activo; si retorna un error, el cambio no se guarda
+type BeforeDisableHook func(user *User) error
+
 type UserUseCase interface {
 	CreateUser(user *User) error
 	GetUserByID(id uint) (*User, error)
@@ -50,9 +68,14 @@ func (u *User) GetFullName() string {
 	return u.FirstName + " " + u.LastName
 }
 
+// IsEmailVerified indica si el usuario confirmó su email
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
 // IsValidForAuth verifica si el usuario puede autenticarse
 func (u *User) IsValidForAuth() bool {
-	return u.IsActive && u.DeletedAt.Time.IsZero()
+	return u.IsActive && u.IsEmailVerified() && u.DeletedAt.Time.IsZero()
 }
 
 // ValidateEmail verifica si el email tiene un formato válido
@@ -1,54 +1,65 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"finanzas-api/internal/users/domain"
+	"finanzas-api/shared/authz"
 
 	"github.com/gin-gonic/gin"
 )
 
 type UserHandler struct {
 	userUseCase domain.UserUseCase
+	policy      *authz.Policy
 }
 
-// NewUserHandler crea una nueva instancia del handler de usuarios
-func NewUserHandler(userUseCase domain.UserUseCase) *UserHandler {
+// NewUserHandler crea una nueva instancia del handler de usuarios. La
+// política decide qué puede hacer cada usuario antes de llamar al caso de uso.
+func NewUserHandler(userUseCase domain.UserUseCase, policy *authz.Policy) *UserHandler {
 	return &UserHandler{
 		userUseCase: userUseCase,
+		policy:      policy,
 	}
 }
 
 // CreateUserRequest representa la estructura de la petición para crear usuario
 type CreateUserRequest struct {
-	Email     string `json:"email" binding:"required,email"`
-	FirstName string `json:"first_name" binding:"required"`
-	LastName  string `json:"last_name" binding:"required"`
-	Password  string `json:"password" binding:"required,min=6"`
-	Role      string `json:"role" binding:"omitempty,oneof=admin user"`
+	Email        string `json:"email" binding:"required,email"`
+	FirstName    string `json:"first_name" binding:"required"`
+	LastName     string `json:"last_name" binding:"required"`
+	Password     string `json:"password" binding:"required,min=6"`
+	Locale       string `json:"locale" binding:"omitempty,oneof=es en"`
+	BaseCurrency string `json:"base_currency" binding:"omitempty,len=3"`
 }
 
 // UpdateUserRequest representa la estructura de la petición para actualizar usuario
 type UpdateUserRequest struct {
-	Email     string `json:"email" binding:"omitempty,email"`
-	FirstName string `json:"first_name" binding:"omitempty"`
-	LastName  string `json:"last_name" binding:"omitempty"`
-	IsActive  *bool  `json:"is_active" binding:"omitempty"`
-	Role      string `json:"role" binding:"omitempty,oneof=admin user"`
+	Email        string `json:"email" binding:"omitempty,email"`
+	FirstName    string `json:"first_name" binding:"omitempty"`
+	LastName     string `json:"last_name" binding:"omitempty"`
+	IsActive     *bool  `json:"is_active" binding:"omitempty"`
+	Locale       string `json:"locale" binding:"omitempty,oneof=es en"`
+	BaseCurrency string `json:"base_currency" binding:"omitempty,len=3"`
 }
 
 // UserResponse representa la respuesta de usuario (sin contraseña)
 type UserResponse struct {
-	ID        uint   `json:"id"`
-	Email     string `json:"email"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Role      string `json:"role"`
-	FullName  string `json:"full_name"`
-	IsActive  bool   `json:"is_active"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID            uint   `json:"id"`
+	Email         string `json:"email"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+	FullName      string `json:"full_name"`
+	IsActive      bool   `json:"is_active"`
+	EmailVerified bool   `json:"email_verified"`
+	Locale        string `json:"locale"`
+	BaseCurrency  string `json:"base_currency"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
 }
 
 // CreateUser maneja la creación de nuevos usuarios
@@ -62,18 +73,25 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
-	// Crear el usuario
-	user := &domain.User{
-		Email:     req.Email,
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
-		Password:  req.Password, // En un caso real, esto debería hashearse
-		Role:      req.Role,
-		IsActive:  true,
+	if !h.policy.Can(subject(c), authz.ActionCreate, authz.Resource{Type: domain.ResourceUser}) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "forbidden",
+		})
+		return
 	}
 
-	if user.Role == "" {
-		user.Role = "user"
+	// Crear el usuario. Quien lo crea responde por el email, así que no
+	// pasa por la verificación del registro público
+	now := time.Now()
+	user := &domain.User{
+		Email:           req.Email,
+		FirstName:       req.FirstName,
+		LastName:        req.LastName,
+		Password:        req.Password, // En un caso real, esto debería hashearse
+		IsActive:        true,
+		Locale:          req.Locale,
+		BaseCurrency:    req.BaseCurrency,
+		EmailVerifiedAt: &now,
 	}
 
 	if err := h.userUseCase.CreateUser(user); err != nil {
@@ -100,8 +118,9 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		return
 	}
 
+	// Un usuario ajeno responde igual que uno inexistente
 	user, err := h.userUseCase.GetUserByID(uint(id))
-	if err != nil {
+	if err != nil || !h.policy.Can(subject(c), authz.ActionRead, user.AsResource()) {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "User not found",
 		})
@@ -135,13 +154,28 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	// Obtener usuario existente
 	user, err := h.userUseCase.GetUserByID(uint(id))
-	if err != nil {
+	if err != nil || !h.policy.Can(subject(c), authz.ActionRead, user.AsResource()) {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "User not found",
 		})
 		return
 	}
 
+	// Solo cuentan los campos protegidos que realmente cambian
+	var changed []string
+	if req.IsActive != nil && *req.IsActive != user.IsActive {
+		changed = append(changed, domain.FieldIsActive)
+	}
+	if req.Email != "" && !strings.EqualFold(strings.TrimSpace(req.Email), user.Email) {
+		changed = append(changed, domain.FieldEmail)
+	}
+	if !h.policy.Can(subject(c), authz.ActionUpdate, user.AsResource(changed...)) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "only users with users:write can change is_active or email",
+		})
+		return
+	}
+
 	// Actualizar campos si se proporcionan
 	if req.Email != "" {
 		user.Email = req.Email
@@ -155,12 +189,19 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	if req.IsActive != nil {
 		user.IsActive = *req.IsActive
 	}
-	if req.Role != "" {
-		user.Role = req.Role
+	if req.Locale != "" {
+		user.Locale = req.Locale
+	}
+	if req.BaseCurrency != "" {
+		user.BaseCurrency = req.BaseCurrency
 	}
 
 	if err := h.userUseCase.UpdateUser(user); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrLastAdmin) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
 			"error": err.Error(),
 		})
 		return
@@ -183,7 +224,21 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
+	target := authz.Resource{Type: domain.ResourceUser, ID: uint(id), OwnerID: uint(id)}
+	if !h.policy.Can(subject(c), authz.ActionDelete, target) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "forbidden",
+		})
+		return
+	}
+
 	if err := h.userUseCase.DeleteUser(uint(id)); err != nil {
+		if errors.Is(err, domain.ErrLastAdmin) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "User not found",
 		})
@@ -197,6 +252,13 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 
 // ListUsers obtiene una lista de usuarios
 func (h *UserHandler) ListUsers(c *gin.Context) {
+	if !h.policy.Can(subject(c), authz.ActionList, authz.Resource{Type: domain.ResourceUser}) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "forbidden",
+		})
+		return
+	}
+
 	// Obtener parámetros de paginación
 	limitStr := c.DefaultQuery("limit", "10")
 	offsetStr := c.DefaultQuery("offset", "0")
@@ -235,17 +297,24 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 	})
 }
 
+// subject identifica al usuario autenticado para la política
+func subject(c *gin.Context) authz.Subject {
+	return authz.Subject{UserID: c.GetUint("userID"), Permissions: c.GetStringSlice("userPermissions")}
+}
+
 // toUserResponse convierte un usuario del dominio a respuesta HTTP
 func (h *UserHandler) toUserResponse(user *domain.User) UserResponse {
 	return UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Role:      user.Role,
-		FullName:  user.GetFullName(),
-		IsActive:  user.IsActive,
-		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		ID:            user.ID,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		FullName:      user.GetFullName(),
+		IsActive:      user.IsActive,
+		EmailVerified: user.IsEmailVerified(),
+		Locale:        user.Locale,
+		BaseCurrency:  user.BaseCurrency,
+		CreatedAt:     user.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:     user.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	}
 }
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"finanzas-api/internal/users/domain"
 	"sync"
@@ -22,6 +23,12 @@ func NewUserMemoryRepository() domain.UserRepository {
 	}
 }
 
+// WithContext retorna el mismo repositorio: en memoria no hay consultas que
+// cancelar
+func (r *userRepositoryMemory) WithContext(ctx context.Context) domain.UserRepository {
+	return r
+}
+
 func (r *userRepositoryMemory) Create(user *domain.User) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
@@ -37,8 +44,9 @@ func (r *userRepositoryMemory) Create(user *domain.User) error {
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = time.Now()
 
-	// Guardar usuario
-	r.users[user.ID] = user
+	// Guardar una copia del usuario
+	copied := *user
+	r.users[user.ID] = &copied
 	r.emails[user.Email] = user.ID
 
 	return nil
@@ -58,7 +66,8 @@ func (r *userRepositoryMemory) GetByID(id uint) (*domain.User, error) {
 		return nil, errors.New("user not found")
 	}
 
-	return user, nil
+	copied := *user
+	return &copied, nil
 }
 
 func (r *userRepositoryMemory) GetByEmail(email string) (*domain.User, error) {
@@ -75,7 +84,8 @@ func (r *userRepositoryMemory) GetByEmail(email string) (*domain.User, error) {
 		return nil, errors.New("user not found")
 	}
 
-	return user, nil
+	copied := *user
+	return &copied, nil
 }
 
 func (r *userRepositoryMemory) Update(user *domain.User) error {
@@ -100,7 +110,8 @@ func (r *userRepositoryMemory) Update(user *domain.User) error {
 	}
 
 	user.UpdatedAt = time.Now()
-	r.users[user.ID] = user
+	copied := *user
+	r.users[user.ID] = &copied
 
 	return nil
 }
@@ -147,7 +158,8 @@ func (r *userRepositoryMemory) List(limit, offset int) ([]*domain.User, error) {
 			break
 		}
 
-		users = append(users, user)
+		copied := *user
+		users = append(users, &copied)
 		count++
 	}
 
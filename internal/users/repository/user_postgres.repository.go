@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+
 	"finanzas-api/internal/users/domain"
 
 	"gorm.io/gorm"
@@ -14,6 +16,10 @@ func NewUserPostgresRepository(db *gorm.DB) domain.UserRepository {
 	return &userPostgresRepository{db: db}
 }
 
+func (r *userPostgresRepository) WithContext(ctx context.Context) domain.UserRepository {
+	return &userPostgresRepository{db: r.db.WithContext(ctx)}
+}
+
 func (r *userPostgresRepository) Create(user *domain.User) error {
 	return r.db.Create(user).Error
 }
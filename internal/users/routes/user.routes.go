@@ -11,20 +11,20 @@ func SetupUserRoutes(router *gin.Engine, userHandler *handler.UserHandler, authM
 	// Grupo de rutas para usuarios
 	userRoutes := router.Group("/api/v1/users")
 	{
-		// POST /api/v1/users - Crear usuario (solo admin)
-		userRoutes.POST("", authMiddleware("admin"), userHandler.CreateUser)
+		// POST /api/v1/users - Crear usuario
+		userRoutes.POST("", authMiddleware("users:write"), userHandler.CreateUser)
 
-		// GET /api/v1/users - Listar usuarios (solo admin)
-		userRoutes.GET("", authMiddleware("admin"), userHandler.ListUsers)
+		// GET /api/v1/users - Listar usuarios
+		userRoutes.GET("", authMiddleware("users:read"), userHandler.ListUsers)
 
-		// GET /api/v1/users/:id - Obtener usuario por ID
-		userRoutes.GET("/:id", authMiddleware("admin", "user"), userHandler.GetUser)
+		// GET /api/v1/users/:id - Obtener usuario por ID (sin users:read, solo el propio)
+		userRoutes.GET("/:id", authMiddleware(), userHandler.GetUser)
 
-		// PUT /api/v1/users/:id - Actualizar usuario
-		userRoutes.PUT("/:id", authMiddleware("admin", "user"), userHandler.UpdateUser)
+		// PUT /api/v1/users/:id - Actualizar usuario (sin users:write, solo el propio y sin cambiar is_active ni email)
+		userRoutes.PUT("/:id", authMiddleware(), userHandler.UpdateUser)
 
-		// DELETE /api/v1/users/:id - Eliminar usuario (solo admin)
-		userRoutes.DELETE("/:id", authMiddleware("admin"), userHandler.DeleteUser)
+		// DELETE /api/v1/users/:id - Eliminar usuario
+		userRoutes.DELETE("/:id", authMiddleware("users:write"), userHandler.DeleteUser)
 	}
 }
 
@@ -3,17 +3,24 @@ package usecase
 import (
 	"errors"
 	"finanzas-api/internal/users/domain"
+	"finanzas-api/shared/money"
 	"finanzas-api/shared/security"
+	"log"
 	"strings"
 )
 
 type UserUseCase struct {
-	userRepo domain.UserRepository
+	userRepo      domain.UserRepository
+	beforeDisable domain.BeforeDisableHook
+	createdHooks  []domain.UserCreatedHook
 }
 
-func NewUserUseCase(UserRepo domain.UserRepository) domain.UserUseCase {
+// NewUserUseCase crea el caso de uso; beforeDisable puede ser nil
+func NewUserUseCase(UserRepo domain.UserRepository, beforeDisable domain.BeforeDisableHook, createdHooks ...domain.UserCreatedHook) domain.UserUseCase {
 	return &UserUseCase{
-		userRepo: UserRepo,
+		userRepo:      UserRepo,
+		beforeDisable: beforeDisable,
+		createdHooks:  createdHooks,
 	}
 }
 
@@ -24,13 +31,22 @@ func (uc *UserUseCase) CreateUser(user *domain.User) error {
 		return err
 	}
 
-	// Verificar si el email ya existe
+	// Verificar si el email ya existe. Una cuenta que nunca confirmó su
+	// email no lo reserva: quien la vuelve a crear reemplaza sus datos y su
+	// contraseña, así no sobrevive la de quien se registró con un email ajeno.
 	exists, err := uc.userRepo.EmailExists(user.Email)
 	if err != nil {
 		return err
 	}
+	var pending *domain.User
 	if exists {
-		return errors.New("email already exists")
+		pending, err = uc.userRepo.GetByEmail(user.Email)
+		if err != nil {
+			return err
+		}
+		if pending.IsEmailVerified() {
+			return errors.New("email already exists")
+		}
 	}
 
 	hashedPassword, err := security.HashPassword(user.Password)
@@ -39,8 +55,26 @@ func (uc *UserUseCase) CreateUser(user *domain.User) error {
 	}
 	user.Password = hashedPassword
 
+	// Los hooks de alta ya corrieron para la cuenta pendiente
+	if pending != nil {
+		user.ID = pending.ID
+		user.CreatedAt = pending.CreatedAt
+		return uc.userRepo.Update(user)
+	}
+
 	// Crear usuario
-	return uc.userRepo.Create(user)
+	if err := uc.userRepo.Create(user); err != nil {
+		return err
+	}
+
+	// El usuario ya existe: un fallo en los hooks no revierte la creación
+	for _, hook := range uc.createdHooks {
+		if err := hook(user); err != nil {
+			log.Printf("⚠️ Error en hook de creación del usuario %d: %v", user.ID, err)
+		}
+	}
+
+	return nil
 }
 
 // DeleteUser implements domain.UserUseCase.
@@ -50,11 +84,17 @@ func (uc *UserUseCase) DeleteUser(id uint) error {
 	}
 
 	// Verificar que el usuario existe
-	_, err := uc.userRepo.GetByID(id)
+	user, err := uc.userRepo.GetByID(id)
 	if err != nil {
 		return err
 	}
 
+	if user.IsActive {
+		if err := uc.checkDisable(user); err != nil {
+			return err
+		}
+	}
+
 	return uc.userRepo.Delete(id)
 }
 
@@ -122,9 +162,23 @@ func (uc *UserUseCase) UpdateUser(user *domain.User) error {
 		}
 	}
 
+	if existingUser.IsActive && !user.IsActive {
+		if err := uc.checkDisable(existingUser); err != nil {
+			return err
+		}
+	}
+
 	return uc.userRepo.Update(user)
 }
 
+// checkDisable ejecuta el hook previo a desactivar o eliminar a user
+func (uc *UserUseCase) checkDisable(user *domain.User) error {
+	if uc.beforeDisable == nil {
+		return nil
+	}
+	return uc.beforeDisable(user)
+}
+
 // ValidateUserData implements domain.UserUseCase.
 func (uc *UserUseCase) ValidateUserData(user *domain.User) error {
 	if user == nil {
@@ -152,6 +206,23 @@ func (uc *UserUseCase) ValidateUserData(user *domain.User) error {
 		return errors.New("last name is required")
 	}
 
+	// Idioma por defecto
+	if user.Locale == "" {
+		user.Locale = "es"
+	}
+	if user.Locale != "es" && user.Locale != "en" {
+		return errors.New("unsupported locale")
+	}
+
+	// Moneda base por defecto
+	user.BaseCurrency = strings.ToUpper(strings.TrimSpace(user.BaseCurrency))
+	if user.BaseCurrency == "" {
+		user.BaseCurrency = "COP"
+	}
+	if !money.IsValidCurrency(user.BaseCurrency) {
+		return errors.New("invalid base currency code")
+	}
+
 	// Limpiar espacios en nombres
 	user.FirstName = strings.TrimSpace(user.FirstName)
 	user.LastName = strings.TrimSpace(user.LastName)
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"finanzas-api/internal/users/domain"
+	"finanzas-api/internal/users/repository"
+)
+
+// TestDisableLastAdmin usa un hook que protege al usuario 1, como el de roles
+// con el último administrador
+func TestDisableLastAdmin(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   uint
+		inactive bool // El usuario ya estaba desactivado
+		delete   bool // Eliminar en lugar de desactivar
+		wantErr  error
+	}{
+		{name: "deactivate the last admin", userID: 1, wantErr: domain.ErrLastAdmin},
+		{name: "delete the last admin", userID: 1, delete: true, wantErr: domain.ErrLastAdmin},
+		{name: "deactivate another user", userID: 2},
+		{name: "delete another user", userID: 2, delete: true},
+		// Un usuario inactivo no cuenta como administrador
+		{name: "delete an inactive admin", userID: 1, inactive: true, delete: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := repository.NewUserMemoryRepository()
+			for _, email := range []string{"admin@example.com", "ana@example.com"} {
+				if err := users.Create(&domain.User{Email: email, FirstName: "Ana", LastName: "Gómez", IsActive: !tt.inactive}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var checked []uint
+			uc := NewUserUseCase(users, func(user *domain.User) error {
+				checked = append(checked, user.ID)
+				if user.ID == 1 {
+					return domain.ErrLastAdmin
+				}
+				return nil
+			})
+
+			var err error
+			if tt.delete {
+				err = uc.DeleteUser(tt.userID)
+			} else {
+				user, getErr := uc.GetUserByID(tt.userID)
+				if getErr != nil {
+					t.Fatal(getErr)
+				}
+				user.IsActive = false
+				err = uc.UpdateUser(user)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if wantChecked := !tt.inactive; (len(checked) == 1) != wantChecked {
+				t.Errorf("hook ran for %v, want it to run = %v", checked, wantChecked)
+			}
+
+			// Un cambio rechazado no se guarda
+			stored, getErr := users.GetByID(tt.userID)
+			if tt.wantErr != nil && (getErr != nil || !stored.IsActive) {
+				t.Errorf("the last admin was disabled: %v", getErr)
+			}
+		})
+	}
+}
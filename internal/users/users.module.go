@@ -1,32 +1,89 @@
 package users
 
 import (
+	"fmt"
+
 	"finanzas-api/internal/users/domain"
 	"finanzas-api/internal/users/handler"
 	"finanzas-api/internal/users/repository"
 	"finanzas-api/internal/users/usecase"
+	"finanzas-api/shared/authz"
 
 	"gorm.io/gorm"
 )
 
 type UsersModule struct {
-	Handler    *handler.UserHandler
-	UseCase    domain.UserUseCase
-	repository domain.UserRepository
+	Handler      *handler.UserHandler
+	UseCase      domain.UserUseCase
+	Repository   domain.UserRepository
+	createdHooks []domain.UserCreatedHook
+	disableHooks []domain.BeforeDisableHook
 }
 
-func NewUsersModule(db *gorm.DB) *UsersModule {
+func NewUsersModule(db *gorm.DB, policy *authz.Policy) *UsersModule {
 	var userRepo domain.UserRepository
 	var userUseCase domain.UserUseCase
 	var userHandler *handler.UserHandler
 
+	// Las cuentas anteriores a la verificación de email las creó un
+	// administrador: se dan por verificadas una sola vez, al agregar la columna
+	backfillVerified := db.Migrator().HasTable(&domain.User{}) && !db.Migrator().HasColumn(&domain.User{}, "EmailVerifiedAt")
+
+	if err := db.AutoMigrate(&domain.User{}); err != nil {
+		panic(fmt.Sprintf("Error migrating users: %v", err))
+	}
+
+	if backfillVerified {
+		if err := db.Model(&domain.User{}).Where("email_verified_at IS NULL").
+			Update("email_verified_at", gorm.Expr("created_at")).Error; err != nil {
+			panic(fmt.Sprintf("Error backfilling verified emails: %v", err))
+		}
+	}
+
+	policy.Register(domain.ResourceUser, domain.UserPolicy)
+
+	module := &UsersModule{}
+
 	userRepo = repository.NewUserPostgresRepository(db)
-	userUseCase = usecase.NewUserUseCase(userRepo)
-	userHandler = handler.NewUserHandler(userUseCase)
+	userUseCase = usecase.NewUserUseCase(userRepo, module.runDisableHooks, module.runCreatedHooks)
+	userHandler = handler.NewUserHandler(userUseCase, policy)
+
+	module.Handler = userHandler
+	module.UseCase = userUseCase
+	module.Repository = userRepo
+	return module
+}
+
+// OnUserCreated registra un hook que se ejecuta tras crear un usuario.
+// Permite que módulos creados después (p. ej. categorías) reaccionen al alta.
+func (m *UsersModule) OnUserCreated(hook domain.UserCreatedHook) {
+	m.createdHooks = append(m.createdHooks, hook)
+}
+
+// runCreatedHooks ejecuta en orden los hooks registrados
+func (m *UsersModule) runCreatedHooks(user *domain.User) error {
+	for _, hook := range m.createdHooks {
+		if err := hook(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OnBeforeDisable registra un hook que se ejecuta antes de desactivar o
+// eliminar un usuario activo y puede impedirlo (p. ej. roles, para no
+// quedarse sin administradores).
+func (m *UsersModule) OnBeforeDisable(hook domain.BeforeDisableHook) {
+	m.disableHooks = append(m.disableHooks, hook)
+}
 
-	return &UsersModule{
-		Handler:    userHandler,
-		UseCase:    userUseCase,
-		repository: userRepo,
+// runDisableHooks ejecuta en orden los hooks registrados; el primer error
+// detiene el cambio
+func (m *UsersModule) runDisableHooks(user *domain.User) error {
+	for _, hook := range m.disableHooks {
+		if err := hook(user); err != nil {
+			return err
+		}
 	}
+	return nil
 }
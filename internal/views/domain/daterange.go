@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// ErrInvalidDateRange se retorna cuando la expresión de periodo no se reconoce
+var ErrInvalidDateRange = errors.New(`invalid date range, expected e.g. "last 90 days", "this month" or "last year"`)
+
+var accentFolder = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ñ", "n")
+
+// ParseDateRange evalúa una expresión de periodo en inglés o español respecto
+// a today y retorna el rango inclusivo de fechas. Acepta:
+//
+//	today | hoy, yesterday | ayer
+//	last N days|weeks|months|years | ultimos N dias|semanas|meses|años
+//	this week|month|year | esta semana, este mes|año (hasta hoy)
+//	last week|month|year | semana|mes|año pasado (el periodo completo)
+//	year to date (igual que this year)
+//
+// Los periodos "last N" terminan hoy: "last 90 days" son hoy y los 89 días
+// anteriores. Las semanas empiezan el lunes.
+func ParseDateRange(expression string, today time.Time) (time.Time, time.Time, error) {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	words := strings.Fields(accentFolder.Replace(strings.ToLower(expression)))
+	phrase := strings.Join(words, " ")
+
+	switch phrase {
+	case "today", "hoy":
+		return today, today, nil
+	case "yesterday", "ayer":
+		yesterday := today.AddDate(0, 0, -1)
+		return yesterday, yesterday, nil
+	case "this week", "esta semana":
+		return startOfWeek(today), today, nil
+	case "this month", "este mes":
+		return startOfMonth(today), today, nil
+	case "this year", "este ano", "year to date":
+		return startOfYear(today), today, nil
+	case "last week", "semana pasada", "la semana pasada":
+		start := startOfWeek(today).AddDate(0, 0, -7)
+		return start, start.AddDate(0, 0, 6), nil
+	case "last month", "mes pasado", "el mes pasado":
+		start := startOfMonth(today).AddDate(0, -1, 0)
+		return start, startOfMonth(today).AddDate(0, 0, -1), nil
+	case "last year", "ano pasado", "el ano pasado":
+		start := startOfYear(today).AddDate(-1, 0, 0)
+		return start, startOfYear(today).AddDate(0, 0, -1), nil
+	}
+
+	// last N <unidad> | ultimos N <unidad>
+	if len(words) != 3 || (words[0] != "last" && words[0] != "ultimos" && words[0] != "ultimas") {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+	n, err := strconv.Atoi(words[1])
+	if err != nil || n <= 0 || n > 3650 {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+
+	var start time.Time
+	switch words[2] {
+	case "day", "days", "dia", "dias":
+		start = today.AddDate(0, 0, -(n - 1))
+	case "week", "weeks", "semana", "semanas":
+		start = today.AddDate(0, 0, -(7*n - 1))
+	case "month", "months", "mes", "meses":
+		start = today.AddDate(0, -n, 1)
+	case "year", "years", "ano", "anos":
+		start = today.AddDate(-n, 0, 1)
+	default:
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+	return start, today, nil
+}
+
+func startOfWeek(day time.Time) time.Time {
+	// time.Sunday es 0: se corre para que el lunes sea el primer día
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func startOfMonth(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func startOfYear(day time.Time) time.Time {
+	return time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+}
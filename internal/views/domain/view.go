@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	userDomain "finanzas-api/internal/users/domain"
+
+	"gorm.io/gorm"
+)
+
+// ErrViewNotFound se retorna cuando la vista no existe o es de otro usuario
+var ErrViewNotFound = errors.New("view not found")
+
+// Definition son los filtros de una vista. Todos son opcionales; las listas
+// coinciden con cualquiera de sus valores. El periodo se define con una
+// expresión relativa (DateRange) o con fechas fijas (From, To), no ambas.
+type Definition struct {
+	Tags        []string                    `json:"tags,omitempty"`
+	CategoryIDs []uint                      `json:"category_ids,omitempty"`
+	AccountIDs  []uint                      `json:"account_ids,omitempty"`
+	Direction   transactionDomain.Direction `json:"direction,omitempty"`
+	MinAmount   *int64                      `json:"min_amount,omitempty"` // En unidades menores, inclusivo
+	MaxAmount   *int64                      `json:"max_amount,omitempty"`
+	Query       string                      `json:"query,omitempty"`      // Contenido en la descripción o el comercio
+	DateRange   string                      `json:"date_range,omitempty"` // P. ej. "last 90 days" o "este mes"
+	From        string                      `json:"from,omitempty"`       // YYYY-MM-DD
+	To          string                      `json:"to,omitempty"`
+}
+
+// SmartView es una definición de filtros guardada por el usuario. Se evalúa
+// cada vez que se consulta, así que las fechas relativas avanzan solas.
+type SmartView struct {
+	ID         uint            `json:"id" gorm:"primaryKey"`
+	UserID     uint            `json:"user_id" gorm:"not null;index"`
+	User       userDomain.User `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Name       string          `json:"name" gorm:"type:varchar(100);not null"`
+	Definition Definition      `json:"filter" gorm:"type:jsonb;serializer:json;not null"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt  `json:"-" gorm:"index"` // Soft delete
+}
+
+// ViewResult son las transacciones de una vista y sus totales
+type ViewResult struct {
+	View         *SmartView
+	From         *time.Time // Periodo evaluado; nil si la vista no lo limita
+	To           *time.Time
+	Transactions []*transactionDomain.Transaction
+	Totals       []*transactionDomain.CurrencyTotals // Por moneda, de todas las transacciones de la vista
+}
+
+// ViewRepository define la interfaz del repositorio de vistas
+type ViewRepository interface {
+	Create(view *SmartView) error
+	GetByID(id uint) (*SmartView, error)
+	Update(view *SmartView) error
+	Delete(id uint) error
+	// ListByUser retorna las vistas ordenadas por nombre
+	ListByUser(userID uint) ([]*SmartView, error)
+}
+
+type ViewUseCase interface {
+	CreateView(view *SmartView) error
+	GetView(userID, id uint) (*SmartView, error)
+	UpdateView(userID uint, view *SmartView) error
+	DeleteView(userID, id uint) error
+	ListViews(userID uint) ([]*SmartView, error)
+	// RunView retorna una página de las transacciones de la vista y los
+	// totales de todas ellas
+	RunView(userID, id uint, limit, offset int) (*ViewResult, error)
+	// ResolveView traduce la vista a criterios de selección con las fechas
+	// relativas evaluadas hoy; la usan reportes y exportaciones
+	ResolveView(userID, id uint) (*transactionDomain.Criteria, error)
+	ValidateViewData(view *SmartView) error
+}
+
+// TableName especifica el nombre de la tabla en la base de datos
+func (SmartView) TableName() string {
+	return "smart_views"
+}
+
+// BelongsTo verifica si la vista pertenece al usuario indicado
+func (v *SmartView) BelongsTo(userID uint) bool {
+	return v.UserID == userID
+}
+
+// Criteria traduce la definición a criterios de selección evaluando el
+// periodo respecto a today
+func (d *Definition) Criteria(today time.Time) (*transactionDomain.Criteria, error) {
+	criteria := &transactionDomain.Criteria{
+		AccountIDs:  d.AccountIDs,
+		CategoryIDs: d.CategoryIDs,
+		Tags:        d.Tags,
+		Direction:   d.Direction,
+		MinAmount:   d.MinAmount,
+		MaxAmount:   d.MaxAmount,
+		Query:       d.Query,
+	}
+
+	if d.DateRange != "" {
+		from, to, err := ParseDateRange(d.DateRange, today)
+		if err != nil {
+			return nil, err
+		}
+		criteria.From, criteria.To = &from, &to
+		return criteria, nil
+	}
+
+	if d.From != "" {
+		from, err := time.Parse(dateLayout, d.From)
+		if err != nil {
+			return nil, errors.New("invalid from date, expected YYYY-MM-DD")
+		}
+		criteria.From = &from
+	}
+	if d.To != "" {
+		to, err := time.Parse(dateLayout, d.To)
+		if err != nil {
+			return nil, errors.New("invalid to date, expected YYYY-MM-DD")
+		}
+		criteria.To = &to
+	}
+	return criteria, nil
+}
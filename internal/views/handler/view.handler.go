@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	"finanzas-api/internal/views/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+type ViewHandler struct {
+	viewUseCase domain.ViewUseCase
+}
+
+// NewViewHandler crea una nueva instancia del handler de vistas
+func NewViewHandler(viewUseCase domain.ViewUseCase) *ViewHandler {
+	return &ViewHandler{
+		viewUseCase: viewUseCase,
+	}
+}
+
+// ViewRequest representa la estructura de la petición para crear o
+// reemplazar una vista
+type ViewRequest struct {
+	Name   string            `json:"name" binding:"required"`
+	Filter domain.Definition `json:"filter"`
+}
+
+// ViewResponse representa la respuesta de una vista
+type ViewResponse struct {
+	ID        uint              `json:"id"`
+	Name      string            `json:"name"`
+	Filter    domain.Definition `json:"filter"`
+	CreatedAt string            `json:"created_at"`
+	UpdatedAt string            `json:"updated_at"`
+}
+
+// ViewTransactionResponse representa una transacción dentro de una vista
+type ViewTransactionResponse struct {
+	ID          uint     `json:"id"`
+	AccountID   uint     `json:"account_id"`
+	CategoryID  *uint    `json:"category_id"`
+	Amount      int64    `json:"amount"`
+	Currency    string   `json:"currency"`
+	Direction   string   `json:"direction"`
+	Date        string   `json:"date"`
+	Description string   `json:"description"`
+	Payee       string   `json:"payee,omitempty"`
+	IsTransfer  bool     `json:"is_transfer"`
+	Tags        []string `json:"tags"`
+}
+
+// TotalsResponse resume las transacciones de la vista en una moneda
+type TotalsResponse struct {
+	Currency string `json:"currency"`
+	Count    int    `json:"count"`
+	Income   int64  `json:"income"` // Sin transferencias
+	Expenses int64  `json:"expenses"`
+	Net      int64  `json:"net"`
+}
+
+// CreateView crea una vista del usuario autenticado
+func (h *ViewHandler) CreateView(c *gin.Context) {
+	var req ViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	view := &domain.SmartView{
+		UserID:     c.GetUint("userID"),
+		Name:       req.Name,
+		Definition: req.Filter,
+	}
+	if err := h.viewUseCase.CreateView(view); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "View created successfully",
+		"view":    h.toViewResponse(view),
+	})
+}
+
+// ListViews lista las vistas del usuario autenticado
+func (h *ViewHandler) ListViews(c *gin.Context) {
+	views, err := h.viewUseCase.ListViews(c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list views",
+		})
+		return
+	}
+
+	responses := make([]ViewResponse, len(views))
+	for i, view := range views {
+		responses[i] = h.toViewResponse(view)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"views": responses,
+	})
+}
+
+// GetView obtiene una vista del usuario autenticado
+func (h *ViewHandler) GetView(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid view ID",
+		})
+		return
+	}
+
+	view, err := h.viewUseCase.GetView(c.GetUint("userID"), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "View not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"view": h.toViewResponse(view),
+	})
+}
+
+// UpdateView reemplaza el nombre y los filtros de una vista
+func (h *ViewHandler) UpdateView(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid view ID",
+		})
+		return
+	}
+
+	var req ViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	view, err := h.viewUseCase.GetView(userID, uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "View not found",
+		})
+		return
+	}
+
+	view.Name = req.Name
+	view.Definition = req.Filter
+	if err := h.viewUseCase.UpdateView(userID, view); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "View updated successfully",
+		"view":    h.toViewResponse(view),
+	})
+}
+
+// DeleteView elimina una vista del usuario autenticado
+func (h *ViewHandler) DeleteView(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid view ID",
+		})
+		return
+	}
+
+	if err := h.viewUseCase.DeleteView(c.GetUint("userID"), uint(id)); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrViewNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "View deleted successfully",
+	})
+}
+
+// GetViewTransactions evalúa la vista y retorna una página de sus
+// transacciones con los totales de todas ellas. Query params: limit, offset.
+func (h *ViewHandler) GetViewTransactions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid view ID",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 0 {
+		limit = 50
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	result, err := h.viewUseCase.RunView(c.GetUint("userID"), uint(id), limit, offset)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrViewNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	transactions := make([]ViewTransactionResponse, len(result.Transactions))
+	for i, transaction := range result.Transactions {
+		transactions[i] = h.toTransactionResponse(transaction)
+	}
+	totals := make([]TotalsResponse, len(result.Totals))
+	for i, t := range result.Totals {
+		totals[i] = TotalsResponse{
+			Currency: t.Currency,
+			Count:    t.Count,
+			Income:   t.Income,
+			Expenses: t.Expenses,
+			Net:      t.Income - t.Expenses,
+		}
+	}
+
+	period := gin.H{"from": nil, "to": nil}
+	if result.From != nil {
+		period["from"] = result.From.Format(dateLayout)
+	}
+	if result.To != nil {
+		period["to"] = result.To.Format(dateLayout)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"view":         h.toViewResponse(result.View),
+		"period":       period,
+		"transactions": transactions,
+		"totals":       totals,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"count":  len(transactions),
+		},
+	})
+}
+
+// toViewResponse convierte una vista del dominio a respuesta HTTP
+func (h *ViewHandler) toViewResponse(view *domain.SmartView) ViewResponse {
+	return ViewResponse{
+		ID:        view.ID,
+		Name:      view.Name,
+		Filter:    view.Definition,
+		CreatedAt: view.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: view.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// toTransactionResponse convierte una transacción de la vista a respuesta HTTP
+func (h *ViewHandler) toTransactionResponse(transaction *transactionDomain.Transaction) ViewTransactionResponse {
+	return ViewTransactionResponse{
+		ID:          transaction.ID,
+		AccountID:   transaction.AccountID,
+		CategoryID:  transaction.CategoryID,
+		Amount:      transaction.Amount,
+		Currency:    transaction.Currency,
+		Direction:   string(transaction.Direction),
+		Date:        transaction.Date.Format(dateLayout),
+		Description: transaction.Description,
+		Payee:       transaction.Payee,
+		IsTransfer:  transaction.IsTransfer,
+		Tags:        transaction.TagNames(),
+	}
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "finanzas-api/internal/views/domain"
+
+type ViewRepository interface {
+	domain.ViewRepository
+}
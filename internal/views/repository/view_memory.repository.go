@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"finanzas-api/internal/views/domain"
+)
+
+type viewRepositoryMemory struct {
+	views  map[uint]*domain.SmartView
+	nextID uint
+	mutex  sync.RWMutex
+}
+
+func NewViewMemoryRepository() domain.ViewRepository {
+	return &viewRepositoryMemory{
+		views:  make(map[uint]*domain.SmartView),
+		nextID: 1,
+	}
+}
+
+func (r *viewRepositoryMemory) Create(view *domain.SmartView) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Asignar ID y timestamps
+	view.ID = r.nextID
+	r.nextID++
+	view.CreatedAt = time.Now()
+	view.UpdatedAt = time.Now()
+
+	copied := *view
+	r.views[view.ID] = &copied
+	return nil
+}
+
+func (r *viewRepositoryMemory) GetByID(id uint) (*domain.SmartView, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	view, exists := r.views[id]
+	if !exists || !view.DeletedAt.Time.IsZero() {
+		return nil, errors.New("view not found")
+	}
+
+	copied := *view
+	return &copied, nil
+}
+
+func (r *viewRepositoryMemory) Update(view *domain.SmartView) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existing, exists := r.views[view.ID]
+	if !exists || !existing.DeletedAt.Time.IsZero() {
+		return errors.New("view not found")
+	}
+
+	view.UpdatedAt = time.Now()
+	copied := *view
+	r.views[view.ID] = &copied
+	return nil
+}
+
+func (r *viewRepositoryMemory) Delete(id uint) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	view, exists := r.views[id]
+	if !exists || !view.DeletedAt.Time.IsZero() {
+		return errors.New("view not found")
+	}
+
+	// Soft delete
+	view.DeletedAt.Time = time.Now()
+	view.DeletedAt.Valid = true
+	view.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (r *viewRepositoryMemory) ListByUser(userID uint) ([]*domain.SmartView, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var views []*domain.SmartView
+	for _, view := range r.views {
+		if view.DeletedAt.Time.IsZero() && view.UserID == userID {
+			copied := *view
+			views = append(views, &copied)
+		}
+	}
+
+	sort.Slice(views, func(i, j int) bool {
+		if views[i].Name != views[j].Name {
+			return views[i].Name < views[j].Name
+		}
+		return views[i].ID < views[j].ID
+	})
+	return views, nil
+}
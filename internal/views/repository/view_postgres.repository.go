@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"finanzas-api/internal/views/domain"
+
+	"gorm.io/gorm"
+)
+
+type viewPostgresRepository struct {
+	db *gorm.DB
+}
+
+func NewViewPostgresRepository(db *gorm.DB) domain.ViewRepository {
+	return &viewPostgresRepository{db: db}
+}
+
+func (r *viewPostgresRepository) Create(view *domain.SmartView) error {
+	return r.db.Create(view).Error
+}
+
+func (r *viewPostgresRepository) GetByID(id uint) (*domain.SmartView, error) {
+	var view domain.SmartView
+	if err := r.db.First(&view, id).Error; err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+func (r *viewPostgresRepository) Update(view *domain.SmartView) error {
+	return r.db.Save(view).Error
+}
+
+func (r *viewPostgresRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.SmartView{}, id).Error // soft delete
+}
+
+func (r *viewPostgresRepository) ListByUser(userID uint) ([]*domain.SmartView, error) {
+	var views []*domain.SmartView
+	if err := r.db.Where("user_id = ?", userID).Order("name, id").Find(&views).Error; err != nil {
+		return nil, err
+	}
+	return views, nil
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"finanzas-api/internal/views/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupViewRoutes configura las rutas para el módulo de vistas guardadas
+func SetupViewRoutes(router *gin.Engine, viewHandler *handler.ViewHandler, authMiddleware func(...string) gin.HandlerFunc) {
+	viewRoutes := router.Group("/api/v1/views")
+	{
+		// POST /api/v1/views - Crear vista
+		viewRoutes.POST("", authMiddleware("views:write"), viewHandler.CreateView)
+		// GET /api/v1/views - Listar vistas
+		viewRoutes.GET("", authMiddleware("views:read"), viewHandler.ListViews)
+		// GET /api/v1/views/:id - Obtener vista
+		viewRoutes.GET("/:id", authMiddleware("views:read"), viewHandler.GetView)
+		// GET /api/v1/views/:id/transactions - Transacciones y totales de la vista
+		viewRoutes.GET("/:id/transactions", authMiddleware("views:read"), viewHandler.GetViewTransactions)
+		// PUT /api/v1/views/:id - Reemplazar vista
+		viewRoutes.PUT("/:id", authMiddleware("views:write"), viewHandler.UpdateView)
+		// DELETE /api/v1/views/:id - Eliminar vista
+		viewRoutes.DELETE("/:id", authMiddleware("views:write"), viewHandler.DeleteView)
+	}
+}
@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	"finanzas-api/internal/views/domain"
+)
+
+type ViewUseCase struct {
+	viewRepo        domain.ViewRepository
+	transactionRepo transactionDomain.TransactionRepository
+	accountRepo     accountDomain.AccountRepository
+	categoryRepo    categoryDomain.CategoryRepository
+}
+
+func NewViewUseCase(
+	viewRepo domain.ViewRepository,
+	transactionRepo transactionDomain.TransactionRepository,
+	accountRepo accountDomain.AccountRepository,
+	categoryRepo categoryDomain.CategoryRepository,
+) domain.ViewUseCase {
+	return &ViewUseCase{
+		viewRepo:        viewRepo,
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		categoryRepo:    categoryRepo,
+	}
+}
+
+// CreateView implements domain.ViewUseCase.
+func (uc *ViewUseCase) CreateView(view *domain.SmartView) error {
+	if err := uc.ValidateViewData(view); err != nil {
+		return err
+	}
+
+	return uc.viewRepo.Create(view)
+}
+
+// GetView implements domain.ViewUseCase.
+func (uc *ViewUseCase) GetView(userID, id uint) (*domain.SmartView, error) {
+	if id == 0 {
+		return nil, errors.New("invalid view ID")
+	}
+
+	view, err := uc.viewRepo.GetByID(id)
+	if err != nil || !view.BelongsTo(userID) {
+		return nil, domain.ErrViewNotFound
+	}
+
+	return view, nil
+}
+
+// UpdateView implements domain.ViewUseCase.
+func (uc *ViewUseCase) UpdateView(userID uint, view *domain.SmartView) error {
+	if view.ID == 0 {
+		return errors.New("view ID is required")
+	}
+
+	existing, err := uc.GetView(userID, view.ID)
+	if err != nil {
+		return err
+	}
+
+	// El propietario no puede cambiar
+	view.UserID = userID
+
+	if err := uc.validateViewData(view, existing.Definition.CategoryIDs); err != nil {
+		return err
+	}
+
+	return uc.viewRepo.Update(view)
+}
+
+// DeleteView implements domain.ViewUseCase.
+func (uc *ViewUseCase) DeleteView(userID, id uint) error {
+	if _, err := uc.GetView(userID, id); err != nil {
+		return err
+	}
+
+	return uc.viewRepo.Delete(id)
+}
+
+// ListViews implements domain.ViewUseCase.
+func (uc *ViewUseCase) ListViews(userID uint) ([]*domain.SmartView, error) {
+	return uc.viewRepo.ListByUser(userID)
+}
+
+// RunView implements domain.ViewUseCase.
+func (uc *ViewUseCase) RunView(userID, id uint, limit, offset int) (*domain.ViewResult, error) {
+	if limit < 0 || offset < 0 {
+		return nil, errors.New("limit and offset must be non-negative")
+	}
+
+	// Valor por defecto para limit
+	if limit == 0 {
+		limit = 50
+	}
+
+	// Máximo 500 transacciones por página
+	if limit > 500 {
+		limit = 500
+	}
+
+	view, err := uc.GetView(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria, err := uc.criteria(view)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := uc.transactionRepo.List(userID, transactionDomain.TransactionFilter{
+		Criteria: criteria,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := uc.transactionRepo.Totals(userID, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ViewResult{
+		View:         view,
+		From:         criteria.From,
+		To:           criteria.To,
+		Transactions: transactions,
+		Totals:       totals,
+	}, nil
+}
+
+// ResolveView implements domain.ViewUseCase.
+func (uc *ViewUseCase) ResolveView(userID, id uint) (*transactionDomain.Criteria, error) {
+	view, err := uc.GetView(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.criteria(view)
+}
+
+// ValidateViewData implements domain.ViewUseCase.
+func (uc *ViewUseCase) ValidateViewData(view *domain.SmartView) error {
+	return uc.validateViewData(view, nil)
+}
+
+// validateViewData valida la vista aceptando sin buscarlas las categorías de
+// storedCategoryIDs, que la vista ya tenía guardadas: si se eliminaron después,
+// editar la vista no obliga a quitarlas.
+func (uc *ViewUseCase) validateViewData(view *domain.SmartView, storedCategoryIDs []uint) error {
+	if view == nil {
+		return errors.New("view is required")
+	}
+
+	if view.UserID == 0 {
+		return errors.New("user ID is required")
+	}
+
+	view.Name = strings.TrimSpace(view.Name)
+	if view.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(view.Name) > 100 {
+		return errors.New("name too long")
+	}
+
+	// El nombre no se repite entre las vistas del usuario
+	views, err := uc.viewRepo.ListByUser(view.UserID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range views {
+		if existing.ID != view.ID && strings.EqualFold(existing.Name, view.Name) {
+			return errors.New("view name already in use")
+		}
+	}
+
+	definition := &view.Definition
+
+	tags, err := transactionDomain.NormalizeTagNames(definition.Tags)
+	if err != nil {
+		return err
+	}
+	definition.Tags = tags
+
+	definition.AccountIDs = uniqueIDs(definition.AccountIDs)
+	for _, accountID := range definition.AccountIDs {
+		account, err := uc.accountRepo.GetByID(accountID)
+		if err != nil || !account.BelongsTo(view.UserID) {
+			return errors.New("account not found")
+		}
+	}
+
+	stored := make(map[uint]bool, len(storedCategoryIDs))
+	for _, categoryID := range storedCategoryIDs {
+		stored[categoryID] = true
+	}
+	definition.CategoryIDs = uniqueIDs(definition.CategoryIDs)
+	for _, categoryID := range definition.CategoryIDs {
+		if stored[categoryID] {
+			continue
+		}
+		category, err := uc.categoryRepo.GetByID(categoryID)
+		if err != nil || !category.BelongsTo(view.UserID) {
+			return errors.New("category not found")
+		}
+	}
+
+	if definition.Direction != "" && definition.Direction != transactionDomain.DirectionIncome && definition.Direction != transactionDomain.DirectionExpense {
+		return errors.New("invalid direction")
+	}
+
+	if (definition.MinAmount != nil && *definition.MinAmount < 0) || (definition.MaxAmount != nil && *definition.MaxAmount < 0) {
+		return errors.New("amounts must be non-negative")
+	}
+	if definition.MinAmount != nil && definition.MaxAmount != nil && *definition.MinAmount > *definition.MaxAmount {
+		return errors.New("invalid amount range")
+	}
+
+	definition.Query = strings.TrimSpace(definition.Query)
+	if len(definition.Query) > 255 {
+		return errors.New("query too long")
+	}
+
+	definition.DateRange = strings.TrimSpace(definition.DateRange)
+	if definition.DateRange != "" && (definition.From != "" || definition.To != "") {
+		return errors.New("use either date_range or from/to, not both")
+	}
+
+	// Evaluar el periodo hoy detecta expresiones y fechas inválidas
+	criteria, err := definition.Criteria(time.Now())
+	if err != nil {
+		return err
+	}
+	if criteria.From != nil && criteria.To != nil && criteria.From.After(*criteria.To) {
+		return errors.New("invalid date range")
+	}
+
+	return nil
+}
+
+// criteria evalúa la vista hoy. Las categorías incluyen sus subcategorías,
+// como en los reportes, que agrupan por categoría raíz.
+func (uc *ViewUseCase) criteria(view *domain.SmartView) (*transactionDomain.Criteria, error) {
+	criteria, err := view.Definition.Criteria(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(criteria.CategoryIDs) > 0 {
+		categories, err := uc.categoryRepo.ListByUser(view.UserID)
+		if err != nil {
+			return nil, err
+		}
+		criteria.CategoryIDs = withDescendants(criteria.CategoryIDs, categories)
+	}
+
+	return criteria, nil
+}
+
+// withDescendants agrega a ids todas las categorías que descienden de ellas
+func withDescendants(ids []uint, categories []*categoryDomain.Category) []uint {
+	children := make(map[uint][]uint)
+	for _, category := range categories {
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+		}
+	}
+
+	included := make(map[uint]bool)
+	result := make([]uint, 0, len(ids))
+	pending := append([]uint(nil), ids...)
+	for len(pending) > 0 {
+		id := pending[0]
+		pending = pending[1:]
+		if included[id] {
+			continue
+		}
+		included[id] = true
+		result = append(result, id)
+		pending = append(pending, children[id]...)
+	}
+	return result
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
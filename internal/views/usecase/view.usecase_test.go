@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	accountRepository "finanzas-api/internal/accounts/repository"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	categoryRepository "finanzas-api/internal/categories/repository"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	transactionRepository "finanzas-api/internal/transactions/repository"
+	"finanzas-api/internal/views/domain"
+	"finanzas-api/internal/views/repository"
+)
+
+// TestUpdateViewWithDeletedCategory cubre una vista guardada con las
+// categorías 1 y 2 del usuario 1 después de eliminar la 1. La categoría 3
+// también se elimina, pero la vista nunca la tuvo.
+func TestUpdateViewWithDeletedCategory(t *testing.T) {
+	tests := []struct {
+		name        string
+		categoryIDs []uint
+		wantErr     string
+	}{
+		{name: "keeps the deleted category it already had", categoryIDs: []uint{1, 2}},
+		{name: "drops the deleted category", categoryIDs: []uint{2}},
+		{name: "adds another deleted category", categoryIDs: []uint{1, 3}, wantErr: "category not found"},
+		{name: "adds a category of another user", categoryIDs: []uint{1, 4}, wantErr: "category not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categories := categoryRepository.NewCategoryMemoryRepository()
+			for _, category := range []*categoryDomain.Category{
+				{UserID: 1, Name: "Mercado", Kind: categoryDomain.KindExpense},
+				{UserID: 1, Name: "Hogar", Kind: categoryDomain.KindExpense},
+				{UserID: 1, Name: "Mascotas", Kind: categoryDomain.KindExpense},
+				{UserID: 2, Name: "Ajena", Kind: categoryDomain.KindExpense},
+			} {
+				if err := categories.Create(category); err != nil {
+					t.Fatal(err)
+				}
+			}
+			views := repository.NewViewMemoryRepository()
+			uc := NewViewUseCase(views, transactionRepository.NewTransactionMemoryRepository(), accountRepository.NewAccountMemoryRepository(), categories)
+
+			view := &domain.SmartView{UserID: 1, Name: "Casa", Definition: domain.Definition{CategoryIDs: []uint{1, 2}}}
+			if err := uc.CreateView(view); err != nil {
+				t.Fatal(err)
+			}
+			for _, id := range []uint{1, 3} {
+				if err := categories.Delete(id); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			update := &domain.SmartView{ID: view.ID, Name: "Casa y mercado", Definition: domain.Definition{CategoryIDs: tt.categoryIDs}}
+			err := uc.UpdateView(1, update)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("UpdateView() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateView() error = %v", err)
+			}
+		})
+	}
+}
+
+// TestRunViewWithDeletedCategory comprueba que una vista cuya única categoría
+// se eliminó no pasa a mostrar las transacciones de todas las categorías
+func TestRunViewWithDeletedCategory(t *testing.T) {
+	accounts := accountRepository.NewAccountMemoryRepository()
+	if err := accounts.Create(&accountDomain.Account{UserID: 1, Name: "Banco", Type: accountDomain.AccountTypeBank, Currency: "COP"}); err != nil {
+		t.Fatal(err)
+	}
+	categories := categoryRepository.NewCategoryMemoryRepository()
+	for _, category := range []*categoryDomain.Category{
+		{UserID: 1, Name: "Mascotas", Kind: categoryDomain.KindExpense},
+		{UserID: 1, Name: "Mercado", Kind: categoryDomain.KindExpense},
+	} {
+		if err := categories.Create(category); err != nil {
+			t.Fatal(err)
+		}
+	}
+	transactions := transactionRepository.NewTransactionMemoryRepository()
+	mercado := uint(2)
+	if err := transactions.Create(&transactionDomain.Transaction{UserID: 1, AccountID: 1, CategoryID: &mercado, Amount: 12000, Currency: "COP", Direction: transactionDomain.DirectionExpense, Description: "Mercado", Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}); err != nil {
+		t.Fatal(err)
+	}
+	uc := NewViewUseCase(repository.NewViewMemoryRepository(), transactions, accounts, categories)
+
+	view := &domain.SmartView{UserID: 1, Name: "Mascotas", Definition: domain.Definition{CategoryIDs: []uint{1}}}
+	if err := uc.CreateView(view); err != nil {
+		t.Fatal(err)
+	}
+	if err := categories.Delete(1); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := uc.RunView(1, view.ID, 0, 0)
+	if err != nil {
+		t.Fatalf("RunView() error = %v", err)
+	}
+	if len(result.Transactions) != 0 {
+		t.Errorf("got %d transactions, want 0", len(result.Transactions))
+	}
+}
@@ -0,0 +1,46 @@
+package views
+
+import (
+	"fmt"
+
+	accountDomain "finanzas-api/internal/accounts/domain"
+	categoryDomain "finanzas-api/internal/categories/domain"
+	transactionDomain "finanzas-api/internal/transactions/domain"
+	"finanzas-api/internal/views/domain"
+	"finanzas-api/internal/views/handler"
+	"finanzas-api/internal/views/repository"
+	"finanzas-api/internal/views/usecase"
+
+	"gorm.io/gorm"
+)
+
+type ViewsModule struct {
+	Handler    *handler.ViewHandler
+	UseCase    domain.ViewUseCase
+	Repository domain.ViewRepository
+}
+
+func NewViewsModule(
+	db *gorm.DB,
+	transactionRepo transactionDomain.TransactionRepository,
+	accountRepo accountDomain.AccountRepository,
+	categoryRepo categoryDomain.CategoryRepository,
+) *ViewsModule {
+	var viewRepo domain.ViewRepository
+	var viewUseCase domain.ViewUseCase
+	var viewHandler *handler.ViewHandler
+
+	if err := db.AutoMigrate(&domain.SmartView{}); err != nil {
+		panic(fmt.Sprintf("Error migrating views: %v", err))
+	}
+
+	viewRepo = repository.NewViewPostgresRepository(db)
+	viewUseCase = usecase.NewViewUseCase(viewRepo, transactionRepo, accountRepo, categoryRepo)
+	viewHandler = handler.NewViewHandler(viewUseCase)
+
+	return &ViewsModule{
+		Handler:    viewHandler,
+		UseCase:    viewUseCase,
+		Repository: viewRepo,
+	}
+}
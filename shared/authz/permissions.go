@@ -0,0 +1,52 @@
+package authz
+
+// PermissionInfo describe un permiso del catálogo
+type PermissionInfo struct {
+	Name        string
+	Description string
+}
+
+// Catalog son los permisos que las rutas pueden exigir. El módulo de roles
+// los sincroniza con la base de datos al iniciar; para agregar uno basta
+// declararlo aquí.
+var Catalog = []PermissionInfo{
+	{"accounts:read", "View accounts"},
+	{"accounts:write", "Create, update and delete accounts"},
+	{"categories:read", "View categories"},
+	{"categories:write", "Create, update, move and merge categories"},
+	{"transactions:read", "View transactions, balances, tags and duplicates"},
+	{"transactions:write", "Create, update and delete transactions and tags, resolve duplicates"},
+	{"ledger:read", "View journal entries"},
+	{"ledger:write", "Post journal entries and transfers"},
+	{"imports:read", "View import batches and profiles"},
+	{"imports:write", "Upload, commit and discard imports, manage profiles"},
+	{"exports:read", "Export transactions"},
+	{"exchange:read", "View exchange rates and convert amounts"},
+	{"exchange:write", "Import exchange rates"},
+	{"budgets:read", "View budgets and their status"},
+	{"budgets:write", "Create, update and delete budgets"},
+	{"reports:read", "View reports and statements"},
+	{"rules:read", "View and test categorization rules"},
+	{"rules:write", "Create, update, delete and apply categorization rules"},
+	{"categorizer:read", "View category suggestions and categorizer settings"},
+	{"categorizer:write", "Train the categorizer and change its settings"},
+	{"recurring:read", "View recurring transactions"},
+	{"recurring:write", "Create, update and delete recurring transactions"},
+	{"dashboard:read", "View the dashboard"},
+	{"views:read", "View saved views and their transactions"},
+	{"views:write", "Create, update and delete saved views"},
+	{"users:read", "View any user"},
+	{"users:write", "Create, update, activate and delete any user"},
+	{"roles:read", "View roles, permissions and role assignments"},
+	{"roles:write", "Manage custom roles and assign roles to users"},
+}
+
+// IsKnownPermission verifica si el permiso está en el catálogo
+func IsKnownPermission(name string) bool {
+	for _, permission := range Catalog {
+		if permission.Name == name {
+			return true
+		}
+	}
+	return false
+}
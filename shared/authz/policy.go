@@ -0,0 +1,106 @@
+package authz
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrForbidden se retorna cuando la política no permite la acción
+var ErrForbidden = errors.New("forbidden")
+
+// Action es una operación sobre un recurso
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionRead   Action = "read"
+	ActionList   Action = "list"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Subject es quien intenta la acción: el usuario autenticado con los
+// permisos de sus roles
+type Subject struct {
+	UserID      uint
+	Permissions []string
+}
+
+// Has verifica si el sujeto tiene el permiso
+func (s Subject) Has(permission string) bool {
+	for _, granted := range s.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Resource describe el objetivo de la acción. Fields lista los atributos
+// que una actualización cambia, para reglas a nivel de campo.
+type Resource struct {
+	Type    string
+	ID      uint
+	OwnerID uint
+	Fields  []string
+}
+
+// Owns verifica si el sujeto es el dueño del recurso
+func (r Resource) Owns(subject Subject) bool {
+	return r.OwnerID != 0 && r.OwnerID == subject.UserID
+}
+
+// Changes verifica si la actualización cambia alguno de los campos
+func (r Resource) Changes(fields ...string) bool {
+	for _, changed := range r.Fields {
+		for _, field := range fields {
+			if changed == field {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Rule decide si el sujeto puede realizar la acción sobre el recurso
+type Rule func(subject Subject, action Action, resource Resource) bool
+
+// Policy agrupa las reglas por tipo de recurso. Niega por defecto: un tipo
+// sin reglas no permite ninguna acción.
+type Policy struct {
+	rules map[string]Rule
+	mutex sync.RWMutex
+}
+
+func NewPolicy() *Policy {
+	return &Policy{rules: make(map[string]Rule)}
+}
+
+// Register asigna la regla de un tipo de recurso. Cada módulo registra la de
+// sus recursos al crearse.
+func (p *Policy) Register(resourceType string, rule Rule) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.rules[resourceType] = rule
+}
+
+// Can verifica si el sujeto puede realizar la acción sobre el recurso
+func (p *Policy) Can(subject Subject, action Action, resource Resource) bool {
+	p.mutex.RLock()
+	rule, exists := p.rules[resource.Type]
+	p.mutex.RUnlock()
+
+	if !exists || subject.UserID == 0 {
+		return false
+	}
+	return rule(subject, action, resource)
+}
+
+// Authorize es Can con ErrForbidden cuando no se permite
+func (p *Policy) Authorize(subject Subject, action Action, resource Resource) error {
+	if !p.Can(subject, action, resource) {
+		return ErrForbidden
+	}
+	return nil
+}
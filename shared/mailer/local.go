@@ -0,0 +1,61 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"time"
+
+	"finanzas-api/shared/security"
+)
+
+// FileMailer guarda cada correo como un archivo .eml en un directorio, para
+// abrirlo con un cliente de correo durante el desarrollo
+type FileMailer struct {
+	dir  string
+	from *mail.Address
+}
+
+// NewFileMailer crea el directorio si no existe
+func NewFileMailer(dir string, from *mail.Address) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating mail directory: %w", err)
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+// Send implements Mailer.
+func (m *FileMailer) Send(msg Message) error {
+	data, err := format(m.from, msg)
+	if err != nil {
+		return err
+	}
+	suffix, err := security.NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+	name := time.Now().UTC().Format("20060102T150405Z") + "-" + suffix[:8] + ".eml"
+	// Los correos llevan enlaces de un solo uso: solo el dueño los lee
+	return os.WriteFile(filepath.Join(m.dir, name), data, 0o600)
+}
+
+// LogMailer escribe los correos en el log en vez de enviarlos
+type LogMailer struct {
+	from *mail.Address
+}
+
+func NewLogMailer(from *mail.Address) *LogMailer {
+	return &LogMailer{from: from}
+}
+
+// Send implements Mailer.
+func (m *LogMailer) Send(msg Message) error {
+	// Se valida igual que un envío real para no ocultar errores en desarrollo
+	if _, err := format(m.from, msg); err != nil {
+		return err
+	}
+	log.Printf("📧 Correo para %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
@@ -0,0 +1,77 @@
+package mailer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"finanzas-api/config"
+)
+
+// Message es un correo de texto plano para un solo destinatario
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer envía correos. Las implementaciones no reintentan: quien llama
+// decide qué hacer si el envío falla.
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// New crea el mailer del driver configurado: smtp para producción, file o
+// log para desarrollo local
+func New(cfg config.MailConfig) (Mailer, error) {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, from), nil
+	case "file":
+		return NewFileMailer(cfg.Dir, from)
+	case "log":
+		return NewLogMailer(from), nil
+	default:
+		return nil, fmt.Errorf("unsupported mail driver: %s", cfg.Driver)
+	}
+}
+
+// format arma el mensaje RFC 5322 con el cuerpo en quoted-printable
+func format(from *mail.Address, msg Message) ([]byte, error) {
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient address: %w", err)
+	}
+	// Un salto de línea en el asunto permitiría inyectar encabezados
+	if strings.ContainsAny(msg.Subject, "\r\n") {
+		return nil, errors.New("invalid subject")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	body := quotedprintable.NewWriter(&buf)
+	if _, err := body.Write([]byte(msg.Body)); err != nil {
+		return nil, err
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
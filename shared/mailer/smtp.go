@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+)
+
+// SMTPMailer envía por SMTP. smtp.SendMail negocia STARTTLS si el servidor
+// lo ofrece, y la autenticación PLAIN solo se envía sobre TLS o a localhost.
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from *mail.Address
+}
+
+// NewSMTPMailer crea un mailer SMTP; sin username no se autentica
+func NewSMTPMailer(host string, port int, username, password string, from *mail.Address) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPMailer{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		auth: auth,
+		from: from,
+	}
+}
+
+// Send implements Mailer.
+func (m *SMTPMailer) Send(msg Message) error {
+	data, err := format(m.from, msg)
+	if err != nil {
+		return err
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(m.addr, m.auth, m.from.Address, []string{to.Address}, data)
+}
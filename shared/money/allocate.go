@@ -0,0 +1,70 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+// Allocate reparte el monto según ratios sin perder unidades menores: cada
+// parte recibe su proporción truncada y el sobrante se entrega de a una
+// unidad a las primeras partes. Los ratios deben ser no negativos y sumar
+// más de cero.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, errors.New("at least one ratio is required")
+	}
+	if m.Amount == math.MinInt64 {
+		return nil, ErrOverflow
+	}
+
+	total := new(big.Int)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, errors.New("ratios must be non-negative")
+		}
+		total.Add(total, big.NewInt(ratio))
+	}
+	if total.Sign() == 0 {
+		return nil, errors.New("ratios must not all be zero")
+	}
+
+	// Se reparte el valor absoluto y luego se restaura el signo
+	amount := new(big.Int).Abs(big.NewInt(m.Amount))
+	parts := make([]Money, len(ratios))
+	remainder := new(big.Int).Set(amount)
+	for i, ratio := range ratios {
+		share := new(big.Int).Mul(amount, big.NewInt(ratio))
+		share.Quo(share, total)
+		remainder.Sub(remainder, share)
+		parts[i] = Money{Amount: share.Int64(), Currency: m.Currency}
+	}
+
+	for i := 0; remainder.Sign() > 0; i = (i + 1) % len(parts) {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].Amount++
+		remainder.Sub(remainder, big.NewInt(1))
+	}
+
+	if m.Amount < 0 {
+		for i := range parts {
+			parts[i].Amount = -parts[i].Amount
+		}
+	}
+	return parts, nil
+}
+
+// Split divide el monto en n partes lo más iguales posible
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, errors.New("split count must be positive")
+	}
+
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
@@ -0,0 +1,93 @@
+package money
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		ratios []int64
+		want   []int64
+	}{
+		{"exact", 1000, []int64{1, 1}, []int64{500, 500}},
+		{"remainder to the first parts", 100, []int64{1, 1, 1}, []int64{34, 33, 33}},
+		{"uneven ratios", 1001, []int64{70, 20, 10}, []int64{701, 200, 100}},
+		{"remainder skips zero ratios", 5, []int64{0, 1, 1}, []int64{0, 3, 2}},
+		{"negative keeps the sign", -100, []int64{1, 1, 1}, []int64{-34, -33, -33}},
+		{"smaller than parts", 2, []int64{1, 1, 1}, []int64{1, 1, 0}},
+		{"max int64", math.MaxInt64, []int64{1, 1}, []int64{math.MaxInt64/2 + 1, math.MaxInt64 / 2}},
+		{"large ratios", 10, []int64{math.MaxInt64, math.MaxInt64}, []int64{5, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := New(tt.amount, "COP").Allocate(tt.ratios...)
+			if err != nil {
+				t.Fatalf("Allocate() error = %v", err)
+			}
+			if len(parts) != len(tt.want) {
+				t.Fatalf("Allocate() returned %d parts, want %d", len(parts), len(tt.want))
+			}
+			for i, part := range parts {
+				if part.Amount != tt.want[i] || part.Currency != "COP" {
+					t.Errorf("part %d = %s, want %d COP", i, part, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAllocateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		ratios []int64
+	}{
+		{"no ratios", 100, nil},
+		{"negative ratio", 100, []int64{1, -1}},
+		{"all zero", 100, []int64{0, 0}},
+		{"min int64", math.MinInt64, []int64{1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.amount, "COP").Allocate(tt.ratios...); err == nil {
+				t.Error("Allocate() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		amount int64
+		n      int
+		want   []int64
+	}{
+		{1000, 3, []int64{334, 333, 333}},
+		{1001, 4, []int64{251, 250, 250, 250}},
+		{-7, 2, []int64{-4, -3}},
+		{0, 2, []int64{0, 0}},
+	}
+	for _, tt := range tests {
+		parts, err := New(tt.amount, "USD").Split(tt.n)
+		if err != nil {
+			t.Fatalf("Split(%d) error = %v", tt.n, err)
+		}
+		var total int64
+		for i, part := range parts {
+			total += part.Amount
+			if part.Amount != tt.want[i] {
+				t.Errorf("Split(%d) of %d: part %d = %d, want %d", tt.n, tt.amount, i, part.Amount, tt.want[i])
+			}
+		}
+		if total != tt.amount {
+			t.Errorf("Split(%d) of %d lost units: total %d", tt.n, tt.amount, total)
+		}
+	}
+
+	if _, err := New(100, "USD").Split(0); err == nil {
+		t.Error("Split(0) error = nil, want an error")
+	}
+}
@@ -0,0 +1,47 @@
+package money
+
+import "testing"
+
+func TestIsValidCurrency(t *testing.T) {
+	tests := []struct {
+		currency string
+		want     bool
+	}{
+		{"COP", true},
+		{"USD", true},
+		{"JPY", true},
+		{"KWD", true},
+		{"ABC", false}, // Tres letras, pero no es ISO 4217
+		{"XXX", false},
+		{"XAU", false},
+		{"usd", false},
+		{"US", false},
+		{"USDT", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsValidCurrency(tt.currency); got != tt.want {
+			t.Errorf("IsValidCurrency(%q) = %v, want %v", tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	tests := []struct {
+		currency string
+		want     int
+	}{
+		{"COP", 2},
+		{"usd", 2},
+		{"JPY", 0},
+		{"CLP", 0},
+		{"BIF", 0},
+		{"KWD", 3},
+		{"CLF", 4},
+	}
+	for _, tt := range tests {
+		if got := MinorUnits(tt.currency); got != tt.want {
+			t.Errorf("MinorUnits(%q) = %d, want %d", tt.currency, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// String formatea el monto en unidades mayores seguido de la moneda,
+// p. ej. "1234.50 COP" o "-15 JPY".
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// Decimal formatea solo el valor en unidades mayores, p. ej. "1234.50"
+func (m Money) Decimal() string {
+	units := MinorUnits(m.Currency)
+	digits := new(big.Int).Abs(big.NewInt(m.Amount)).String()
+	if units > 0 {
+		if len(digits) <= units {
+			digits = strings.Repeat("0", units-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-units] + "." + digits[len(digits)-units:]
+	}
+	if m.Amount < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Parse interpreta un monto con el formato de String ("1234.50 COP"). El
+// valor no puede tener más decimales que la unidad menor de la moneda.
+func Parse(value string) (Money, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return Money{}, errors.New(`invalid money format, expected "<amount> <currency>"`)
+	}
+	return ParseDecimal(fields[0], fields[1])
+}
+
+// ParseDecimal interpreta un valor en unidades mayores ("1234.5") en la
+// moneda indicada.
+func ParseDecimal(value, currency string) (Money, error) {
+	currency = normalizeCurrency(currency)
+	if !IsValidCurrency(currency) {
+		return Money{}, errors.New("invalid currency code")
+	}
+
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	units := MinorUnits(currency)
+	if whole == "" || len(fraction) > units || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, errors.New("invalid money amount")
+	}
+
+	amount, err := strconv.ParseInt(whole+fraction+strings.Repeat("0", units-len(fraction)), 10, 64)
+	if err != nil {
+		return Money{}, ErrOverflow
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// MarshalJSON serializa el monto como cadena ("1234.50 COP") para que los
+// clientes no lo interpreten como número de punto flotante.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON acepta solo la representación en cadena
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return errors.New(`money must be a JSON string like "1234.50 COP"`)
+	}
+
+	parsed, err := Parse(value)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package money
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParseRoundTrip(t *testing.T) {
+	tests := []struct {
+		text string
+		want Money
+	}{
+		{"1234.50 COP", New(123450, "COP")},
+		{"-0.05 USD", New(-5, "USD")},
+		{"1500 JPY", New(1500, "JPY")},
+		{"1.250 KWD", New(1250, "KWD")},
+		{"92233720368547758.07 USD", New(math.MaxInt64, "USD")},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.text)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.text, err)
+		}
+		if !got.Equals(tt.want) {
+			t.Errorf("Parse(%q) = %s, want %s", tt.text, got, tt.want)
+		}
+		if got.String() != tt.text {
+			t.Errorf("String() = %q, want %q", got.String(), tt.text)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{
+		"12.345 USD",               // Más decimales que la unidad menor
+		"10.5 JPY",                 // JPY no tiene decimales
+		"12,50 COP",                // Coma decimal
+		"1e3 USD",                  // Notación científica
+		"12.50 ABC",                // Moneda inexistente
+		"12.50",                    // Sin moneda
+		"92233720368547758.08 USD", // Fuera de int64
+	} {
+		if _, err := Parse(text); err == nil {
+			t.Errorf("Parse(%q) error = nil, want an error", text)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	original := New(-123456, "EUR")
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"-1234.56 EUR"` {
+		t.Errorf("MarshalJSON() = %s", data)
+	}
+
+	var decoded Money
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+	if !decoded.Equals(original) {
+		t.Errorf("round trip = %s, want %s", decoded, original)
+	}
+
+	// Un número JSON se interpretaría como float64
+	if err := json.Unmarshal([]byte(`1234.56`), &decoded); err == nil {
+		t.Error("UnmarshalJSON(number) error = nil, want an error")
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		src     any
+		want    int64
+		wantErr bool
+	}{
+		{int64(1250), 1250, false},
+		{[]byte("1250.00"), 1250, false},
+		{"-99", -99, false},
+		{nil, 0, false},
+		{"12.5", 0, true},
+		{1.5, 0, true},
+	}
+	for _, tt := range tests {
+		var m Money
+		err := m.Scan(tt.src)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Scan(%v) error = %v, wantErr %v", tt.src, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && m.Amount != tt.want {
+			t.Errorf("Scan(%v) = %d, want %d", tt.src, m.Amount, tt.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Package money representa montos exactos: un entero de unidades menores
+// (centavos) y su moneda ISO 4217. Nunca usa float64.
+package money
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+var (
+	// ErrCurrencyMismatch se retorna al operar montos de monedas distintas
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	// ErrOverflow se retorna cuando el resultado no cabe en int64
+	ErrOverflow = errors.New("money amount overflow")
+)
+
+// Money es un monto en unidades menores de Currency
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// New crea un monto a partir de unidades menores
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: normalizeCurrency(currency)}
+}
+
+// Zero retorna el monto cero en la moneda indicada
+func Zero(currency string) Money {
+	return New(0, currency)
+}
+
+// IsZero indica si el monto es cero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsNegative indica si el monto es menor que cero
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// IsPositive indica si el monto es mayor que cero
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// SameCurrency indica si ambos montos están en la misma moneda
+func (m Money) SameCurrency(other Money) bool {
+	return m.Currency == other.Currency
+}
+
+// Add suma dos montos de la misma moneda
+func (m Money) Add(other Money) (Money, error) {
+	if !m.SameCurrency(other) {
+		return Money{}, ErrCurrencyMismatch
+	}
+	if (other.Amount > 0 && m.Amount > math.MaxInt64-other.Amount) ||
+		(other.Amount < 0 && m.Amount < math.MinInt64-other.Amount) {
+		return Money{}, ErrOverflow
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Sub resta other de m; ambos deben estar en la misma moneda
+func (m Money) Sub(other Money) (Money, error) {
+	if other.Amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(other.Neg())
+}
+
+// Neg retorna el monto con el signo invertido
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Abs retorna el valor absoluto del monto
+func (m Money) Abs() Money {
+	if m.Amount < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Multiply multiplica el monto por un entero
+func (m Money) Multiply(factor int64) (Money, error) {
+	if m.Amount == 0 || factor == 0 {
+		return Zero(m.Currency), nil
+	}
+	result := m.Amount * factor
+	if result/factor != m.Amount || (m.Amount == -1 && factor == math.MinInt64) || (factor == -1 && m.Amount == math.MinInt64) {
+		return Money{}, ErrOverflow
+	}
+	return Money{Amount: result, Currency: m.Currency}, nil
+}
+
+// Cmp compara dos montos de la misma moneda: -1 si m < other, 0 si son
+// iguales y 1 si m > other.
+func (m Money) Cmp(other Money) (int, error) {
+	if !m.SameCurrency(other) {
+		return 0, ErrCurrencyMismatch
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Equals indica si ambos montos tienen la misma moneda y valor
+func (m Money) Equals(other Money) bool {
+	return m.Currency == other.Currency && m.Amount == other.Amount
+}
+
+// Sum suma montos de una misma moneda; sin montos retorna cero en currency
+func Sum(currency string, amounts ...Money) (Money, error) {
+	total := Zero(currency)
+	for _, amount := range amounts {
+		var err error
+		if total, err = total.Add(amount); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+func normalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
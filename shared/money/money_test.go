@@ -0,0 +1,105 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAddOverflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    int64
+		want    int64
+		wantErr error
+	}{
+		{"positive", 150, 250, 400, nil},
+		{"up to max", math.MaxInt64 - 1, 1, math.MaxInt64, nil},
+		{"past max", math.MaxInt64, 1, 0, ErrOverflow},
+		{"down to min", math.MinInt64 + 1, -1, math.MinInt64, nil},
+		{"past min", math.MinInt64, -1, 0, ErrOverflow},
+		{"extremes cancel", math.MaxInt64, math.MinInt64, -1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.a, "COP").Add(New(tt.b, "COP"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Add() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.Amount != tt.want {
+				t.Errorf("Add() = %d, want %d", got.Amount, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubOverflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    int64
+		want    int64
+		wantErr error
+	}{
+		{"positive", 400, 250, 150, nil},
+		{"down to min", math.MinInt64 + 1, 1, math.MinInt64, nil},
+		{"past min", math.MinInt64, 1, 0, ErrOverflow},
+		{"past max", math.MaxInt64, -1, 0, ErrOverflow},
+		{"subtract min int64", 0, math.MinInt64, 0, ErrOverflow},
+		{"subtract min int64 from negative", -1, math.MinInt64, 0, ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.a, "COP").Sub(New(tt.b, "COP"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Sub() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.Amount != tt.want {
+				t.Errorf("Sub() = %d, want %d", got.Amount, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultiplyOverflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  int64
+		factor  int64
+		want    int64
+		wantErr error
+	}{
+		{"simple", 1250, 3, 3750, nil},
+		{"zero factor", math.MaxInt64, 0, 0, nil},
+		{"exact max", math.MaxInt64, 1, math.MaxInt64, nil},
+		{"half max doubled", math.MaxInt64 / 2, 2, math.MaxInt64 - 1, nil},
+		{"past max", math.MaxInt64/2 + 1, 2, 0, ErrOverflow},
+		{"min times one", math.MinInt64, 1, math.MinInt64, nil},
+		{"min times minus one", math.MinInt64, -1, 0, ErrOverflow},
+		{"minus one times min", -1, math.MinInt64, 0, ErrOverflow},
+		{"negative result at min", math.MinInt64 / 2, 2, math.MinInt64, nil},
+		{"negative past min", math.MinInt64/2 - 1, 2, 0, ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.amount, "COP").Multiply(tt.factor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Multiply() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.Amount != tt.want {
+				t.Errorf("Multiply() = %d, want %d", got.Amount, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrencyMismatch(t *testing.T) {
+	if _, err := New(100, "COP").Add(New(100, "USD")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add() error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+	if _, err := Sum("COP", New(100, "COP"), New(100, "USD")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Sum() error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+	if _, err := New(100, "COP").Cmp(New(100, "USD")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Cmp() error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+}
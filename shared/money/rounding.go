@@ -0,0 +1,97 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+)
+
+// RoundingMode define cómo se resuelven las fracciones de unidad menor
+type RoundingMode int
+
+const (
+	// HalfEven redondea al más cercano y los empates al par (redondeo bancario)
+	HalfEven RoundingMode = iota
+	// HalfUp redondea al más cercano y los empates lejos de cero
+	HalfUp
+)
+
+// Round redondea r a un entero según mode
+func Round(r *big.Rat, mode RoundingMode) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	// Comparar 2*|resto| con el denominador
+	doubled := new(big.Int).Abs(remainder)
+	doubled.Lsh(doubled, 1)
+	cmp := doubled.Cmp(r.Denom())
+
+	awayFromZero := cmp > 0
+	if cmp == 0 {
+		switch mode {
+		case HalfUp:
+			awayFromZero = true
+		default:
+			awayFromZero = quotient.Bit(0) == 1
+		}
+	}
+
+	if awayFromZero {
+		if r.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return quotient
+}
+
+// MulRat multiplica el monto por un factor exacto (p. ej. un porcentaje)
+// y redondea el resultado a unidades menores.
+func (m Money) MulRat(factor *big.Rat, mode RoundingMode) (Money, error) {
+	value := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), factor)
+	return fromRat(value, m.Currency, mode)
+}
+
+// Convert convierte el monto a la moneda to con rate unidades de to por
+// cada unidad de m.Currency, ajustando la escala de unidades menores.
+func (m Money) Convert(rate *big.Rat, to string, mode RoundingMode) (Money, error) {
+	if rate == nil || rate.Sign() <= 0 {
+		return Money{}, errors.New("exchange rate must be positive")
+	}
+	to = normalizeCurrency(to)
+
+	value := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), rate)
+
+	shift := MinorUnits(to) - MinorUnits(m.Currency)
+	if shift != 0 {
+		exponent := shift
+		if exponent < 0 {
+			exponent = -exponent
+		}
+		scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil))
+		if shift > 0 {
+			value.Mul(value, scale)
+		} else {
+			value.Quo(value, scale)
+		}
+	}
+
+	return fromRat(value, to, mode)
+}
+
+// Rat retorna el monto en unidades mayores como racional exacto
+func (m Money) Rat() *big.Rat {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(MinorUnits(m.Currency))), nil)
+	return new(big.Rat).SetFrac(big.NewInt(m.Amount), scale)
+}
+
+// fromRat redondea un valor en unidades menores a Money
+func fromRat(value *big.Rat, currency string, mode RoundingMode) (Money, error) {
+	rounded := Round(value, mode)
+	if !rounded.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	return Money{Amount: rounded.Int64(), Currency: currency}, nil
+}
@@ -0,0 +1,76 @@
+package money
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestRoundTies(t *testing.T) {
+	tests := []struct {
+		value    string
+		halfEven int64
+		halfUp   int64
+	}{
+		{"5/2", 2, 3},     // 2.5
+		{"7/2", 4, 4},     // 3.5
+		{"-5/2", -2, -3},  // -2.5
+		{"-7/2", -4, -4},  // -3.5
+		{"1/2", 0, 1},     // 0.5
+		{"-1/2", 0, -1},   // -0.5
+		{"251/100", 3, 3}, // 2.51, no es empate
+		{"-249/100", -2, -2},
+		{"3", 3, 3},
+	}
+	for _, tt := range tests {
+		value, ok := new(big.Rat).SetString(tt.value)
+		if !ok {
+			t.Fatalf("invalid test value %q", tt.value)
+		}
+		if got := Round(value, HalfEven).Int64(); got != tt.halfEven {
+			t.Errorf("Round(%s, HalfEven) = %d, want %d", tt.value, got, tt.halfEven)
+		}
+		if got := Round(value, HalfUp).Int64(); got != tt.halfUp {
+			t.Errorf("Round(%s, HalfUp) = %d, want %d", tt.value, got, tt.halfUp)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Money
+		rate   string
+		to     string
+		mode   RoundingMode
+		want   int64
+	}{
+		{"same scale", New(1000, "USD"), "4000", "COP", HalfEven, 4000000},     // 10.00 USD
+		{"to zero decimals", New(1000, "USD"), "150.5", "JPY", HalfEven, 1505}, // 10.00 USD
+		{"from zero decimals", New(1505, "JPY"), "0.0066", "USD", HalfEven, 993},
+		{"to three decimals", New(100, "USD"), "0.3075", "KWD", HalfEven, 308}, // 1.00 USD = 0.3075 KWD
+		{"tie half even", New(5, "USD"), "0.5", "EUR", HalfEven, 2},
+		{"tie half up", New(5, "USD"), "0.5", "EUR", HalfUp, 3},
+		{"negative tie half even", New(-5, "USD"), "0.5", "EUR", HalfEven, -2},
+		{"negative tie half up", New(-5, "USD"), "0.5", "EUR", HalfUp, -3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, _ := new(big.Rat).SetString(tt.rate)
+			got, err := tt.amount.Convert(rate, tt.to, tt.mode)
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if got.Amount != tt.want || got.Currency != tt.to {
+				t.Errorf("Convert() = %s, want %d %s", got, tt.want, tt.to)
+			}
+		})
+	}
+}
+
+func TestConvertRejectsNonPositiveRate(t *testing.T) {
+	for _, rate := range []*big.Rat{nil, big.NewRat(0, 1), big.NewRat(-1, 2)} {
+		if _, err := New(100, "USD").Convert(rate, "COP", HalfEven); err == nil {
+			t.Errorf("Convert(rate=%v) error = nil, want an error", rate)
+		}
+	}
+}
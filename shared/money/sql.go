@@ -0,0 +1,53 @@
+package money
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Value implements driver.Valuer. Se persisten las unidades menores como
+// NUMERIC entero; la moneda va en su propia columna del modelo.
+func (m Money) Value() (driver.Value, error) {
+	return strconv.FormatInt(m.Amount, 10), nil
+}
+
+// Scan implements sql.Scanner. Solo lee las unidades menores: el modelo
+// debe asignar Currency desde su columna de moneda. Rechaza valores con
+// fracción y de punto flotante.
+func (m *Money) Scan(src any) error {
+	var text string
+	switch value := src.(type) {
+	case nil:
+		m.Amount = 0
+		return nil
+	case int64:
+		m.Amount = value
+		return nil
+	case []byte:
+		text = string(value)
+	case string:
+		text = value
+	default:
+		return fmt.Errorf("money: cannot scan %T", src)
+	}
+
+	// NUMERIC puede volver con escala, p. ej. "1250.00" en numeric(20,2)
+	whole, fraction, _ := strings.Cut(strings.TrimSpace(text), ".")
+	if strings.Trim(fraction, "0") != "" {
+		return fmt.Errorf("money: %q is not a whole number of minor units", text)
+	}
+
+	amount, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return fmt.Errorf("money: %w", err)
+	}
+	m.Amount = amount
+	return nil
+}
+
+// GormDataType define el tipo de columna para las migraciones de GORM
+func (Money) GormDataType() string {
+	return "numeric(20,0)"
+}
@@ -0,0 +1,273 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// kidTimeLayout es el prefijo de los kid generados; de él se obtiene la
+// fecha de creación aunque el archivo se copie
+const kidTimeLayout = "20060102T150405Z"
+
+// reloadCooldown limita las relecturas del directorio al recibir un kid
+// desconocido, p. ej. de una clave que otra instancia acaba de rotar
+const reloadCooldown = time.Minute
+
+// SigningKey es una clave del directorio, identificada por su kid
+type SigningKey struct {
+	ID        string
+	CreatedAt time.Time
+	Signer    Signer
+}
+
+// KeySet mantiene las claves de firma cargadas desde un directorio de
+// archivos PEM (PKCS#8), uno por clave: <kid>.pem. Firma siempre con la más
+// reciente y verifica con cualquiera de las cargadas.
+type KeySet struct {
+	dir        string
+	algorithm  string
+	rotateEach time.Duration // Antigüedad a partir de la cual se genera una clave nueva
+	tokenTTL   time.Duration // Vigencia máxima de los tokens firmados
+
+	keys       []*SigningKey // Ordenadas de la más antigua a la más reciente
+	lastReload time.Time     // Último intento de relectura, haya fallado o no
+	mutex      sync.RWMutex
+}
+
+// LoadKeySet carga las claves de dir (lo crea si no existe) y rota si hace
+// falta, de modo que siempre haya una clave vigente del algoritmo pedido.
+func LoadKeySet(dir, algorithm string, rotateEach, tokenTTL time.Duration) (*KeySet, error) {
+	if !IsSupportedAlgorithm(algorithm) {
+		return nil, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+	if rotateEach <= 0 {
+		return nil, errors.New("key rotation interval must be positive")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	keySet := &KeySet{dir: dir, algorithm: algorithm, rotateEach: rotateEach, tokenTTL: tokenTTL}
+	if err := keySet.Rotate(time.Now()); err != nil {
+		return nil, err
+	}
+	return keySet, nil
+}
+
+// Rotate relee el directorio, genera una clave nueva si la más reciente ya
+// cumplió el intervalo de rotación o es de otro algoritmo, y retira las
+// claves reemplazadas hace más que la vigencia de los tokens: ningún token
+// firmado con ellas sigue vigente.
+func (k *KeySet) Rotate(now time.Time) error {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if err := k.reload(now); err != nil {
+		return err
+	}
+
+	current := k.current()
+	if current == nil || current.Signer.Algorithm() != k.algorithm || !now.Before(current.CreatedAt.Add(k.rotateEach)) {
+		key, err := k.generate(now)
+		if err != nil {
+			return err
+		}
+		k.keys = append(k.keys, key)
+	}
+
+	// La clave i dejó de firmar cuando se creó la i+1. Margen de un minuto
+	// para relojes desfasados entre instancias.
+	last := len(k.keys) - 1
+	retained := make([]*SigningKey, 0, len(k.keys))
+	for i, key := range k.keys {
+		if i < last && now.After(k.keys[i+1].CreatedAt.Add(k.tokenTTL+time.Minute)) {
+			if err := os.Remove(k.path(key.ID)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		retained = append(retained, key)
+	}
+	k.keys = retained
+	return nil
+}
+
+// Current retorna la clave con la que se firman los tokens nuevos
+func (k *KeySet) Current() *SigningKey {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+
+	return k.current()
+}
+
+// Key busca una clave por kid. Si no está, relee el directorio (como mucho
+// una vez por minuto, aunque la relectura falle) por si otra instancia la
+// generó. Si falla, se conservan las claves ya cargadas.
+func (k *KeySet) Key(id string) (*SigningKey, bool) {
+	k.mutex.RLock()
+	key := k.find(id)
+	stale := time.Since(k.lastReload) > reloadCooldown
+	k.mutex.RUnlock()
+
+	if key != nil || !stale {
+		return key, key != nil
+	}
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	if time.Since(k.lastReload) > reloadCooldown {
+		now := time.Now()
+		if err := k.reload(now); err != nil {
+			k.lastReload = now
+			log.Printf("⚠️ Error releyendo las claves de firma: %v", err)
+			return nil, false
+		}
+	}
+	key = k.find(id)
+	return key, key != nil
+}
+
+// JWKS retorna las claves públicas de todas las claves cargadas
+func (k *KeySet) JWKS() JWKSet {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+
+	set := JWKSet{Keys: make([]JWK, 0, len(k.keys))}
+	for i := len(k.keys) - 1; i >= 0; i-- {
+		jwk := k.keys[i].Signer.PublicJWK()
+		jwk.Kid = k.keys[i].ID
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+func (k *KeySet) current() *SigningKey {
+	if len(k.keys) == 0 {
+		return nil
+	}
+	return k.keys[len(k.keys)-1]
+}
+
+func (k *KeySet) find(id string) *SigningKey {
+	for _, key := range k.keys {
+		if key.ID == id {
+			return key
+		}
+	}
+	return nil
+}
+
+// reload reemplaza las claves por las del directorio; requiere el lock
+func (k *KeySet) reload(now time.Time) error {
+	entries, err := os.ReadDir(k.dir)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]*SigningKey, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".pem" {
+			continue
+		}
+		key, err := k.load(entry)
+		if err != nil {
+			return fmt.Errorf("loading key %s: %w", entry.Name(), err)
+		}
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].ID < keys[j].ID
+		}
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	k.keys = keys
+	k.lastReload = now
+	return nil
+}
+
+func (k *KeySet) load(entry os.DirEntry) (*SigningKey, error) {
+	data, err := os.ReadFile(filepath.Join(k.dir, entry.Name()))
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, errors.New("expected a PKCS#8 PEM private key")
+	}
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := newSigner(private)
+	if err != nil {
+		return nil, err
+	}
+
+	id := strings.TrimSuffix(entry.Name(), ".pem")
+	// Las claves agregadas a mano pueden tener cualquier nombre; en ese caso
+	// cuenta la fecha de modificación del archivo
+	createdAt, err := time.Parse(kidTimeLayout, strings.SplitN(id, "-", 2)[0])
+	if err != nil {
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		createdAt = info.ModTime()
+	}
+	return &SigningKey{ID: id, CreatedAt: createdAt, Signer: signer}, nil
+}
+
+// generate crea una clave del algoritmo configurado y la guarda en el directorio
+func (k *KeySet) generate(now time.Time) (*SigningKey, error) {
+	private, err := generatePrivateKey(k.algorithm)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := newSigner(private)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+	createdAt := now.UTC().Truncate(time.Second)
+	id := createdAt.Format(kidTimeLayout) + "-" + hex.EncodeToString(suffix)
+
+	// O_EXCL: nunca sobrescribir una clave existente
+	file, err := os.OpenFile(k.path(id), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	err = pem.Encode(file, &pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(k.path(id))
+		return nil, err
+	}
+	return &SigningKey{ID: id, CreatedAt: createdAt, Signer: signer}, nil
+}
+
+func (k *KeySet) path(id string) string {
+	return filepath.Join(k.dir, id+".pem")
+}
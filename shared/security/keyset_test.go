@@ -0,0 +1,133 @@
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestKeySetRotate(t *testing.T) {
+	const (
+		rotateEach = 24 * time.Hour
+		tokenTTL   = time.Hour
+	)
+	tests := []struct {
+		name     string
+		after    time.Duration // Tiempo desde la carga hasta Rotate
+		wantKeys int
+		wantNew  bool // Se generó una clave para firmar
+	}{
+		{name: "before the interval", after: rotateEach - time.Minute, wantKeys: 1},
+		{name: "interval reached", after: rotateEach, wantKeys: 2, wantNew: true},
+		// La clave anterior se retira cuando ningún token firmado con ella
+		// sigue vigente, con un minuto de margen
+		{name: "previous key still verifies", after: rotateEach + tokenTTL, wantKeys: 2, wantNew: true},
+		{name: "previous key retired", after: rotateEach + tokenTTL + 2*time.Minute, wantKeys: 1, wantNew: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			keys, err := LoadKeySet(dir, AlgorithmEdDSA, rotateEach, tokenTTL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			first := keys.Current()
+
+			// La rotación se evalúa en dos pasos: la que genera la clave
+			// nueva y la que retira la anterior
+			rotatedAt := first.CreatedAt.Add(tt.after)
+			if tt.after >= rotateEach {
+				if err := keys.Rotate(first.CreatedAt.Add(rotateEach)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := keys.Rotate(rotatedAt); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := len(keys.JWKS().Keys); got != tt.wantKeys {
+				t.Errorf("got %d keys, want %d", got, tt.wantKeys)
+			}
+			if current := keys.Current(); (current.ID != first.ID) != tt.wantNew {
+				t.Errorf("current key %s, first key %s, want new = %v", current.ID, first.ID, tt.wantNew)
+			}
+			_, err = os.Stat(filepath.Join(dir, first.ID+".pem"))
+			if retired := os.IsNotExist(err); retired != (tt.wantKeys == 1 && tt.wantNew) {
+				t.Errorf("first key file removed = %v", retired)
+			}
+		})
+	}
+}
+
+// TestKeySetRotateAlgorithm cambia el algoritmo configurado: la clave vigente
+// de otro algoritmo se reemplaza aunque no haya cumplido el intervalo
+func TestKeySetRotateAlgorithm(t *testing.T) {
+	dir := t.TempDir()
+	previous, err := LoadKeySet(dir, AlgorithmEdDSA, 24*time.Hour, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := LoadKeySet(dir, AlgorithmRS256, 24*time.Hour, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if algorithm := keys.Current().Signer.Algorithm(); algorithm != AlgorithmRS256 {
+		t.Errorf("current algorithm = %s, want RS256", algorithm)
+	}
+	if _, ok := keys.Key(previous.Current().ID); !ok {
+		t.Error("previous EdDSA key should still verify")
+	}
+}
+
+// TestKeySetReloadCooldown simula dos instancias sobre el mismo directorio:
+// un kid desconocido solo relee el directorio una vez por reloadCooldown
+func TestKeySetReloadCooldown(t *testing.T) {
+	tests := []struct {
+		name       string
+		sinceLoad  time.Duration // Antigüedad de la última relectura
+		corruptDir bool          // Un archivo inválido hace fallar la relectura
+		wantFound  bool
+	}{
+		{name: "within the cooldown", sinceLoad: 0},
+		{name: "after the cooldown", sinceLoad: 2 * reloadCooldown, wantFound: true},
+		{name: "failed reload", sinceLoad: 2 * reloadCooldown, corruptDir: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			keys, err := LoadKeySet(dir, AlgorithmEdDSA, 24*time.Hour, time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			loaded := keys.Current()
+
+			other, err := LoadKeySet(dir, AlgorithmEdDSA, 24*time.Hour, time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := other.Rotate(loaded.CreatedAt.Add(24 * time.Hour)); err != nil {
+				t.Fatal(err)
+			}
+			if tt.corruptDir {
+				if err := os.WriteFile(filepath.Join(dir, "roto.pem"), []byte("no es una clave"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			keys.lastReload = time.Now().Add(-tt.sinceLoad)
+
+			if _, found := keys.Key(other.Current().ID); found != tt.wantFound {
+				t.Fatalf("Key() found = %v, want %v", found, tt.wantFound)
+			}
+			// Cualquier intento, exitoso o no, reinicia la espera
+			if time.Since(keys.lastReload) > reloadCooldown {
+				t.Error("the reload attempt did not reset the cooldown")
+			}
+			// Una relectura fallida conserva las claves ya cargadas
+			if _, found := keys.Key(loaded.ID); !found {
+				t.Error("the loaded key was lost")
+			}
+		})
+	}
+}
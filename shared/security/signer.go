@@ -0,0 +1,142 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Algoritmos JWS soportados para firmar tokens
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// Signer firma y verifica con una clave privada de un algoritmo JWS. Para
+// agregar un algoritmo basta implementarlo y registrarlo en newSigner y
+// generatePrivateKey.
+type Signer interface {
+	Algorithm() string
+	Sign(signingInput []byte) ([]byte, error)
+	Verify(signingInput, signature []byte) error
+	// PublicJWK retorna la clave pública en formato JWK, sin kid
+	PublicJWK() JWK
+}
+
+// JWK es una clave pública en formato JSON Web Key (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`   // RSA: módulo
+	E   string `json:"e,omitempty"`   // RSA: exponente
+	Crv string `json:"crv,omitempty"` // OKP: curva
+	X   string `json:"x,omitempty"`   // OKP: clave pública
+}
+
+// JWKSet es el documento que se publica en /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+var errInvalidSignature = errors.New("invalid signature")
+
+// IsSupportedAlgorithm verifica si el algoritmo es RS256 o EdDSA
+func IsSupportedAlgorithm(algorithm string) bool {
+	return algorithm == AlgorithmRS256 || algorithm == AlgorithmEdDSA
+}
+
+type rsaSigner struct {
+	key *rsa.PrivateKey
+}
+
+func (s *rsaSigner) Algorithm() string {
+	return AlgorithmRS256
+}
+
+func (s *rsaSigner) Sign(signingInput []byte) ([]byte, error) {
+	digest := sha256.Sum256(signingInput)
+	return rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+}
+
+func (s *rsaSigner) Verify(signingInput, signature []byte) error {
+	digest := sha256.Sum256(signingInput)
+	if err := rsa.VerifyPKCS1v15(&s.key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+func (s *rsaSigner) PublicJWK() JWK {
+	return JWK{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: AlgorithmRS256,
+		N:   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+	}
+}
+
+type ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+func (s *ed25519Signer) Algorithm() string {
+	return AlgorithmEdDSA
+}
+
+func (s *ed25519Signer) Sign(signingInput []byte) ([]byte, error) {
+	return ed25519.Sign(s.key, signingInput), nil
+}
+
+func (s *ed25519Signer) Verify(signingInput, signature []byte) error {
+	if !ed25519.Verify(s.key.Public().(ed25519.PublicKey), signingInput, signature) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+func (s *ed25519Signer) PublicJWK() JWK {
+	return JWK{
+		Kty: "OKP",
+		Use: "sig",
+		Alg: AlgorithmEdDSA,
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(s.key.Public().(ed25519.PublicKey)),
+	}
+}
+
+// newSigner elige el algoritmo según el tipo de la clave privada
+func newSigner(key crypto.PrivateKey) (Signer, error) {
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < 2048 {
+			return nil, errors.New("RSA keys must have at least 2048 bits")
+		}
+		return &rsaSigner{key: key}, nil
+	case ed25519.PrivateKey:
+		return &ed25519Signer{key: key}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// generatePrivateKey crea una clave nueva para el algoritmo
+func generatePrivateKey(algorithm string) (crypto.PrivateKey, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case AlgorithmEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+}
@@ -1,52 +1,144 @@
 package security
 
 import (
-	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"strings"
 	"time"
 )
 
+// clockSkew es la tolerancia para iat de tokens emitidos por instancias con
+// el reloj adelantado
+const clockSkew = time.Minute
+
 type TokenClaims struct {
-	UserID uint   `json:"user_id"`
-	Role   string `json:"role"`
-	Exp    int64  `json:"exp"`
+	UserID      uint     `json:"user_id"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"perms"` // Unión de los permisos de los roles al emitir el token
+	SessionID   uint     `json:"sid"`   // Sesión (familia de refresh tokens) que emitió el token
+	ID          string   `json:"jti"`
+	Issuer      string   `json:"iss"`
+	Audience    Audience `json:"aud"`
+	IssuedAt    int64    `json:"iat"`
+	Exp         int64    `json:"exp"`
+}
+
+// Audience es el claim aud: según el RFC 7519 puede ser un texto o una lista
+type Audience []string
+
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// Contains verifica si audience está entre los destinatarios
+func (a Audience) Contains(audience string) bool {
+	for _, value := range a {
+		if value == audience {
+			return true
+		}
+	}
+	return false
 }
 
-func GenerateToken(userID uint, role string, secret string, duration time.Duration) (string, error) {
-	claims := TokenClaims{
-		UserID: userID,
-		Role:   role,
-		Exp:    time.Now().Add(duration).Unix(),
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+// TokenService emite y verifica los access tokens con las claves de un KeySet
+type TokenService struct {
+	keys     *KeySet
+	issuer   string
+	audience string
+}
+
+func NewTokenService(keys *KeySet, issuer, audience string) *TokenService {
+	return &TokenService{keys: keys, issuer: issuer, audience: audience}
+}
+
+// GenerateToken firma claims con la clave actual. Completa jti, iss, aud,
+// iat y exp; el resto lo aporta quien llama.
+func (s *TokenService) GenerateToken(claims TokenClaims, duration time.Duration) (string, error) {
+	jti, err := NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	key := s.keys.Current()
+	now := time.Now()
+	claims.ID = jti
+	claims.Issuer = s.issuer
+	claims.Audience = Audience{s.audience}
+	claims.IssuedAt = now.Unix()
+	claims.Exp = now.Add(duration).Unix()
+	headerBytes, err := json.Marshal(tokenHeader{Alg: key.Signer.Algorithm(), Typ: "JWT", Kid: key.ID})
+	if err != nil {
+		return "", err
 	}
-	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
 	payloadBytes, err := json.Marshal(claims)
 	if err != nil {
 		return "", err
 	}
-	payload := base64.RawURLEncoding.EncodeToString(payloadBytes)
-	unsigned := header + "." + payload
-	mac := hmac.New(sha256.New, []byte(secret))
-	mac.Write([]byte(unsigned))
-	signature := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
-	return unsigned + "." + signature, nil
+	unsigned := base64.RawURLEncoding.EncodeToString(headerBytes) + "." + base64.RawURLEncoding.EncodeToString(payloadBytes)
+	signature, err := key.Signer.Sign([]byte(unsigned))
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
 }
 
-func ParseToken(tokenString, secret string) (*TokenClaims, error) {
+// ParseToken verifica la firma con la clave del kid y exige que alg sea el de
+// esa clave: así un token no puede elegir un algoritmo más débil ("none",
+// HS256 con la clave pública como secreto).
+func (s *TokenService) ParseToken(tokenString string) (*TokenClaims, error) {
 	parts := strings.Split(tokenString, ".")
 	if len(parts) != 3 {
 		return nil, errors.New("invalid token")
 	}
-	unsigned := parts[0] + "." + parts[1]
-	mac := hmac.New(sha256.New, []byte(secret))
-	mac.Write([]byte(unsigned))
-	expected := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
-	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
-		return nil, errors.New("invalid signature")
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	var header tokenHeader
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, err
+	}
+	key, ok := s.keys.Key(header.Kid)
+	if !ok {
+		return nil, errors.New("unknown signing key")
+	}
+	if header.Alg != key.Signer.Algorithm() {
+		return nil, errors.New("unexpected signing algorithm")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	if err := key.Signer.Verify([]byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
 	}
+
 	payloadBytes, err := base64.RawURLEncoding.DecodeString(parts[1])
 	if err != nil {
 		return nil, err
@@ -55,8 +147,37 @@ func ParseToken(tokenString, secret string) (*TokenClaims, error) {
 	if err := json.Unmarshal(payloadBytes, &claims); err != nil {
 		return nil, err
 	}
-	if time.Now().Unix() > claims.Exp {
+	now := time.Now()
+	if now.Unix() > claims.Exp {
 		return nil, errors.New("token expired")
 	}
+	if claims.IssuedAt > now.Add(clockSkew).Unix() {
+		return nil, errors.New("token issued in the future")
+	}
+	if claims.Issuer != s.issuer || !claims.Audience.Contains(s.audience) {
+		return nil, errors.New("invalid token issuer or audience")
+	}
 	return &claims, nil
 }
+
+// JWKS retorna las claves públicas para que otros servicios verifiquen tokens
+func (s *TokenService) JWKS() JWKSet {
+	return s.keys.JWKS()
+}
+
+// NewOpaqueToken genera 32 bytes aleatorios codificados en base64 URL. Se usa
+// para los refresh tokens y los identificadores (jti) de los access tokens.
+func NewOpaqueToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashOpaqueToken retorna el SHA-256 en hexadecimal del token. Los refresh
+// tokens tienen suficiente entropía, así que no hace falta bcrypt.
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
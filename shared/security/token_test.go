@@ -0,0 +1,153 @@
+package security
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// newTestTokens crea un servicio con una clave EdDSA en un directorio temporal
+func newTestTokens(t *testing.T) (*TokenService, *KeySet) {
+	t.Helper()
+	keys, err := LoadKeySet(t.TempDir(), AlgorithmEdDSA, 24*time.Hour, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewTokenService(keys, "finanzas-api", "finanzas-web"), keys
+}
+
+// signToken arma un token con header y claims arbitrarios; sign recibe la
+// entrada a firmar y retorna la firma
+func signToken(t *testing.T, header tokenHeader, claims interface{}, sign func([]byte) []byte) string {
+	t.Helper()
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payloadBytes, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(headerBytes) + "." + base64.RawURLEncoding.EncodeToString(payloadBytes)
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(unsigned)))
+}
+
+func validClaims() TokenClaims {
+	now := time.Now()
+	return TokenClaims{UserID: 1, SessionID: 1, ID: "jti", Issuer: "finanzas-api", Audience: Audience{"finanzas-web"}, IssuedAt: now.Unix(), Exp: now.Add(time.Hour).Unix()}
+}
+
+func TestParseTokenAlgorithm(t *testing.T) {
+	tokens, keys := newTestTokens(t)
+	key := keys.Current()
+	signWithKey := func(input []byte) []byte {
+		signature, err := key.Signer.Sign(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signature
+	}
+	// HS256 con la clave pública como secreto: el ataque clásico de
+	// confusión de algoritmos
+	public := key.Signer.(*ed25519Signer).key.Public().(ed25519.PublicKey)
+	signWithHMAC := func(input []byte) []byte {
+		mac := hmac.New(sha256.New, public)
+		mac.Write(input)
+		return mac.Sum(nil)
+	}
+
+	tests := []struct {
+		name    string
+		header  tokenHeader
+		sign    func([]byte) []byte
+		wantErr string
+	}{
+		{name: "key algorithm", header: tokenHeader{Alg: AlgorithmEdDSA, Typ: "JWT", Kid: key.ID}, sign: signWithKey},
+		{name: "none", header: tokenHeader{Alg: "none", Typ: "JWT", Kid: key.ID}, sign: func([]byte) []byte { return nil }, wantErr: "unexpected signing algorithm"},
+		{name: "HS256 with the public key", header: tokenHeader{Alg: "HS256", Typ: "JWT", Kid: key.ID}, sign: signWithHMAC, wantErr: "unexpected signing algorithm"},
+		{name: "alg swapped to RS256", header: tokenHeader{Alg: AlgorithmRS256, Typ: "JWT", Kid: key.ID}, sign: signWithKey, wantErr: "unexpected signing algorithm"},
+		{name: "unknown kid", header: tokenHeader{Alg: AlgorithmEdDSA, Typ: "JWT", Kid: "otra"}, sign: signWithKey, wantErr: "unknown signing key"},
+		{name: "bad signature", header: tokenHeader{Alg: AlgorithmEdDSA, Typ: "JWT", Kid: key.ID}, sign: func(input []byte) []byte { return signWithKey(append([]byte("x"), input...)) }, wantErr: errInvalidSignature.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tokens.ParseToken(signToken(t, tt.header, validClaims(), tt.sign))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ParseToken() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("ParseToken() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseTokenClaims(t *testing.T) {
+	tokens, keys := newTestTokens(t)
+	key := keys.Current()
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		edit    func(claims *TokenClaims)
+		wantErr string
+	}{
+		{name: "valid", edit: func(claims *TokenClaims) {}},
+		{name: "audience list", edit: func(claims *TokenClaims) { claims.Audience = Audience{"otro", "finanzas-web"} }},
+		{name: "iat within the clock skew", edit: func(claims *TokenClaims) { claims.IssuedAt = now.Add(30 * time.Second).Unix() }},
+		{name: "other issuer", edit: func(claims *TokenClaims) { claims.Issuer = "otro" }, wantErr: "invalid token issuer or audience"},
+		{name: "other audience", edit: func(claims *TokenClaims) { claims.Audience = Audience{"otro"} }, wantErr: "invalid token issuer or audience"},
+		{name: "no audience", edit: func(claims *TokenClaims) { claims.Audience = nil }, wantErr: "invalid token issuer or audience"},
+		{name: "issued in the future", edit: func(claims *TokenClaims) { claims.IssuedAt = now.Add(5 * time.Minute).Unix() }, wantErr: "token issued in the future"},
+		{name: "expired", edit: func(claims *TokenClaims) { claims.Exp = now.Add(-time.Second).Unix() }, wantErr: "token expired"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			tt.edit(&claims)
+			token := signToken(t, tokenHeader{Alg: AlgorithmEdDSA, Typ: "JWT", Kid: key.ID}, claims, func(input []byte) []byte {
+				signature, err := key.Signer.Sign(input)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return signature
+			})
+
+			_, err := tokens.ParseToken(token)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ParseToken() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("ParseToken() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestGenerateToken comprueba que un token emitido se verifica y lleva los
+// claims que completa el servicio
+func TestGenerateToken(t *testing.T) {
+	tokens, _ := newTestTokens(t)
+	token, err := tokens.GenerateToken(TokenClaims{UserID: 7, SessionID: 3, Permissions: []string{"reports:read"}}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := tokens.ParseToken(token)
+	if err != nil {
+		t.Fatalf("ParseToken() error = %v", err)
+	}
+	if claims.UserID != 7 || claims.SessionID != 3 || claims.ID == "" || claims.Issuer != "finanzas-api" || !claims.Audience.Contains("finanzas-web") {
+		t.Errorf("claims = %+v", claims)
+	}
+}